@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
@@ -15,6 +16,7 @@ import (
 
 	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/snapshot"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
 
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
 )
@@ -68,7 +70,7 @@ func (s *AccountService) GetBalanceAtSnapshot(tezosAddr string, cycle int) (floa
 		return 0, errors.Wrapf(err, "could not get balance for %s at snapshot at %d cycle", tezosAddr, cycle)
 	}
 
-	return s.GetBalanceAtBlock(tezosAddr, snapShot.AssociatedHash)
+	return s.GetBalanceAtBlock(tezosAddr, blockid.Hash(snapShot.AssociatedHash))
 }
 
 // GetBalance gets the balance of a public key hash at a specific snapshot for a cycle.
@@ -93,14 +95,9 @@ func (s *AccountService) GetBalance(tezosAddr string) (float64, error) {
 	return floatBalance / MUTEZ, nil
 }
 
-// GetBalanceAtBlock get the balance of an address at a specific hash
-func (s *AccountService) GetBalanceAtBlock(tezosAddr string, id interface{}) (float64, error) {
-	blockID, err := s.blockService.IDToString(id)
-	if err != nil {
-		return 0, errors.Wrapf(err, "could not get balance at block %v", id)
-	}
-
-	query := "/chains/main/blocks/" + blockID + "/context/contracts/" + tezosAddr + "/balance"
+// GetBalanceAtBlock get the balance of an address at a specific block
+func (s *AccountService) GetBalanceAtBlock(tezosAddr string, id blockid.BlockID) (float64, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + tezosAddr + "/balance"
 	resp, err := s.tzclient.Get(query, nil)
 	if err != nil {
 		return 0, errors.Wrapf(err, "could not get balance at snapshot '%s'", query)
@@ -119,6 +116,21 @@ func (s *AccountService) GetBalanceAtBlock(tezosAddr string, id interface{}) (fl
 	return floatBalance / MUTEZ, nil
 }
 
+// Balance gets the balance of address, an implicit account or an originated contract, at the block id.
+func (s *AccountService) Balance(id blockid.BlockID, address string) (tez.Mutez, error) {
+	var balance tez.Mutez
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + address + "/balance"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return balance, errors.Wrapf(err, "could not get balance '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &balance); err != nil {
+		return balance, errors.Wrapf(err, "could not get balance '%s'", query)
+	}
+	return balance, nil
+}
+
 // CreateWallet returns Wallet with the mnemonic and password provided
 func (s *AccountService) CreateWallet(mnenomic string, password string) (Wallet, error) {
 
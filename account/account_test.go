@@ -5,7 +5,9 @@ import (
 
 	"gotest.tools/assert"
 
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
 )
 
 func Test_CreateWalletWithMnemonic(t *testing.T) {
@@ -144,13 +146,13 @@ func Test_GetBalanceAtSnapshot(t *testing.T) {
 func Test_GetBalance(t *testing.T) {
 	var cases = []struct {
 		address  string
-		block    int
+		block    blockid.BlockID
 		tzclient tzc.TezosClient
 		want     float64
 	}{
 		{
 			address: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
-			block:   100000,
+			block:   blockid.Level(100000),
 			tzclient: &clientMock{
 				ReturnBody: []byte(`"450209832"`),
 			},
@@ -165,3 +167,39 @@ func Test_GetBalance(t *testing.T) {
 		assert.Equal(t, bal, tc.want)
 	}
 }
+
+func Test_Balance(t *testing.T) {
+	cases := []struct {
+		name     string
+		address  string
+		block    blockid.BlockID
+		want     tez.Mutez
+		wantPath string
+	}{
+		{
+			name:     "Implicit account at head",
+			address:  "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
+			block:    blockid.Head(),
+			want:     450209832,
+			wantPath: "/chains/main/blocks/head/context/contracts/tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ/balance",
+		},
+		{
+			name:     "Contract at a level",
+			address:  "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+			block:    blockid.Level(100000),
+			want:     450209832,
+			wantPath: "/chains/main/blocks/100000/context/contracts/KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t/balance",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(`"450209832"`)}
+			accountService := NewAccountService(client, &blockServiceMock{}, &snapshotServiceMock{})
+			balance, err := accountService.Balance(tc.block, tc.address)
+			assert.NilError(t, err)
+			assert.Equal(t, balance, tc.want)
+			assert.Equal(t, client.Path, tc.wantPath)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package account
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// Counter gets the counter of the implicit account address at the block id, the counter of its last manager operation.
+func (s *AccountService) Counter(id blockid.BlockID, address string) (int, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + address + "/counter"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get counter '%s'", query)
+	}
+
+	strCounter, err := unmarshalString(resp)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get counter '%s'", query)
+	}
+
+	counter, err := strconv.Atoi(strCounter)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get counter '%s'", query)
+	}
+	return counter, nil
+}
+
+// Delegate gets the delegate of address at the block id, or an empty string when address does not delegate.
+func (s *AccountService) Delegate(id blockid.BlockID, address string) (string, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + address + "/delegate"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		// The node answers 404 for contracts without delegate
+		var respErr *tzc.ResponseError
+		if stderrors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "could not get delegate '%s'", query)
+	}
+
+	delegate, err := unmarshalString(resp)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get delegate '%s'", query)
+	}
+	return delegate, nil
+}
+
+// ManagerKey gets the public key revealed by the implicit account address at the block id,
+// or an empty string when the account is not revealed.
+func (s *AccountService) ManagerKey(id blockid.BlockID, address string) (string, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + address + "/manager_key"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get manager key '%s'", query)
+	}
+
+	var key *string
+	if err := json.Unmarshal(resp, &key); err != nil {
+		return "", errors.Wrapf(err, "could not get manager key '%s'", query)
+	}
+	if key == nil {
+		return "", nil
+	}
+	return *key, nil
+}
@@ -0,0 +1,92 @@
+package account
+
+import (
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+const contractsPath = "/chains/main/blocks/head/context/contracts/tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ"
+
+func Test_Counter(t *testing.T) {
+	client := &clientMock{ReturnBody: []byte(`"2045"`)}
+	accountService := NewAccountService(client, &blockServiceMock{}, &snapshotServiceMock{})
+
+	counter, err := accountService.Counter(blockid.Head(), "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ")
+	assert.NilError(t, err)
+	assert.Equal(t, counter, 2045)
+	assert.Equal(t, client.Path, contractsPath+"/counter")
+}
+
+func Test_Delegate(t *testing.T) {
+	cases := []struct {
+		name    string
+		client  *clientMock
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Delegating account",
+			client: &clientMock{ReturnBody: []byte(`"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"`)},
+			want:   "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+		},
+		{
+			name:   "Account without delegate",
+			client: &clientMock{ReturnErr: &tzc.ResponseError{StatusCode: http.StatusNotFound}},
+			want:   "",
+		},
+		{
+			name:    "Node error",
+			client:  &clientMock{ReturnErr: &tzc.ResponseError{StatusCode: http.StatusInternalServerError}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			accountService := NewAccountService(tc.client, &blockServiceMock{}, &snapshotServiceMock{})
+			delegate, err := accountService.Delegate(blockid.Head(), "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ")
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, delegate, tc.want)
+			assert.Equal(t, tc.client.Path, contractsPath+"/delegate")
+		})
+	}
+}
+
+func Test_ManagerKey(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "Revealed account",
+			body: `"edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm"`,
+			want: "edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm",
+		},
+		{
+			name: "Unrevealed account",
+			body: `null`,
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(tc.body)}
+			accountService := NewAccountService(client, &blockServiceMock{}, &snapshotServiceMock{})
+			key, err := accountService.ManagerKey(blockid.Head(), "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ")
+			assert.NilError(t, err)
+			assert.Equal(t, key, tc.want)
+			assert.Equal(t, client.Path, contractsPath+"/manager_key")
+		})
+	}
+}
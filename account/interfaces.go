@@ -1,9 +1,18 @@
 package account
 
+import (
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
 type TezosAccountService interface {
 	GetBalanceAtSnapshot(tezosAddr string, cycle int) (float64, error)
 	GetBalance(tezosAddr string) (float64, error)
-	GetBalanceAtBlock(tezosAddr string, id interface{}) (float64, error)
+	GetBalanceAtBlock(tezosAddr string, id blockid.BlockID) (float64, error)
+	Balance(id blockid.BlockID, address string) (tez.Mutez, error)
+	Counter(id blockid.BlockID, address string) (int, error)
+	Delegate(id blockid.BlockID, address string) (string, error)
+	ManagerKey(id blockid.BlockID, address string) (string, error)
 	CreateWallet(mnenomic string, password string) (Wallet, error)
 	ImportWallet(address, public, secret string) (Wallet, error)
 	ImportEncryptedWallet(pw, encKey string) (Wallet, error)
@@ -1,7 +1,10 @@
 package account
 
 import (
+	"context"
+
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/snapshot"
 )
 
@@ -28,18 +31,56 @@ func (b *blockServiceMock) GetHead() (block.Block, error) {
 	}, nil
 }
 
-func (b *blockServiceMock) Get(id interface{}) (block.Block, error) {
+func (b *blockServiceMock) Get(id blockid.BlockID) (block.Block, error) {
 	return block.Block{
 		Hash: "BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
 	}, nil
 }
 
-func (b *blockServiceMock) IDToString(id interface{}) (string, error) {
-	return "", nil
+func (b *blockServiceMock) GetWithOptions(id blockid.BlockID, opts block.BlockOptions) (block.Block, error) {
+	return b.Get(id)
+}
+
+func (b *blockServiceMock) GetHeader(id blockid.BlockID) (block.Header, error) {
+	return block.Header{}, nil
+}
+
+func (b *blockServiceMock) GetOperations(id blockid.BlockID, pass int) ([]block.Operations, error) {
+	return nil, nil
+}
+
+func (b *blockServiceMock) GetOperation(id blockid.BlockID, pass, index int) (block.Operations, error) {
+	return block.Operations{}, nil
+}
+
+func (b *blockServiceMock) GetLiveBlocks(id blockid.BlockID) ([]string, error) {
+	return nil, nil
+}
+
+func (b *blockServiceMock) GetRange(ctx context.Context, from, to int, opts block.RangeOptions) (<-chan block.Block, <-chan error) {
+	return nil, nil
+}
+
+func (b *blockServiceMock) SubscribeHeads(ctx context.Context) (<-chan block.Header, <-chan error) {
+	return nil, nil
+}
+
+func (b *blockServiceMock) SubscribeEvents(ctx context.Context, filter block.EventFilter) (<-chan block.Event, <-chan error) {
+	return nil, nil
+}
+
+func (b *blockServiceMock) WaitConfirmed(ctx context.Context, opHash string, confirmations int) (block.Block, block.Operations, error) {
+	return block.Block{}, block.Operations{}, nil
+}
+
+func (b *blockServiceMock) WaitConfirmedWithOptions(ctx context.Context, opHash string, opts block.WaitOptions) (block.Block, block.Operations, error) {
+	return block.Block{}, block.Operations{}, nil
 }
 
 type clientMock struct {
 	ReturnBody []byte
+	ReturnErr  error
+	Path       string
 }
 
 func (c *clientMock) Post(path, args string) ([]byte, error) {
@@ -47,5 +88,6 @@ func (c *clientMock) Post(path, args string) ([]byte, error) {
 }
 
 func (c *clientMock) Get(path string, params map[string]string) ([]byte, error) {
-	return c.ReturnBody, nil
+	c.Path = path
+	return c.ReturnBody, c.ReturnErr
 }
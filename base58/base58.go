@@ -0,0 +1,179 @@
+// Package base58 encodes and decodes the base58check values of Tezos, e.g. addresses, keys, signatures and hashes,
+// checking their prefix and the length of their payload. Errors do not hold the value decoded, which may be a
+// secret key, callers add it when it is safe to.
+package base58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Prefix is a kind of base58check encoded value: Name is how its encoding starts, e.g. tz1, Bytes are the bytes
+// prefixing its payload before encoding and Length is the length of its payload.
+type Prefix struct {
+	Name   string
+	Bytes  []byte
+	Length int
+}
+
+// Prefixes of the values of Tezos
+var (
+	Tz1  = Prefix{"tz1", []byte{6, 161, 159}, 20}
+	Tz2  = Prefix{"tz2", []byte{6, 161, 161}, 20}
+	Tz3  = Prefix{"tz3", []byte{6, 161, 164}, 20}
+	Tz4  = Prefix{"tz4", []byte{6, 161, 166}, 20}
+	KT1  = Prefix{"KT1", []byte{2, 90, 121}, 20}
+	Sr1  = Prefix{"sr1", []byte{6, 124, 117}, 20}
+	Txr1 = Prefix{"txr1", []byte{1, 128, 120, 31}, 20}
+
+	BlockHash     = Prefix{"B", []byte{1, 52}, 32}
+	OperationHash = Prefix{"o", []byte{5, 116}, 32}
+	ChainID       = Prefix{"Net", []byte{87, 82, 0}, 4}
+	ScriptExpr    = Prefix{"expr", []byte{13, 44, 64, 27}, 32}
+	NonceHash     = Prefix{"nce", []byte{69, 220, 169}, 32}
+
+	Edpk = Prefix{"edpk", []byte{13, 15, 37, 217}, 32}
+	Sppk = Prefix{"sppk", []byte{3, 254, 226, 86}, 33}
+	P2pk = Prefix{"p2pk", []byte{3, 178, 139, 127}, 33}
+	BLpk = Prefix{"BLpk", []byte{6, 149, 135, 204}, 48}
+
+	// EdskSeed is the edsk encoding of ed25519 seeds, Edsk the one of ed25519 secret keys with their public key
+	EdskSeed = Prefix{"edsk", []byte{13, 15, 58, 7}, 32}
+	Edsk     = Prefix{"edsk", []byte{43, 246, 78, 7}, 64}
+	Spsk     = Prefix{"spsk", []byte{17, 162, 224, 201}, 32}
+	P2sk     = Prefix{"p2sk", []byte{16, 81, 238, 189}, 32}
+	BLsk     = Prefix{"BLsk", []byte{3, 150, 192, 40}, 32}
+
+	Edesk = Prefix{"edesk", []byte{7, 90, 60, 179, 41}, 56}
+	Spesk = Prefix{"spesk", []byte{9, 237, 241, 174, 150}, 56}
+	P2esk = Prefix{"p2esk", []byte{9, 48, 57, 115, 171}, 56}
+	BLesk = Prefix{"BLesk", []byte{2, 5, 30, 53, 25}, 56}
+
+	Edsig = Prefix{"edsig", []byte{9, 245, 205, 134, 18}, 64}
+	Spsig = Prefix{"spsig", []byte{13, 115, 101, 19, 63}, 64}
+	P2sig = Prefix{"p2sig", []byte{54, 240, 44, 52}, 64}
+	BLsig = Prefix{"BLsig", []byte{40, 171, 64, 207}, 96}
+	Sig   = Prefix{"sig", []byte{4, 130, 43}, 64}
+)
+
+// Prefixes are all the prefixes of the package, the ones DecodeAny recognizes.
+var Prefixes = []Prefix{
+	Tz1, Tz2, Tz3, Tz4, KT1, Sr1, Txr1,
+	BlockHash, OperationHash, ChainID, ScriptExpr, NonceHash,
+	Edpk, Sppk, P2pk, BLpk,
+	EdskSeed, Edsk, Spsk, P2sk, BLsk,
+	Edesk, Spesk, P2esk, BLesk,
+	Edsig, Spsig, P2sig, BLsig, Sig,
+}
+
+// Encode returns the base58check encoding of payload with prefix, e.g. the tz1 address of a public key hash.
+func Encode(prefix Prefix, payload []byte) (string, error) {
+	if len(payload) != prefix.Length {
+		return "", errors.Errorf("could not encode %s, expected %d bytes, got %d", prefix.Name, prefix.Length, len(payload))
+	}
+	return CheckEncode(append(append([]byte{}, prefix.Bytes...), payload...)), nil
+}
+
+// Decode decodes s, which must be encoded with prefix, and returns its payload.
+func Decode(s string, prefix Prefix) ([]byte, error) {
+	decoded, err := CheckDecode(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s", prefix.Name)
+	}
+	if !bytes.HasPrefix(decoded, prefix.Bytes) {
+		return nil, errors.Errorf("could not decode %s, unexpected prefix", prefix.Name)
+	}
+	if len(decoded) != len(prefix.Bytes)+prefix.Length {
+		return nil, errors.Errorf("could not decode %s, expected %d bytes, got %d", prefix.Name, prefix.Length, len(decoded)-len(prefix.Bytes))
+	}
+	return decoded[len(prefix.Bytes):], nil
+}
+
+// DecodeAny decodes s encoded with any of Prefixes and returns its prefix and its payload.
+func DecodeAny(s string) (Prefix, []byte, error) {
+	return DecodeOneOf(s, Prefixes...)
+}
+
+// DecodeOneOf decodes s encoded with one of prefixes and returns its prefix and its payload, e.g. to decode a
+// public key of any curve.
+func DecodeOneOf(s string, prefixes ...Prefix) (Prefix, []byte, error) {
+	decoded, err := CheckDecode(s)
+	if err != nil {
+		return Prefix{}, nil, errors.Wrap(err, "could not decode")
+	}
+	for _, prefix := range prefixes {
+		if prefix.match(decoded) {
+			return prefix, decoded[len(prefix.Bytes):], nil
+		}
+	}
+	return Prefix{}, nil, errors.New("could not decode, unknown prefix")
+}
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// CheckEncode returns the base58check encoding of data, which includes its prefix if any.
+func CheckEncode(data []byte) string {
+	first := sha256.Sum256(data)
+	checksum := sha256.Sum256(first[:])
+	data = append(append([]byte{}, data...), checksum[:4]...)
+
+	// base58 drops leading zeros, which are encoded as 1s
+	var encoded []byte
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, alphabet[0])
+	}
+
+	var digits []byte
+	n, radix, mod := new(big.Int).SetBytes(data), big.NewInt(58), new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		digits = append(digits, alphabet[mod.Int64()])
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		encoded = append(encoded, digits[i])
+	}
+	return string(encoded)
+}
+
+// CheckDecode decodes the base58check string s and verifies its checksum, it returns the decoded bytes with their
+// prefix.
+func CheckDecode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	n, radix := new(big.Int), big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		digit := bytes.IndexByte([]byte(alphabet), s[i])
+		if digit == -1 {
+			return nil, errors.New("character not found in alphabet")
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	decoded := append(make([]byte, zeros), n.Bytes()...)
+	if len(decoded) < 4 {
+		return nil, errors.New("data too short to hold a checksum")
+	}
+	data, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+
+	first := sha256.Sum256(data)
+	hash := sha256.Sum256(first[:])
+	if !bytes.Equal(checksum, hash[:4]) {
+		return nil, errors.New("data and checksum don't match")
+	}
+	return data, nil
+}
+
+// match reports whether decoded is a payload of the length of p prefixed with p
+func (p Prefix) match(decoded []byte) bool {
+	return bytes.HasPrefix(decoded, p.Bytes) && len(decoded) == len(p.Bytes)+p.Length
+}
@@ -0,0 +1,80 @@
+package base58
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_DecodeAny(t *testing.T) {
+	cases := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr string
+	}{
+		{name: "Address", s: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", want: "tz1"},
+		{name: "Contract", s: "KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi", want: "KT1"},
+		{name: "Block", s: "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT", want: "B"},
+		{name: "Chain", s: "NetXdQprcVkpaWU", want: "Net"},
+		{name: "Script expression", s: "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", want: "expr"},
+		{name: "Public key", s: "edpkunwa7a3Y5vDr9eoKy4E21pzonuhqvNjscT9XG27aQV4gXq4dNm", want: "edpk"},
+		{name: "Seed", s: "edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X", want: "edsk"},
+		{name: "Invalid checksum", s: "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoU", wantErr: "could not decode: data and checksum don't match"},
+		{name: "Unknown prefix", s: CheckEncode([]byte{1, 2, 3}), wantErr: "unknown prefix"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, payload, err := DecodeAny(tc.s)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, prefix.Name, tc.want)
+
+			encoded, err := Encode(prefix, payload)
+			assert.NilError(t, err)
+			assert.Equal(t, encoded, tc.s)
+		})
+	}
+}
+
+func Test_Prefixes(t *testing.T) {
+	// every prefix encodes to its name and decodes to itself
+	for _, prefix := range Prefixes {
+		t.Run(prefix.Name, func(t *testing.T) {
+			for _, fill := range []byte{0, 255} {
+				payload := make([]byte, prefix.Length)
+				for i := range payload {
+					payload[i] = fill
+				}
+				encoded, err := Encode(prefix, payload)
+				assert.NilError(t, err)
+				assert.Assert(t, strings.HasPrefix(encoded, prefix.Name), encoded)
+
+				decoded, payloadDecoded, err := DecodeAny(encoded)
+				assert.NilError(t, err)
+				assert.DeepEqual(t, decoded, prefix)
+				assert.DeepEqual(t, payloadDecoded, payload)
+			}
+		})
+	}
+}
+
+func Test_Decode(t *testing.T) {
+	payload, err := Decode("tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", Tz1)
+	assert.NilError(t, err)
+	assert.Equal(t, len(payload), 20)
+
+	_, err = Decode("tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", KT1)
+	assert.Error(t, err, "could not decode KT1, unexpected prefix")
+
+	_, err = Decode(CheckEncode(append(append([]byte{}, Tz1.Bytes...), 1)), Tz1)
+	assert.Error(t, err, "could not decode tz1, expected 20 bytes, got 1")
+
+	_, err = Encode(Tz1, []byte{1})
+	assert.Error(t, err, "could not encode tz1, expected 20 bytes, got 1")
+}
@@ -1,13 +1,17 @@
 package block
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
 )
 
 // BlockService is a struct wrapper for all block functions
@@ -25,37 +29,105 @@ type Block struct {
 	Operations [][]Operations `json:"operations"`
 }
 
-// Header is a header in a block returned by the Tezos RPC API.
+// Header is a header in a block returned by the Tezos RPC API. It holds the fields of every protocol,
+// Priority is set before Ithaca and PayloadHash and PayloadRound from Ithaca onward, see Round.
 type Header struct {
-	Level            int       `json:"level"`
-	Proto            int       `json:"proto"`
-	Predecessor      string    `json:"Predecessor"`
-	Timestamp        time.Time `json:"timestamp"`
-	ValidationPass   int       `json:"validation_pass"`
-	OperationsHash   string    `json:"operations_hash"`
-	Fitness          []string  `json:"fitness"`
-	Context          string    `json:"context"`
-	Priority         int       `json:"priority"`
-	ProofOfWorkNonce string    `json:"proof_of_work_nonce"`
-	Signature        string    `json:"signature"`
-}
-
-// Metadata is the Metadata in a block returned by the Tezos RPC API.
+	Protocol                  string    `json:"protocol,omitempty"`
+	ChainID                   string    `json:"chain_id,omitempty"`
+	Hash                      string    `json:"hash,omitempty"`
+	Level                     int       `json:"level"`
+	Proto                     int       `json:"proto"`
+	Predecessor               string    `json:"predecessor"`
+	Timestamp                 time.Time `json:"timestamp"`
+	ValidationPass            int       `json:"validation_pass"`
+	OperationsHash            string    `json:"operations_hash"`
+	Fitness                   []string  `json:"fitness"`
+	Context                   string    `json:"context"`
+	Priority                  int       `json:"priority"`
+	PayloadHash               string    `json:"payload_hash,omitempty"`
+	PayloadRound              int       `json:"payload_round,omitempty"`
+	ProofOfWorkNonce          string    `json:"proof_of_work_nonce"`
+	SeedNonceHash             string    `json:"seed_nonce_hash,omitempty"`
+	LiquidityBakingEscapeVote bool      `json:"liquidity_baking_escape_vote,omitempty"`
+	LiquidityBakingToggleVote string    `json:"liquidity_baking_toggle_vote,omitempty"`
+	AdaptiveIssuanceVote      string    `json:"adaptive_issuance_vote,omitempty"`
+	Signature                 string    `json:"signature"`
+}
+
+// Round returns the round the block payload was proposed at, the priority of the block before Ithaca.
+func (h Header) Round() int {
+	if h.PayloadHash != "" {
+		return h.PayloadRound
+	}
+	return h.Priority
+}
+
+// Metadata is the Metadata in a block returned by the Tezos RPC API. It holds the fields of every protocol,
+// Level and VotingPeriodKind are filled from LevelInfo and VotingPeriodInfo for protocols from Granada onward.
 type Metadata struct {
-	Protocol               string                   `json:"protocol"`
-	NextProtocol           string                   `json:"next_protocol"`
-	TestChainStatus        TestChainStatus          `json:"test_chain_status"`
-	MaxOperationsTTL       int                      `json:"max_operations_ttl"`
-	MaxOperationDataLength int                      `json:"max_operation_data_length"`
-	MaxBlockHeaderLength   int                      `json:"max_block_header_length"`
-	MaxOperationListLength []MaxOperationListLength `json:"max_operation_list_length"`
-	Baker                  string                   `json:"baker"`
-	Level                  Level                    `json:"level"`
-	VotingPeriodKind       string                   `json:"voting_period_kind"`
-	NonceHash              interface{}              `json:"nonce_hash"`
-	ConsumedGas            string                   `json:"consumed_gas"`
-	Deactivated            []string                 `json:"deactivated"`
-	BalanceUpdates         []BalanceUpdates         `json:"balance_updates"`
+	Protocol                        string                   `json:"protocol"`
+	NextProtocol                    string                   `json:"next_protocol"`
+	TestChainStatus                 TestChainStatus          `json:"test_chain_status"`
+	MaxOperationsTTL                int                      `json:"max_operations_ttl"`
+	MaxOperationDataLength          int                      `json:"max_operation_data_length"`
+	MaxBlockHeaderLength            int                      `json:"max_block_header_length"`
+	MaxOperationListLength          []MaxOperationListLength `json:"max_operation_list_length"`
+	Proposer                        string                   `json:"proposer,omitempty"`
+	Baker                           string                   `json:"baker"`
+	ProposerConsensusKey            string                   `json:"proposer_consensus_key,omitempty"`
+	BakerConsensusKey               string                   `json:"baker_consensus_key,omitempty"`
+	Level                           Level                    `json:"level"`
+	LevelInfo                       *Level                   `json:"level_info,omitempty"`
+	VotingPeriodKind                string                   `json:"voting_period_kind"`
+	VotingPeriodInfo                *VotingPeriodInfo        `json:"voting_period_info,omitempty"`
+	NonceHash                       interface{}              `json:"nonce_hash"`
+	ConsumedGas                     tez.Zarith               `json:"consumed_gas"`
+	ConsumedMilligas                tez.Zarith               `json:"consumed_milligas"`
+	Deactivated                     []string                 `json:"deactivated"`
+	BalanceUpdates                  []BalanceUpdates         `json:"balance_updates"`
+	LiquidityBakingEscapeEma        int                      `json:"liquidity_baking_escape_ema,omitempty"`
+	LiquidityBakingToggleEma        int                      `json:"liquidity_baking_toggle_ema,omitempty"`
+	AdaptiveIssuanceVoteEma         int                      `json:"adaptive_issuance_vote_ema,omitempty"`
+	AdaptiveIssuanceActivationCycle *int                     `json:"adaptive_issuance_activation_cycle,omitempty"`
+	ImplicitOperationsResults       []OperationResult        `json:"implicit_operations_results,omitempty"`
+	DalAttestation                  string                   `json:"dal_attestation,omitempty"`
+}
+
+// VotingPeriodInfo is the voting period a block belongs to, found in the Metadata of blocks from Granada onward.
+type VotingPeriodInfo struct {
+	VotingPeriod VotingPeriod `json:"voting_period"`
+	Position     int          `json:"position"`
+	Remaining    int          `json:"remaining"`
+}
+
+// VotingPeriod is a voting period found in VotingPeriodInfo.
+type VotingPeriod struct {
+	Index         int    `json:"index"`
+	Kind          string `json:"kind"`
+	StartPosition int    `json:"start_position"`
+}
+
+// UnmarshalJSON unmarshals Metadata, filling Level and VotingPeriodKind from their newer equivalents when missing.
+func (m *Metadata) UnmarshalJSON(v []byte) error {
+	type metadata Metadata
+	var raw metadata
+	if err := json.Unmarshal(v, &raw); err != nil {
+		return err
+	}
+
+	*m = Metadata(raw)
+	if m.LevelInfo != nil && m.Level.Level == 0 {
+		m.Level = *m.LevelInfo
+		if m.VotingPeriodInfo != nil {
+			m.Level.VotingPeriod = m.VotingPeriodInfo.VotingPeriod.Index
+			m.Level.VotingPeriodPosition = m.VotingPeriodInfo.Position
+		}
+	}
+	if m.VotingPeriodInfo != nil && m.VotingPeriodKind == "" {
+		m.VotingPeriodKind = m.VotingPeriodInfo.VotingPeriod.Kind
+	}
+
+	return nil
 }
 
 // TestChainStatus is the TestChainStatus found in the Metadata of a block returned by the Tezos RPC API.
@@ -82,20 +154,76 @@ type Level struct {
 
 // BalanceUpdates is the BalanceUpdates found in the Metadata of a block returned by the Tezos RPC API.
 type BalanceUpdates struct {
-	Kind     string `json:"kind"`
-	Contract string `json:"contract,omitempty"`
-	Change   string `json:"change"`
-	Category string `json:"category,omitempty"`
-	Delegate string `json:"delegate,omitempty"`
-	Cycle    int    `json:"cycle,omitempty"`
-	Level    int    `json:"level,omitempty"`
+	Kind     string    `json:"kind"`
+	Contract string    `json:"contract,omitempty"`
+	Change   tez.Mutez `json:"change"`
+	Category string    `json:"category,omitempty"`
+	Delegate string    `json:"delegate,omitempty"`
+	Cycle    int       `json:"cycle,omitempty"`
+	Level    int       `json:"level,omitempty"`
+
+	// Fields of protocols from Ithaca onward
+	Origin               string          `json:"origin,omitempty"`
+	Participation        *bool           `json:"participation,omitempty"`
+	Revelation           *bool           `json:"revelation,omitempty"`
+	Committer            string          `json:"committer,omitempty"`
+	Staker               json.RawMessage `json:"staker,omitempty"`
+	DelayedOperationHash string          `json:"delayed_operation_hash,omitempty"`
 }
 
 // OperationResult is the OperationResult found in metadata of block returned by the Tezos RPC API.
 type OperationResult struct {
-	Status      string  `json:"status"`
-	ConsumedGas string  `json:"consumed_gas,omitempty"`
-	Errors      []Error `json:"errors,omitempty"`
+	Status                       string            `json:"status"`
+	ConsumedGas                  tez.Zarith        `json:"consumed_gas"`
+	ConsumedMilligas             tez.Zarith        `json:"consumed_milligas"`
+	Errors                       []Error           `json:"errors,omitempty"`
+	Storage                      json.RawMessage   `json:"storage,omitempty"`
+	BigMapDiff                   []BigMapDiff      `json:"big_map_diff,omitempty"`
+	LazyStorageDiff              []LazyStorageDiff `json:"lazy_storage_diff,omitempty"`
+	BalanceUpdates               []BalanceUpdates  `json:"balance_updates,omitempty"`
+	OriginatedContracts          []string          `json:"originated_contracts,omitempty"`
+	StorageSize                  tez.Zarith        `json:"storage_size"`
+	PaidStorageSizeDiff          tez.Zarith        `json:"paid_storage_size_diff"`
+	AllocatedDestinationContract bool              `json:"allocated_destination_contract,omitempty"`
+	GlobalAddress                string            `json:"global_address,omitempty"`
+	TicketUpdates                json.RawMessage   `json:"ticket_updates,omitempty"`
+	TicketReceipt                json.RawMessage   `json:"ticket_receipt,omitempty"`
+	Address                      string            `json:"address,omitempty"`
+	GenesisCommitmentHash        string            `json:"genesis_commitment_hash,omitempty"`
+	Size                         string            `json:"size,omitempty"`
+}
+
+// BigMapDiff is a change to a big map made by an operation, found in the big_map_diff of an OperationResult.
+// Action is one of update, remove, copy or alloc, it is empty for operations of protocols before Babylon.
+type BigMapDiff struct {
+	Action            string          `json:"action,omitempty"`
+	BigMap            string          `json:"big_map,omitempty"`
+	KeyHash           string          `json:"key_hash,omitempty"`
+	Key               json.RawMessage `json:"key,omitempty"`
+	Value             json.RawMessage `json:"value,omitempty"`
+	SourceBigMap      string          `json:"source_big_map,omitempty"`
+	DestinationBigMap string          `json:"destination_big_map,omitempty"`
+	KeyType           json.RawMessage `json:"key_type,omitempty"`
+	ValueType         json.RawMessage `json:"value_type,omitempty"`
+}
+
+// InternalOperationResult is an operation emitted by a smart contract while applying a manager operation,
+// e.g. a transfer, an origination or an event. It is found in the metadata of the Contents that triggered it.
+type InternalOperationResult struct {
+	Kind        string           `json:"kind"`
+	Source      string           `json:"source"`
+	Nonce       int              `json:"nonce"`
+	Amount      tez.Mutez        `json:"amount,omitempty"`
+	Destination string           `json:"destination,omitempty"`
+	Parameters  *Parameters      `json:"parameters,omitempty"`
+	PublicKey   string           `json:"public_key,omitempty"`
+	Balance     tez.Mutez        `json:"balance,omitempty"`
+	Delegate    string           `json:"delegate,omitempty"`
+	Script      *Script          `json:"script,omitempty"`
+	Type        json.RawMessage  `json:"type,omitempty"`
+	Tag         string           `json:"tag,omitempty"`
+	Payload     json.RawMessage  `json:"payload,omitempty"`
+	Result      *OperationResult `json:"result,omitempty"`
 }
 
 // Operations is the Operations found in a block returned by the Tezos RPC API.
@@ -108,27 +236,254 @@ type Operations struct {
 	Signature string     `json:"signature"`
 }
 
+// Validation passes operations of a block are grouped by, see GetOperations
+const (
+	// ConsensusPass holds endorsements/attestations.
+	ConsensusPass = 0
+	// VotingPass holds proposals and ballots.
+	VotingPass = 1
+	// AnonymousPass holds nonce revelations, denunciations and activations.
+	AnonymousPass = 2
+	// ManagerPass holds manager operations such as transactions, originations and delegations.
+	ManagerPass = 3
+)
+
+// Kinds of operation Contents
+const (
+	KindEndorsement                     = "endorsement"
+	KindEndorsementWithSlot             = "endorsement_with_slot"
+	KindPreendorsement                  = "preendorsement"
+	KindAttestation                     = "attestation"
+	KindAttestationWithDal              = "attestation_with_dal"
+	KindPreattestation                  = "preattestation"
+	KindDalAttestation                  = "dal_attestation"
+	KindSeedNonceRevelation             = "seed_nonce_revelation"
+	KindVdfRevelation                   = "vdf_revelation"
+	KindDoubleEndorsementEvidence       = "double_endorsement_evidence"
+	KindDoublePreendorsementEvidence    = "double_preendorsement_evidence"
+	KindDoubleAttestationEvidence       = "double_attestation_evidence"
+	KindDoublePreattestationEvidence    = "double_preattestation_evidence"
+	KindDoubleBakingEvidence            = "double_baking_evidence"
+	KindActivateAccount                 = "activate_account"
+	KindDrainDelegate                   = "drain_delegate"
+	KindProposals                       = "proposals"
+	KindBallot                          = "ballot"
+	KindReveal                          = "reveal"
+	KindTransaction                     = "transaction"
+	KindOrigination                     = "origination"
+	KindDelegation                      = "delegation"
+	KindRegisterGlobalConstant          = "register_global_constant"
+	KindSetDepositsLimit                = "set_deposits_limit"
+	KindIncreasePaidStorage             = "increase_paid_storage"
+	KindUpdateConsensusKey              = "update_consensus_key"
+	KindTransferTicket                  = "transfer_ticket"
+	KindSmartRollupOriginate            = "smart_rollup_originate"
+	KindSmartRollupAddMessages          = "smart_rollup_add_messages"
+	KindSmartRollupCement               = "smart_rollup_cement"
+	KindSmartRollupPublish              = "smart_rollup_publish"
+	KindSmartRollupRefute               = "smart_rollup_refute"
+	KindSmartRollupTimeout              = "smart_rollup_timeout"
+	KindSmartRollupExecuteOutboxMessage = "smart_rollup_execute_outbox_message"
+	KindSmartRollupRecoverBond          = "smart_rollup_recover_bond"
+	KindDalPublishCommitment            = "dal_publish_commitment"
+	KindZkRollupOrigination             = "zk_rollup_origination"
+	KindZkRollupPublish                 = "zk_rollup_publish"
+	KindZkRollupUpdate                  = "zk_rollup_update"
+	KindTxRollupOrigination             = "tx_rollup_origination"
+	KindTxRollupSubmitBatch             = "tx_rollup_submit_batch"
+	KindTxRollupCommit                  = "tx_rollup_commit"
+	KindTxRollupReturnBond              = "tx_rollup_return_bond"
+	KindTxRollupFinalizeCommitment      = "tx_rollup_finalize_commitment"
+	KindTxRollupRemoveCommitment        = "tx_rollup_remove_commitment"
+	KindTxRollupRejection               = "tx_rollup_rejection"
+	KindTxRollupDispatchTickets         = "tx_rollup_dispatch_tickets"
+	KindFailingNoop                     = "failing_noop"
+)
+
 // Contents is the Contents found in a operation of a block returned by the Tezos RPC API.
+// It holds the fields of every kind of operation, only the fields of Kind are set.
 type Contents struct {
 	Kind             string            `json:"kind,omitempty"`
 	Source           string            `json:"source,omitempty"`
-	Fee              string            `json:"fee,omitempty"`
-	Counter          string            `json:"counter,omitempty"`
-	GasLimit         string            `json:"gas_limit,omitempty"`
-	StorageLimit     string            `json:"storage_limit,omitempty"`
-	Amount           string            `json:"amount,omitempty"`
+	Fee              tez.Mutez         `json:"fee"`
+	Counter          tez.Zarith        `json:"counter"`
+	GasLimit         tez.Zarith        `json:"gas_limit"`
+	StorageLimit     tez.Zarith        `json:"storage_limit"`
+	Amount           tez.Mutez         `json:"amount"`
 	Destination      string            `json:"destination,omitempty"`
 	Delegate         string            `json:"delegate,omitempty"`
 	Phk              string            `json:"phk,omitempty"`
 	Secret           string            `json:"secret,omitempty"`
 	Level            int               `json:"level,omitempty"`
 	ManagerPublicKey string            `json:"managerPubkey,omitempty"`
-	Balance          string            `json:"balance,omitempty"`
+	Balance          tez.Mutez         `json:"balance"`
 	Period           int               `json:"period,omitempty"`
 	Proposal         string            `json:"proposal,omitempty"`
 	Proposals        []string          `json:"proposals,omitempty"`
 	Ballot           string            `json:"ballot,omitempty"`
 	Metadata         *ContentsMetadata `json:"metadata,omitempty"`
+
+	// Consensus operations
+	Slot             *int              `json:"slot,omitempty"`
+	Round            *int              `json:"round,omitempty"`
+	BlockPayloadHash string            `json:"block_payload_hash,omitempty"`
+	Endorsement      *InlinedOperation `json:"endorsement,omitempty"`
+	DalAttestation   string            `json:"dal_attestation,omitempty"`
+	Attestor         string            `json:"attestor,omitempty"`
+	Attestation      json.RawMessage   `json:"attestation,omitempty"`
+
+	// Anonymous operations
+	Nonce        string            `json:"nonce,omitempty"`
+	Solution     []string          `json:"solution,omitempty"`
+	Op1          *InlinedOperation `json:"op1,omitempty"`
+	Op2          *InlinedOperation `json:"op2,omitempty"`
+	Bh1          *Header           `json:"bh1,omitempty"`
+	Bh2          *Header           `json:"bh2,omitempty"`
+	Pkh          string            `json:"pkh,omitempty"`
+	ConsensusKey string            `json:"consensus_key,omitempty"`
+	Arbitrary    string            `json:"arbitrary,omitempty"`
+
+	// Manager operations
+	PublicKey      string          `json:"public_key,omitempty"`
+	Parameters     *Parameters     `json:"parameters,omitempty"`
+	Script         *Script         `json:"script,omitempty"`
+	ManagerPubkey  string          `json:"manager_pubkey,omitempty"`
+	Spendable      *bool           `json:"spendable,omitempty"`
+	Delegatable    *bool           `json:"delegatable,omitempty"`
+	Value          json.RawMessage `json:"value,omitempty"`
+	Limit          *tez.Mutez      `json:"limit,omitempty"`
+	Pk             string          `json:"pk,omitempty"`
+	Proof          string          `json:"proof,omitempty"`
+	TicketContents json.RawMessage `json:"ticket_contents,omitempty"`
+	TicketType     json.RawMessage `json:"ticket_ty,omitempty"`
+	TicketTicketer string          `json:"ticket_ticketer,omitempty"`
+	TicketAmount   tez.Zarith      `json:"ticket_amount"`
+	Entrypoint     string          `json:"entrypoint,omitempty"`
+
+	// Rollup operations
+	Rollup             string          `json:"rollup,omitempty"`
+	PvmKind            string          `json:"pvm_kind,omitempty"`
+	Kernel             string          `json:"kernel,omitempty"`
+	ParametersType     json.RawMessage `json:"parameters_ty,omitempty"`
+	Whitelist          []string        `json:"whitelist,omitempty"`
+	Message            []string        `json:"message,omitempty"`
+	Commitment         json.RawMessage `json:"commitment,omitempty"`
+	Opponent           string          `json:"opponent,omitempty"`
+	Refutation         json.RawMessage `json:"refutation,omitempty"`
+	Stakers            json.RawMessage `json:"stakers,omitempty"`
+	Staker             string          `json:"staker,omitempty"`
+	CementedCommitment string          `json:"cemented_commitment,omitempty"`
+	OutputProof        string          `json:"output_proof,omitempty"`
+	SlotHeader         json.RawMessage `json:"slot_header,omitempty"`
+	PublicParameters   json.RawMessage `json:"public_parameters,omitempty"`
+	CircuitsInfo       json.RawMessage `json:"circuits_info,omitempty"`
+	InitState          json.RawMessage `json:"init_state,omitempty"`
+	NbOps              int             `json:"nb_ops,omitempty"`
+	ZkRollup           string          `json:"zk_rollup,omitempty"`
+	Op                 json.RawMessage `json:"op,omitempty"`
+	Update             json.RawMessage `json:"update,omitempty"`
+	Content            string          `json:"content,omitempty"`
+	BurnLimit          string          `json:"burn_limit,omitempty"`
+
+	// Raw is the contents as returned by the node, it keeps the fields of kinds the struct does not cover
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON unmarshals Contents keeping the raw contents. Some kinds, like tx_rollup_rejection,
+// use field names of other kinds with another type, only their common fields are unmarshaled.
+func (c *Contents) UnmarshalJSON(v []byte) error {
+	type contents Contents
+	var typed contents
+	if err := json.Unmarshal(v, &typed); err != nil {
+		var common struct {
+			Kind         string            `json:"kind"`
+			Source       string            `json:"source"`
+			Fee          tez.Mutez         `json:"fee"`
+			Counter      tez.Zarith        `json:"counter"`
+			GasLimit     tez.Zarith        `json:"gas_limit"`
+			StorageLimit tez.Zarith        `json:"storage_limit"`
+			Metadata     *ContentsMetadata `json:"metadata"`
+		}
+		if json.Unmarshal(v, &common) != nil {
+			return err
+		}
+		typed = contents{
+			Kind:         common.Kind,
+			Source:       common.Source,
+			Fee:          common.Fee,
+			Counter:      common.Counter,
+			GasLimit:     common.GasLimit,
+			StorageLimit: common.StorageLimit,
+			Metadata:     common.Metadata,
+		}
+	}
+
+	*c = Contents(typed)
+	c.Raw = append(json.RawMessage{}, v...)
+	return nil
+}
+
+// MarshalJSON marshals Contents leaving out the numeric fields Kind does not have,
+// e.g. the fee and counter of consensus operations.
+func (c Contents) MarshalJSON() ([]byte, error) {
+	type contents Contents
+	v, err := json.Marshal(contents(c))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(v, &fields); err != nil {
+		return nil, err
+	}
+
+	manager := IsManagerKind(c.Kind)
+	required := map[string]bool{
+		"fee":           manager,
+		"counter":       manager,
+		"gas_limit":     manager,
+		"storage_limit": manager,
+		"amount":        c.Kind == KindTransaction || c.Kind == KindIncreasePaidStorage,
+		"balance":       c.Kind == KindOrigination,
+		"ticket_amount": c.Kind == KindTransferTicket,
+	}
+	for name, isRequired := range required {
+		if !isRequired && string(fields[name]) == `"0"` {
+			delete(fields, name)
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// IsManagerKind reports if kind is the kind of a manager operation, an operation with a source paying a fee.
+func IsManagerKind(kind string) bool {
+	switch kind {
+	case KindReveal, KindTransaction, KindOrigination, KindDelegation, KindRegisterGlobalConstant,
+		KindSetDepositsLimit, KindIncreasePaidStorage, KindUpdateConsensusKey, KindTransferTicket,
+		KindDalPublishCommitment:
+		return true
+	}
+	return strings.HasPrefix(kind, "smart_rollup_") || strings.HasPrefix(kind, "zk_rollup_") || strings.HasPrefix(kind, "tx_rollup_")
+}
+
+// Parameters are the parameters of a transaction calling a smart contract.
+type Parameters struct {
+	Entrypoint string          `json:"entrypoint"`
+	Value      json.RawMessage `json:"value"`
+}
+
+// Script is the code and initial storage of an originated contract.
+type Script struct {
+	Code    json.RawMessage `json:"code"`
+	Storage json.RawMessage `json:"storage"`
+}
+
+// InlinedOperation is an operation embedded in another, e.g. the endorsements of a double endorsement evidence.
+type InlinedOperation struct {
+	Branch     string    `json:"branch"`
+	Operations *Contents `json:"operations"`
+	Signature  string    `json:"signature,omitempty"`
 }
 
 // ContentsMetadata is the Metadata found in the Contents in a operation of a block returned by the Tezos RPC API.
@@ -136,6 +491,15 @@ type ContentsMetadata struct {
 	BalanceUpdates  []BalanceUpdates `json:"balance_updates"`
 	OperationResult *OperationResult `json:"operation_result,omitempty"`
 	Slots           []int            `json:"slots"`
+
+	InternalOperationResults []InternalOperationResult `json:"internal_operation_results,omitempty"`
+
+	// Consensus operations
+	Delegate            string `json:"delegate,omitempty"`
+	ConsensusKey        string `json:"consensus_key,omitempty"`
+	EndorsementPower    int    `json:"endorsement_power,omitempty"`
+	PreendorsementPower int    `json:"preendorsement_power,omitempty"`
+	ConsensusPower      int    `json:"consensus_power,omitempty"`
 }
 
 // Error is the Error found in the OperationResult in a metadata of operation of a block returned by the Tezos RPC API.
@@ -144,11 +508,48 @@ type Error struct {
 	ID   string `json:"id"`
 }
 
+// MetadataMode selects if the node returns the metadata of a block, see BlockOptions
+type MetadataMode string
+
+const (
+	// MetadataAlways asks the node to return metadata, recomputing it for blocks it pruned.
+	MetadataAlways MetadataMode = "always"
+	// MetadataNever asks the node to leave out metadata, which shrinks the payload a lot.
+	MetadataNever MetadataMode = "never"
+)
+
+// BlockOptions are the query parameters of block requests. The zero value keeps the node defaults.
+type BlockOptions struct {
+	Metadata MetadataMode
+	// Version is the version of the RPC output, e.g. "1"
+	Version string
+}
+
+// params returns the query parameters for opts
+func (o BlockOptions) params() map[string]string {
+	params := map[string]string{}
+	if o.Metadata != "" {
+		params["metadata"] = string(o.Metadata)
+	}
+	if o.Version != "" {
+		params["version"] = o.Version
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
 // NewBlockService creates a new BlockService
 func NewBlockService(tzclient tzc.TezosClient) *BlockService {
 	return &BlockService{tzclient: tzclient}
 }
 
+// withContext returns a copy of b sending its requests with ctx, see tzc.WithContext
+func (b *BlockService) withContext(ctx context.Context) *BlockService {
+	return &BlockService{tzclient: tzc.WithContext(ctx, b.tzclient)}
+}
+
 // GetHead returns the head block
 func (b *BlockService) GetHead() (Block, error) {
 	var block Block
@@ -166,42 +567,92 @@ func (b *BlockService) GetHead() (Block, error) {
 	return block, nil
 }
 
-// Get returns a Block at a specific level or hash
-func (b *BlockService) Get(id interface{}) (Block, error) {
+// Get returns the Block with id, e.g. blockid.Level(1000) or blockid.Head().Minus(2)
+func (b *BlockService) Get(id blockid.BlockID) (Block, error) {
+	return b.GetWithOptions(id, BlockOptions{})
+}
+
+// GetWithOptions returns the Block with id, using opts to skip or force its metadata
+func (b *BlockService) GetWithOptions(id blockid.BlockID, opts BlockOptions) (Block, error) {
 	var block Block
+	query := "/chains/main/blocks/" + id.String()
+	resp, err := b.tzclient.Get(query, opts.params())
+	if err != nil {
+		return block, errors.Wrapf(err, "could not get block '%s'", query)
+	}
+
+	block, err = block.unmarshalJSON(resp)
+	if err != nil {
+		return block, errors.Wrapf(err, "could not get block '%s'", query)
+	}
 
-	query := "/chains/main/blocks/"
+	return block, nil
+}
 
-	blockID, err := b.IDToString(id)
+// GetHeader returns only the Header of the block with id, which is much lighter than the full Block
+func (b *BlockService) GetHeader(id blockid.BlockID) (Header, error) {
+	var header Header
+	query := "/chains/main/blocks/" + id.String() + "/header"
+	resp, err := b.tzclient.Get(query, nil)
 	if err != nil {
-		return block, err
+		return header, errors.Wrapf(err, "could not get header '%s'", query)
 	}
 
-	query += blockID
+	header, err = header.unmarshalJSON(resp)
+	if err != nil {
+		return header, errors.Wrapf(err, "could not get header '%s'", query)
+	}
+
+	return header, nil
+}
 
+// GetOperations returns the operations of the validation pass in the block with id, e.g. ManagerPass
+func (b *BlockService) GetOperations(id blockid.BlockID, pass int) ([]Operations, error) {
+	var operations []Operations
+	query := "/chains/main/blocks/" + id.String() + "/operations/" + strconv.Itoa(pass)
 	resp, err := b.tzclient.Get(query, nil)
 	if err != nil {
-		return block, errors.Wrap(err, "could not get block '%s'")
+		return operations, errors.Wrapf(err, "could not get operations '%s'", query)
 	}
 
-	block, err = block.unmarshalJSON(resp)
+	if err := json.Unmarshal(resp, &operations); err != nil {
+		return operations, errors.Wrapf(err, "could not get operations '%s'", query)
+	}
+
+	return operations, nil
+}
+
+// GetOperation returns the operation at index in the validation pass of the block with id
+func (b *BlockService) GetOperation(id blockid.BlockID, pass, index int) (Operations, error) {
+	var operation Operations
+	query := "/chains/main/blocks/" + id.String() + "/operations/" + strconv.Itoa(pass) + "/" + strconv.Itoa(index)
+	resp, err := b.tzclient.Get(query, nil)
 	if err != nil {
-		return block, errors.Wrap(err, "could not get block '%s'")
+		return operation, errors.Wrapf(err, "could not get operation '%s'", query)
 	}
 
-	return block, nil
+	if err := json.Unmarshal(resp, &operation); err != nil {
+		return operation, errors.Wrapf(err, "could not get operation '%s'", query)
+	}
+
+	return operation, nil
 }
 
-// IDToString returns a queryable block reference for a specific level or hash
-func (b *BlockService) IDToString(id interface{}) (string, error) {
-	switch v := id.(type) {
-	case int:
-		return strconv.Itoa(v), nil
-	case string:
-		return v, nil
-	default:
-		return "", errors.Errorf("invalid block id type, must be string or int")
+// GetLiveBlocks returns the hashes of the blocks an operation forged on top of the block with id may use
+// as its branch, the operation would be refused once its branch is no longer live.
+func (b *BlockService) GetLiveBlocks(id blockid.BlockID) ([]string, error) {
+	var liveBlocks []string
+	query := "/chains/main/blocks/" + id.String() + "/live_blocks"
+	resp, err := b.tzclient.Get(query, nil)
+	if err != nil {
+		return liveBlocks, errors.Wrapf(err, "could not get live blocks '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &liveBlocks); err != nil {
+		return liveBlocks, errors.Wrapf(err, "could not get live blocks '%s'", query)
 	}
+
+	return liveBlocks, nil
 }
 
 // UnmarshalJSON unmarshals the bytes received as a parameter, into the type Block.
@@ -213,3 +664,13 @@ func (b *Block) unmarshalJSON(v []byte) (Block, error) {
 	}
 	return block, nil
 }
+
+// unmarshalJSON unmarshals the bytes received as a parameter, into the type Header.
+func (h *Header) unmarshalJSON(v []byte) (Header, error) {
+	header := Header{}
+	err := json.Unmarshal(v, &header)
+	if err != nil {
+		return header, errors.Wrap(err, "could not unmarshal bytes to Header")
+	}
+	return header, nil
+}
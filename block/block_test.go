@@ -6,18 +6,20 @@ import (
 
 	"gotest.tools/assert"
 
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	tezc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
 )
 
 func Test_Get(t *testing.T) {
 	cases := []struct {
-		id       interface{}
+		id       blockid.BlockID
 		want     []byte
 		wantErr  bool
 		tzclient tezc.TezosClient
 	}{
 		{
-			id:      "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT",
+			id:      blockid.Hash("BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT"),
 			want:    goldenBlock,
 			wantErr: false,
 			tzclient: &client{
@@ -25,7 +27,7 @@ func Test_Get(t *testing.T) {
 			},
 		},
 		{
-			id:      "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT",
+			id:      blockid.Hash("BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT"),
 			want:    goldenBlock,
 			wantErr: true,
 			tzclient: &client{
@@ -96,3 +98,293 @@ func Test_GetHead(t *testing.T) {
 
 	}
 }
+
+func Test_GetWithOptions(t *testing.T) {
+	cases := []struct {
+		opts       BlockOptions
+		wantParams map[string]string
+	}{
+		{
+			opts: BlockOptions{},
+		},
+		{
+			opts:       BlockOptions{Metadata: MetadataNever},
+			wantParams: map[string]string{"metadata": "never"},
+		},
+		{
+			opts:       BlockOptions{Metadata: MetadataAlways, Version: "1"},
+			wantParams: map[string]string{"metadata": "always", "version": "1"},
+		},
+	}
+
+	for _, tc := range cases {
+		tzclient := &client{ReturnBody: goldenBlock}
+		blockService := NewBlockService(tzclient)
+
+		_, err := blockService.GetWithOptions(blockid.Level(524067), tc.opts)
+		assert.NilError(t, err)
+		assert.Equal(t, tzclient.Path, "/chains/main/blocks/524067")
+		assert.DeepEqual(t, tzclient.Params, tc.wantParams)
+	}
+}
+
+func Test_GetHeader(t *testing.T) {
+	cases := []struct {
+		tzclient *client
+		want     Header
+		wantErr  bool
+	}{
+		{
+			tzclient: &client{
+				ReturnBody: []byte(`{"protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","chain_id":"NetXdQprcVkpaWU","hash":"BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT","level":524067,"proto":4,"predecessor":"BMdw66rEAHYSu1WRwpVehpWUrB2tdt8RmGRYEt5YT6vs63zuWPU"}`),
+			},
+			want: Header{
+				Protocol:    "PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS",
+				ChainID:     "NetXdQprcVkpaWU",
+				Hash:        "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT",
+				Level:       524067,
+				Proto:       4,
+				Predecessor: "BMdw66rEAHYSu1WRwpVehpWUrB2tdt8RmGRYEt5YT6vs63zuWPU",
+			},
+		},
+		{
+			tzclient: &client{
+				ReturnBody: []byte("malformed response"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		header, err := NewBlockService(tc.tzclient).GetHeader(blockid.Head())
+		assert.Equal(t, tc.tzclient.Path, "/chains/main/blocks/head/header")
+		if tc.wantErr {
+			assert.Assert(t, err != nil)
+			continue
+		}
+		assert.NilError(t, err)
+		assert.DeepEqual(t, header, tc.want)
+	}
+}
+
+func Test_GetOperations(t *testing.T) {
+	tzclient := &client{
+		ReturnBody: []byte(`[{"protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","hash":"opA","contents":[{"kind":"transaction","amount":"1000"}]}]`),
+	}
+	blockService := NewBlockService(tzclient)
+
+	operations, err := blockService.GetOperations(blockid.Level(10), ManagerPass)
+	assert.NilError(t, err)
+	assert.Equal(t, tzclient.Path, "/chains/main/blocks/10/operations/3")
+	assert.Equal(t, len(operations), 1)
+	assert.Equal(t, operations[0].Contents[0].Amount, tez.Mutez(1000))
+
+	tzclient.ReturnBody = []byte(`{"hash":"opB","contents":[{"kind":"ballot","ballot":"yay"}]}`)
+	operation, err := blockService.GetOperation(blockid.Level(10), VotingPass, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, tzclient.Path, "/chains/main/blocks/10/operations/1/2")
+	assert.Equal(t, operation.Hash, "opB")
+	assert.Equal(t, operation.Contents[0].Ballot, "yay")
+
+	tzclient.ReturnBody = []byte("malformed response")
+	_, err = blockService.GetOperations(blockid.Head(), ConsensusPass)
+	assert.Assert(t, err != nil)
+}
+
+func Test_ContentsRoundTrip(t *testing.T) {
+	cases := []string{
+		`{"kind":"attestation","slot":0,"level":5000000,"round":1,"block_payload_hash":"vh2TyrWeZ2dydEy9ZjmvrjQvyCs5tdRoC2yQ6a2fjmUjpXxHNAzE"}`,
+		`{"kind":"endorsement_with_slot","endorsement":{"branch":"BLa","operations":{"kind":"endorsement","level":1000},"signature":"sigA"},"slot":3}`,
+		`{"kind":"seed_nonce_revelation","level":1000,"nonce":"a5a1"}`,
+		`{"kind":"vdf_revelation","solution":["00aa","00bb"]}`,
+		`{"kind":"double_baking_evidence","bh1":{"level":10,"proto":1,"predecessor":"BLa","timestamp":"2019-07-16T14:59:56Z","validation_pass":4,"operations_hash":"LLoa","fitness":["00"],"context":"CoV","priority":0,"proof_of_work_nonce":"00","signature":"sigA"},"bh2":{"level":10,"proto":1,"predecessor":"BLa","timestamp":"2019-07-16T14:59:56Z","validation_pass":4,"operations_hash":"LLob","fitness":["00"],"context":"CoV","priority":0,"proof_of_work_nonce":"00","signature":"sigB"}}`,
+		`{"kind":"double_attestation_evidence","op1":{"branch":"BLa","operations":{"kind":"attestation","slot":1,"level":10,"round":0,"block_payload_hash":"vha"},"signature":"sigA"},"op2":{"branch":"BLa","operations":{"kind":"attestation","slot":1,"level":10,"round":0,"block_payload_hash":"vhb"},"signature":"sigB"}}`,
+		`{"kind":"activate_account","pkh":"tz1a","secret":"41f9"}`,
+		`{"kind":"drain_delegate","consensus_key":"tz1a","delegate":"tz1b","destination":"tz1c"}`,
+		`{"kind":"reveal","source":"tz4a","fee":"374","counter":"10","gas_limit":"1000","storage_limit":"0","public_key":"BLpk1","proof":"BLsig1"}`,
+		`{"kind":"transaction","source":"tz1a","fee":"1000","counter":"11","gas_limit":"2000","storage_limit":"100","amount":"0","destination":"KT1a","parameters":{"entrypoint":"transfer","value":{"prim":"Unit"}}}`,
+		`{"kind":"origination","source":"tz1a","fee":"1000","counter":"12","gas_limit":"2000","storage_limit":"500","balance":"0","script":{"code":[{"prim":"parameter","args":[{"prim":"unit"}]}],"storage":{"int":"0"}}}`,
+		`{"kind":"register_global_constant","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","value":{"prim":"Unit"}}`,
+		`{"kind":"set_deposits_limit","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","limit":"1000000"}`,
+		`{"kind":"update_consensus_key","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","pk":"edpk1"}`,
+		`{"kind":"transfer_ticket","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","ticket_contents":{"string":"a"},"ticket_ty":{"prim":"string"},"ticket_ticketer":"KT1a","ticket_amount":"5","destination":"KT1b","entrypoint":"default"}`,
+		`{"kind":"smart_rollup_originate","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","pvm_kind":"wasm_2_0_0","kernel":"0061","parameters_ty":{"prim":"bytes"},"whitelist":["tz1a"]}`,
+		`{"kind":"smart_rollup_add_messages","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","message":["00","01"]}`,
+		`{"kind":"smart_rollup_publish","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","rollup":"sr1a","commitment":{"compressed_state":"srs1","inbox_level":10,"predecessor":"src1","number_of_ticks":"100"}}`,
+		`{"kind":"smart_rollup_timeout","source":"tz1a","fee":"1","counter":"1","gas_limit":"1","storage_limit":"1","rollup":"sr1a","stakers":{"alice":"tz1a","bob":"tz1b"}}`,
+		`{"kind":"failing_noop","arbitrary":"hello"}`,
+	}
+
+	for _, tc := range cases {
+		var contents Contents
+		assert.NilError(t, json.Unmarshal([]byte(tc), &contents))
+		assert.Equal(t, string(contents.Raw), tc)
+
+		have, err := json.Marshal(contents)
+		assert.NilError(t, err)
+
+		var haveFields, wantFields interface{}
+		assert.NilError(t, json.Unmarshal(have, &haveFields))
+		assert.NilError(t, json.Unmarshal([]byte(tc), &wantFields))
+		assert.DeepEqual(t, haveFields, wantFields)
+	}
+}
+
+func Test_ContentsUnmarshalClashingKind(t *testing.T) {
+	raw := `{"kind":"tx_rollup_rejection","source":"tz1a","fee":"10","counter":"1","gas_limit":"1","storage_limit":"0","rollup":"txr1a","message":{"batch":"00"},"proof":{"version":3}}`
+
+	var contents Contents
+	assert.NilError(t, json.Unmarshal([]byte(raw), &contents))
+	assert.Equal(t, contents.Kind, KindTxRollupRejection)
+	assert.Equal(t, contents.Fee, tez.Mutez(10))
+	assert.Equal(t, string(contents.Raw), raw)
+}
+
+func Test_InternalOperationResults(t *testing.T) {
+	raw := `{
+		"kind": "transaction",
+		"source": "tz1a",
+		"fee": "2000",
+		"counter": "20",
+		"gas_limit": "10000",
+		"storage_limit": "300",
+		"amount": "0",
+		"destination": "KT1dex",
+		"parameters": {"entrypoint": "swap", "value": {"int": "10"}},
+		"metadata": {
+			"balance_updates": [],
+			"operation_result": {
+				"status": "applied",
+				"storage": {"prim": "Pair", "args": [{"int": "12"}, {"int": "1"}]},
+				"big_map_diff": [
+					{"action": "update", "big_map": "12", "key_hash": "exprA", "key": {"string": "tz1a"}, "value": {"int": "100"}}
+				],
+				"consumed_milligas": "1500000",
+				"storage_size": "5000"
+			},
+			"internal_operation_results": [
+				{
+					"kind": "transaction",
+					"source": "KT1dex",
+					"nonce": 0,
+					"amount": "0",
+					"destination": "KT1token",
+					"parameters": {"entrypoint": "transfer", "value": {"prim": "Unit"}},
+					"result": {
+						"status": "applied",
+						"big_map_diff": [
+							{"action": "remove", "big_map": "7", "key_hash": "exprB", "key": {"bytes": "00"}},
+							{"action": "copy", "source_big_map": "7", "destination_big_map": "-3"}
+						],
+						"balance_updates": [{"kind": "contract", "contract": "tz1a", "change": "-25"}],
+						"consumed_milligas": "800000"
+					}
+				},
+				{
+					"kind": "event",
+					"source": "KT1dex",
+					"nonce": 1,
+					"type": {"prim": "nat"},
+					"tag": "swapped",
+					"payload": {"int": "10"},
+					"result": {"status": "applied", "consumed_milligas": "100000"}
+				}
+			]
+		}
+	}`
+
+	var contents Contents
+	assert.NilError(t, json.Unmarshal([]byte(raw), &contents))
+
+	result := contents.Metadata.OperationResult
+	assert.Equal(t, result.Status, "applied")
+	assert.Equal(t, result.StorageSize.Int64(), int64(5000))
+	assert.Equal(t, len(result.BigMapDiff), 1)
+	assert.Equal(t, result.BigMapDiff[0].BigMap, "12")
+	assert.Equal(t, string(result.BigMapDiff[0].Value), `{"int": "100"}`)
+
+	internal := contents.Metadata.InternalOperationResults
+	assert.Equal(t, len(internal), 2)
+	assert.Equal(t, internal[0].Destination, "KT1token")
+	assert.Equal(t, internal[0].Parameters.Entrypoint, "transfer")
+	assert.Equal(t, internal[0].Result.BigMapDiff[1].Action, "copy")
+	assert.Equal(t, internal[0].Result.BigMapDiff[1].DestinationBigMap, "-3")
+	assert.Equal(t, internal[0].Result.BalanceUpdates[0].Change, tez.Mutez(-25))
+	assert.Equal(t, internal[1].Kind, "event")
+	assert.Equal(t, internal[1].Nonce, 1)
+	assert.Equal(t, internal[1].Tag, "swapped")
+	assert.Equal(t, string(internal[1].Payload), `{"int": "10"}`)
+}
+
+func Test_ProtocolAwareDecoding(t *testing.T) {
+	cases := []struct {
+		name             string
+		raw              string
+		wantRound        int
+		wantCycle        int
+		wantVotingPeriod string
+	}{
+		{
+			name:             "Babylon block",
+			raw:              `{"header":{"level":700000,"priority":2},"metadata":{"level":{"level":700000,"cycle":170,"voting_period":21},"voting_period_kind":"proposal"}}`,
+			wantRound:        2,
+			wantCycle:        170,
+			wantVotingPeriod: "proposal",
+		},
+		{
+			name:             "Tenderbake block",
+			raw:              `{"header":{"level":3000000,"payload_hash":"vh1","payload_round":1,"liquidity_baking_toggle_vote":"pass"},"metadata":{"proposer":"tz1a","level_info":{"level":3000000,"level_position":2999999,"cycle":580,"cycle_position":100,"expected_commitment":false},"voting_period_info":{"voting_period":{"index":90,"kind":"exploration","start_position":2990000},"position":10,"remaining":100}}}`,
+			wantRound:        1,
+			wantCycle:        580,
+			wantVotingPeriod: "exploration",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var block Block
+			assert.NilError(t, json.Unmarshal([]byte(tc.raw), &block))
+			assert.Equal(t, block.Header.Round(), tc.wantRound)
+			assert.Equal(t, block.Metadata.Level.Cycle, tc.wantCycle)
+			assert.Equal(t, block.Metadata.VotingPeriodKind, tc.wantVotingPeriod)
+		})
+	}
+}
+
+func Test_ContentsMarshalNumericFields(t *testing.T) {
+	cases := []struct {
+		contents Contents
+		want     string
+	}{
+		{
+			contents: Contents{Kind: KindTransaction, Source: "tz1a", Destination: "tz1b", StorageLimit: tez.NewZarith(0), Counter: tez.NewZarith(5)},
+			want:     `{"amount":"0","counter":"5","destination":"tz1b","fee":"0","gas_limit":"0","kind":"transaction","source":"tz1a","storage_limit":"0"}`,
+		},
+		{
+			contents: Contents{Kind: KindDelegation, Source: "tz1a", Fee: 1000, Counter: tez.NewZarith(5), GasLimit: tez.NewZarith(1000), StorageLimit: tez.NewZarith(0)},
+			want:     `{"counter":"5","fee":"1000","gas_limit":"1000","kind":"delegation","source":"tz1a","storage_limit":"0"}`,
+		},
+		{
+			contents: Contents{Kind: KindSeedNonceRevelation, Level: 10, Nonce: "aa"},
+			want:     `{"kind":"seed_nonce_revelation","level":10,"nonce":"aa"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		v, err := json.Marshal(tc.contents)
+		assert.NilError(t, err)
+		assert.Equal(t, string(v), tc.want)
+	}
+}
+
+func Test_GetLiveBlocks(t *testing.T) {
+	tzclient := &client{ReturnBody: []byte(`["BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT","BMdw66rEAHYSu1WRwpVehpWUrB2tdt8RmGRYEt5YT6vs63zuWPU"]`)}
+	liveBlocks, err := NewBlockService(tzclient).GetLiveBlocks(blockid.Head())
+	assert.NilError(t, err)
+	assert.Equal(t, tzclient.Path, "/chains/main/blocks/head/live_blocks")
+	assert.DeepEqual(t, liveBlocks, []string{"BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT", "BMdw66rEAHYSu1WRwpVehpWUrB2tdt8RmGRYEt5YT6vs63zuWPU"})
+
+	tzclient.ReturnBody = []byte("malformed response")
+	_, err = NewBlockService(tzclient).GetLiveBlocks(blockid.Head())
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,157 @@
+package block
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// WaitOptions are the optional settings of WaitConfirmedWithOptions.
+type WaitOptions struct {
+	// Confirmations is the number of blocks to wait for on top of the block including the operation
+	Confirmations int
+	// Branch is the hash of the block the operation was forged on, only the blocks above it are searched when
+	// the wait starts. The blocks within the max operations TTL of the head are searched when it is empty.
+	Branch string
+}
+
+// WaitConfirmed waits until the operation with opHash is included in a block with at least confirmations
+// blocks on top of it, and returns that block and the operation, see WaitConfirmedWithOptions.
+func (b *BlockService) WaitConfirmed(ctx context.Context, opHash string, confirmations int) (Block, Operations, error) {
+	return b.WaitConfirmedWithOptions(ctx, opHash, WaitOptions{Confirmations: confirmations})
+}
+
+// WaitConfirmedWithOptions waits until the operation with opHash is included in a block with at least
+// opts.Confirmations blocks on top of it, and returns that block and the operation. The recent blocks of the
+// chain are searched first, down to opts.Branch, then the branch of every new head down to the blocks already
+// searched, so that blocks brought in by a reorg are searched too. If the including block is orphaned by a reorg
+// before enough confirmations the search starts over. It returns with ctx.Err() once ctx is done, or with the
+// error of the subscription to the heads.
+func (b *BlockService) WaitConfirmedWithOptions(ctx context.Context, opHash string, opts WaitOptions) (Block, Operations, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	heads, headErrs := b.SubscribeHeads(ctx)
+
+	service := b.withContext(ctx)
+	search := &operationSearch{blocks: service, opHash: opHash, searched: make(map[string]bool)}
+	included, operation, found, err := search.recent(opts.Branch)
+	if err != nil {
+		return Block{}, Operations{}, errors.Wrapf(err, "could not wait for operation '%s'", opHash)
+	}
+
+	for head := range heads {
+		// Another block at or below the including block means the chain switched branch
+		if found && head.Level <= included.Header.Level && head.Hash != included.Hash {
+			found = false
+		}
+
+		if !found {
+			included, operation, found, err = search.branch(head.Hash)
+			if err != nil {
+				return Block{}, Operations{}, errors.Wrapf(err, "could not wait for operation '%s'", opHash)
+			}
+			if !found {
+				continue
+			}
+		}
+
+		if head.Level-included.Header.Level < opts.Confirmations {
+			continue
+		}
+
+		// The including block must still be part of the chain
+		header, err := service.GetHeader(blockid.Level(included.Header.Level))
+		if err != nil {
+			return Block{}, Operations{}, errors.Wrapf(err, "could not wait for operation '%s'", opHash)
+		}
+		if header.Hash != included.Hash {
+			found = false
+			continue
+		}
+
+		return included, operation, nil
+	}
+
+	return Block{}, Operations{}, errors.Wrapf(<-headErrs, "could not wait for operation '%s'", opHash)
+}
+
+// operationSearch searches an operation in the blocks of the chain, every block is fetched once unless it
+// includes the operation, so that it is found again when its branch becomes the chain again
+type operationSearch struct {
+	blocks   *BlockService
+	opHash   string
+	searched map[string]bool
+	// floor is the lowest level searched, the operation cannot be included lower
+	floor int
+}
+
+// recent searches the blocks of the head down to the block above branch, or down to the max operations TTL
+// of the head when branch is empty
+func (s *operationSearch) recent(branch string) (Block, Operations, bool, error) {
+	head, err := s.blocks.Get(blockid.Head())
+	if err != nil {
+		return Block{}, Operations{}, false, err
+	}
+
+	depth := head.Metadata.MaxOperationsTTL
+	if branch != "" {
+		header, err := s.blocks.GetHeader(blockid.Hash(branch))
+		if err != nil {
+			return Block{}, Operations{}, false, err
+		}
+		if above := head.Header.Level - header.Level; depth == 0 || above < depth {
+			depth = above
+		}
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	s.floor = head.Header.Level - depth + 1
+
+	return s.walk(head)
+}
+
+// branch searches the block with hash and its predecessors down to the blocks already searched
+func (s *operationSearch) branch(hash string) (Block, Operations, bool, error) {
+	if s.searched[hash] {
+		return Block{}, Operations{}, false, nil
+	}
+	block, err := s.blocks.Get(blockid.Hash(hash))
+	if err != nil {
+		return Block{}, Operations{}, false, err
+	}
+	return s.walk(block)
+}
+
+// walk searches block and its predecessors until the operation is found, a searched block or the floor is reached
+func (s *operationSearch) walk(block Block) (Block, Operations, bool, error) {
+	for {
+		if operation, ok := findOperation(block, s.opHash); ok {
+			return block, operation, true, nil
+		}
+		s.searched[block.Hash] = true
+
+		if block.Header.Level <= s.floor || s.searched[block.Header.Predecessor] {
+			return Block{}, Operations{}, false, nil
+		}
+
+		var err error
+		if block, err = s.blocks.Get(blockid.Hash(block.Header.Predecessor)); err != nil {
+			return Block{}, Operations{}, false, err
+		}
+	}
+}
+
+func findOperation(block Block, opHash string) (Operations, bool) {
+	for _, pass := range block.Operations {
+		for _, operation := range pass {
+			if operation.Hash == opHash {
+				return operation, true
+			}
+		}
+	}
+	return Operations{}, false
+}
@@ -0,0 +1,129 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+func Test_WaitConfirmed(t *testing.T) {
+	cases := []struct {
+		name      string
+		client    *streamClient
+		opts      WaitOptions
+		wantBlock string
+	}{
+		{
+			name: "Confirmed on the first branch",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLa","level":10}`),
+					[]byte(`{"hash":"BLb","level":11,"predecessor":"BLa"}`),
+					[]byte(`{"hash":"BLc","level":12,"predecessor":"BLb"}`),
+				},
+				Headers: map[string][]byte{
+					"/chains/main/blocks/head":      []byte(`{"hash":"BLa","header":{"level":10},"operations":[[],[],[],[]]}`),
+					"/chains/main/blocks/BLb":       []byte(`{"hash":"BLb","header":{"level":11,"predecessor":"BLa"},"operations":[[],[],[],[{"hash":"ooOp","contents":[{"kind":"transaction"}]}]]}`),
+					"/chains/main/blocks/11/header": []byte(`{"hash":"BLb","level":11}`),
+				},
+			},
+			opts:      WaitOptions{Confirmations: 1},
+			wantBlock: "BLb",
+		},
+		{
+			name: "Included again after a reorg",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLb","level":11,"predecessor":"BLa"}`),
+					[]byte(`{"hash":"BLb2","level":11,"predecessor":"BLa"}`),
+					[]byte(`{"hash":"BLc2","level":12,"predecessor":"BLb2"}`),
+					[]byte(`{"hash":"BLd2","level":13,"predecessor":"BLc2"}`),
+				},
+				Headers: map[string][]byte{
+					"/chains/main/blocks/head":      []byte(`{"hash":"BLa","header":{"level":10},"operations":[[]]}`),
+					"/chains/main/blocks/BLb":       []byte(`{"hash":"BLb","header":{"level":11,"predecessor":"BLa"},"operations":[[{"hash":"ooOp"}]]}`),
+					"/chains/main/blocks/BLb2":      []byte(`{"hash":"BLb2","header":{"level":11,"predecessor":"BLa"},"operations":[[]]}`),
+					"/chains/main/blocks/BLc2":      []byte(`{"hash":"BLc2","header":{"level":12,"predecessor":"BLb2"},"operations":[[{"hash":"ooOp"}]]}`),
+					"/chains/main/blocks/12/header": []byte(`{"hash":"BLc2","level":12}`),
+				},
+			},
+			opts:      WaitOptions{Confirmations: 1},
+			wantBlock: "BLc2",
+		},
+		{
+			name: "Included before the wait",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLc","level":12,"predecessor":"BLb"}`),
+				},
+				Headers: map[string][]byte{
+					"/chains/main/blocks/head":      []byte(`{"hash":"BLc","header":{"level":12,"predecessor":"BLb"},"metadata":{"max_operations_ttl":3},"operations":[[]]}`),
+					"/chains/main/blocks/BLb":       []byte(`{"hash":"BLb","header":{"level":11,"predecessor":"BLa"},"operations":[[{"hash":"ooOp"}]]}`),
+					"/chains/main/blocks/11/header": []byte(`{"hash":"BLb","level":11}`),
+				},
+			},
+			opts:      WaitOptions{Confirmations: 1},
+			wantBlock: "BLb",
+		},
+		{
+			name: "Included in a block brought in by a branch switch",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLb","level":11,"predecessor":"BLa"}`),
+					[]byte(`{"hash":"BLc","level":12,"predecessor":"BLb"}`),
+					[]byte(`{"hash":"BLd2","level":13,"predecessor":"BLc2"}`),
+				},
+				Headers: map[string][]byte{
+					"/chains/main/blocks/head":      []byte(`{"hash":"BLa","header":{"level":10},"operations":[[]]}`),
+					"/chains/main/blocks/BLb":       []byte(`{"hash":"BLb","header":{"level":11,"predecessor":"BLa"},"operations":[[]]}`),
+					"/chains/main/blocks/BLc":       []byte(`{"hash":"BLc","header":{"level":12,"predecessor":"BLb"},"operations":[[]]}`),
+					"/chains/main/blocks/BLd2":      []byte(`{"hash":"BLd2","header":{"level":13,"predecessor":"BLc2"},"operations":[[]]}`),
+					"/chains/main/blocks/BLc2":      []byte(`{"hash":"BLc2","header":{"level":12,"predecessor":"BLb2"},"operations":[[]]}`),
+					"/chains/main/blocks/BLb2":      []byte(`{"hash":"BLb2","header":{"level":11,"predecessor":"BLa"},"operations":[[{"hash":"ooOp"}]]}`),
+					"/chains/main/blocks/11/header": []byte(`{"hash":"BLb2","level":11}`),
+				},
+			},
+			opts:      WaitOptions{Confirmations: 1},
+			wantBlock: "BLb2",
+		},
+		{
+			name: "Searched down to the branch",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLd","level":13,"predecessor":"BLc"}`),
+				},
+				Headers: map[string][]byte{
+					"/chains/main/blocks/head":       []byte(`{"hash":"BLc","header":{"level":12,"predecessor":"BLb"},"metadata":{"max_operations_ttl":60},"operations":[[]]}`),
+					"/chains/main/blocks/BLb/header": []byte(`{"hash":"BLb","level":11}`),
+					"/chains/main/blocks/BLd":        []byte(`{"hash":"BLd","header":{"level":13,"predecessor":"BLc"},"operations":[[{"hash":"ooOp"}]]}`),
+					"/chains/main/blocks/13/header":  []byte(`{"hash":"BLd","level":13}`),
+				},
+			},
+			opts:      WaitOptions{Branch: "BLb"},
+			wantBlock: "BLd",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			block, operation, err := NewBlockService(tc.client).WaitConfirmedWithOptions(context.Background(), "ooOp", tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, block.Hash, tc.wantBlock)
+			assert.Equal(t, operation.Hash, "ooOp")
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := NewBlockService(&streamClient{}).WaitConfirmed(ctx, "ooOp", 1)
+	assert.Assert(t, err != nil)
+
+	// a refused subscription is returned rather than waited on
+	_, _, err = NewBlockService(&streamClient{
+		Headers:   map[string][]byte{"/chains/main/blocks/head": []byte(`{"hash":"BLa","header":{"level":10},"operations":[[]]}`)},
+		StreamErr: errors.New("403 Forbidden"),
+	}).WaitConfirmed(context.Background(), "ooOp", 1)
+	assert.ErrorContains(t, err, "could not subscribe to heads: 403 Forbidden")
+}
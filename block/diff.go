@@ -0,0 +1,154 @@
+package block
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Kinds of LazyStorageDiff
+const (
+	LazyStorageBigMap       = "big_map"
+	LazyStorageSaplingState = "sapling_state"
+)
+
+// Actions of BigMapDiff and lazy storage diffs
+const (
+	DiffActionAlloc  = "alloc"
+	DiffActionUpdate = "update"
+	DiffActionCopy   = "copy"
+	DiffActionRemove = "remove"
+)
+
+// LazyStorageDiff is a change to a lazy storage, a big map or a sapling state, found in the lazy_storage_diff of an OperationResult.
+// BigMap is set for big maps and SaplingState for sapling states.
+type LazyStorageDiff struct {
+	Kind         string
+	ID           string
+	BigMap       *BigMapLazyDiff
+	SaplingState *SaplingStateLazyDiff
+}
+
+// BigMapLazyDiff is the diff of a big map in a LazyStorageDiff. Source is the big map copied for copy actions,
+// KeyType and ValueType are set for alloc actions.
+type BigMapLazyDiff struct {
+	Action    string          `json:"action"`
+	Source    string          `json:"source,omitempty"`
+	KeyType   json.RawMessage `json:"key_type,omitempty"`
+	ValueType json.RawMessage `json:"value_type,omitempty"`
+	Updates   []BigMapUpdate  `json:"updates,omitempty"`
+}
+
+// BigMapUpdate is a key set or removed in a big map. Value is empty when the key was removed.
+type BigMapUpdate struct {
+	KeyHash string          `json:"key_hash"`
+	Key     json.RawMessage `json:"key"`
+	Value   json.RawMessage `json:"value,omitempty"`
+}
+
+// SaplingStateLazyDiff is the diff of a sapling state in a LazyStorageDiff.
+type SaplingStateLazyDiff struct {
+	Action   string          `json:"action"`
+	Source   string          `json:"source,omitempty"`
+	MemoSize int             `json:"memo_size,omitempty"`
+	Updates  *SaplingUpdates `json:"updates,omitempty"`
+}
+
+// SaplingUpdates are the commitments and nullifiers added to a sapling state.
+type SaplingUpdates struct {
+	CommitmentsAndCiphertexts json.RawMessage `json:"commitments_and_ciphertexts"`
+	Nullifiers                []string        `json:"nullifiers"`
+}
+
+type lazyStorageDiff struct {
+	Kind string          `json:"kind"`
+	ID   string          `json:"id"`
+	Diff json.RawMessage `json:"diff"`
+}
+
+// UnmarshalJSON unmarshals the diff of a LazyStorageDiff according to its kind.
+func (l *LazyStorageDiff) UnmarshalJSON(v []byte) error {
+	var raw lazyStorageDiff
+	if err := json.Unmarshal(v, &raw); err != nil {
+		return errors.Wrap(err, "could not unmarshal bytes to LazyStorageDiff")
+	}
+
+	*l = LazyStorageDiff{Kind: raw.Kind, ID: raw.ID}
+	switch raw.Kind {
+	case LazyStorageBigMap:
+		l.BigMap = &BigMapLazyDiff{}
+		if err := json.Unmarshal(raw.Diff, l.BigMap); err != nil {
+			return errors.Wrap(err, "could not unmarshal bytes to LazyStorageDiff")
+		}
+	case LazyStorageSaplingState:
+		l.SaplingState = &SaplingStateLazyDiff{}
+		if err := json.Unmarshal(raw.Diff, l.SaplingState); err != nil {
+			return errors.Wrap(err, "could not unmarshal bytes to LazyStorageDiff")
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON marshals a LazyStorageDiff the way the RPC returns it.
+func (l LazyStorageDiff) MarshalJSON() ([]byte, error) {
+	var diff interface{} = struct{}{}
+	if l.BigMap != nil {
+		diff = l.BigMap
+	} else if l.SaplingState != nil {
+		diff = l.SaplingState
+	}
+
+	v, err := json.Marshal(diff)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(lazyStorageDiff{Kind: l.Kind, ID: l.ID, Diff: v})
+}
+
+// BigMapDiffs returns the changes the operation made to big maps. Protocols since Edo only return
+// lazy_storage_diff, it is converted to the big_map_diff format when big_map_diff is missing.
+func (o *OperationResult) BigMapDiffs() []BigMapDiff {
+	if len(o.BigMapDiff) > 0 {
+		return o.BigMapDiff
+	}
+
+	var diffs []BigMapDiff
+	for _, lazyDiff := range o.LazyStorageDiff {
+		if lazyDiff.BigMap == nil {
+			continue
+		}
+
+		switch lazyDiff.BigMap.Action {
+		case DiffActionRemove:
+			diffs = append(diffs, BigMapDiff{Action: DiffActionRemove, BigMap: lazyDiff.ID})
+			continue
+		case DiffActionAlloc:
+			diffs = append(diffs, BigMapDiff{
+				Action:    DiffActionAlloc,
+				BigMap:    lazyDiff.ID,
+				KeyType:   lazyDiff.BigMap.KeyType,
+				ValueType: lazyDiff.BigMap.ValueType,
+			})
+		case DiffActionCopy:
+			diffs = append(diffs, BigMapDiff{
+				Action:            DiffActionCopy,
+				SourceBigMap:      lazyDiff.BigMap.Source,
+				DestinationBigMap: lazyDiff.ID,
+			})
+		}
+
+		for _, update := range lazyDiff.BigMap.Updates {
+			diffs = append(diffs, BigMapDiff{
+				Action:  DiffActionUpdate,
+				BigMap:  lazyDiff.ID,
+				KeyHash: update.KeyHash,
+				Key:     update.Key,
+				Value:   update.Value,
+			})
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,50 @@
+package block
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_LazyStorageDiff(t *testing.T) {
+	raw := `[
+		{"kind":"big_map","id":"12","diff":{"action":"update","updates":[{"key_hash":"exprA","key":{"string":"tz1a"},"value":{"int":"100"}},{"key_hash":"exprB","key":{"string":"tz1b"}}]}},
+		{"kind":"big_map","id":"-3","diff":{"action":"alloc","key_type":{"prim":"address"},"value_type":{"prim":"nat"}}},
+		{"kind":"big_map","id":"-4","diff":{"action":"copy","source":"7","updates":[{"key_hash":"exprC","key":{"int":"1"},"value":{"int":"2"}}]}},
+		{"kind":"big_map","id":"5","diff":{"action":"remove"}},
+		{"kind":"sapling_state","id":"8","diff":{"action":"update","updates":{"commitments_and_ciphertexts":[],"nullifiers":["aa"]}}}
+	]`
+
+	var diffs []LazyStorageDiff
+	assert.NilError(t, json.Unmarshal([]byte(raw), &diffs))
+	assert.Equal(t, len(diffs), 5)
+	assert.Equal(t, diffs[0].BigMap.Updates[1].KeyHash, "exprB")
+	assert.Equal(t, len(diffs[0].BigMap.Updates[1].Value), 0)
+	assert.Equal(t, diffs[2].BigMap.Source, "7")
+	assert.Equal(t, diffs[4].SaplingState.Updates.Nullifiers[0], "aa")
+	assert.Assert(t, diffs[4].BigMap == nil)
+
+	have, err := json.Marshal(diffs)
+	assert.NilError(t, err)
+	var haveFields, wantFields interface{}
+	assert.NilError(t, json.Unmarshal(have, &haveFields))
+	assert.NilError(t, json.Unmarshal([]byte(raw), &wantFields))
+	assert.DeepEqual(t, haveFields, wantFields)
+
+	result := OperationResult{LazyStorageDiff: diffs}
+	bigMapDiffs := result.BigMapDiffs()
+	actions := []string{}
+	for _, diff := range bigMapDiffs {
+		actions = append(actions, diff.Action+":"+diff.BigMap+diff.DestinationBigMap)
+	}
+	assert.DeepEqual(t, actions, []string{"update:12", "update:12", "alloc:-3", "copy:-4", "update:-4", "remove:5"})
+}
+
+func Test_BigMapDiffsPrefersBigMapDiff(t *testing.T) {
+	result := OperationResult{
+		BigMapDiff:      []BigMapDiff{{Action: DiffActionUpdate, BigMap: "1"}},
+		LazyStorageDiff: []LazyStorageDiff{{Kind: LazyStorageBigMap, ID: "2", BigMap: &BigMapLazyDiff{Action: DiffActionRemove}}},
+	}
+	assert.DeepEqual(t, result.BigMapDiffs(), result.BigMapDiff)
+}
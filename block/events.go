@@ -0,0 +1,117 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// KindEvent is the kind of the internal operations of the events emitted by contracts
+const KindEvent = "event"
+
+// Event is an event emitted by a contract with the EMIT instruction, from Lima onward. Type and Payload
+// are the Micheline type and value of the event.
+type Event struct {
+	Source        string
+	Tag           string
+	Type          json.RawMessage
+	Payload       json.RawMessage
+	Nonce         int
+	OperationHash string
+	BlockHash     string
+	Level         int
+}
+
+// EventFilter selects events by the contracts emitting them and by tag, empty lists select every event.
+type EventFilter struct {
+	Contracts []string
+	Tags      []string
+}
+
+func (f EventFilter) match(e Event) bool {
+	return (len(f.Contracts) == 0 || contains(f.Contracts, e.Source)) && (len(f.Tags) == 0 || contains(f.Tags, e.Tag))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Events returns the events matching filter emitted by the applied operations of block, in the order they were emitted.
+func Events(block Block, filter EventFilter) []Event {
+	var events []Event
+	for _, pass := range block.Operations {
+		for _, operation := range pass {
+			for _, contents := range operation.Contents {
+				if contents.Metadata == nil {
+					continue
+				}
+				for _, internal := range contents.Metadata.InternalOperationResults {
+					if internal.Kind != KindEvent || internal.Result == nil || internal.Result.Status != "applied" {
+						continue
+					}
+					event := Event{
+						Source:        internal.Source,
+						Tag:           internal.Tag,
+						Type:          internal.Type,
+						Payload:       internal.Payload,
+						Nonce:         internal.Nonce,
+						OperationHash: operation.Hash,
+						BlockHash:     block.Hash,
+						Level:         block.Header.Level,
+					}
+					if filter.match(event) {
+						events = append(events, event)
+					}
+				}
+			}
+		}
+	}
+	return events
+}
+
+// SubscribeEvents returns a channel receiving the events matching filter emitted in every new head of the chain,
+// see SubscribeHeads. At most one error is sent on the error channel, when a head could not be fetched, when the
+// subscription to the heads failed or when ctx is done, after which both channels are closed.
+func (b *BlockService) SubscribeEvents(ctx context.Context, filter EventFilter) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	heads, headErrs := b.SubscribeHeads(ctx)
+	service := b.withContext(ctx)
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		for head := range heads {
+			block, err := service.Get(blockid.Hash(head.Hash))
+			if err != nil {
+				errs <- errors.Wrapf(err, "could not get events of block '%s'", head.Hash)
+				return
+			}
+			for _, event := range Events(block, filter) {
+				select {
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				case events <- event:
+				}
+			}
+		}
+
+		err := <-headErrs
+		if ctx.Err() == nil {
+			err = errors.Wrap(err, "could not subscribe to events")
+		}
+		errs <- err
+	}()
+
+	return events, errs
+}
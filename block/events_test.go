@@ -0,0 +1,106 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var eventsBlock = []byte(`{
+	"hash": "BLb",
+	"header": {"level": 11},
+	"operations": [[], [], [], [
+		{
+			"hash": "ooSwap",
+			"contents": [{
+				"kind": "transaction",
+				"metadata": {
+					"operation_result": {"status": "applied"},
+					"internal_operation_results": [
+						{"kind": "transaction", "source": "KT1dex", "nonce": 0, "destination": "KT1token", "result": {"status": "applied"}},
+						{"kind": "event", "source": "KT1dex", "nonce": 1, "type": {"prim": "nat"}, "tag": "swapped", "payload": {"int": "10"}, "result": {"status": "applied"}},
+						{"kind": "event", "source": "KT1token", "nonce": 2, "type": {"prim": "unit"}, "tag": "transferred", "payload": {"prim": "Unit"}, "result": {"status": "applied"}}
+					]
+				}
+			}]
+		},
+		{
+			"hash": "ooFailed",
+			"contents": [{
+				"kind": "transaction",
+				"metadata": {
+					"operation_result": {"status": "failed"},
+					"internal_operation_results": [
+						{"kind": "event", "source": "KT1dex", "nonce": 0, "type": {"prim": "nat"}, "tag": "swapped", "payload": {"int": "20"}, "result": {"status": "backtracked"}}
+					]
+				}
+			}]
+		}
+	]]
+}`)
+
+func Test_Events(t *testing.T) {
+	var block Block
+	assert.NilError(t, json.Unmarshal(eventsBlock, &block))
+
+	cases := []struct {
+		name   string
+		filter EventFilter
+		want   []string
+	}{
+		{name: "Every applied event", filter: EventFilter{}, want: []string{"swapped", "transferred"}},
+		{name: "By contract", filter: EventFilter{Contracts: []string{"KT1token"}}, want: []string{"transferred"}},
+		{name: "By tag", filter: EventFilter{Tags: []string{"swapped"}}, want: []string{"swapped"}},
+		{name: "No match", filter: EventFilter{Contracts: []string{"KT1dex"}, Tags: []string{"transferred"}}, want: nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tags []string
+			for _, event := range Events(block, tc.filter) {
+				tags = append(tags, event.Tag)
+			}
+			assert.DeepEqual(t, tags, tc.want)
+		})
+	}
+
+	events := Events(block, EventFilter{Tags: []string{"swapped"}})
+	assert.Equal(t, events[0].Source, "KT1dex")
+	assert.Equal(t, events[0].Nonce, 1)
+	assert.Equal(t, events[0].OperationHash, "ooSwap")
+	assert.Equal(t, events[0].BlockHash, "BLb")
+	assert.Equal(t, events[0].Level, 11)
+	assert.Equal(t, string(events[0].Type), `{"prim": "nat"}`)
+	assert.Equal(t, string(events[0].Payload), `{"int": "10"}`)
+}
+
+func Test_SubscribeEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &streamClient{
+		Values: [][]byte{
+			[]byte(`{"hash":"BLa","level":10}`),
+			[]byte(`{"hash":"BLb","level":11}`),
+		},
+		Headers: map[string][]byte{
+			"/chains/main/blocks/BLa": []byte(`{"hash":"BLa","header":{"level":10},"operations":[[],[],[],[]]}`),
+			"/chains/main/blocks/BLb": eventsBlock,
+		},
+	}
+	events, errs := NewBlockService(client).SubscribeEvents(ctx, EventFilter{Contracts: []string{"KT1dex"}})
+
+	event := <-events
+	assert.Equal(t, event.Tag, "swapped")
+	assert.Equal(t, event.Level, 11)
+
+	cancel()
+	for range events {
+	}
+	assert.Equal(t, <-errs, context.Canceled)
+
+	_, errs = NewBlockService(&client.client).SubscribeEvents(context.Background(), EventFilter{})
+	assert.ErrorContains(t, <-errs, "could not subscribe to events")
+}
@@ -1,7 +1,22 @@
 package block
 
+import (
+	"context"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
 type TezosBlockService interface {
 	GetHead() (Block, error)
-	Get(id interface{}) (Block, error)
-	IDToString(id interface{}) (string, error)
+	Get(id blockid.BlockID) (Block, error)
+	GetWithOptions(id blockid.BlockID, opts BlockOptions) (Block, error)
+	GetHeader(id blockid.BlockID) (Header, error)
+	GetOperations(id blockid.BlockID, pass int) ([]Operations, error)
+	GetOperation(id blockid.BlockID, pass, index int) (Operations, error)
+	GetLiveBlocks(id blockid.BlockID) ([]string, error)
+	GetRange(ctx context.Context, from, to int, opts RangeOptions) (<-chan Block, <-chan error)
+	SubscribeHeads(ctx context.Context) (<-chan Header, <-chan error)
+	SubscribeEvents(ctx context.Context, filter EventFilter) (<-chan Event, <-chan error)
+	WaitConfirmed(ctx context.Context, opHash string, confirmations int) (Block, Operations, error)
+	WaitConfirmedWithOptions(ctx context.Context, opHash string, opts WaitOptions) (Block, Operations, error)
 }
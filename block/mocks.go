@@ -1,5 +1,16 @@
 package block
 
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
 var (
 	goldenBlock = []byte(`{
 		"chain_id": "NetXdQprcVkpaWU",
@@ -865,6 +876,8 @@ var (
 
 type client struct {
 	ReturnBody []byte
+	Path       string
+	Params     map[string]string
 }
 
 func (c *client) Post(path, args string) ([]byte, error) {
@@ -872,5 +885,49 @@ func (c *client) Post(path, args string) ([]byte, error) {
 }
 
 func (c *client) Get(path string, params map[string]string) ([]byte, error) {
+	c.Path, c.Params = path, params
 	return c.ReturnBody, nil
 }
+
+// levelClient answers block requests with a block at the level requested, and fails for the levels in Fail
+type levelClient struct {
+	client
+	Fail map[int]bool
+}
+
+func (c *levelClient) Get(path string, params map[string]string) ([]byte, error) {
+	level, err := strconv.Atoi(strings.TrimPrefix(path, "/chains/main/blocks/"))
+	if err != nil || c.Fail[level] {
+		return nil, errors.Errorf("could not get '%s'", path)
+	}
+	return []byte(fmt.Sprintf(`{"header":{"level":%d}}`, level)), nil
+}
+
+type streamClient struct {
+	client
+	Values  [][]byte
+	Headers map[string][]byte
+	// StreamErr ends the stream after Values when set, the stream lasts until ctx is done otherwise
+	StreamErr error
+}
+
+func (c *streamClient) Get(path string, params map[string]string) ([]byte, error) {
+	return c.Headers[path], nil
+}
+
+func (c *streamClient) Stream(ctx context.Context, path string, params map[string]string, fn tzc.StreamHandler) error {
+	for _, value := range c.Values {
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+	if c.StreamErr != nil {
+		return c.StreamErr
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *streamClient) StreamOnce(ctx context.Context, path string, params map[string]string, fn tzc.StreamHandler) error {
+	return nil
+}
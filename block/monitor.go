@@ -0,0 +1,79 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// SubscribeHeads returns a channel receiving the header of every new head of the chain, read from /monitor/heads/main.
+// The subscription reconnects when the connection to the node drops, and headers of the levels missed while
+// disconnected are fetched so that no level is skipped. At most one error is sent on the error channel, when the
+// node refuses the subscription, when a missed level could not be fetched or when ctx is done, after which both
+// channels are closed.
+func (b *BlockService) SubscribeHeads(ctx context.Context) (<-chan Header, <-chan error) {
+	heads := make(chan Header)
+	errs := make(chan error, 1)
+
+	streamer, ok := b.tzclient.(tzc.Streamer)
+	if !ok {
+		errs <- errors.New("could not subscribe to heads, client does not support streaming")
+		close(heads)
+		close(errs)
+		return heads, errs
+	}
+
+	service := b.withContext(ctx)
+	go func() {
+		defer close(errs)
+		defer close(heads)
+
+		var last Header
+		err := streamer.Stream(ctx, "/monitor/heads/main", nil, func(value json.RawMessage) error {
+			var head Header
+			if err := json.Unmarshal(value, &head); err != nil {
+				return nil
+			}
+
+			// The node sends the current head again after reconnecting
+			if head.Hash != "" && head.Hash == last.Hash {
+				return nil
+			}
+
+			if last.Level != 0 {
+				for level := last.Level + 1; level < head.Level; level++ {
+					missed, err := service.GetHeader(blockid.Level(level))
+					if err != nil {
+						return errors.Wrapf(err, "could not get missed head at level %d", level)
+					}
+					if err := sendHead(ctx, heads, missed); err != nil {
+						return err
+					}
+				}
+			}
+
+			last = head
+			return sendHead(ctx, heads, head)
+		})
+		if ctx.Err() != nil {
+			errs <- ctx.Err()
+			return
+		}
+		errs <- errors.Wrap(err, "could not subscribe to heads")
+	}()
+
+	return heads, errs
+}
+
+func sendHead(ctx context.Context, heads chan<- Header, head Header) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case heads <- head:
+		return nil
+	}
+}
@@ -0,0 +1,112 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+func Test_SubscribeHeads(t *testing.T) {
+	cases := []struct {
+		name    string
+		client  *streamClient
+		want    []int
+		wantErr string
+	}{
+		{
+			name: "Receives every head",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLa","level":10}`),
+					[]byte(`{"hash":"BLb","level":11}`),
+				},
+			},
+			want: []int{10, 11},
+		},
+		{
+			name: "Skips the head sent again after reconnecting",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLa","level":10}`),
+					[]byte(`{"hash":"BLa","level":10}`),
+					[]byte(`{"hash":"BLb","level":11}`),
+				},
+			},
+			want: []int{10, 11},
+		},
+		{
+			name: "Fills levels missed while disconnected",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLa","level":10}`),
+					[]byte(`{"hash":"BLd","level":13}`),
+				},
+				Headers: map[string][]byte{
+					"/chains/main/blocks/11/header": []byte(`{"hash":"BLb","level":11}`),
+					"/chains/main/blocks/12/header": []byte(`{"hash":"BLc","level":12}`),
+				},
+			},
+			want: []int{10, 11, 12, 13},
+		},
+		{
+			name: "Reports a missed level that could not be fetched",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLa","level":10}`),
+					[]byte(`{"hash":"BLd","level":13}`),
+				},
+				Headers: map[string][]byte{
+					"/chains/main/blocks/11/header": []byte(`{"hash":"BLb","level":11}`),
+				},
+			},
+			want:    []int{10, 11},
+			wantErr: "could not get missed head at level 12",
+		},
+		{
+			name: "Reports the refused subscription",
+			client: &streamClient{
+				Values: [][]byte{
+					[]byte(`{"hash":"BLa","level":10}`),
+				},
+				StreamErr: errors.New("401 Unauthorized"),
+			},
+			want:    []int{10},
+			wantErr: "could not subscribe to heads: 401 Unauthorized",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			heads, errs := NewBlockService(tc.client).SubscribeHeads(ctx)
+
+			var levels []int
+			for range tc.want {
+				head := <-heads
+				levels = append(levels, head.Level)
+			}
+			assert.DeepEqual(t, levels, tc.want)
+
+			if tc.wantErr != "" {
+				_, ok := <-heads
+				assert.Assert(t, !ok)
+				assert.ErrorContains(t, <-errs, tc.wantErr)
+				return
+			}
+
+			cancel()
+			for range heads {
+			}
+			assert.Equal(t, <-errs, context.Canceled)
+		})
+	}
+
+	heads, errs := NewBlockService(&client{}).SubscribeHeads(context.Background())
+	_, ok := <-heads
+	assert.Assert(t, !ok)
+	assert.ErrorContains(t, <-errs, "client does not support streaming")
+}
@@ -0,0 +1,103 @@
+package block
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+var (
+	// defaultRangeWorkers is how many blocks GetRange fetches concurrently when RangeOptions.Workers is not set.
+	defaultRangeWorkers = 4
+)
+
+// RangeOptions configures GetRange
+type RangeOptions struct {
+	// Workers is how many blocks are fetched concurrently.
+	Workers int
+	// Block are the options every block is fetched with.
+	Block BlockOptions
+}
+
+type rangeResult struct {
+	block Block
+	err   error
+}
+
+// GetRange fetches the blocks from level from to level to, both included, with opts.Workers
+// concurrent requests, and sends them in order on the returned Block channel. At most one error,
+// ctx.Err() when ctx is done first, is sent on the error channel, after which no more blocks are sent.
+// Both channels are closed once every block was sent or on error.
+func (b *BlockService) GetRange(ctx context.Context, from, to int, opts RangeOptions) (<-chan Block, <-chan error) {
+	blocks := make(chan Block)
+	errs := make(chan error, 1)
+
+	if from > to {
+		errs <- errors.Errorf("could not get blocks from %d to %d, invalid range", from, to)
+		close(blocks)
+		close(errs)
+		return blocks, errs
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultRangeWorkers
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	service := b.withContext(ctx)
+	// sem bounds the blocks fetched but not sent yet
+	sem := make(chan struct{}, workers)
+	queue := make(chan chan rangeResult, workers)
+
+	go func() {
+		defer close(queue)
+		for level := from; level <= to; level++ {
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			result := make(chan rangeResult, 1)
+			go func(level int) {
+				block, err := service.GetWithOptions(blockid.Level(level), opts.Block)
+				result <- rangeResult{block: block, err: err}
+			}(level)
+			queue <- result
+		}
+	}()
+
+	go func() {
+		defer close(errs)
+		defer close(blocks)
+		defer cancel()
+
+		for result := range queue {
+			var r rangeResult
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case r = <-result:
+			}
+
+			if r.err != nil {
+				errs <- errors.Wrap(r.err, "could not get block range")
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case blocks <- r.block:
+			}
+			<-sem
+		}
+	}()
+
+	return blocks, errs
+}
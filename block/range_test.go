@@ -0,0 +1,65 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_GetRange(t *testing.T) {
+	cases := []struct {
+		name    string
+		from    int
+		to      int
+		opts    RangeOptions
+		fail    map[int]bool
+		want    []int
+		wantErr bool
+	}{
+		{
+			name: "Sends blocks in order",
+			from: 1,
+			to:   20,
+			opts: RangeOptions{Workers: 3},
+			want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+		},
+		{
+			name: "Single block with default workers",
+			from: 7,
+			to:   7,
+			want: []int{7},
+		},
+		{
+			name:    "Stops at first error",
+			from:    1,
+			to:      10,
+			opts:    RangeOptions{Workers: 2},
+			fail:    map[int]bool{4: true},
+			want:    []int{1, 2, 3},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid range",
+			from:    10,
+			to:      1,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			blockService := NewBlockService(&levelClient{Fail: tc.fail})
+			blocks, errs := blockService.GetRange(context.Background(), tc.from, tc.to, tc.opts)
+
+			var levels []int
+			for block := range blocks {
+				levels = append(levels, block.Header.Level)
+			}
+			err := <-errs
+
+			assert.DeepEqual(t, levels, tc.want)
+			assert.Equal(t, err != nil, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package block
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+const defaultReorgDepth = 60
+
+// Reorg is a switch of the chain to another branch. Orphaned holds the hashes of the blocks that
+// are no longer part of the chain, highest level first, down to the level above Ancestor.
+type Reorg struct {
+	Head     Header
+	Ancestor int
+	Orphaned []string
+}
+
+// ReorgDetector tracks the hashes of the recent blocks of the chain to detect reorgs. It is not safe for concurrent use.
+type ReorgDetector struct {
+	blocks TezosBlockService
+	depth  int
+	top    int
+	hashes map[int]string
+}
+
+// NewReorgDetector returns a new ReorgDetector tracking the last depth levels of the chain, 60 when depth is not positive.
+// A reorg deeper than depth only reports the orphaned blocks that were still tracked.
+func NewReorgDetector(blocks TezosBlockService, depth int) *ReorgDetector {
+	if depth <= 0 {
+		depth = defaultReorgDepth
+	}
+	return &ReorgDetector{
+		blocks: blocks,
+		depth:  depth,
+		hashes: make(map[int]string),
+	}
+}
+
+// Observe records head as the new head of the chain and returns the reorg it caused, if any.
+// The predecessors of head are fetched until the branch of head joins the tracked blocks. The tracked
+// blocks are left unchanged when a predecessor could not be fetched, so head can be observed again.
+func (d *ReorgDetector) Observe(head Header) (*Reorg, error) {
+	if d.hashes[head.Level] == head.Hash {
+		return nil, nil
+	}
+
+	// Blocks at and above the level of the new head are no longer part of the chain
+	var orphaned []string
+	for level := d.top; level >= head.Level; level-- {
+		if hash, ok := d.hashes[level]; ok {
+			orphaned = append(orphaned, hash)
+		}
+	}
+
+	// The branch of head is walked down while it can still join the tracked blocks, across the levels that
+	// are not tracked, e.g. when head is several levels above the previous one
+	lowest := d.lowest()
+	branch := []Header{head}
+	current := head
+	for current.Level-1 >= lowest && current.Level-1 > head.Level-d.depth {
+		hash, ok := d.hashes[current.Level-1]
+		if ok && hash == current.Predecessor {
+			break
+		}
+		if ok {
+			orphaned = append(orphaned, hash)
+		}
+
+		predecessor, err := d.blocks.GetHeader(blockid.Hash(current.Predecessor))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get predecessor of block '%s'", current.Hash)
+		}
+		current = predecessor
+		branch = append(branch, current)
+	}
+
+	for level := d.top; level >= head.Level; level-- {
+		delete(d.hashes, level)
+	}
+	for _, header := range branch {
+		d.hashes[header.Level] = header.Hash
+	}
+	d.top = head.Level
+	for level := range d.hashes {
+		if level <= head.Level-d.depth {
+			delete(d.hashes, level)
+		}
+	}
+
+	if len(orphaned) == 0 {
+		return nil, nil
+	}
+	return &Reorg{Head: head, Ancestor: current.Level - 1, Orphaned: orphaned}, nil
+}
+
+// lowest returns the lowest tracked level, or a level above every block when no block is tracked
+func (d *ReorgDetector) lowest() int {
+	lowest := math.MaxInt32
+	for level := range d.hashes {
+		if level < lowest {
+			lowest = level
+		}
+	}
+	return lowest
+}
+
+// Watch subscribes to the heads of the chain and returns a channel receiving every reorg. At most one
+// error is sent on the error channel, when a head could not be observed, when the subscription to the
+// heads failed or when ctx is done, after which both channels are closed.
+func (d *ReorgDetector) Watch(ctx context.Context) (<-chan Reorg, <-chan error) {
+	reorgs := make(chan Reorg)
+	errs := make(chan error, 1)
+
+	heads, headErrs := d.blocks.SubscribeHeads(ctx)
+	go func() {
+		defer close(errs)
+		defer close(reorgs)
+
+		for head := range heads {
+			reorg, err := d.Observe(head)
+			if err != nil {
+				errs <- errors.Wrapf(err, "could not observe block '%s'", head.Hash)
+				return
+			}
+			if reorg == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case reorgs <- *reorg:
+			}
+		}
+
+		err := <-headErrs
+		if ctx.Err() == nil {
+			err = errors.Wrap(err, "could not watch reorgs")
+		}
+		errs <- err
+	}()
+
+	return reorgs, errs
+}
@@ -0,0 +1,161 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+func Test_ReorgDetectorObserve(t *testing.T) {
+	client := &streamClient{
+		Headers: map[string][]byte{
+			"/chains/main/blocks/BLc2/header": []byte(`{"hash":"BLc2","level":12,"predecessor":"BLb2"}`),
+			"/chains/main/blocks/BLb2/header": []byte(`{"hash":"BLb2","level":11,"predecessor":"BLa"}`),
+		},
+	}
+
+	cases := []struct {
+		name string
+		head Header
+		want *Reorg
+	}{
+		{
+			name: "First head",
+			head: Header{Hash: "BLa", Level: 10, Predecessor: "BLz"},
+		},
+		{
+			name: "Extends the chain",
+			head: Header{Hash: "BLb", Level: 11, Predecessor: "BLa"},
+		},
+		{
+			name: "Same head again",
+			head: Header{Hash: "BLb", Level: 11, Predecessor: "BLa"},
+		},
+		{
+			name: "Extends the chain again",
+			head: Header{Hash: "BLc", Level: 12, Predecessor: "BLb"},
+		},
+		{
+			name: "Switches to another branch",
+			head: Header{Hash: "BLd2", Level: 13, Predecessor: "BLc2"},
+			want: &Reorg{
+				Head:     Header{Hash: "BLd2", Level: 13, Predecessor: "BLc2"},
+				Ancestor: 10,
+				Orphaned: []string{"BLc", "BLb"},
+			},
+		},
+		{
+			name: "Replaces the head at the same level",
+			head: Header{Hash: "BLd3", Level: 13, Predecessor: "BLc2"},
+			want: &Reorg{
+				Head:     Header{Hash: "BLd3", Level: 13, Predecessor: "BLc2"},
+				Ancestor: 12,
+				Orphaned: []string{"BLd2"},
+			},
+		},
+	}
+
+	detector := NewReorgDetector(NewBlockService(client), 0)
+	for _, tc := range cases {
+		reorg, err := detector.Observe(tc.head)
+		assert.NilError(t, err, tc.name)
+		assert.DeepEqual(t, reorg, tc.want)
+	}
+
+	_, err := detector.Observe(Header{Hash: "BLd4", Level: 13, Predecessor: "BLc4"})
+	assert.Assert(t, err != nil)
+
+	// the failed head left the tracked blocks unchanged, BLd3 is still the head
+	reorg, err := detector.Observe(Header{Hash: "BLd5", Level: 13, Predecessor: "BLc2"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, reorg, &Reorg{
+		Head:     Header{Hash: "BLd5", Level: 13, Predecessor: "BLc2"},
+		Ancestor: 12,
+		Orphaned: []string{"BLd3"},
+	})
+}
+
+func Test_ReorgDetectorObserveUntrackedLevels(t *testing.T) {
+	client := &streamClient{
+		Headers: map[string][]byte{
+			"/chains/main/blocks/BLn2/header": []byte(`{"hash":"BLn2","level":14,"predecessor":"BLm2"}`),
+			"/chains/main/blocks/BLm2/header": []byte(`{"hash":"BLm2","level":13,"predecessor":"BLl2"}`),
+			"/chains/main/blocks/BLl2/header": []byte(`{"hash":"BLl2","level":12,"predecessor":"BLk2"}`),
+			"/chains/main/blocks/BLk2/header": []byte(`{"hash":"BLk2","level":11,"predecessor":"BLa"}`),
+		},
+	}
+
+	detector := NewReorgDetector(NewBlockService(client), 0)
+	for _, head := range []Header{
+		{Hash: "BLa", Level: 10},
+		{Hash: "BLb", Level: 11, Predecessor: "BLa"},
+		{Hash: "BLc", Level: 12, Predecessor: "BLb"},
+	} {
+		_, err := detector.Observe(head)
+		assert.NilError(t, err)
+	}
+
+	// the new branch joins the tracked blocks below the untracked levels 13 and 14
+	head := Header{Hash: "BLo2", Level: 15, Predecessor: "BLn2"}
+	reorg, err := detector.Observe(head)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, reorg, &Reorg{Head: head, Ancestor: 10, Orphaned: []string{"BLc", "BLb"}})
+
+	reorg, err = detector.Observe(Header{Hash: "BLp2", Level: 16, Predecessor: "BLo2"})
+	assert.NilError(t, err)
+	assert.Assert(t, reorg == nil)
+}
+
+func Test_ReorgDetectorWatch(t *testing.T) {
+	client := &streamClient{
+		Values: [][]byte{
+			[]byte(`{"hash":"BLa","level":10}`),
+			[]byte(`{"hash":"BLb","level":11,"predecessor":"BLa"}`),
+			[]byte(`{"hash":"BLb2","level":11,"predecessor":"BLa"}`),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reorgs, errs := NewReorgDetector(NewBlockService(client), 10).Watch(ctx)
+
+	reorg := <-reorgs
+	assert.Equal(t, reorg.Head.Hash, "BLb2")
+	assert.Equal(t, reorg.Ancestor, 10)
+	assert.DeepEqual(t, reorg.Orphaned, []string{"BLb"})
+
+	cancel()
+	for range reorgs {
+	}
+	assert.Equal(t, <-errs, context.Canceled)
+}
+
+func Test_ReorgDetectorWatchError(t *testing.T) {
+	client := &streamClient{
+		Values: [][]byte{
+			[]byte(`{"hash":"BLa","level":10}`),
+			[]byte(`{"hash":"BLb","level":11,"predecessor":"BLa"}`),
+			[]byte(`{"hash":"BLc2","level":12,"predecessor":"BLb2"}`),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reorgs, errs := NewReorgDetector(NewBlockService(client), 10).Watch(ctx)
+	for range reorgs {
+	}
+	assert.ErrorContains(t, <-errs, "could not observe block 'BLc2'")
+}
+
+func Test_ReorgDetectorWatchSubscriptionError(t *testing.T) {
+	client := &streamClient{StreamErr: errors.New("404 Not Found")}
+
+	reorgs, errs := NewReorgDetector(NewBlockService(client), 10).Watch(context.Background())
+	for range reorgs {
+	}
+	assert.ErrorContains(t, <-errs, "could not watch reorgs: could not subscribe to heads: 404 Not Found")
+}
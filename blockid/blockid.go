@@ -0,0 +1,131 @@
+package blockid
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	head       = "head"
+	genesis    = "genesis"
+	checkpoint = "checkpoint"
+	savepoint  = "savepoint"
+	caboose    = "caboose"
+
+	// hashLength is the length of a base58 encoded block hash
+	hashLength = 51
+)
+
+// BlockID identifies a block in an RPC path, e.g. /chains/main/blocks/<block_id>. A BlockID is a
+// hash, a level or an alias such as head, optionally offset by a number of levels like head~2.
+// The zero value is the head of the chain.
+type BlockID struct {
+	id     string
+	offset int
+}
+
+// Head returns the BlockID of the current head of the chain.
+func Head() BlockID {
+	return BlockID{id: head}
+}
+
+// Genesis returns the BlockID of the genesis block of the chain.
+func Genesis() BlockID {
+	return BlockID{id: genesis}
+}
+
+// Checkpoint returns the BlockID of the checkpoint of the node.
+func Checkpoint() BlockID {
+	return BlockID{id: checkpoint}
+}
+
+// Savepoint returns the BlockID of the savepoint of the node, the lowest block with metadata.
+func Savepoint() BlockID {
+	return BlockID{id: savepoint}
+}
+
+// Caboose returns the BlockID of the caboose of the node, the lowest block it stores.
+func Caboose() BlockID {
+	return BlockID{id: caboose}
+}
+
+// Level returns the BlockID of the block at level.
+func Level(level int) BlockID {
+	return BlockID{id: strconv.Itoa(level)}
+}
+
+// Hash returns the BlockID of the block with hash.
+func Hash(hash string) BlockID {
+	return BlockID{id: hash}
+}
+
+// Parse parses a block ID of the RPC grammar, e.g. head, 1000, BL... or head~2.
+func Parse(s string) (BlockID, error) {
+	var blockID BlockID
+
+	base, offset := s, 0
+	if i := strings.IndexAny(s, "~-+"); i >= 0 {
+		n, err := strconv.Atoi(s[i+1:])
+		if err != nil || n < 0 {
+			return blockID, errors.Errorf("invalid block id '%s', bad offset", s)
+		}
+		base, offset = s[:i], n
+		if s[i] != '+' {
+			offset = -n
+		}
+	}
+
+	switch {
+	case base == head || base == genesis || base == checkpoint || base == savepoint || base == caboose:
+	case isLevel(base):
+	case len(base) == hashLength && strings.HasPrefix(base, "B"):
+	default:
+		return blockID, errors.Errorf("invalid block id '%s'", s)
+	}
+
+	return BlockID{id: base, offset: offset}, nil
+}
+
+// Minus returns the BlockID of the block n levels before b, e.g. Head().Minus(2) is head~2.
+func (b BlockID) Minus(n int) BlockID {
+	return BlockID{id: b.base(), offset: b.offset - n}
+}
+
+// Plus returns the BlockID of the block n levels after b, e.g. Level(1000).Plus(2) is 1000+2.
+func (b BlockID) Plus(n int) BlockID {
+	return BlockID{id: b.base(), offset: b.offset + n}
+}
+
+// String returns the block ID as used in RPC paths.
+func (b BlockID) String() string {
+	switch {
+	case b.offset < 0:
+		return b.base() + "~" + strconv.Itoa(-b.offset)
+	case b.offset > 0:
+		return b.base() + "+" + strconv.Itoa(b.offset)
+	default:
+		return b.base()
+	}
+}
+
+// base returns the block b is relative to, defaulting to head for the zero value
+func (b BlockID) base() string {
+	if b.id == "" {
+		return head
+	}
+	return b.id
+}
+
+func isLevel(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
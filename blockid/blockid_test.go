@@ -0,0 +1,59 @@
+package blockid
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_String(t *testing.T) {
+	cases := []struct {
+		id   BlockID
+		want string
+	}{
+		{id: BlockID{}, want: "head"},
+		{id: Head(), want: "head"},
+		{id: Genesis(), want: "genesis"},
+		{id: Checkpoint(), want: "checkpoint"},
+		{id: Level(1000), want: "1000"},
+		{id: Hash("BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT"), want: "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT"},
+		{id: Head().Minus(2), want: "head~2"},
+		{id: Head().Minus(2).Minus(3), want: "head~5"},
+		{id: Level(1000).Plus(2), want: "1000+2"},
+		{id: Head().Minus(2).Plus(2), want: "head"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.id.String(), tc.want)
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    BlockID
+		wantErr bool
+	}{
+		{input: "head", want: Head()},
+		{input: "savepoint", want: Savepoint()},
+		{input: "1000", want: Level(1000)},
+		{input: "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT", want: Hash("BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT")},
+		{input: "head~2", want: Head().Minus(2)},
+		{input: "head-2", want: Head().Minus(2)},
+		{input: "1000+2", want: Level(1000).Plus(2)},
+		{input: "", wantErr: true},
+		{input: "tail", wantErr: true},
+		{input: "head~x", wantErr: true},
+		{input: "BLTGSUUj", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		id, err := Parse(tc.input)
+		if tc.wantErr {
+			assert.Assert(t, err != nil, tc.input)
+			continue
+		}
+		assert.NilError(t, err, tc.input)
+		assert.Equal(t, id, tc.want)
+	}
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+)
+
+// CredentialsFunc sets credentials on a request before it is sent, it is called again on every retry
+// so short lived tokens can be refreshed. Returning an error aborts the request.
+type CredentialsFunc func(req *Request) error
+
+// WithBasicAuth authenticates every request with HTTP basic auth.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		c.headers.Set("Authorization", "Basic "+credentials)
+	}
+}
+
+// WithBearerToken authenticates every request with a bearer token.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.headers.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// WithAPIKey authenticates every request with key sent in header, e.g. WithAPIKey("X-Api-Key", key).
+func WithAPIKey(header, key string) Option {
+	return func(c *Client) {
+		c.headers.Set(header, key)
+	}
+}
+
+// WithCredentials registers fn to set credentials on every request right before it is sent.
+func WithCredentials(fn CredentialsFunc) Option {
+	return func(c *Client) {
+		c.credentials = append(c.credentials, fn)
+	}
+}
+
+func (c *Client) setCredentials(req *Request) error {
+	for _, fn := range c.credentials {
+		if err := fn(req); err != nil {
+			return errors.Wrap(err, "could not set request credentials")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ClientAuth(t *testing.T) {
+	cases := []struct {
+		opts       []Option
+		wantHeader string
+		want       string
+	}{
+		{
+			opts:       []Option{WithBasicAuth("baker", "taco")},
+			wantHeader: "Authorization",
+			want:       "Basic YmFrZXI6dGFjbw==",
+		},
+		{
+			opts:       []Option{WithBearerToken("taco")},
+			wantHeader: "Authorization",
+			want:       "Bearer taco",
+		},
+		{
+			opts:       []Option{WithAPIKey("X-Api-Key", "taco")},
+			wantHeader: "X-Api-Key",
+			want:       "taco",
+		},
+		{
+			opts: []Option{WithCredentials(func(req *Request) error {
+				req.Header.Set("X-Signature", req.Method+" "+req.Path)
+				return nil
+			})},
+			wantHeader: "X-Signature",
+			want:       "GET /example/get",
+		},
+	}
+
+	for _, tc := range cases {
+		netClient := &httpClientMock{ReturnStatus: http.StatusOK}
+		client := New("http://127.0.0.1:8732", tc.opts...)
+		client.netClient = netClient
+
+		_, err := client.Get("/example/get", nil)
+		assert.NilError(t, err)
+		assert.Equal(t, netClient.Requests[0].Header.Get(tc.wantHeader), tc.want)
+	}
+}
+
+func Test_ClientCredentialsError(t *testing.T) {
+	netClient := &httpClientMock{ReturnStatus: http.StatusOK}
+	client := New("http://127.0.0.1:8732", WithCredentials(func(req *Request) error {
+		return errors.New("token expired")
+	}))
+	client.netClient = netClient
+
+	_, err := client.Get("/example/get", nil)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, len(netClient.Requests), 0)
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"container/list"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores the responses of RPC calls that can never change, see WithCache.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// WithCache caches the responses of GET requests for blocks referenced by hash, which are immutable.
+// Requests for head, levels or relative block IDs are never cached.
+func WithCache(cache Cache) Option {
+	return WithMiddleware(cacheMiddleware(cache))
+}
+
+func cacheMiddleware(cache Cache) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			if !isImmutable(req) {
+				return next(req)
+			}
+
+			key := cacheKey(req)
+			// the cache holds its own copy of the body, which callers may modify
+			if body, ok := cache.Get(key); ok {
+				return &Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: append([]byte(nil), body...)}, nil
+			}
+
+			// a node may answer 200 with an array of Tezos errors, which must be asked again
+			resp, err := next(req)
+			if err == nil && resp != nil && resp.StatusCode == http.StatusOK &&
+				len(newResponseError(resp.StatusCode, resp.Body).Errors) == 0 {
+				cache.Set(key, append([]byte(nil), resp.Body...))
+			}
+			return resp, err
+		}
+	}
+}
+
+// isImmutable reports if req is a GET for a /chains/{chain}/blocks/{block_hash} path
+func isImmutable(req *Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+
+	segments := strings.Split(req.Path, "/")
+	if len(segments) < 5 || segments[1] != "chains" || segments[3] != "blocks" {
+		return false
+	}
+	return isBlockHash(segments[4])
+}
+
+// isBlockHash reports if id is a base58 block hash rather than head, a level or a relative block ID
+func isBlockHash(id string) bool {
+	return len(id) == 51 && id[0] == 'B' && !strings.ContainsAny(id, "~+-")
+}
+
+func cacheKey(req *Request) string {
+	if len(req.Params) == 0 {
+		return req.Path
+	}
+
+	keys := make([]string, 0, len(req.Params))
+	for k := range req.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(req.Path)
+	for i, k := range keys {
+		if i == 0 {
+			b.WriteByte('?')
+		} else {
+			b.WriteByte('&')
+		}
+		b.WriteString(k + "=" + req.Params[k])
+	}
+	return b.String()
+}
+
+// LRUCache is a Cache keeping at most size entries, evicting the least recently used
+// entry first. Entries older than ttl are evicted too when ttl is not zero.
+type LRUCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache returns a new LRUCache holding at most size entries for at most ttl, or forever if ttl is zero.
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		index:   map[string]*list.Element{},
+	}
+}
+
+// Get returns the value cached for key
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.index[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if l.ttl > 0 && time.Now().After(entry.expires) {
+		l.remove(elem)
+		return nil, false
+	}
+
+	l.entries.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set caches value for key
+func (l *LRUCache) Set(key string, value []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.index[key]; ok {
+		l.remove(elem)
+	}
+
+	entry := &lruEntry{key: key, value: value}
+	if l.ttl > 0 {
+		entry.expires = time.Now().Add(l.ttl)
+	}
+	l.index[key] = l.entries.PushFront(entry)
+
+	for l.size > 0 && l.entries.Len() > l.size {
+		l.remove(l.entries.Back())
+	}
+}
+
+// Len returns the number of entries in the cache
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.entries.Len()
+}
+
+func (l *LRUCache) remove(elem *list.Element) {
+	l.entries.Remove(elem)
+	delete(l.index, elem.Value.(*lruEntry).key)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_ClientCache(t *testing.T) {
+	cases := []struct {
+		path     string
+		params   map[string]string
+		requests int
+	}{
+		{path: "/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT", requests: 1},
+		{path: "/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT/context/constants", requests: 1},
+		{path: "/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT", params: map[string]string{"metadata": "never"}, requests: 1},
+		{path: "/chains/main/blocks/head", requests: 2},
+		{path: "/chains/main/blocks/524067", requests: 2},
+		{path: "/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT~2", requests: 2},
+	}
+
+	for _, tc := range cases {
+		netClient := &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte("block")}
+		client := New("http://127.0.0.1:8732", WithCache(NewLRUCache(10, 0)))
+		client.netClient = netClient
+
+		for i := 0; i < 2; i++ {
+			bytes, err := client.Get(tc.path, tc.params)
+			assert.NilError(t, err)
+			assert.Equal(t, string(bytes), "block")
+		}
+		assert.Equal(t, len(netClient.Requests), tc.requests, tc.path)
+	}
+}
+
+func Test_ClientCacheSkipsErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   []byte
+	}{
+		{name: "Error status", status: http.StatusInternalServerError},
+		{name: "Tezos errors", status: http.StatusOK, body: []byte(`[{"kind":"generic","error":"Failure in storage"}]`)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			netClient := &httpClientMock{ReturnStatus: tc.status, ReturnBody: tc.body}
+			cache := NewLRUCache(10, 0)
+			client := New("http://127.0.0.1:8732", WithCache(cache))
+			client.netClient = netClient
+
+			_, err := client.Get("/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT", nil)
+			assert.Assert(t, err != nil)
+			assert.Equal(t, cache.Len(), 0)
+		})
+	}
+}
+
+func Test_ClientCacheCopiesBody(t *testing.T) {
+	body := []byte("block")
+	next := func(req *Request) (*Response, error) {
+		return &Response{StatusCode: http.StatusOK, Body: body}, nil
+	}
+	handler := cacheMiddleware(NewLRUCache(10, 0))(next)
+	req := &Request{Method: http.MethodGet, Path: "/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT"}
+
+	// neither the response of the node nor a cache hit share their body with the cache
+	resp, err := handler(req)
+	assert.NilError(t, err)
+	resp.Body[0] = 'B'
+
+	resp, err = handler(req)
+	assert.NilError(t, err)
+	assert.Equal(t, string(resp.Body), "block")
+	resp.Body[0] = 'B'
+
+	resp, err = handler(req)
+	assert.NilError(t, err)
+	assert.Equal(t, string(resp.Body), "block")
+}
+
+func Test_LRUCache(t *testing.T) {
+	cache := NewLRUCache(2, 0)
+	cache.Set("a", []byte("a"))
+	cache.Set("b", []byte("b"))
+	_, ok := cache.Get("a")
+	assert.Assert(t, ok)
+
+	cache.Set("c", []byte("c"))
+	_, ok = cache.Get("b")
+	assert.Assert(t, !ok)
+	_, ok = cache.Get("a")
+	assert.Assert(t, ok)
+	assert.Equal(t, cache.Len(), 2)
+
+	expiring := NewLRUCache(2, time.Millisecond)
+	expiring.Set("a", []byte("a"))
+	time.Sleep(5 * time.Millisecond)
+	_, ok = expiring.Get("a")
+	assert.Assert(t, !ok)
+	assert.Equal(t, expiring.Len(), 0)
+}
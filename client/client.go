@@ -2,39 +2,94 @@ package client
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 )
 
+var (
+	// defaultTimeout is the request timeout used when no WithTimeout option is provided.
+	defaultTimeout = 10 * time.Second
+	// defaultChain is the chain queried when no WithChain option is provided.
+	defaultChain = "main"
+)
+
+const (
+	unixScheme = "unix://"
+	// unixHost is the placeholder host of requests sent over a unix socket
+	unixHost = "http://unix"
+)
+
 // Client is a struct to represent the http or rpc client
 type Client struct {
-	URL       string
-	netClient httpClient
+	URL          string
+	netClient    httpClient
+	timeout      time.Duration
+	retries      int
+	retryBackoff time.Duration
+	chain        string
+	headers      http.Header
+	middleware   []Middleware
+	logger       Logger
+	credentials  []CredentialsFunc
+
+	streamBackoff time.Duration
 }
 
-// RPCGenericError is an Error helper for the RPC
-type genericRPCError struct {
-	Kind  string `json:"kind"`
-	Error string `json:"error"`
+// Option configures a Client, see New.
+type Option func(c *Client)
+
+// WithHTTPClient sets the http.Client used to reach the node, e.g. to add proxies, custom TLS or instrumentation.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.netClient = httpClient
+	}
 }
 
-// RPCGenericErrors and array of RPCGenericErrors
-type genericRPCErrors []genericRPCError
+// WithTimeout sets the timeout of a single request to the node.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
 
-// NewClient returns a new client
-func NewClient(URL string) *Client {
-	if URL[len(URL)-1] == '/' {
-		URL = URL[:len(URL)-1]
+// WithRetry retries requests that failed to reach the node, or that the node
+// could not serve (502, 503 and 504), up to retries times waiting backoff between attempts.
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithChain sets the chain queried by every /chains/main/... path, e.g. "test" or a chain ID.
+func WithChain(chain string) Option {
+	return func(c *Client) {
+		c.chain = chain
 	}
-	if !strings.HasPrefix(URL, "http://") && !strings.HasPrefix(URL, "https://") {
-		URL = fmt.Sprintf("http://%s", URL) //default to http
+}
+
+// WithHeaders sets static headers sent with every request.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		for k, v := range headers {
+			c.headers.Set(k, v)
+		}
+	}
+}
+
+// New returns a new client for the node at host configured with opts. host may be a unix:// URL
+// to reach a node serving its RPC over a local socket, e.g. unix:///var/run/tezos/node.sock
+func New(host string, opts ...Option) *Client {
+	if host[len(host)-1] == '/' {
+		host = host[:len(host)-1]
 	}
 
 	var netTransport = &http.Transport{
@@ -44,28 +99,99 @@ func NewClient(URL string) *Client {
 		TLSHandshakeTimeout: 10 * time.Second,
 	}
 
+	if strings.HasPrefix(host, unixScheme) {
+		socket := strings.TrimPrefix(host, unixScheme)
+		dialer := &net.Dialer{Timeout: 10 * time.Second}
+		netTransport.Dial = nil
+		netTransport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+		host = unixHost
+	} else if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = fmt.Sprintf("http://%s", host) //default to http
+	}
+
 	var netClient = &http.Client{
-		Timeout:   time.Second * 10,
 		Transport: netTransport,
 	}
 
-	return &Client{URL: URL, netClient: netClient}
+	c := &Client{
+		URL:           host,
+		netClient:     netClient,
+		timeout:       defaultTimeout,
+		chain:         defaultChain,
+		headers:       http.Header{},
+		logger:        nopLogger{},
+		streamBackoff: defaultStreamBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
+// NewClient returns a new client
+//
+// Deprecated: use New, which accepts options.
+func NewClient(URL string) *Client {
+	return New(URL)
+}
+
+// Post sends a POST request with args as the JSON body to path
 func (c *Client) Post(path, args string) ([]byte, error) {
-	var respBytes []byte
-	resp, err := c.netClient.Post(c.URL+path, "application/json", bytes.NewBuffer([]byte(args)))
-	if err != nil {
-		return respBytes, err
-	}
+	return c.do(context.Background(), http.MethodPost, path, nil, []byte(args))
+}
 
-	respBytes, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return respBytes, errors.Wrap(err, "could not post")
+// PostWithParams sends a POST request with args as the JSON body to path with params as the query string
+func (c *Client) PostWithParams(path string, params map[string]string, args string) ([]byte, error) {
+	return c.do(context.Background(), http.MethodPost, path, params, []byte(args))
+}
+
+// Get sends a GET request to path with params as the query string
+func (c *Client) Get(path string, params map[string]string) ([]byte, error) {
+	return c.do(context.Background(), http.MethodGet, path, params, nil)
+}
+
+// PostContext is PostWithParams with ctx, the request and its retries are abandoned when ctx is done
+func (c *Client) PostContext(ctx context.Context, path string, params map[string]string, args string) ([]byte, error) {
+	return c.do(ctx, http.MethodPost, path, params, []byte(args))
+}
+
+// GetContext is Get with ctx, the request and its retries are abandoned when ctx is done
+func (c *Client) GetContext(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, path, params, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, params map[string]string, body []byte) ([]byte, error) {
+	req := &Request{
+		Context: ctx,
+		Method:  method,
+		Path:    c.chainPath(path),
+		Params:  c.chainParams(path, params),
+		Body:    body,
+		Header:  c.headers.Clone(),
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return respBytes, errors.Errorf("%d error: %s", resp.StatusCode, string(respBytes))
+	var respBytes []byte
+	start := time.Now()
+	resp, err := c.handler()(req)
+	status := 0
+	if resp != nil {
+		respBytes = resp.Body
+		status = resp.StatusCode
+	}
+	c.logger.Debug("rpc request",
+		"method", req.Method,
+		"path", req.Path,
+		"status", status,
+		"bytes", len(respBytes),
+		"duration", time.Since(start),
+		"err", err,
+	)
+	if err != nil {
+		return respBytes, err
 	}
 
 	err = c.handleRPCError(respBytes)
@@ -73,22 +199,63 @@ func (c *Client) Post(path, args string) ([]byte, error) {
 		return nil, err
 	}
 
-	c.netClient.CloseIdleConnections()
-
 	return respBytes, nil
 }
 
-func (c *Client) Get(path string, params map[string]string) ([]byte, error) {
-	var bytes []byte
+// send is the innermost Handler, it sends req to the node retrying as configured
+func (c *Client) send(req *Request) (*Response, error) {
+	var resp *Response
+	var err error
 
-	req, err := http.NewRequest("GET", c.URL+path, nil)
+	for attempt := 0; ; attempt++ {
+		if err := c.setCredentials(req); err != nil {
+			return nil, err
+		}
+		resp, err = c.roundTrip(req)
+		if err == nil || attempt >= c.retries || !isRetriable(err) {
+			break
+		}
+		c.logger.Debug("retrying rpc request",
+			"method", req.Method,
+			"path", req.Path,
+			"attempt", attempt+1,
+			"backoff", c.retryBackoff,
+			"err", err,
+		)
+		select {
+		case <-req.Context.Done():
+			return nil, errors.Wrapf(req.Context.Err(), "could not retry rpc request '%s'", req.Path)
+		case <-time.After(c.retryBackoff):
+		}
+	}
+
+	return resp, err
+}
+
+func (c *Client) roundTrip(r *Request) (*Response, error) {
+	ctx := r.Context
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequest(r.Method, c.URL+r.Path, bytes.NewReader(r.Body))
 	if err != nil {
-		return bytes, err
+		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	q := req.URL.Query()
-	if len(params) > 0 {
-		for k, v := range params {
+	for k := range r.Header {
+		req.Header.Set(k, r.Header.Get(k))
+	}
+	if r.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if len(r.Params) > 0 {
+		q := req.URL.Query()
+		for k, v := range r.Params {
 			q.Add(k, v)
 		}
 		req.URL.RawQuery = q.Encode()
@@ -96,47 +263,108 @@ func (c *Client) Get(path string, params map[string]string) ([]byte, error) {
 
 	resp, err := c.netClient.Do(req)
 	if err != nil {
-		return bytes, err
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	bytes, err = ioutil.ReadAll(resp.Body)
+	respBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return bytes, err
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return bytes, errors.Errorf("%d error: %s", resp.StatusCode, string(bytes))
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBytes,
 	}
 
-	err = c.handleRPCError(bytes)
-	if err != nil {
-		return nil, err
+	if resp.StatusCode != http.StatusOK {
+		return response, newResponseError(resp.StatusCode, respBytes)
 	}
 
 	c.netClient.CloseIdleConnections()
 
-	return bytes, nil
+	return response, nil
 }
 
-func (c *Client) handleRPCError(resp []byte) error {
-	if strings.Contains(string(resp), "\"error\":") {
-		rpcErrors := genericRPCErrors{}
-		rpcErrors, err := rpcErrors.unmarshalJSON(resp)
-		if err != nil {
-			return err
-		}
-		return errors.Errorf("rpc error (%s): %s", rpcErrors[0].Kind, rpcErrors[0].Error)
+// Chain returns the chain the client queries
+func (c *Client) Chain() string {
+	return c.chain
+}
+
+// ForChain returns a copy of the client querying chain instead, e.g. "test" or a chain ID.
+// The copy shares the connection, headers and middleware of the client.
+func (c *Client) ForChain(chain string) TezosClient {
+	return c.forChain(chain)
+}
+
+func (c *Client) forChain(chain string) *Client {
+	clone := *c
+	clone.chain = chain
+	clone.middleware = append([]Middleware(nil), c.middleware...)
+	clone.credentials = append([]CredentialsFunc(nil), c.credentials...)
+	return &clone
+}
+
+// chainPath points paths on the main chain at the chain the client was configured with
+func (c *Client) chainPath(path string) string {
+	if c.chain == defaultChain {
+		return path
 	}
-	return nil
+	if path == "/chains/main" || strings.HasPrefix(path, "/chains/main/") {
+		return "/chains/" + c.chain + strings.TrimPrefix(path, "/chains/main")
+	}
+	if path == "/monitor/heads/main" {
+		return "/monitor/heads/" + c.chain
+	}
+	return path
 }
 
-// UnmarshalJSON unmarhsels bytes into RPCGenericErrors
-func (ge *genericRPCErrors) unmarshalJSON(v []byte) (genericRPCErrors, error) {
-	r := genericRPCErrors{}
+// chainParams adds the chain query parameter expected by injection paths when querying another chain than main
+func (c *Client) chainParams(path string, params map[string]string) map[string]string {
+	if c.chain == defaultChain || !strings.HasPrefix(path, "/injection/") {
+		return params
+	}
+	if _, ok := params["chain"]; ok {
+		return params
+	}
 
-	err := json.Unmarshal(v, &r)
-	if err != nil {
-		return r, errors.Wrap(err, "could not unmarshal bytes into genericRPCErrors")
+	withChain := map[string]string{"chain": c.chain}
+	for k, v := range params {
+		withChain[k] = v
 	}
-	return r, nil
+	return withChain
+}
+
+// isRetriable reports if a failed request may succeed when sent again: the node was unreachable or answered it
+// was unavailable. A cancelled or expired context and local errors, e.g. a request that could not be built,
+// are not retried.
+func isRetriable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if respErr, ok := err.(*ResponseError); ok {
+		switch respErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
+
+// handleRPCError returns the error array the node answered with despite a 200 status
+func (c *Client) handleRPCError(resp []byte) error {
+	if strings.Contains(string(resp), "\"error\":") {
+		respErr := newResponseError(http.StatusOK, resp)
+		if len(respErr.Errors) == 0 {
+			return errors.New("could not unmarshal bytes into rpc errors")
+		}
+		return respErr
+	}
+	return nil
 }
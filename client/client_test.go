@@ -1,8 +1,19 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 )
@@ -39,7 +50,7 @@ func Test_ClientPost(t *testing.T) {
 		},
 	}
 
-	client := NewClient("http://127.0.0.1:8732")
+	client := New("http://127.0.0.1:8732")
 
 	for _, tc := range cases {
 		client.netClient = tc.client
@@ -78,7 +89,7 @@ func Test_ClientPostBadStatus(t *testing.T) {
 		},
 	}
 
-	client := NewClient("http://127.0.0.1:8732")
+	client := New("http://127.0.0.1:8732")
 	netClient := &httpClientMock{}
 	client.netClient = netClient
 
@@ -89,7 +100,7 @@ func Test_ClientPostBadStatus(t *testing.T) {
 }
 
 func Test_ClientGet(t *testing.T) {
-	client := NewClient("http://127.0.0.1:8732")
+	client := New("http://127.0.0.1:8732")
 	netClient := &httpClientMock{
 		ReturnStatus: http.StatusOK,
 		ReturnBody:   []byte("some GET value"),
@@ -102,10 +113,238 @@ func Test_ClientGet(t *testing.T) {
 }
 
 func Test_ClientGetBadStatus(t *testing.T) {
-	client := NewClient("http://127.0.0.1:8732")
+	client := New("http://127.0.0.1:8732")
 	netClient := &httpClientMock{ReturnStatus: http.StatusInternalServerError}
 	client.netClient = netClient
 
 	_, err := client.Get("/example/get", nil)
 	assert.Assert(t, err != nil)
 }
+
+func Test_New(t *testing.T) {
+	cases := []struct {
+		host string
+		want string
+	}{
+		{host: "127.0.0.1:8732", want: "http://127.0.0.1:8732"},
+		{host: "https://mainnet.example.org/", want: "https://mainnet.example.org"},
+	}
+
+	for _, tc := range cases {
+		client := New(tc.host)
+		assert.Equal(t, client.URL, tc.want)
+	}
+}
+
+func Test_ClientOptions(t *testing.T) {
+	netClient := &httpClientMock{
+		ReturnStatus: http.StatusOK,
+		ReturnBody:   []byte("some GET value"),
+	}
+	client := New("http://127.0.0.1:8732",
+		WithChain("test"),
+		WithHeaders(map[string]string{"X-Api-Key": "taco"}),
+	)
+	client.netClient = netClient
+
+	_, err := client.Get("/chains/main/blocks/head", map[string]string{"metadata": "never"})
+	assert.NilError(t, err)
+	_, err = client.Get("/network/versions", nil)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(netClient.Requests), 2)
+	assert.Equal(t, netClient.Requests[0].URL.String(), "http://127.0.0.1:8732/chains/test/blocks/head?metadata=never")
+	assert.Equal(t, netClient.Requests[0].Header.Get("X-Api-Key"), "taco")
+	assert.Equal(t, netClient.Requests[1].URL.Path, "/network/versions")
+}
+
+func Test_ClientRetry(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	cases := []struct {
+		status   int
+		err      error
+		attempts int
+	}{
+		{status: http.StatusServiceUnavailable, attempts: 3},
+		{status: http.StatusInternalServerError, attempts: 1},
+		{err: &url.Error{Op: "Get", URL: "http://127.0.0.1:8732", Err: refused}, attempts: 3},
+		{err: refused, attempts: 3},
+		{err: &url.Error{Op: "Get", URL: "http://127.0.0.1:8732", Err: context.Canceled}, attempts: 1},
+		{err: &url.Error{Op: "Get", URL: "http://127.0.0.1:8732", Err: context.DeadlineExceeded}, attempts: 1},
+		{err: errors.New("could not get credentials"), attempts: 1},
+	}
+
+	for _, tc := range cases {
+		netClient := &httpClientMock{ReturnStatus: tc.status, ReturnErr: tc.err}
+		client := New("http://127.0.0.1:8732", WithRetry(2, 0))
+		client.netClient = netClient
+
+		_, err := client.Get("/example/get", nil)
+		assert.Assert(t, err != nil)
+		assert.Equal(t, len(netClient.Requests), tc.attempts)
+	}
+}
+
+func Test_ClientRetryCancelled(t *testing.T) {
+	netClient := &httpClientMock{ReturnStatus: http.StatusServiceUnavailable}
+	client := New("http://127.0.0.1:8732", WithRetry(2, time.Hour))
+	client.netClient = netClient
+
+	// the context is cancelled while waiting for the first retry
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.GetContext(ctx, "/example/get", nil)
+	assert.Assert(t, errors.Is(err, context.Canceled), err)
+	assert.Assert(t, time.Since(start) < time.Minute)
+	assert.Equal(t, len(netClient.Requests), 1)
+}
+
+func Test_Endpoint(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "/chains/main/blocks/head/context/contracts/tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n/balance",
+			want: "/chains/{chain}/blocks/{block_id}/context/contracts/{contract}/balance",
+		},
+		{
+			path: "/chains/main/blocks/524067/context/delegates",
+			want: "/chains/{chain}/blocks/{block_id}/context/delegates",
+		},
+		{
+			path: "/network/versions",
+			want: "/network/versions",
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, Endpoint(tc.path), tc.want)
+	}
+}
+
+func Test_ClientLogger(t *testing.T) {
+	var out bytes.Buffer
+	netClient := &httpClientMock{ReturnStatus: http.StatusServiceUnavailable}
+	client := New("http://127.0.0.1:8732",
+		WithRetry(1, 0),
+		WithLogger(NewStdLogger(log.New(&out, "", 0))),
+	)
+	client.netClient = netClient
+
+	_, err := client.Get("/example/get", nil)
+	assert.Assert(t, err != nil)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(lines), 2)
+	assert.Assert(t, strings.HasPrefix(lines[0], "retrying rpc request method=GET path=/example/get attempt=1"))
+	assert.Assert(t, strings.HasPrefix(lines[1], "rpc request method=GET path=/example/get status=503"))
+}
+
+func Test_ClientForChain(t *testing.T) {
+	netClient := &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte(`"ophash"`)}
+	client := New("http://127.0.0.1:8732")
+	client.netClient = netClient
+
+	test := client.ForChain("test")
+	_, err := test.Get("/chains/main/blocks/head", nil)
+	assert.NilError(t, err)
+	_, err = test.Post("/injection/operation", `"00"`)
+	assert.NilError(t, err)
+	_, err = test.Get("/monitor/heads/main", nil)
+	assert.NilError(t, err)
+	_, err = client.Get("/chains/main/blocks/head", nil)
+	assert.NilError(t, err)
+	_, err = test.(ParamsPoster).PostWithParams("/injection/operation", map[string]string{"async": "true"}, `"00"`)
+	assert.NilError(t, err)
+	_, err = test.(ParamsPoster).PostWithParams("/injection/operation", map[string]string{"chain": "NetXdQprcVkpaWU"}, `"00"`)
+	assert.NilError(t, err)
+
+	assert.Equal(t, netClient.Requests[0].URL.Path, "/chains/test/blocks/head")
+	assert.Equal(t, netClient.Requests[1].URL.String(), "http://127.0.0.1:8732/injection/operation?chain=test")
+	assert.Equal(t, netClient.Requests[2].URL.Path, "/monitor/heads/test")
+	assert.Equal(t, netClient.Requests[3].URL.Path, "/chains/main/blocks/head")
+	assert.Equal(t, netClient.Requests[4].URL.String(), "http://127.0.0.1:8732/injection/operation?async=true&chain=test")
+	assert.Equal(t, netClient.Requests[5].URL.String(), "http://127.0.0.1:8732/injection/operation?chain=NetXdQprcVkpaWU")
+	assert.Equal(t, client.Chain(), "main")
+}
+
+func Test_ClientUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotezos")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "node.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NilError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	client := New("unix://" + socket)
+	bytes, err := client.Get("/chains/main/blocks/head", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bytes), "/chains/main/blocks/head")
+}
+
+// pathClient is a TezosClient recording the paths of its POST requests
+type pathClient struct {
+	paths []string
+}
+
+func (c *pathClient) Post(path, args string) ([]byte, error) {
+	c.paths = append(c.paths, path)
+	return nil, nil
+}
+
+func (c *pathClient) Get(path string, params map[string]string) ([]byte, error) {
+	return nil, nil
+}
+
+func Test_PostParams(t *testing.T) {
+	netClient := &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte(`"ok"`)}
+	client := New("http://127.0.0.1:8732")
+	client.netClient = netClient
+
+	_, err := PostParams(client, "/injection/operation", map[string]string{"async": "true"}, `"00"`)
+	assert.NilError(t, err)
+	assert.Equal(t, netClient.Requests[0].URL.String(), "http://127.0.0.1:8732/injection/operation?async=true")
+
+	// clients that are not a ParamsPoster get the parameters in the path
+	plain := &pathClient{}
+	_, err = PostParams(plain, "/injection/operation", map[string]string{"async": "true"}, `"00"`)
+	assert.NilError(t, err)
+	_, err = PostParams(plain, "/injection/operation", nil, `"00"`)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, plain.paths, []string{"/injection/operation?async=true", "/injection/operation"})
+}
+
+type contextKey struct{}
+
+func Test_WithContext(t *testing.T) {
+	netClient := &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte(`"ok"`)}
+	client := New("http://127.0.0.1:8732")
+	client.netClient = netClient
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "operation")
+	bound := WithContext(ctx, client)
+	_, err := bound.Get("/example/get", nil)
+	assert.NilError(t, err)
+	_, err = PostParams(bound, "/injection/operation", map[string]string{"async": "true"}, `"00"`)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(netClient.Requests), 2)
+	for _, req := range netClient.Requests {
+		assert.Equal(t, req.Context().Value(contextKey{}), "operation")
+	}
+	assert.Equal(t, netClient.Requests[1].URL.String(), "http://127.0.0.1:8732/injection/operation?async=true")
+
+	// clients without contexts are used as they are
+	plain := &pathClient{}
+	assert.Equal(t, WithContext(ctx, plain), TezosClient(plain))
+}
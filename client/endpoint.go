@@ -0,0 +1,25 @@
+package client
+
+import "strings"
+
+// placeholders maps a path segment to the placeholder of the identifier following it
+var placeholders = map[string]string{
+	"chains":    "{chain}",
+	"blocks":    "{block_id}",
+	"contracts": "{contract}",
+	"delegates": "{delegate}",
+	"big_maps":  "{big_map}",
+}
+
+// Endpoint returns path with chain, block, contract, delegate and big map identifiers replaced
+// by placeholders, e.g. /chains/{chain}/blocks/{block_id}/header. It is meant to label
+// requests by endpoint in logs, traces and metrics without unbounded cardinality.
+func Endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if placeholder, ok := placeholders[segments[i-1]]; ok && segments[i] != "" {
+			segments[i] = placeholder
+		}
+	}
+	return strings.Join(segments, "/")
+}
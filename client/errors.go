@@ -0,0 +1,154 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrCounterInThePast is returned when an operation uses a counter that was already used by the source.
+	ErrCounterInThePast = &RPCError{ID: "contract.counter_in_the_past"}
+	// ErrCounterInTheFuture is returned when an operation skips counters of the source.
+	ErrCounterInTheFuture = &RPCError{ID: "contract.counter_in_the_future"}
+	// ErrBalanceTooLow is returned when the source cannot afford an operation.
+	ErrBalanceTooLow = &RPCError{ID: "contract.balance_too_low"}
+	// ErrUnrevealedKey is returned when the source of a manager operation has not revealed its public key.
+	ErrUnrevealedKey = &RPCError{ID: "contract.unrevealed_key"}
+	// ErrEmptyImplicitContract is returned when an operation is sent from an empty implicit account.
+	ErrEmptyImplicitContract = &RPCError{ID: "implicit.empty_implicit_contract"}
+	// ErrNonExistingContract is returned when an operation targets a contract that does not exist.
+	ErrNonExistingContract = &RPCError{ID: "contract.non_existing_contract"}
+	// ErrGasExhausted is returned when an operation runs out of gas.
+	ErrGasExhausted = &RPCError{ID: "gas_exhausted.operation"}
+	// ErrStorageExhausted is returned when an operation exceeds its storage limit.
+	ErrStorageExhausted = &RPCError{ID: "storage_exhausted.operation"}
+	// ErrScriptRejected is returned when a contract call hits a FAILWITH.
+	ErrScriptRejected = &RPCError{ID: "michelson_v1.script_rejected"}
+	// ErrInvalidSignature is returned when an operation signature does not match its source.
+	ErrInvalidSignature = &RPCError{ID: "operation.invalid_signature"}
+)
+
+// RPCError is a single error of the error array returned by a Tezos node.
+// Error specific fields (contract, expected, found...) are kept in Raw.
+type RPCError struct {
+	Kind string          `json:"kind"`
+	ID   string          `json:"id"`
+	Msg  string          `json:"msg,omitempty"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+// ResponseError is returned when a node answers with an error status, or with an error array.
+// Errors holds the errors the node returned when the body could be parsed as an error array.
+type ResponseError struct {
+	StatusCode int
+	Body       []byte
+	Errors     []*RPCError
+}
+
+// UnmarshalJSON unmarshals an RPCError, keeping the raw error. Generic errors
+// carrying an "error" description instead of "msg" are supported.
+func (e *RPCError) UnmarshalJSON(v []byte) error {
+	type rpcError RPCError
+	var r struct {
+		rpcError
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(v, &r); err != nil {
+		return err
+	}
+
+	*e = RPCError(r.rpcError)
+	if e.Msg == "" {
+		e.Msg = r.Error
+	}
+	e.Raw = append(json.RawMessage{}, v...)
+	return nil
+}
+
+// ShortID returns the ID of the error without its protocol prefix, e.g. contract.counter_in_the_past
+// for proto.005-PsBabyM1.contract.counter_in_the_past.
+func (e *RPCError) ShortID() string {
+	if !strings.HasPrefix(e.ID, "proto.") {
+		return e.ID
+	}
+	parts := strings.SplitN(e.ID, ".", 3)
+	if len(parts) < 3 {
+		return e.ID
+	}
+	return parts[2]
+}
+
+func (e *RPCError) Error() string {
+	id := e.ID
+	if id == "" {
+		id = e.Kind
+	}
+	if e.Msg == "" {
+		return id
+	}
+	return fmt.Sprintf("%s: %s", id, e.Msg)
+}
+
+// Is reports if target is an *RPCError with the same ID. Targets without a protocol prefix
+// like ErrCounterInThePast match errors of every protocol.
+func (e *RPCError) Is(target error) bool {
+	t, ok := target.(*RPCError)
+	if !ok {
+		return false
+	}
+	return t.ID == e.ID || t.ID == e.ShortID()
+}
+
+func (e *ResponseError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("%d error: %s", e.StatusCode, string(e.Body))
+	}
+
+	msg := fmt.Sprintf("rpc error (%s): %s", e.Errors[0].Kind, e.Errors[0].Error())
+	if len(e.Errors) > 1 {
+		msg = fmt.Sprintf("%s (and %d more)", msg, len(e.Errors)-1)
+	}
+	return msg
+}
+
+// Is reports if any of the errors returned by the node matches target.
+func (e *ResponseError) Is(target error) bool {
+	for _, rpcErr := range e.Errors {
+		if rpcErr.Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As sets target to the first error returned by the node when target is a **RPCError.
+func (e *ResponseError) As(target interface{}) bool {
+	t, ok := target.(**RPCError)
+	if !ok || len(e.Errors) == 0 {
+		return false
+	}
+	*t = e.Errors[0]
+	return true
+}
+
+// Has reports if the node returned an error with the ID provided, with or without protocol prefix.
+func (e *ResponseError) Has(id string) bool {
+	return e.Is(&RPCError{ID: id})
+}
+
+// newResponseError parses the error array in body when there is one
+func newResponseError(statusCode int, body []byte) *ResponseError {
+	respErr := &ResponseError{StatusCode: statusCode, Body: body}
+
+	var rpcErrors []*RPCError
+	if err := json.Unmarshal(body, &rpcErrors); err == nil {
+		for _, rpcErr := range rpcErrors {
+			if rpcErr != nil && (rpcErr.ID != "" || rpcErr.Kind != "") {
+				respErr.Errors = append(respErr.Errors, rpcErr)
+			}
+		}
+	}
+
+	return respErr
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+var goldenCounterError = []byte(`[
+	{
+		"kind": "temporary",
+		"id": "proto.005-PsBabyM1.contract.counter_in_the_past",
+		"contract": "tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n",
+		"expected": "21",
+		"found": "20"
+	},
+	{
+		"kind": "permanent",
+		"id": "proto.005-PsBabyM1.contract.balance_too_low",
+		"contract": "tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n",
+		"balance": "10",
+		"amount": "1000000"
+	}
+]`)
+
+func Test_ResponseError(t *testing.T) {
+	client := New("http://127.0.0.1:8732")
+	client.netClient = &httpClientMock{
+		ReturnStatus: http.StatusInternalServerError,
+		ReturnBody:   goldenCounterError,
+	}
+
+	_, err := client.Post("/injection/operation", `"00"`)
+	err = pkgerrors.Wrap(err, "could not inject operation")
+
+	assert.Assert(t, errors.Is(err, ErrCounterInThePast))
+	assert.Assert(t, errors.Is(err, ErrBalanceTooLow))
+	assert.Assert(t, !errors.Is(err, ErrUnrevealedKey))
+
+	var respErr *ResponseError
+	assert.Assert(t, errors.As(err, &respErr))
+	assert.Equal(t, respErr.StatusCode, http.StatusInternalServerError)
+	assert.Equal(t, len(respErr.Errors), 2)
+	assert.Assert(t, respErr.Has("contract.balance_too_low"))
+
+	var rpcErr *RPCError
+	assert.Assert(t, errors.As(err, &rpcErr))
+	assert.Equal(t, rpcErr.Kind, "temporary")
+	assert.Equal(t, rpcErr.ShortID(), "contract.counter_in_the_past")
+	assert.Assert(t, len(rpcErr.Raw) > 0)
+
+	assert.Equal(t, respErr.Error(), "rpc error (temporary): proto.005-PsBabyM1.contract.counter_in_the_past (and 1 more)")
+}
+
+func Test_ResponseErrorUnparsed(t *testing.T) {
+	client := New("http://127.0.0.1:8732")
+	client.netClient = &httpClientMock{
+		ReturnStatus: http.StatusNotFound,
+		ReturnBody:   []byte("not found"),
+	}
+
+	_, err := client.Get("/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT", nil)
+
+	var respErr *ResponseError
+	assert.Assert(t, errors.As(err, &respErr))
+	assert.Equal(t, len(respErr.Errors), 0)
+	assert.Equal(t, err.Error(), "404 error: not found")
+}
+
+func Test_ResponseErrorGeneric(t *testing.T) {
+	client := New("http://127.0.0.1:8732")
+	client.netClient = &httpClientMock{
+		ReturnStatus: http.StatusOK,
+		ReturnBody:   []byte(`[{"kind":"generic","error":"Invalid_argument"}]`),
+	}
+
+	_, err := client.Get("/chains/main/blocks/head", nil)
+
+	var rpcErr *RPCError
+	assert.Assert(t, errors.As(err, &rpcErr))
+	assert.Equal(t, rpcErr.Msg, "Invalid_argument")
+	assert.Equal(t, err.Error(), "rpc error (generic): generic: Invalid_argument")
+}
@@ -1,8 +1,9 @@
 package client
 
 import (
-	"io"
+	"context"
 	"net/http"
+	"net/url"
 )
 
 type TezosClient interface {
@@ -10,9 +11,79 @@ type TezosClient interface {
 	Get(path string, params map[string]string) ([]byte, error)
 }
 
+// ChainSelector is implemented by clients that can be pointed at another chain than
+// the one they were created for, see Client.ForChain.
+type ChainSelector interface {
+	ForChain(chain string) TezosClient
+}
+
+// ParamsPoster is implemented by clients that can send query parameters with POST requests,
+// see Client.PostWithParams.
+type ParamsPoster interface {
+	PostWithParams(path string, params map[string]string, args string) ([]byte, error)
+}
+
+// PostParams sends a POST request with args as the JSON body to path with params as the query string. The
+// parameters are encoded in the path of clients that are not a ParamsPoster.
+func PostParams(c TezosClient, path string, params map[string]string, args string) ([]byte, error) {
+	if len(params) == 0 {
+		return c.Post(path, args)
+	}
+	if poster, ok := c.(ParamsPoster); ok {
+		return poster.PostWithParams(path, params, args)
+	}
+
+	query := url.Values{}
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	return c.Post(path+"?"+query.Encode(), args)
+}
+
+// ContextClient is implemented by clients whose requests can be cancelled, see Client.GetContext and
+// Client.PostContext.
+type ContextClient interface {
+	GetContext(ctx context.Context, path string, params map[string]string) ([]byte, error)
+	PostContext(ctx context.Context, path string, params map[string]string, args string) ([]byte, error)
+}
+
+// WithContext returns a client sending the requests of c with ctx, so that they are abandoned when ctx is done.
+// Clients that are not a ContextClient are returned as they are.
+func WithContext(ctx context.Context, c TezosClient) TezosClient {
+	cc, ok := c.(ContextClient)
+	if !ok {
+		return c
+	}
+	return &contextClient{ctx: ctx, client: cc}
+}
+
+// contextClient is a ContextClient bound to a context
+type contextClient struct {
+	ctx    context.Context
+	client ContextClient
+}
+
+func (c *contextClient) Post(path, args string) ([]byte, error) {
+	return c.client.PostContext(c.ctx, path, nil, args)
+}
+
+func (c *contextClient) PostWithParams(path string, params map[string]string, args string) ([]byte, error) {
+	return c.client.PostContext(c.ctx, path, params, args)
+}
+
+func (c *contextClient) Get(path string, params map[string]string) ([]byte, error) {
+	return c.client.GetContext(c.ctx, path, params)
+}
+
+// Streamer is implemented by clients that can read the streamed responses of
+// the /monitor endpoints, see Client.Stream and Client.StreamOnce.
+type Streamer interface {
+	Stream(ctx context.Context, path string, params map[string]string, fn StreamHandler) error
+	StreamOnce(ctx context.Context, path string, params map[string]string, fn StreamHandler) error
+}
+
 // httpClient is an interface that exposes the HTTP methods for testing.
 type httpClient interface {
 	Do(req *http.Request) (*http.Response, error)
-	Post(url, contentType string, body io.Reader) (*http.Response, error)
 	CloseIdleConnections()
 }
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger receives debug level summaries of requests, responses, retries and failovers from the client.
+// keysAndValues alternate between a string key and its value.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+}
+
+// WithLogger sets the Logger the client reports to, by default nothing is logged.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, keysAndValues ...interface{}) {}
+
+// stdLogger is a Logger writing key=value lines to a standard library log.Logger
+type stdLogger struct {
+	logger *log.Logger
+}
+
+// NewStdLogger returns a Logger that writes key=value lines to logger
+func NewStdLogger(logger *log.Logger) Logger {
+	return &stdLogger{logger: logger}
+}
+
+func (s *stdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var value interface{} = "(missing)"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		fmt.Fprintf(&b, " %v=%v", keysAndValues[i], value)
+	}
+	s.logger.Print(b.String())
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request is an RPC request as seen by Middleware. Path already points at the chain the client is configured with.
+type Request struct {
+	Context context.Context
+	Method  string
+	Path    string
+	Params  map[string]string
+	Body    []byte
+	Header  http.Header
+}
+
+// Response is an RPC response as seen by Middleware.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Handler sends a Request to the node and returns its Response. When the node answers
+// with an error status both the Response and an error are returned.
+type Handler func(req *Request) (*Response, error)
+
+// Middleware wraps a Handler, it may inspect or modify the Request before calling next,
+// inspect or replace the Response after, or answer without calling next at all.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware registers middleware on the client, see Client.Use.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(middleware...)
+	}
+}
+
+// Use registers middleware on the client. Middleware run in the order they are
+// registered, the first one sees the request first and the response last.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// handler chains the registered middleware in front of the transport
+func (c *Client) handler() Handler {
+	h := Handler(c.send)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
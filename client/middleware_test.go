@@ -0,0 +1,64 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Middleware(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *Request) (*Response, error) {
+				calls = append(calls, name+" "+req.Method+" "+req.Path)
+				resp, err := next(req)
+				calls = append(calls, name+" "+string(resp.Body))
+				return resp, err
+			}
+		}
+	}
+	sign := func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			req.Header.Set("Authorization", "Bearer taco")
+			return next(req)
+		}
+	}
+
+	netClient := &httpClientMock{
+		ReturnStatus: http.StatusOK,
+		ReturnBody:   []byte("hard taco"),
+	}
+	client := New("http://127.0.0.1:8732", WithMiddleware(record("first")))
+	client.Use(record("second"), sign)
+	client.netClient = netClient
+
+	bytes, err := client.Post("/example/post", "taco")
+	assert.NilError(t, err)
+	assert.Equal(t, string(bytes), "hard taco")
+	assert.DeepEqual(t, calls, []string{
+		"first POST /example/post",
+		"second POST /example/post",
+		"second hard taco",
+		"first hard taco",
+	})
+	assert.Equal(t, netClient.Requests[0].Header.Get("Authorization"), "Bearer taco")
+}
+
+func Test_MiddlewareShortCircuit(t *testing.T) {
+	cached := func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			return &Response{StatusCode: http.StatusOK, Body: []byte("cached taco")}, nil
+		}
+	}
+
+	netClient := &httpClientMock{ReturnStatus: http.StatusInternalServerError}
+	client := New("http://127.0.0.1:8732", WithMiddleware(cached))
+	client.netClient = netClient
+
+	bytes, err := client.Get("/example/get", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bytes), "cached taco")
+	assert.Equal(t, len(netClient.Requests), 0)
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"bytes"
-	"io"
 	"io/ioutil"
 	"net/http"
 )
@@ -10,16 +9,15 @@ import (
 type httpClientMock struct {
 	ReturnStatus int
 	ReturnBody   []byte
+	ReturnErr    error
+	Requests     []*http.Request
 }
 
 func (h *httpClientMock) Do(req *http.Request) (*http.Response, error) {
-	return &http.Response{
-		Body:       ioutil.NopCloser(bytes.NewReader(h.ReturnBody)),
-		StatusCode: h.ReturnStatus,
-	}, nil
-}
-
-func (h *httpClientMock) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	h.Requests = append(h.Requests, req)
+	if h.ReturnErr != nil {
+		return nil, h.ReturnErr
+	}
 	return &http.Response{
 		Body:       ioutil.NopCloser(bytes.NewReader(h.ReturnBody)),
 		StatusCode: h.ReturnStatus,
@@ -0,0 +1,370 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// defaultMaxLevelLag is how many levels a node may trail the highest known head before it is considered unhealthy.
+	defaultMaxLevelLag = 2
+)
+
+// Pool is a TezosClient backed by several Tezos nodes. Requests are routed to the
+// first healthy node and fail over to the next one when a node errors or falls behind.
+type Pool struct {
+	// MaxLevelLag is how many levels a node may trail the highest head in the pool before it is marked unhealthy.
+	MaxLevelLag int
+
+	mu         sync.RWMutex
+	nodes      []*poolNode
+	onFailover []func(node string, err error)
+}
+
+// NodeStatus is the health of a node in a Pool
+type NodeStatus struct {
+	URL     string
+	Healthy bool
+	Level   int
+	Err     error
+}
+
+type poolNode struct {
+	client  *Client
+	healthy bool
+	lagging bool
+	level   int
+	err     error
+}
+
+type headHeader struct {
+	Level int `json:"level"`
+}
+
+// NewPool returns a new Pool for the node URLs provided, every node client is configured with opts.
+// All nodes start out healthy.
+func NewPool(URLs []string, opts ...Option) (*Pool, error) {
+	if len(URLs) == 0 {
+		return nil, errors.New("could not create pool, no node URLs provided")
+	}
+
+	pool := &Pool{MaxLevelLag: defaultMaxLevelLag}
+	for _, URL := range URLs {
+		if URL == "" {
+			return nil, errors.New("could not create pool, empty node URL")
+		}
+		pool.nodes = append(pool.nodes, &poolNode{client: New(URL, opts...), healthy: true})
+	}
+
+	return pool, nil
+}
+
+// Post sends a POST request to the first healthy node, failing over to the others on error.
+func (p *Pool) Post(path, args string) ([]byte, error) {
+	return p.do(context.Background(), func(c *Client) ([]byte, error) {
+		return c.Post(path, args)
+	})
+}
+
+// PostWithParams sends a POST request with query parameters to the first healthy node, failing over to the
+// others on error.
+func (p *Pool) PostWithParams(path string, params map[string]string, args string) ([]byte, error) {
+	return p.do(context.Background(), func(c *Client) ([]byte, error) {
+		return c.PostWithParams(path, params, args)
+	})
+}
+
+// Get sends a GET request to the first healthy node, failing over to the others on error.
+func (p *Pool) Get(path string, params map[string]string) ([]byte, error) {
+	return p.do(context.Background(), func(c *Client) ([]byte, error) {
+		return c.Get(path, params)
+	})
+}
+
+// PostContext is PostWithParams with ctx, the request is neither retried nor failed over once ctx is done.
+func (p *Pool) PostContext(ctx context.Context, path string, params map[string]string, args string) ([]byte, error) {
+	return p.do(ctx, func(c *Client) ([]byte, error) {
+		return c.PostContext(ctx, path, params, args)
+	})
+}
+
+// GetContext is Get with ctx, the request is neither retried nor failed over once ctx is done.
+func (p *Pool) GetContext(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+	return p.do(ctx, func(c *Client) ([]byte, error) {
+		return c.GetContext(ctx, path, params)
+	})
+}
+
+// Stream reads the stream at path from the first healthy node. When the connection drops
+// the stream reconnects to the next healthy node, see Client.Stream.
+func (p *Pool) Stream(ctx context.Context, path string, params map[string]string, fn StreamHandler) error {
+	return p.stream(ctx, true, func(c *Client) error {
+		return c.streamOnce(ctx, path, params, fn)
+	})
+}
+
+// StreamOnce reads the stream at path from the first healthy node until the node closes it,
+// failing over to the next healthy node when the connection fails, see Client.StreamOnce.
+func (p *Pool) StreamOnce(ctx context.Context, path string, params map[string]string, fn StreamHandler) error {
+	return p.stream(ctx, false, func(c *Client) error {
+		return c.streamOnce(ctx, path, params, fn)
+	})
+}
+
+// ForChain returns a client sending requests for chain through the nodes of the pool.
+// The returned client shares the health of the nodes with the pool.
+func (p *Pool) ForChain(chain string) TezosClient {
+	return &chainPool{pool: p, chain: chain}
+}
+
+// OnFailover registers fn to be called every time a request fails over away from a node.
+// node is the URL of the node that failed and err the reason.
+func (p *Pool) OnFailover(fn func(node string, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onFailover = append(p.onFailover, fn)
+}
+
+// CheckHealth queries the head of every node in the pool and marks nodes that
+// fail to answer, or trail the highest head by more than MaxLevelLag, as unhealthy.
+func (p *Pool) CheckHealth() {
+	var wg sync.WaitGroup
+	levels := make([]int, len(p.nodes))
+	errs := make([]error, len(p.nodes))
+
+	for i, node := range p.nodes {
+		wg.Add(1)
+		go func(i int, node *poolNode) {
+			defer wg.Done()
+			levels[i], errs[i] = headLevel(node.client)
+		}(i, node)
+	}
+	wg.Wait()
+
+	maxLevel := 0
+	for i := range levels {
+		if errs[i] == nil && levels[i] > maxLevel {
+			maxLevel = levels[i]
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, node := range p.nodes {
+		node.err = errs[i]
+		node.lagging = false
+		if errs[i] != nil {
+			node.healthy = false
+			continue
+		}
+		node.level = levels[i]
+		node.lagging = maxLevel-levels[i] > p.MaxLevelLag
+		node.healthy = !node.lagging
+		if node.lagging {
+			node.err = errors.Errorf("node is at level %d, %d levels behind head %d", levels[i], maxLevel-levels[i], maxLevel)
+		}
+	}
+}
+
+// Watch runs CheckHealth every interval until done is closed.
+func (p *Pool) Watch(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	p.CheckHealth()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			p.CheckHealth()
+		}
+	}
+}
+
+// Status returns the last known health of every node in the pool.
+func (p *Pool) Status() []NodeStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status := make([]NodeStatus, len(p.nodes))
+	for i, node := range p.nodes {
+		status[i] = NodeStatus{
+			URL:     node.client.URL,
+			Healthy: node.healthy,
+			Level:   node.level,
+			Err:     node.err,
+		}
+	}
+	return status
+}
+
+// do runs req against healthy nodes in order, then against unhealthy nodes as a last resort.
+// Requests failing because ctx is done do not fail over, the node is not to blame.
+func (p *Pool) do(ctx context.Context, req func(c *Client) ([]byte, error)) ([]byte, error) {
+	var lastErr error
+	for _, node := range p.candidates() {
+		resp, err := req(node.client)
+		if err == nil {
+			p.markHealthy(node)
+			return resp, nil
+		}
+
+		if ctx.Err() != nil || !shouldFailover(err) {
+			return resp, err
+		}
+
+		p.markUnhealthy(node, err)
+		p.failover(node, err)
+		lastErr = err
+	}
+
+	return nil, errors.Wrap(lastErr, "all nodes in pool failed")
+}
+
+// stream runs streamOnce against the first candidate node until the stream is stopped,
+// failing over to the next candidate every time the connection drops. Streams closed
+// by the node are only read again when reconnect is set.
+func (p *Pool) stream(ctx context.Context, reconnect bool, streamOnce func(c *Client) error) error {
+	for {
+		node := p.candidates()[0]
+		err := streamOnce(node.client)
+		if err == nil && !reconnect {
+			return nil
+		}
+		if stop, err := stopStream(ctx, err); stop {
+			return err
+		}
+
+		p.markUnhealthy(node, err)
+		p.failover(node, err)
+		if err := sleepContext(ctx, node.client.streamBackoff); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Pool) candidates() []*poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var healthy, unhealthy []*poolNode
+	for _, node := range p.nodes {
+		if node.healthy {
+			healthy = append(healthy, node)
+		} else {
+			unhealthy = append(unhealthy, node)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (p *Pool) markHealthy(node *poolNode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Nodes marked unhealthy for falling behind are only restored by CheckHealth.
+	if node.lagging {
+		return
+	}
+	node.healthy = true
+	node.err = nil
+}
+
+func (p *Pool) markUnhealthy(node *poolNode, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	node.healthy = false
+	node.err = err
+}
+
+func (p *Pool) failover(node *poolNode, err error) {
+	node.client.logger.Debug("failing over from node", "node", node.client.URL, "err", err)
+
+	p.mu.RLock()
+	hooks := p.onFailover
+	p.mu.RUnlock()
+
+	for _, fn := range hooks {
+		fn(node.client.URL, err)
+	}
+}
+
+// shouldFailover reports if err is a node failure rather than a rejected request.
+// Nodes answering with a Tezos error array are working, the request itself was refused.
+func shouldFailover(err error) bool {
+	if respErr, ok := err.(*ResponseError); ok {
+		return len(respErr.Errors) == 0 && respErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+func headLevel(c *Client) (int, error) {
+	resp, err := c.Get("/chains/main/blocks/head/header", nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get head level")
+	}
+
+	var header headHeader
+	if err := json.Unmarshal(resp, &header); err != nil {
+		return 0, errors.Wrap(err, "could not get head level")
+	}
+
+	return header.Level, nil
+}
+
+// chainPool is a view of a Pool querying another chain
+type chainPool struct {
+	pool  *Pool
+	chain string
+}
+
+func (c *chainPool) Post(path, args string) ([]byte, error) {
+	return c.pool.do(context.Background(), func(client *Client) ([]byte, error) {
+		return client.forChain(c.chain).Post(path, args)
+	})
+}
+
+func (c *chainPool) PostWithParams(path string, params map[string]string, args string) ([]byte, error) {
+	return c.pool.do(context.Background(), func(client *Client) ([]byte, error) {
+		return client.forChain(c.chain).PostWithParams(path, params, args)
+	})
+}
+
+func (c *chainPool) Get(path string, params map[string]string) ([]byte, error) {
+	return c.pool.do(context.Background(), func(client *Client) ([]byte, error) {
+		return client.forChain(c.chain).Get(path, params)
+	})
+}
+
+func (c *chainPool) PostContext(ctx context.Context, path string, params map[string]string, args string) ([]byte, error) {
+	return c.pool.do(ctx, func(client *Client) ([]byte, error) {
+		return client.forChain(c.chain).PostContext(ctx, path, params, args)
+	})
+}
+
+func (c *chainPool) GetContext(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+	return c.pool.do(ctx, func(client *Client) ([]byte, error) {
+		return client.forChain(c.chain).GetContext(ctx, path, params)
+	})
+}
+
+func (c *chainPool) Stream(ctx context.Context, path string, params map[string]string, fn StreamHandler) error {
+	return c.pool.stream(ctx, true, func(client *Client) error {
+		return client.forChain(c.chain).streamOnce(ctx, path, params, fn)
+	})
+}
+
+func (c *chainPool) StreamOnce(ctx context.Context, path string, params map[string]string, fn StreamHandler) error {
+	return c.pool.stream(ctx, false, func(client *Client) error {
+		return client.forChain(c.chain).streamOnce(ctx, path, params, fn)
+	})
+}
+
+func (c *chainPool) ForChain(chain string) TezosClient {
+	return c.pool.ForChain(chain)
+}
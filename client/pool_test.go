@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func newTestPool(t *testing.T, netClients ...httpClient) *Pool {
+	URLs := make([]string, len(netClients))
+	for i := range netClients {
+		URLs[i] = "http://127.0.0.1:8732"
+	}
+
+	pool, err := NewPool(URLs)
+	assert.NilError(t, err)
+	for i, netClient := range netClients {
+		pool.nodes[i].client.netClient = netClient
+	}
+	return pool
+}
+
+func Test_NewPool(t *testing.T) {
+	_, err := NewPool(nil)
+	assert.Assert(t, err != nil)
+
+	_, err = NewPool([]string{"http://127.0.0.1:8732", ""})
+	assert.Assert(t, err != nil)
+}
+
+func Test_PoolGetFailover(t *testing.T) {
+	pool := newTestPool(t,
+		&httpClientMock{ReturnErr: errors.New("connection refused")},
+		&httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte("from backup")},
+	)
+
+	bytes, err := pool.Get("/example/get", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bytes), "from backup")
+
+	status := pool.Status()
+	assert.Equal(t, status[0].Healthy, false)
+	assert.Equal(t, status[1].Healthy, true)
+}
+
+func Test_PoolPostNoFailoverOnBadRequest(t *testing.T) {
+	pool := newTestPool(t,
+		&httpClientMock{ReturnStatus: http.StatusNotFound},
+		&httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte("from backup")},
+	)
+
+	_, err := pool.Post("/example/post", "taco")
+	assert.Assert(t, err != nil)
+	assert.Equal(t, pool.Status()[0].Healthy, true)
+}
+
+func Test_PoolAllNodesFail(t *testing.T) {
+	pool := newTestPool(t,
+		&httpClientMock{ReturnStatus: http.StatusInternalServerError},
+		&httpClientMock{ReturnErr: errors.New("connection refused")},
+	)
+
+	_, err := pool.Get("/example/get", nil)
+	assert.Assert(t, err != nil)
+}
+
+func Test_PoolNoFailoverOnCancel(t *testing.T) {
+	backup := &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte("from backup")}
+	pool := newTestPool(t,
+		&httpClientMock{ReturnErr: errors.New("connection refused")},
+		backup,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := pool.GetContext(ctx, "/example/get", nil)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, len(backup.Requests), 0)
+	assert.Equal(t, pool.Status()[0].Healthy, true)
+}
+
+func Test_PoolCheckHealth(t *testing.T) {
+	pool := newTestPool(t,
+		&httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte(`{"level": 100}`)},
+		&httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte(`{"level": 110}`)},
+		&httpClientMock{ReturnErr: errors.New("connection refused")},
+	)
+
+	pool.CheckHealth()
+	status := pool.Status()
+	assert.Equal(t, status[0].Healthy, false)
+	assert.Equal(t, status[0].Level, 100)
+	assert.Equal(t, status[1].Healthy, true)
+	assert.Equal(t, status[2].Healthy, false)
+
+	bytes, err := pool.Get("/example/get", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bytes), `{"level": 110}`)
+	assert.Equal(t, pool.Status()[0].Healthy, false)
+}
+
+func Test_PoolForChain(t *testing.T) {
+	netClient := &httpClientMock{ReturnErr: errors.New("connection refused")}
+	backup := &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte("from backup")}
+	pool := newTestPool(t, netClient, backup)
+
+	bytes, err := pool.ForChain("NetXdQprcVkpaWU").Get("/chains/main/chain_id", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bytes), "from backup")
+	assert.Equal(t, backup.Requests[0].URL.Path, "/chains/NetXdQprcVkpaWU/chain_id")
+	assert.Equal(t, pool.Status()[0].Healthy, false)
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// WithSingleFlight collapses identical GET requests made concurrently into a single request
+// to the node, every caller receiving the same response. This helps when many goroutines
+// ask for the head block at once.
+func WithSingleFlight() Option {
+	return WithMiddleware(singleFlightMiddleware(&singleflight.Group{}))
+}
+
+type singleFlightResult struct {
+	resp *Response
+	err  error
+}
+
+func singleFlightMiddleware(group *singleflight.Group) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			if req.Method != http.MethodGet {
+				return next(req)
+			}
+
+			v, _, _ := group.Do(cacheKey(req), func() (interface{}, error) {
+				resp, err := next(req)
+				return singleFlightResult{resp: resp, err: err}, nil
+			})
+
+			result := v.(singleFlightResult)
+			if result.resp == nil {
+				return nil, result.err
+			}
+			// every caller gets its own copy of the shared response, which it may modify
+			resp := *result.resp
+			resp.Header = resp.Header.Clone()
+			resp.Body = append([]byte(nil), resp.Body...)
+			return &resp, result.err
+		}
+	}
+}
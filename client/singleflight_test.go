@@ -0,0 +1,74 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"gotest.tools/assert"
+)
+
+func Test_SingleFlight(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	slow := func(req *Request) (*Response, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &Response{StatusCode: http.StatusOK, Body: []byte("head")}, nil
+	}
+	handler := singleFlightMiddleware(&singleflight.Group{})(slow)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := handler(&Request{Method: http.MethodGet, Path: "/chains/main/blocks/head"})
+			assert.NilError(t, err)
+			assert.Equal(t, string(resp.Body), "head")
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(1))
+
+	_, err := handler(&Request{Method: http.MethodPost, Path: "/injection/operation"})
+	assert.NilError(t, err)
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(2))
+}
+
+func Test_SingleFlightCopiesResponse(t *testing.T) {
+	release := make(chan struct{})
+	slow := func(req *Request) (*Response, error) {
+		<-release
+		return &Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}}, Body: []byte("head")}, nil
+	}
+	handler := singleFlightMiddleware(&singleflight.Group{})(slow)
+
+	responses := make([]*Response, 2)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := handler(&Request{Method: http.MethodGet, Path: "/chains/main/blocks/head"})
+			assert.NilError(t, err)
+			responses[i] = resp
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// one caller modifying its response does not change the response of the other
+	responses[0].Body[0] = 'H'
+	responses[0].Header.Set("Content-Type", "text/plain")
+	assert.Equal(t, string(responses[1].Body), "head")
+	assert.Equal(t, responses[1].Header.Get("Content-Type"), "application/json")
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// defaultStreamBackoff is how long a stream waits before reconnecting when no WithStreamBackoff option is provided.
+	defaultStreamBackoff = time.Second
+)
+
+// StreamHandler is called with every JSON value read from a stream. Returning an error stops the stream.
+type StreamHandler func(value json.RawMessage) error
+
+// handlerError marks errors returned by a StreamHandler, they stop the stream instead of reconnecting
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string {
+	return e.err.Error()
+}
+
+// WithStreamBackoff sets how long streams wait before reconnecting after the connection dropped.
+func WithStreamBackoff(backoff time.Duration) Option {
+	return func(c *Client) {
+		c.streamBackoff = backoff
+	}
+}
+
+// Stream reads the never ending stream of JSON values served at path, like /monitor/heads/main,
+// and calls fn with every value. When the connection drops it reconnects after the stream backoff.
+// Stream returns when ctx is done, when fn returns an error, or when the node refuses the request.
+func (c *Client) Stream(ctx context.Context, path string, params map[string]string, fn StreamHandler) error {
+	for {
+		err := c.streamOnce(ctx, path, params, fn)
+		if stop, err := stopStream(ctx, err); stop {
+			return err
+		}
+
+		c.logger.Debug("reconnecting stream", "path", path, "backoff", c.streamBackoff, "err", err)
+		if err := sleepContext(ctx, c.streamBackoff); err != nil {
+			return err
+		}
+	}
+}
+
+// StreamOnce reads the stream of JSON values served at path, calling fn with every value, until the node
+// closes it. It is meant for streams that end, like /monitor/bootstrapped, and does not reconnect.
+func (c *Client) StreamOnce(ctx context.Context, path string, params map[string]string, fn StreamHandler) error {
+	err := c.streamOnce(ctx, path, params, fn)
+	if handlerErr, ok := err.(*handlerError); ok {
+		return handlerErr.err
+	}
+	return err
+}
+
+// streamOnce reads the stream at path until the connection drops, it returns nil when the node closed the stream
+func (c *Client) streamOnce(ctx context.Context, path string, params map[string]string, fn StreamHandler) error {
+	r := &Request{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    c.chainPath(path),
+		Params:  params,
+		Header:  c.headers.Clone(),
+	}
+	if err := c.setCredentials(r); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(r.Method, c.URL+r.Path, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	for k := range r.Header {
+		req.Header.Set(k, r.Header.Get(k))
+	}
+
+	if len(r.Params) > 0 {
+		q := req.URL.Query()
+		for k, v := range r.Params {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.netClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBytes, _ := ioutil.ReadAll(resp.Body)
+		return newResponseError(resp.StatusCode, respBytes)
+	}
+
+	c.logger.Debug("stream connected", "path", r.Path)
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if err := fn(value); err != nil {
+			return &handlerError{err: err}
+		}
+	}
+}
+
+// stopStream reports if a stream must stop after err rather than reconnect, and the error to return
+func stopStream(ctx context.Context, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return true, ctx.Err()
+	}
+	if handlerErr, ok := err.(*handlerError); ok {
+		return true, handlerErr.err
+	}
+	if respErr, ok := err.(*ResponseError); ok && respErr.StatusCode < http.StatusInternalServerError {
+		return true, errors.Wrap(err, "could not stream")
+	}
+	if err == nil {
+		return false, errors.New("stream closed by node")
+	}
+	return false, err
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
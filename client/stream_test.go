@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_ClientStream(t *testing.T) {
+	netClient := &httpClientMock{
+		ReturnStatus: http.StatusOK,
+		ReturnBody:   []byte("{\"level\":1}\n{\"level\":2}\n"),
+	}
+	client := New("http://127.0.0.1:8732", WithStreamBackoff(time.Millisecond), WithChain("test"), WithBearerToken("token"))
+	client.netClient = netClient
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var levels []int
+	err := client.Stream(ctx, "/monitor/heads/main", nil, func(value json.RawMessage) error {
+		var head headHeader
+		if err := json.Unmarshal(value, &head); err != nil {
+			return err
+		}
+		levels = append(levels, head.Level)
+		if len(levels) == 4 {
+			cancel()
+		}
+		return nil
+	})
+	assert.Equal(t, err, context.Canceled)
+	assert.DeepEqual(t, levels, []int{1, 2, 1, 2})
+	assert.Equal(t, len(netClient.Requests), 2)
+	assert.Equal(t, netClient.Requests[0].URL.Path, "/monitor/heads/test")
+	assert.Equal(t, netClient.Requests[0].Header.Get("Authorization"), "Bearer token")
+}
+
+func Test_ClientStreamStop(t *testing.T) {
+	errStop := errors.New("stop")
+	cases := []struct {
+		name      string
+		netClient *httpClientMock
+		fn        StreamHandler
+		wantErr   error
+	}{
+		{
+			name:      "Stops when handler fails",
+			netClient: &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte(`{"level":1}`)},
+			fn:        func(json.RawMessage) error { return errStop },
+			wantErr:   errStop,
+		},
+		{
+			name:      "Stops when node refuses request",
+			netClient: &httpClientMock{ReturnStatus: http.StatusNotFound, ReturnBody: []byte("not found")},
+			fn:        func(json.RawMessage) error { return nil },
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := New("http://127.0.0.1:8732", WithStreamBackoff(time.Millisecond))
+			client.netClient = tc.netClient
+
+			err := client.Stream(context.Background(), "/monitor/heads/main", nil, tc.fn)
+			assert.Assert(t, err != nil)
+			if tc.wantErr != nil {
+				assert.Equal(t, err, tc.wantErr)
+			}
+			assert.Equal(t, len(tc.netClient.Requests), 1)
+		})
+	}
+}
+
+func Test_PoolStreamFailover(t *testing.T) {
+	failing := &httpClientMock{ReturnErr: errors.New("connection refused")}
+	backup := &httpClientMock{ReturnStatus: http.StatusOK, ReturnBody: []byte(`{"level":5}`)}
+	pool := newTestPool(t, failing, backup)
+	for _, node := range pool.nodes {
+		node.client.streamBackoff = time.Millisecond
+	}
+
+	var failedOver string
+	pool.OnFailover(func(node string, err error) {
+		failedOver = node
+	})
+
+	errStop := errors.New("stop")
+	err := pool.Stream(context.Background(), "/monitor/heads/main", nil, func(json.RawMessage) error {
+		return errStop
+	})
+	assert.Equal(t, err, errStop)
+	assert.Equal(t, len(failing.Requests), 1)
+	assert.Equal(t, len(backup.Requests), 1)
+	assert.Equal(t, failedOver, "http://127.0.0.1:8732")
+	assert.Equal(t, pool.Status()[0].Healthy, false)
+}
+
+func Test_ClientStreamOnce(t *testing.T) {
+	netClient := &httpClientMock{
+		ReturnStatus: http.StatusOK,
+		ReturnBody:   []byte(`{"block":"BLa"}{"block":"BLb"}`),
+	}
+	client := New("http://127.0.0.1:8732")
+	client.netClient = netClient
+
+	var values []string
+	err := client.StreamOnce(context.Background(), "/monitor/bootstrapped", nil, func(value json.RawMessage) error {
+		values = append(values, string(value))
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, []string{`{"block":"BLa"}`, `{"block":"BLb"}`})
+	assert.Equal(t, len(netClient.Requests), 1)
+}
@@ -0,0 +1,79 @@
+// Command tzgen generates the typed Go binding of a contract, from the script of a contract on chain or from a .tz
+// file. It is meant to be run by go:generate:
+//
+//	//go:generate go run github.com/DefinitelyNotAGoat/go-tezos/v2/cmd/tzgen -node https://rpc.tzkt.io/mainnet -contract KT1... -name Token -package token -out token.go
+//	//go:generate go run github.com/DefinitelyNotAGoat/go-tezos/v2/cmd/tzgen -file token.tz -name Token -package token -out token.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/contracts"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/contracts/bindgen"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+func main() {
+	var (
+		node     = flag.String("node", "", "URL of the node to read the script of -contract from")
+		contract = flag.String("contract", "", "address of the contract")
+		file     = flag.String("file", "", "Michelson source of the contract, instead of -node and -contract")
+		name     = flag.String("name", "", "name of the binding type")
+		pkg      = flag.String("package", "", "package of the generated file")
+		out      = flag.String("out", "", "generated file, standard output by default")
+	)
+	flag.Parse()
+
+	if err := run(*node, *contract, *file, *out, bindgen.Options{Package: *pkg, Name: *name}); err != nil {
+		fmt.Fprintln(os.Stderr, "tzgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(node, contract, file, out string, opts bindgen.Options) error {
+	script, source, err := readScript(node, contract, file)
+	if err != nil {
+		return err
+	}
+	opts.Source = source
+
+	src, err := bindgen.Generate(script, opts)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
+
+// readScript reads the script from file or from the contract on node, it returns the script with its source
+func readScript(node, contract, file string) (micheline.Node, string, error) {
+	if file != "" {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "could not read '%s'", file)
+		}
+		script, err := micheline.Parse(string(src))
+		return script, file, err
+	}
+
+	if node == "" || contract == "" {
+		return nil, "", errors.New("-file or -node and -contract are required")
+	}
+	script, err := contracts.NewContractService(tzc.New(node)).ContractScript(blockid.Head(), contract)
+	if err != nil {
+		return nil, "", err
+	}
+	code, err := micheline.Unmarshal(script.Code)
+	return code, contract, err
+}
@@ -0,0 +1,132 @@
+package contracts
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+)
+
+// BigMapOptions select a page of the contents of a big map.
+type BigMapOptions struct {
+	// Offset is the number of entries to skip
+	Offset int
+	// Length is the maximum number of entries returned, every entry is returned when it is 0
+	Length int
+}
+
+func (o BigMapOptions) params() map[string]string {
+	params := map[string]string{}
+	if o.Offset > 0 {
+		params["offset"] = strconv.Itoa(o.Offset)
+	}
+	if o.Length > 0 {
+		params["length"] = strconv.Itoa(o.Length)
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+// BigMapEntry is a value of a big map with the expr hash of its key.
+type BigMapEntry struct {
+	KeyHash string
+	Value   json.RawMessage
+}
+
+// BigMapValue gets the value bound to key in the big map bigMapID at the block id. The key is a Micheline
+// expression of type keyType, it is packed and hashed locally into the expr hash the node indexes values by.
+func (s *ContractService) BigMapValue(id blockid.BlockID, bigMapID int, key, keyType json.RawMessage) (json.RawMessage, error) {
+	packed, err := forge.Pack(key, keyType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get big map value of '%s'", string(key))
+	}
+
+	return s.bigMapValueByHash(id, bigMapID, forge.ScriptExprHash(packed))
+}
+
+// bigMapValueByHash gets the value bound to the key with the expr hash keyHash in the big map bigMapID
+func (s *ContractService) bigMapValueByHash(id blockid.BlockID, bigMapID int, keyHash string) (json.RawMessage, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/big_maps/" + strconv.Itoa(bigMapID) + "/" + keyHash
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get big map value '%s'", query)
+	}
+
+	var value json.RawMessage
+	if err := json.Unmarshal(resp, &value); err != nil {
+		return nil, errors.Wrapf(err, "could not get big map value '%s'", query)
+	}
+	return value, nil
+}
+
+// BigMapValues gets a page of the values of the big map bigMapID at the block id, in the order of the hashes of their keys.
+func (s *ContractService) BigMapValues(id blockid.BlockID, bigMapID int, opts BigMapOptions) ([]json.RawMessage, error) {
+	var values []json.RawMessage
+	query := "/chains/main/blocks/" + id.String() + "/context/big_maps/" + strconv.Itoa(bigMapID)
+	resp, err := s.tzclient.Get(query, opts.params())
+	if err != nil {
+		return values, errors.Wrapf(err, "could not get big map values '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &values); err != nil {
+		return values, errors.Wrapf(err, "could not get big map values '%s'", query)
+	}
+	return values, nil
+}
+
+// BigMapKeyHashes gets the sorted expr hashes of every key of the big map bigMapID at the block id from the raw context.
+func (s *ContractService) BigMapKeyHashes(id blockid.BlockID, bigMapID int) ([]string, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/raw/json/big_maps/index/" + strconv.Itoa(bigMapID) + "/contents"
+	resp, err := s.tzclient.Get(query, map[string]string{"depth": "1"})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get big map key hashes '%s'", query)
+	}
+
+	// Nodes list the directory either as the names of its entries or as an object keyed by them
+	var hashes []string
+	if err := json.Unmarshal(resp, &hashes); err != nil {
+		var contents map[string]json.RawMessage
+		if err := json.Unmarshal(resp, &contents); err != nil {
+			return nil, errors.Wrapf(err, "could not get big map key hashes '%s'", query)
+		}
+		for hash := range contents {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	sort.Strings(hashes)
+	return hashes, nil
+}
+
+// BigMapEntries gets a page of the entries of the big map bigMapID at the block id, the values with the expr hashes of their keys.
+// Each value of the page is fetched by the hash of its key, so indexers can enumerate a big map page by page.
+func (s *ContractService) BigMapEntries(id blockid.BlockID, bigMapID int, opts BigMapOptions) ([]BigMapEntry, error) {
+	hashes, err := s.BigMapKeyHashes(id, bigMapID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get entries of big map %d", bigMapID)
+	}
+
+	if opts.Offset >= len(hashes) {
+		return []BigMapEntry{}, nil
+	}
+	hashes = hashes[opts.Offset:]
+	if opts.Length > 0 && opts.Length < len(hashes) {
+		hashes = hashes[:opts.Length]
+	}
+
+	entries := make([]BigMapEntry, 0, len(hashes))
+	for _, hash := range hashes {
+		value, err := s.bigMapValueByHash(id, bigMapID, hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get entries of big map %d", bigMapID)
+		}
+		entries = append(entries, BigMapEntry{KeyHash: hash, Value: value})
+	}
+	return entries, nil
+}
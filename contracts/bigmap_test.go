@@ -0,0 +1,138 @@
+package contracts
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_BigMapValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		key      string
+		keyType  string
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name:     "Address key",
+			key:      `{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}`,
+			keyType:  `{"prim":"address"}`,
+			wantPath: "/chains/main/blocks/head/context/big_maps/17/expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv",
+		},
+		{
+			name:     "Pair key",
+			key:      `{"prim":"Pair","args":[{"string":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t"},{"int":"7"}]}`,
+			keyType:  `{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}`,
+			wantPath: "/chains/main/blocks/head/context/big_maps/17/exprtiCqgPSwWEYMfz4xff6CHLc47373eFcnMQqTgDa3Y44j9nTonh",
+		},
+		{
+			name:    "Invalid key",
+			key:     `{"string":"tz1"}`,
+			keyType: `{"prim":"address"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(`{"int":"100"}`)}
+			value, err := NewContractService(client).BigMapValue(blockid.Head(), 17, []byte(tc.key), []byte(tc.keyType))
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, tc.wantPath)
+			assert.Equal(t, string(value), `{"int":"100"}`)
+		})
+	}
+}
+
+func Test_BigMapValues(t *testing.T) {
+	cases := []struct {
+		name       string
+		opts       BigMapOptions
+		wantParams map[string]string
+	}{
+		{
+			name: "Every value",
+		},
+		{
+			name:       "Page",
+			opts:       BigMapOptions{Offset: 10, Length: 5},
+			wantParams: map[string]string{"offset": "10", "length": "5"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(`[{"int":"100"},{"int":"200"}]`)}
+			values, err := NewContractService(client).BigMapValues(blockid.Head(), 17, tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/context/big_maps/17")
+			assert.DeepEqual(t, client.Params, tc.wantParams)
+			assert.Equal(t, len(values), 2)
+			assert.Equal(t, string(values[1]), `{"int":"200"}`)
+		})
+	}
+}
+
+func Test_BigMapEntries(t *testing.T) {
+	const bigMapPath = "/chains/main/blocks/head/context/big_maps/17/"
+	cases := []struct {
+		name     string
+		listing  string
+		opts     BigMapOptions
+		want     []BigMapEntry
+		wantKeys []string
+	}{
+		{
+			name:    "Listing of names",
+			listing: `["exprtiCqgPSwWEYMfz4xff6CHLc47373eFcnMQqTgDa3Y44j9nTonh","expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv","exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC"]`,
+			opts:    BigMapOptions{Offset: 1, Length: 1},
+			want: []BigMapEntry{
+				{KeyHash: "exprtiCqgPSwWEYMfz4xff6CHLc47373eFcnMQqTgDa3Y44j9nTonh", Value: []byte(`{"int":"2"}`)},
+			},
+			wantKeys: []string{"exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "exprtiCqgPSwWEYMfz4xff6CHLc47373eFcnMQqTgDa3Y44j9nTonh", "expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv"},
+		},
+		{
+			name:    "Listing of an object",
+			listing: `{"expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv":{},"exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC":{}}`,
+			want: []BigMapEntry{
+				{KeyHash: "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", Value: []byte(`{"int":"1"}`)},
+				{KeyHash: "expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv", Value: []byte(`{"int":"3"}`)},
+			},
+			wantKeys: []string{"exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv"},
+		},
+		{
+			name:     "Offset past the end",
+			listing:  `["exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC"]`,
+			opts:     BigMapOptions{Offset: 1},
+			want:     []BigMapEntry{},
+			wantKeys: []string{"exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{Bodies: map[string][]byte{
+				"/chains/main/blocks/head/context/raw/json/big_maps/index/17/contents": []byte(tc.listing),
+				bigMapPath + "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC":  []byte(`{"int":"1"}`),
+				bigMapPath + "exprtiCqgPSwWEYMfz4xff6CHLc47373eFcnMQqTgDa3Y44j9nTonh":  []byte(`{"int":"2"}`),
+				bigMapPath + "expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv":  []byte(`{"int":"3"}`),
+			}}
+			contractService := NewContractService(client)
+
+			keys, err := contractService.BigMapKeyHashes(blockid.Head(), 17)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, keys, tc.wantKeys)
+
+			entries, err := contractService.BigMapEntries(blockid.Head(), 17, tc.opts)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, entries, tc.want)
+		})
+	}
+}
@@ -0,0 +1,439 @@
+// Package bindgen generates typed Go bindings of contracts, like abigen does for Ethereum contracts. A binding
+// has a method building the transaction calling each entrypoint of the contract, and a method getting and decoding
+// its storage, with Go types generated from the Michelson types of the script. cmd/tzgen runs it from go:generate.
+package bindgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+// Options are the settings of a generated binding.
+type Options struct {
+	// Package of the generated file
+	Package string
+	// Name of the binding type, e.g. Token, the names of the generated types start with it
+	Name string
+	// Source of the script noted in the generated file, e.g. the address of the contract
+	Source string
+}
+
+// Generate returns the Go source of the binding of script, the code of a contract with its parameter and storage
+// sections, e.g. the code of block.Script or a .tz file read by micheline.Parse.
+func Generate(script micheline.Node, opts Options) ([]byte, error) {
+	if opts.Package == "" || opts.Name == "" {
+		return nil, errors.New("could not generate binding, package and name are required")
+	}
+
+	parameter, storage, err := sections(script)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate binding")
+	}
+
+	g := generator{
+		name:    exported(opts.Name),
+		types:   map[string]bool{},
+		imports: map[string]bool{},
+	}
+	src, err := g.generate(parameter, storage, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate binding")
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not format binding")
+	}
+	return formatted, nil
+}
+
+// sections returns the types of the parameter and storage sections of script
+func sections(script micheline.Node) (micheline.Node, micheline.Node, error) {
+	seq, ok := script.(micheline.Seq)
+	if !ok {
+		return nil, nil, errors.New("script is not a sequence of sections")
+	}
+
+	var parameter, storage micheline.Node
+	for _, n := range seq {
+		section, ok := n.(micheline.Prim)
+		if !ok || len(section.Args) != 1 {
+			continue
+		}
+		switch section.Prim {
+		case "parameter":
+			parameter = section.Args[0]
+		case "storage":
+			storage = section.Args[0]
+		}
+	}
+	if parameter == nil || storage == nil {
+		return nil, nil, errors.New("script without parameter or storage")
+	}
+	return parameter, storage, nil
+}
+
+// Entrypoints returns the types of the parameters of the entrypoints of the parameter type, the branches of its ors
+// with a field annotation. The default entrypoint takes the whole parameter when no branch is annotated %default.
+func Entrypoints(parameter micheline.Node) map[string]micheline.Node {
+	entrypoints := map[string]micheline.Node{}
+	var visit func(n micheline.Node)
+	visit = func(n micheline.Node) {
+		p, ok := n.(micheline.Prim)
+		if !ok {
+			return
+		}
+		if name := p.Annot(micheline.FieldAnnot); name != "" {
+			entrypoints[name] = n
+		}
+		if p.Prim == "or" {
+			for _, arg := range p.Args {
+				visit(arg)
+			}
+		}
+	}
+	visit(parameter)
+
+	if _, ok := entrypoints["default"]; !ok {
+		entrypoints["default"] = parameter
+	}
+	return entrypoints
+}
+
+// generator writes the Go types of Michelson types and the methods of a binding
+type generator struct {
+	name    string
+	decls   bytes.Buffer
+	types   map[string]bool
+	imports map[string]bool
+}
+
+func (g *generator) generate(parameter, storage micheline.Node, opts Options) ([]byte, error) {
+	g.imports["encoding/json"] = true
+	g.imports["github.com/DefinitelyNotAGoat/go-tezos/v2/block"] = true
+	g.imports["github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"] = true
+	g.imports["github.com/DefinitelyNotAGoat/go-tezos/v2/contracts"] = true
+	g.imports["github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"] = true
+	g.imports["github.com/DefinitelyNotAGoat/go-tezos/v2/tez"] = true
+
+	var methods bytes.Buffer
+	storageType, _, err := g.goType(storage, g.name+"Storage", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid storage type")
+	}
+
+	entrypoints := Entrypoints(parameter)
+	names := make([]string, 0, len(entrypoints))
+	for name := range entrypoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	methodNames := map[string]bool{"Storage": true}
+	for _, name := range names {
+		method := exported(name)
+		if methodNames[method] {
+			method = "Call" + method
+		}
+		methodNames[method] = true
+
+		typ := entrypoints[name]
+		if p, ok := typ.(micheline.Prim); ok && p.Prim == "unit" {
+			fmt.Fprintf(&methods, "\n// %s builds the transaction calling the entrypoint %s of the contract with amount.\n", method, name)
+			fmt.Fprintf(&methods, "func (c *%s) %s(amount tez.Mutez) (block.Contents, error) {\n", g.name, method)
+			fmt.Fprintf(&methods, "\treturn c.call(%q, amount, struct{}{})\n}\n", name)
+			continue
+		}
+
+		paramType, _, err := g.goType(typ, g.name+exported(name)+"Param", false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid parameter type of the entrypoint %s", name)
+		}
+		fmt.Fprintf(&methods, "\n// %s builds the transaction calling the entrypoint %s of the contract with amount and param.\n", method, name)
+		fmt.Fprintf(&methods, "func (c *%s) %s(amount tez.Mutez, param %s) (block.Contents, error) {\n", g.name, method, paramType)
+		fmt.Fprintf(&methods, "\treturn c.call(%q, amount, param)\n}\n", name)
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by tzgen from %s. DO NOT EDIT.\n\npackage %s\n\nimport (\n", opts.Source, opts.Package)
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	for _, imp := range imports {
+		fmt.Fprintf(&src, "\t%q\n", imp)
+	}
+	src.WriteString(")\n")
+
+	fmt.Fprintf(&src, `
+// %[1]s is the binding of the contract %[2]s, its methods build the transactions calling its entrypoints
+// and decode its storage.
+type %[1]s struct {
+	Address  string
+	contract contracts.TezosContractsService
+}
+
+// New%[1]s returns the binding of the contract at address, the node is called through contract.
+func New%[1]s(address string, contract contracts.TezosContractsService) *%[1]s {
+	return &%[1]s{Address: address, contract: contract}
+}
+
+// Storage gets the storage of the contract at the block id.
+func (c *%[1]s) Storage(id blockid.BlockID) (%[3]s, error) {
+	var storage %[3]s
+	data, err := c.contract.ContractStorage(id, c.Address, contracts.StorageOptions{Normalize: true, UnparsingMode: contracts.Readable})
+	if err != nil {
+		return storage, err
+	}
+	n, err := micheline.Unmarshal(data)
+	if err != nil {
+		return storage, err
+	}
+	err = micheline.Decode(n, %[4]sStorageType, &storage)
+	return storage, err
+}
+
+// call builds the transaction calling entrypoint with amount and the parameter param
+func (c *%[1]s) call(entrypoint string, amount tez.Mutez, param interface{}) (block.Contents, error) {
+	value, err := micheline.Encode(param, %[4]sEntrypointTypes[entrypoint])
+	if err != nil {
+		return block.Contents{}, err
+	}
+	parameter, err := json.Marshal(value)
+	if err != nil {
+		return block.Contents{}, err
+	}
+	return c.contract.CallContract(c.Address, entrypoint, amount, json.RawMessage(parameter))
+}
+`, g.name, opts.Source, storageType, unexported(g.name))
+	src.Write(methods.Bytes())
+	src.Write(g.decls.Bytes())
+
+	storageJSON, err := json.Marshal(storage)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&src, "\n// %sStorageType is the type of the storage of the contract\nvar %[1]sStorageType = %[1]sType(%s)\n", unexported(g.name), literal(storageJSON))
+
+	fmt.Fprintf(&src, "\n// %sEntrypointTypes are the types of the parameters of the entrypoints of the contract\nvar %[1]sEntrypointTypes = map[string]micheline.Node{\n", unexported(g.name))
+	for _, name := range names {
+		typeJSON, err := json.Marshal(entrypoints[name])
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&src, "\t%q: %sType(%s),\n", name, unexported(g.name), literal(typeJSON))
+	}
+	src.WriteString("}\n")
+
+	fmt.Fprintf(&src, `
+func %[1]sType(typ string) micheline.Node {
+	n, err := micheline.Unmarshal([]byte(typ))
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+`, unexported(g.name))
+	return src.Bytes(), nil
+}
+
+// goType returns the Go type of the Michelson type t, declaring the structs of pairs and ors under name. It
+// reports whether the Go type is comparable, key tells the type is the key of a map.
+func (g *generator) goType(t micheline.Node, name string, key bool) (string, bool, error) {
+	p, ok := t.(micheline.Prim)
+	if !ok {
+		return "", false, errors.Errorf("invalid type %T", t)
+	}
+
+	switch p.Prim {
+	case "pair":
+		return g.structType(name, leaves(p, "pair", nil), false, key)
+	case "or":
+		return g.structType(name, leaves(p, "or", nil), true, key)
+	case "option":
+		if len(p.Args) != 1 {
+			return "", false, errors.New("invalid option type")
+		}
+		elem, comparable, err := g.goType(p.Args[0], name, key)
+		return "*" + elem, comparable, err
+	case "list", "set":
+		if len(p.Args) != 1 {
+			return "", false, errors.Errorf("invalid %s type", p.Prim)
+		}
+		elem, _, err := g.goType(p.Args[0], name+"Item", false)
+		return "[]" + elem, false, err
+	case "map":
+		if len(p.Args) != 2 {
+			return "", false, errors.New("invalid map type")
+		}
+		k, comparable, err := g.goType(p.Args[0], name+"Key", true)
+		if err != nil {
+			return "", false, err
+		}
+		if !comparable {
+			return "micheline.Node", false, nil
+		}
+		v, _, err := g.goType(p.Args[1], name+"Value", false)
+		return "map[" + k + "]" + v, false, err
+	case "big_map", "sapling_state":
+		// the data of a big map or a sapling state is its id, the values of big maps are read with
+		// ContractService.BigMapValue
+		return "int64", true, nil
+	case "int", "nat":
+		if key {
+			return "int64", true, nil
+		}
+		g.imports["math/big"] = true
+		return "*big.Int", false, nil
+	case "mutez":
+		return "tez.Mutez", true, nil
+	case "string", "address", "contract", "key", "key_hash", "signature", "chain_id":
+		return "string", true, nil
+	case "bytes", "chest", "chest_key", "sapling_transaction", "sapling_transaction_deprecated":
+		return "[]byte", false, nil
+	case "bool":
+		return "bool", true, nil
+	case "unit":
+		return "struct{}", true, nil
+	case "timestamp":
+		g.imports["time"] = true
+		return "time.Time", true, nil
+	}
+	return "micheline.Node", false, nil
+}
+
+// leaf is a field of the struct of a pair or an or
+type leaf struct {
+	typ  micheline.Node
+	path []string
+}
+
+// leaves returns the leaves of the pair or the or p, flattening its nested pairs or ors without field annotation
+// the way micheline.Decode does
+func leaves(p micheline.Prim, prim string, path []string) []leaf {
+	args := p.Args
+	if prim == "pair" && len(args) > 2 {
+		args = []micheline.Node{args[0], micheline.NewPrim("pair", args[1:]...)}
+	}
+
+	var result []leaf
+	for i, arg := range args {
+		argPath := path
+		if prim == "or" {
+			side := "Left"
+			if i == 1 {
+				side = "Right"
+			}
+			argPath = append(append([]string{}, path...), side)
+		}
+		if a, ok := arg.(micheline.Prim); ok && a.Prim == prim && a.Annot(micheline.FieldAnnot) == "" {
+			result = append(result, leaves(a, prim, argPath)...)
+			continue
+		}
+		result = append(result, leaf{typ: arg, path: argPath})
+	}
+	return result
+}
+
+// structType declares the struct name with a field per leaf, the fields of ors are pointers
+func (g *generator) structType(name string, fields []leaf, or, key bool) (string, bool, error) {
+	name = g.unique(name)
+
+	var decl bytes.Buffer
+	kind := "a pair"
+	if or {
+		kind = "an or, only the field of its branch is set"
+	}
+	fmt.Fprintf(&decl, "\n// %s is %s.\ntype %s struct {\n", name, kind, name)
+
+	comparable := true
+	fieldNames := map[string]bool{}
+	for i, f := range fields {
+		annot := ""
+		if p, ok := f.typ.(micheline.Prim); ok {
+			annot = p.Annot(micheline.FieldAnnot)
+		}
+
+		fieldName := exported(annot)
+		switch {
+		case annot == "" && or:
+			fieldName = strings.Join(f.path, "")
+		case annot == "":
+			fieldName = fmt.Sprintf("Field%d", i)
+		}
+		for fieldNames[fieldName] {
+			fieldName += "_"
+		}
+		fieldNames[fieldName] = true
+
+		typ, c, err := g.goType(f.typ, name+fieldName, key)
+		if err != nil {
+			return "", false, err
+		}
+		comparable = comparable && c
+		if or {
+			typ = "*" + typ
+		}
+
+		fmt.Fprintf(&decl, "\t%s %s", fieldName, typ)
+		if annot != "" {
+			fmt.Fprintf(&decl, " `micheline:%q`", annot)
+		}
+		decl.WriteString("\n")
+	}
+	decl.WriteString("}\n")
+
+	g.decls.Write(decl.Bytes())
+	return name, comparable, nil
+}
+
+// unique returns name, or name with a number when a type is already named so
+func (g *generator) unique(name string) string {
+	unique := name
+	for i := 2; g.types[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.types[unique] = true
+	return unique
+}
+
+// exported returns the Go identifier of a Michelson name, e.g. TokenID for token_id
+func exported(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if strings.EqualFold(word, "id") {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	s := b.String()
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "F" + s
+	}
+	return s
+}
+
+// literal returns the Go string literal of s, a raw string when possible
+func literal(s []byte) string {
+	if strconv.CanBackquote(string(s)) {
+		return "`" + string(s) + "`"
+	}
+	return strconv.Quote(string(s))
+}
+
+// unexported returns the exported identifier name starting with a lower case letter
+func unexported(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,86 @@
+package bindgen
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+const tokenScript = `
+parameter (or (or (list %transfer (pair (address %from_) (list %txs (pair (address %to_) (nat %token_id) (nat %amount)))))
+                  (unit %pause))
+              (pair %mint (address %owner) (mutez %price)));
+storage (pair (big_map %ledger (pair address nat) nat)
+              (map %metadata string bytes)
+              (option %admin address)
+              (bool %paused));
+code { CDR ; NIL operation ; PAIR }`
+
+func Test_Generate(t *testing.T) {
+	script, err := micheline.Parse(tokenScript)
+	assert.NilError(t, err)
+
+	src, err := Generate(script, Options{Package: "token", Name: "token", Source: "token.tz"})
+	assert.NilError(t, err)
+
+	want := []string{
+		"// Code generated by tzgen from token.tz. DO NOT EDIT.\n\npackage token\n",
+		"func NewToken(address string, contract contracts.TezosContractsService) *Token {",
+		"func (c *Token) Storage(id blockid.BlockID) (TokenStorage, error) {",
+		"func (c *Token) Default(amount tez.Mutez, param TokenDefaultParam) (block.Contents, error) {",
+		"func (c *Token) Mint(amount tez.Mutez, param TokenMintParam) (block.Contents, error) {",
+		"func (c *Token) Pause(amount tez.Mutez) (block.Contents, error) {",
+		"func (c *Token) Transfer(amount tez.Mutez, param []TokenTransferParamItem) (block.Contents, error) {",
+		"type TokenStorage struct {\n\tLedger   int64             `micheline:\"ledger\"`\n\tMetadata map[string][]byte `micheline:\"metadata\"`\n\tAdmin    *string           `micheline:\"admin\"`\n\tPaused   bool              `micheline:\"paused\"`\n}",
+		"type TokenTransferParamItem struct {\n\tFrom string                          `micheline:\"from_\"`\n\tTxs  []TokenTransferParamItemTxsItem `micheline:\"txs\"`\n}",
+		"type TokenTransferParamItemTxsItem struct {\n\tTo      string   `micheline:\"to_\"`\n\tTokenID *big.Int `micheline:\"token_id\"`\n\tAmount  *big.Int `micheline:\"amount\"`\n}",
+		"type TokenMintParam struct {\n\tOwner string    `micheline:\"owner\"`\n\tPrice tez.Mutez `micheline:\"price\"`\n}",
+		"type TokenDefaultParam struct {\n\tTransfer *[]TokenDefaultParamTransferItem `micheline:\"transfer\"`\n\tPause    *struct{}                        `micheline:\"pause\"`\n\tMint     *TokenDefaultParamMint           `micheline:\"mint\"`\n}",
+		"\"pause\":    tokenType(`{\"prim\":\"unit\",\"annots\":[\"%pause\"]}`),",
+	}
+	for _, w := range want {
+		assert.Assert(t, strings.Contains(string(src), w), "missing %q in\n%s", w, src)
+	}
+}
+
+func Test_Generate_Errors(t *testing.T) {
+	cases := []struct {
+		name    string
+		script  micheline.Node
+		opts    Options
+		wantErr string
+	}{
+		{
+			name:    "No name",
+			script:  micheline.Seq{},
+			opts:    Options{Package: "token"},
+			wantErr: "could not generate binding, package and name are required",
+		},
+		{
+			name:    "No storage",
+			script:  micheline.Seq{micheline.NewPrim("parameter", micheline.NewPrim("unit"))},
+			opts:    Options{Package: "token", Name: "Token"},
+			wantErr: "could not generate binding: script without parameter or storage",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Generate(tc.script, tc.opts)
+			assert.Error(t, err, tc.wantErr)
+		})
+	}
+}
+
+func Test_Entrypoints(t *testing.T) {
+	parameter, err := micheline.Parse(`or (nat %default) (or (unit %a) (int %b))`)
+	assert.NilError(t, err)
+
+	entrypoints := Entrypoints(parameter)
+	assert.Equal(t, len(entrypoints), 3)
+	assert.Assert(t, micheline.Equal(entrypoints["default"], micheline.Prim{Prim: "nat", Annots: []string{"%default"}}))
+	assert.Assert(t, micheline.Equal(entrypoints["b"], micheline.Prim{Prim: "int", Annots: []string{"%b"}}))
+}
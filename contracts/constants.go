@@ -0,0 +1,90 @@
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// maxConstantDepth bounds the nesting of global constants referencing other constants
+const maxConstantDepth = 100
+
+// GlobalConstant gets the Micheline value of the global constant at address, an expr hash.
+func (c *ContractService) GlobalConstant(id blockid.BlockID, address string) (json.RawMessage, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/global_constants/" + address
+	resp, err := c.tzclient.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get global constant '%s'", query)
+	}
+	if !json.Valid(resp) {
+		return nil, errors.Errorf("could not get global constant '%s', invalid JSON", query)
+	}
+	return json.RawMessage(resp), nil
+}
+
+// ExpandGlobalConstants replaces the global constants referenced in the Micheline expression expr, e.g. the code of
+// a script, by their value. Constants referenced by constants are expanded too, every constant is fetched once.
+func (c *ContractService) ExpandGlobalConstants(id blockid.BlockID, expr json.RawMessage) (json.RawMessage, error) {
+	expanded, err := c.expandConstants(id, expr, map[string]json.RawMessage{}, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not expand global constants")
+	}
+	return expanded, nil
+}
+
+func (c *ContractService) expandConstants(id blockid.BlockID, expr json.RawMessage, constants map[string]json.RawMessage, depth int) (json.RawMessage, error) {
+	var seq []json.RawMessage
+	if err := json.Unmarshal(expr, &seq); err == nil {
+		for i, item := range seq {
+			if seq[i], err = c.expandConstants(id, item, constants, depth); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(seq)
+	}
+
+	var prim struct {
+		Prim   string            `json:"prim"`
+		Args   []json.RawMessage `json:"args,omitempty"`
+		Annots []string          `json:"annots,omitempty"`
+	}
+	if err := json.Unmarshal(expr, &prim); err != nil || prim.Prim == "" {
+		// int, string and bytes literals
+		return expr, nil
+	}
+
+	if prim.Prim != "constant" {
+		for i, arg := range prim.Args {
+			var err error
+			if prim.Args[i], err = c.expandConstants(id, arg, constants, depth); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(prim)
+	}
+
+	var address struct {
+		String string `json:"string"`
+	}
+	if len(prim.Args) != 1 || json.Unmarshal(prim.Args[0], &address) != nil || address.String == "" {
+		return nil, errors.Errorf("invalid constant '%s'", string(expr))
+	}
+	if value, ok := constants[address.String]; ok {
+		return value, nil
+	}
+	if depth >= maxConstantDepth {
+		return nil, errors.Errorf("constant '%s' nested too deep", address.String)
+	}
+
+	value, err := c.GlobalConstant(id, address.String)
+	if err != nil {
+		return nil, err
+	}
+	if value, err = c.expandConstants(id, value, constants, depth+1); err != nil {
+		return nil, err
+	}
+	constants[address.String] = value
+	return value, nil
+}
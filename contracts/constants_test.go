@@ -0,0 +1,67 @@
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_GlobalConstant(t *testing.T) {
+	client := &clientMock{ReturnBody: []byte(`[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]}]`)}
+	value, err := NewContractService(client).GlobalConstant(blockid.Head(), "exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF")
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/blocks/head/context/global_constants/exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF")
+	assert.Equal(t, string(value), `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]}]`)
+}
+
+func Test_ExpandGlobalConstants(t *testing.T) {
+	const query = "/chains/main/blocks/head/context/global_constants/"
+	constants := map[string][]byte{
+		query + "exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF": []byte(`[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"constant","args":[{"string":"expruu5BTdW7ajqJ9XPTF3kgcV78pRiaBW3Gq31mgp3WSYjjUBYxre"}]}]}]`),
+		query + "expruu5BTdW7ajqJ9XPTF3kgcV78pRiaBW3Gq31mgp3WSYjjUBYxre": []byte(`{"prim":"operation"}`),
+	}
+
+	cases := []struct {
+		name    string
+		expr    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "Without constants",
+			expr: `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"int","annots":[":s"]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"PUSH","args":[{"prim":"int"},{"int":"1"}]}]]}]`,
+			want: `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"int","annots":[":s"]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"PUSH","args":[{"prim":"int"},{"int":"1"}]}]]}]`,
+		},
+		{
+			name: "Nested constants",
+			expr: `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"constant","args":[{"string":"expruu5BTdW7ajqJ9XPTF3kgcV78pRiaBW3Gq31mgp3WSYjjUBYxre"}]}]},{"prim":"code","args":[{"prim":"constant","args":[{"string":"exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF"}]}]}]`,
+			want: `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"operation"}]},{"prim":"code","args":[[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]}]]}]`,
+		},
+		{
+			name:    "Invalid constant",
+			expr:    `{"prim":"constant","args":[{"int":"1"}]}`,
+			wantErr: `could not expand global constants: invalid constant '{"prim":"constant","args":[{"int":"1"}]}'`,
+		},
+		{
+			name:    "Unknown constant",
+			expr:    `{"prim":"constant","args":[{"string":"exprunknown"}]}`,
+			wantErr: "could not get global constant '" + query + "exprunknown', invalid JSON",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(`<html>`), Bodies: constants}
+			expanded, err := NewContractService(client).ExpandGlobalConstants(blockid.Head(), json.RawMessage(tc.expr))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(expanded), tc.want)
+		})
+	}
+}
@@ -20,7 +20,7 @@ func (s *ContractService) GetStorage(contract string) ([]byte, error) {
 	query := "/chains/main/blocks/head/context/contracts/" + contract + "/storage"
 	resp, err := s.tzclient.Get(query, nil)
 	if err != nil {
-		return resp, errors.Wrap(err, "could not get storage '%s'")
+		return resp, errors.Wrapf(err, "could not get storage '%s'", query)
 	}
 	return resp, nil
 }
@@ -0,0 +1,144 @@
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+// StorageDiff is the difference between the storages of a contract at two blocks. Storage are the changed values of
+// the storage, see micheline.Diff, and BigMaps the changed values of its big maps.
+type StorageDiff struct {
+	Storage []micheline.Change
+	BigMaps []BigMapChange
+}
+
+// BigMapChange is a value of a big map of a contract that changed between two blocks. Path locates the big map in
+// the storage and ID is its id at the later block. Before is nil for an added value and After for a removed one.
+type BigMapChange struct {
+	Path    string
+	ID      int64
+	KeyHash string
+	Before  micheline.Node
+	After   micheline.Node
+}
+
+// StorageDiff compares the storage of the contract kt1 at the blocks from and to, e.g. for monitoring tools to alert
+// on unexpected state changes. The big maps of the storage are compared entry by entry, every entry is fetched from
+// the raw context of the node at both blocks, which can be slow for large big maps. A big map removed from the
+// storage is only reported by the change of its id in Storage.
+func (s *ContractService) StorageDiff(kt1 string, from, to blockid.BlockID) (StorageDiff, error) {
+	var result StorageDiff
+
+	rawType, err := s.storageType(to, kt1)
+	if err != nil {
+		return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+	}
+	storageType, err := micheline.Unmarshal(rawType)
+	if err != nil {
+		return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+	}
+
+	before, err := s.readableStorage(from, kt1, rawType)
+	if err != nil {
+		return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+	}
+	after, err := s.readableStorage(to, kt1, rawType)
+	if err != nil {
+		return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+	}
+
+	if result.Storage, err = micheline.Diff(before, after, storageType); err != nil {
+		return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+	}
+
+	beforeMaps, err := micheline.BigMaps(before, storageType)
+	if err != nil {
+		return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+	}
+	afterMaps, err := micheline.BigMaps(after, storageType)
+	if err != nil {
+		return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+	}
+
+	// big maps are matched by their path, a big map replaced by another one is compared to it
+	previous := make(map[string]int64, len(beforeMaps))
+	for _, bigMap := range beforeMaps {
+		previous[bigMap.Path] = bigMap.ID
+	}
+	for _, bigMap := range afterMaps {
+		var beforeEntries []BigMapEntry
+		if id, ok := previous[bigMap.Path]; ok {
+			if beforeEntries, err = s.BigMapEntries(from, int(id), BigMapOptions{}); err != nil {
+				return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+			}
+		}
+		afterEntries, err := s.BigMapEntries(to, int(bigMap.ID), BigMapOptions{})
+		if err != nil {
+			return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+		}
+
+		changes, err := diffBigMap(bigMap, beforeEntries, afterEntries)
+		if err != nil {
+			return result, errors.Wrapf(err, "could not diff storage of '%s'", kt1)
+		}
+		result.BigMaps = append(result.BigMaps, changes...)
+	}
+	return result, nil
+}
+
+// readableStorage gets the storage of the contract kt1 at the block id normalized in Readable mode
+func (s *ContractService) readableStorage(id blockid.BlockID, kt1 string, storageType json.RawMessage) (micheline.Node, error) {
+	storage, err := s.ContractStorage(id, kt1, StorageOptions{})
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := s.normalizeData(id, storage, storageType, Readable)
+	if err != nil {
+		return nil, err
+	}
+	return micheline.Unmarshal(normalized)
+}
+
+// diffBigMap returns the changes between the entries of a big map, the changed and added entries in the order of
+// after followed by the removed entries
+func diffBigMap(bigMap micheline.BigMap, before, after []BigMapEntry) ([]BigMapChange, error) {
+	values := func(entries []BigMapEntry) (map[string]micheline.Node, error) {
+		m := make(map[string]micheline.Node, len(entries))
+		for _, entry := range entries {
+			value, err := micheline.Unmarshal(entry.Value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value of key '%s' in big map %d", entry.KeyHash, bigMap.ID)
+			}
+			m[entry.KeyHash] = value
+		}
+		return m, nil
+	}
+
+	beforeValues, err := values(before)
+	if err != nil {
+		return nil, err
+	}
+	afterValues, err := values(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []BigMapChange
+	for _, entry := range after {
+		b, a := beforeValues[entry.KeyHash], afterValues[entry.KeyHash]
+		if b != nil && micheline.Equal(b, a) {
+			continue
+		}
+		changes = append(changes, BigMapChange{Path: bigMap.Path, ID: bigMap.ID, KeyHash: entry.KeyHash, Before: b, After: a})
+	}
+	for _, entry := range before {
+		if _, ok := afterValues[entry.KeyHash]; !ok {
+			changes = append(changes, BigMapChange{Path: bigMap.Path, ID: bigMap.ID, KeyHash: entry.KeyHash, Before: beforeValues[entry.KeyHash]})
+		}
+	}
+	return changes, nil
+}
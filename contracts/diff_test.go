@@ -0,0 +1,56 @@
+package contracts
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+func Test_StorageDiff(t *testing.T) {
+	client := &clientMock{Bodies: map[string][]byte{
+		"/chains/main/blocks/head/context/contracts/KT1/script": []byte(`{"code":[
+			{"prim":"parameter","args":[{"prim":"unit"}]},
+			{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}],"annots":["%ledger"]},{"prim":"nat","annots":["%counter"]}]}]},
+			{"prim":"code","args":[[]]}],"storage":{}}`),
+		"/chains/main/blocks/10/context/contracts/KT1/storage":                []byte(`{"prim":"Pair","args":[{"int":"7"},{"int":"1"}]}`),
+		"/chains/main/blocks/head/context/contracts/KT1/storage":              []byte(`{"prim":"Pair","args":[{"int":"7"},{"int":"2"}]}`),
+		"/chains/main/blocks/10/helpers/scripts/normalize_data":               []byte(`{"normalized":{"prim":"Pair","args":[{"int":"7"},{"int":"1"}]}}`),
+		"/chains/main/blocks/head/helpers/scripts/normalize_data":             []byte(`{"normalized":{"prim":"Pair","args":[{"int":"7"},{"int":"2"}]}}`),
+		"/chains/main/blocks/10/context/raw/json/big_maps/index/7/contents":   []byte(`["exprA","exprB"]`),
+		"/chains/main/blocks/head/context/raw/json/big_maps/index/7/contents": []byte(`["exprA","exprC"]`),
+		"/chains/main/blocks/10/context/big_maps/7/exprA":                     []byte(`{"int":"1"}`),
+		"/chains/main/blocks/10/context/big_maps/7/exprB":                     []byte(`{"int":"2"}`),
+		"/chains/main/blocks/head/context/big_maps/7/exprA":                   []byte(`{"int":"5"}`),
+		"/chains/main/blocks/head/context/big_maps/7/exprC":                   []byte(`{"int":"3"}`),
+	}}
+
+	diff, err := NewContractService(client).StorageDiff("KT1", blockid.Level(10), blockid.Head())
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(diff.Storage), 1)
+	assert.Equal(t, diff.Storage[0].Path, "counter")
+	assert.Assert(t, micheline.Equal(diff.Storage[0].Before, micheline.NewInt(1)))
+	assert.Assert(t, micheline.Equal(diff.Storage[0].After, micheline.NewInt(2)))
+
+	cases := []struct {
+		keyHash string
+		before  micheline.Node
+		after   micheline.Node
+	}{
+		{keyHash: "exprA", before: micheline.NewInt(1), after: micheline.NewInt(5)},
+		{keyHash: "exprC", before: nil, after: micheline.NewInt(3)},
+		{keyHash: "exprB", before: micheline.NewInt(2), after: nil},
+	}
+	assert.Equal(t, len(diff.BigMaps), len(cases))
+	for i, tc := range cases {
+		change := diff.BigMaps[i]
+		assert.Equal(t, change.Path, "ledger")
+		assert.Equal(t, change.ID, int64(7))
+		assert.Equal(t, change.KeyHash, tc.keyHash)
+		assert.Assert(t, (tc.before == nil && change.Before == nil) || micheline.Equal(change.Before, tc.before))
+		assert.Assert(t, (tc.after == nil && change.After == nil) || micheline.Equal(change.After, tc.after))
+	}
+}
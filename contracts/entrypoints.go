@@ -0,0 +1,66 @@
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// defaultEntrypoint is the entrypoint of calls without entrypoint, every contract has it
+const defaultEntrypoint = "default"
+
+// Entrypoints gets the entrypoints of the contract kt1 at the block id with the types of their parameters.
+func (s *ContractService) Entrypoints(id blockid.BlockID, kt1 string) (map[string]json.RawMessage, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + kt1 + "/entrypoints"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get entrypoints '%s'", query)
+	}
+
+	var entrypoints struct {
+		Entrypoints map[string]json.RawMessage `json:"entrypoints"`
+	}
+	if err := json.Unmarshal(resp, &entrypoints); err != nil {
+		return nil, errors.Wrapf(err, "could not get entrypoints '%s'", query)
+	}
+	return entrypoints.Entrypoints, nil
+}
+
+// CallContract builds the transaction calling entrypoint of the contract kt1 with amount and the parameter value.
+// The value is Micheline JSON or a Go value encoded with Micheline. The entrypoint is checked against the entrypoints
+// of the contract at the head, an empty entrypoint calls the default one. The transaction is added to a Batch to be sent.
+func (s *ContractService) CallContract(kt1, entrypoint string, amount tez.Mutez, value interface{}) (block.Contents, error) {
+	var call block.Contents
+	if entrypoint == "" {
+		entrypoint = defaultEntrypoint
+	}
+
+	if entrypoint != defaultEntrypoint {
+		entrypoints, err := s.Entrypoints(blockid.Head(), kt1)
+		if err != nil {
+			return call, errors.Wrapf(err, "could not call '%s' of '%s'", entrypoint, kt1)
+		}
+		if _, ok := entrypoints[entrypoint]; !ok {
+			return call, errors.Errorf("could not call '%s' of '%s', no such entrypoint", entrypoint, kt1)
+		}
+	}
+
+	parameter, err := Micheline(value)
+	if err != nil {
+		return call, errors.Wrapf(err, "could not call '%s' of '%s'", entrypoint, kt1)
+	}
+
+	return block.Contents{
+		Kind:        block.KindTransaction,
+		Amount:      amount,
+		Destination: kt1,
+		Parameters: &block.Parameters{
+			Entrypoint: entrypoint,
+			Value:      parameter,
+		},
+	}, nil
+}
@@ -0,0 +1,90 @@
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+var goldenEntrypoints = []byte(`{"entrypoints":{"transfer":{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]},"burn":{"prim":"nat"}}}`)
+
+func Test_Entrypoints(t *testing.T) {
+	client := &clientMock{ReturnBody: goldenEntrypoints}
+
+	entrypoints, err := NewContractService(client).Entrypoints(blockid.Head(), "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t")
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, contractPath+"/entrypoints")
+	assert.Equal(t, len(entrypoints), 2)
+	assert.Equal(t, string(entrypoints["burn"]), `{"prim":"nat"}`)
+}
+
+func Test_CallContract(t *testing.T) {
+	cases := []struct {
+		name       string
+		entrypoint string
+		value      interface{}
+		want       block.Contents
+		wantErr    bool
+	}{
+		{
+			name:       "Typed parameters",
+			entrypoint: "transfer",
+			value:      Pair{"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", 10},
+			want: block.Contents{
+				Kind:        block.KindTransaction,
+				Amount:      1000,
+				Destination: "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+				Parameters: &block.Parameters{
+					Entrypoint: "transfer",
+					Value:      json.RawMessage(`{"prim":"Pair","args":[{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},{"int":"10"}]}`),
+				},
+			},
+		},
+		{
+			name:  "Micheline parameters to the default entrypoint",
+			value: json.RawMessage(`{"int":"5"}`),
+			want: block.Contents{
+				Kind:        block.KindTransaction,
+				Amount:      1000,
+				Destination: "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+				Parameters: &block.Parameters{
+					Entrypoint: "default",
+					Value:      json.RawMessage(`{"int":"5"}`),
+				},
+			},
+		},
+		{
+			name:       "Unknown entrypoint",
+			entrypoint: "mint",
+			value:      10,
+			wantErr:    true,
+		},
+		{
+			name:       "Unsupported value",
+			entrypoint: "burn",
+			value:      map[string]int{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: goldenEntrypoints}
+			call, err := NewContractService(client).CallContract("KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t", tc.entrypoint, tez.Mutez(1000), tc.value)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, call.Kind, tc.want.Kind)
+			assert.Equal(t, call.Amount, tc.want.Amount)
+			assert.Equal(t, call.Destination, tc.want.Destination)
+			assert.DeepEqual(t, call.Parameters, tc.want.Parameters)
+		})
+	}
+}
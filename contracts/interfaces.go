@@ -1,5 +1,32 @@
 package contracts
 
+import (
+	"encoding/json"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
 type TezosContractsService interface {
 	GetStorage(contract string) ([]byte, error)
+	ContractStorage(id blockid.BlockID, kt1 string, opts StorageOptions) (json.RawMessage, error)
+	ContractScript(id blockid.BlockID, kt1 string) (block.Script, error)
+	NormalizedContractScript(id blockid.BlockID, kt1 string, opts ScriptOptions) (block.Script, error)
+	StorageDiff(kt1 string, from, to blockid.BlockID) (StorageDiff, error)
+	BigMapValue(id blockid.BlockID, bigMapID int, key, keyType json.RawMessage) (json.RawMessage, error)
+	BigMapValues(id blockid.BlockID, bigMapID int, opts BigMapOptions) ([]json.RawMessage, error)
+	BigMapKeyHashes(id blockid.BlockID, bigMapID int) ([]string, error)
+	BigMapEntries(id blockid.BlockID, bigMapID int, opts BigMapOptions) ([]BigMapEntry, error)
+	GlobalConstant(id blockid.BlockID, address string) (json.RawMessage, error)
+	ExpandGlobalConstants(id blockid.BlockID, expr json.RawMessage) (json.RawMessage, error)
+	Entrypoints(id blockid.BlockID, kt1 string) (map[string]json.RawMessage, error)
+	CallContract(kt1, entrypoint string, amount tez.Mutez, value interface{}) (block.Contents, error)
+	RunScriptView(id blockid.BlockID, kt1, view string, input interface{}, opts ViewOptions) (json.RawMessage, error)
+	RunView(id blockid.BlockID, kt1, entrypoint string, input interface{}, opts ViewOptions) (json.RawMessage, error)
+	RunCode(id blockid.BlockID, code json.RawMessage, storage, input interface{}, opts RunCodeOptions) (RunCodeResult, error)
+	TypecheckCode(id blockid.BlockID, code json.RawMessage, opts TypecheckOptions) (TypecheckResult, error)
+	TypecheckData(id blockid.BlockID, data, dataType json.RawMessage, opts TypecheckOptions) (string, error)
+	GetTicketBalance(contract string, ticket Ticket) (tez.Zarith, error)
+	GetAllTicketBalances(contract string) ([]TicketBalance, error)
 }
@@ -0,0 +1,99 @@
+package contracts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Pair is encoded by Micheline as a Pair of its values, e.g. Pair{"tz1...", 10} for a pair address nat.
+type Pair []interface{}
+
+// Micheline returns the Micheline JSON of the Go value. Micheline JSON (json.RawMessage) is returned as is, nil is Unit,
+// booleans are True or False, integers, tez.Mutez, tez.Zarith and *big.Int are ints, strings are strings, []byte are
+// bytes, time.Time are timestamp strings, Pair are pairs and other slices are sequences.
+func Micheline(value interface{}) (json.RawMessage, error) {
+	switch v := value.(type) {
+	case nil:
+		return json.RawMessage(`{"prim":"Unit"}`), nil
+	case json.RawMessage:
+		if !json.Valid(v) {
+			return nil, errors.Errorf("invalid Micheline JSON '%s'", string(v))
+		}
+		return v, nil
+	case bool:
+		if v {
+			return json.RawMessage(`{"prim":"True"}`), nil
+		}
+		return json.RawMessage(`{"prim":"False"}`), nil
+	case string:
+		return json.Marshal(map[string]string{"string": v})
+	case []byte:
+		return json.Marshal(map[string]string{"bytes": hex.EncodeToString(v)})
+	case time.Time:
+		return json.Marshal(map[string]string{"string": v.UTC().Format(time.RFC3339)})
+	case tez.Mutez:
+		return intExpr(strconv.FormatInt(int64(v), 10))
+	case tez.Zarith:
+		return intExpr(v.String())
+	case *big.Int:
+		return intExpr(v.String())
+	case Pair:
+		if len(v) < 2 {
+			return nil, errors.Errorf("invalid pair of %d values", len(v))
+		}
+		args, err := michelines(v)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(struct {
+			Prim string            `json:"prim"`
+			Args []json.RawMessage `json:"args"`
+		}{"Pair", args})
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intExpr(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return intExpr(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+		seq, err := michelines(items)
+		if err != nil {
+			return nil, err
+		}
+		if seq == nil {
+			seq = []json.RawMessage{}
+		}
+		return json.Marshal(seq)
+	}
+	return nil, errors.Errorf("could not encode %T with Micheline", value)
+}
+
+func michelines(values []interface{}) ([]json.RawMessage, error) {
+	var exprs []json.RawMessage
+	for _, value := range values {
+		expr, err := Micheline(value)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+func intExpr(i string) (json.RawMessage, error) {
+	return json.Marshal(map[string]string{"int": i})
+}
@@ -0,0 +1,50 @@
+package contracts
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_Micheline(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "Unit", value: nil, want: `{"prim":"Unit"}`},
+		{name: "Micheline", value: json.RawMessage(`{"prim":"None"}`), want: `{"prim":"None"}`},
+		{name: "Bool", value: true, want: `{"prim":"True"}`},
+		{name: "String", value: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", want: `{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}`},
+		{name: "Bytes", value: []byte{0xca, 0xfe}, want: `{"bytes":"cafe"}`},
+		{name: "Int", value: -7, want: `{"int":"-7"}`},
+		{name: "Uint", value: uint64(18446744073709551615), want: `{"int":"18446744073709551615"}`},
+		{name: "Mutez", value: tez.Mutez(1000000), want: `{"int":"1000000"}`},
+		{name: "Big int", value: new(big.Int).Lsh(big.NewInt(1), 100), want: `{"int":"1267650600228229401496703205376"}`},
+		{name: "Timestamp", value: time.Date(2019, 9, 26, 10, 59, 51, 0, time.UTC), want: `{"string":"2019-09-26T10:59:51Z"}`},
+		{name: "Sequence", value: []string{"a", "b"}, want: `[{"string":"a"},{"string":"b"}]`},
+		{name: "Empty sequence", value: []int{}, want: `[]`},
+		{name: "Pair", value: Pair{1, Pair{"a", true}}, want: `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"a"},{"prim":"True"}]}]}`},
+		{name: "Pair of one value", value: Pair{1}, wantErr: true},
+		{name: "Invalid Micheline", value: json.RawMessage(`{`), wantErr: true},
+		{name: "Unsupported", value: struct{}{}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := Micheline(tc.value)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(expr), tc.want)
+		})
+	}
+}
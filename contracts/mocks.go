@@ -6,12 +6,26 @@ var (
 
 type clientMock struct {
 	ReturnBody []byte
+	// Bodies are returned instead of ReturnBody for the paths they are keyed by
+	Bodies map[string][]byte
+	Path   string
+	Params map[string]string
+	Args   string
 }
 
 func (c *clientMock) Post(path, args string) ([]byte, error) {
-	return c.ReturnBody, nil
+	c.Path, c.Args = path, args
+	return c.body(path), nil
 }
 
 func (c *clientMock) Get(path string, params map[string]string) ([]byte, error) {
-	return c.ReturnBody, nil
+	c.Path, c.Params = path, params
+	return c.body(path), nil
+}
+
+func (c *clientMock) body(path string) []byte {
+	if body, ok := c.Bodies[path]; ok {
+		return body
+	}
+	return c.ReturnBody
 }
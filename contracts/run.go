@@ -0,0 +1,124 @@
+package contracts
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// RunCodeOptions are the optional settings of RunCode, they set the context the code runs in.
+type RunCodeOptions struct {
+	// Entrypoint called, the default one when empty
+	Entrypoint string
+	// Amount is the AMOUNT sent with the call
+	Amount tez.Mutez
+	// Balance is the BALANCE of the contract, the node default when 0
+	Balance tez.Mutez
+	// Source is the SOURCE and SENDER of the call, the zero address by default
+	Source string
+	// Payer is the account paying for the storage allocated by the call
+	Payer string
+	// Self is the SELF_ADDRESS of the contract
+	Self string
+	// Now is the NOW of the call, the timestamp of the block by default
+	Now time.Time
+	// Level is the LEVEL of the call, the level of the block by default
+	Level int
+	// Gas limits the gas of the call, the node default applies when it is 0
+	Gas int64
+	// UnparsingMode of the result, Readable by default
+	UnparsingMode UnparsingMode
+	// ChainID of the chain the code runs on, the chain of the node by default
+	ChainID string
+}
+
+// RunCodeResult is the result of RunCode: the new storage, the operations emitted by the code and the changes
+// made to its big maps.
+type RunCodeResult struct {
+	Storage         json.RawMessage                 `json:"storage"`
+	Operations      []block.InternalOperationResult `json:"operations"`
+	LazyStorageDiff []block.LazyStorageDiff         `json:"lazy_storage_diff,omitempty"`
+}
+
+type runCodeRequest struct {
+	Script        json.RawMessage `json:"script"`
+	Storage       json.RawMessage `json:"storage"`
+	Input         json.RawMessage `json:"input"`
+	Amount        tez.Mutez       `json:"amount"`
+	Balance       *tez.Mutez      `json:"balance,omitempty"`
+	ChainID       string          `json:"chain_id"`
+	Source        string          `json:"source,omitempty"`
+	Payer         string          `json:"payer,omitempty"`
+	Self          string          `json:"self,omitempty"`
+	Entrypoint    string          `json:"entrypoint,omitempty"`
+	UnparsingMode UnparsingMode   `json:"unparsing_mode"`
+	Gas           string          `json:"gas,omitempty"`
+	Now           string          `json:"now,omitempty"`
+	Level         string          `json:"level,omitempty"`
+}
+
+// RunCode runs the Micheline code of a contract with the storage and the input, Micheline JSON or Go values
+// encoded with Micheline, at the block id without originating it. It returns the resulting storage and
+// operations, e.g. to unit test a contract from Go. A failing contract makes the node return the FAILWITH error.
+func (s *ContractService) RunCode(id blockid.BlockID, code json.RawMessage, storage, input interface{}, opts RunCodeOptions) (RunCodeResult, error) {
+	var result RunCodeResult
+	query := "/chains/main/blocks/" + id.String() + "/helpers/scripts/run_code"
+
+	req := runCodeRequest{
+		Script:        code,
+		Amount:        opts.Amount,
+		ChainID:       opts.ChainID,
+		Source:        opts.Source,
+		Payer:         opts.Payer,
+		Self:          opts.Self,
+		Entrypoint:    opts.Entrypoint,
+		UnparsingMode: opts.UnparsingMode,
+		Gas:           gas(opts.Gas),
+	}
+
+	var err error
+	if req.Storage, err = Micheline(storage); err != nil {
+		return result, errors.Wrapf(err, "could not run code '%s', invalid storage", query)
+	}
+	if req.Input, err = Micheline(input); err != nil {
+		return result, errors.Wrapf(err, "could not run code '%s', invalid input", query)
+	}
+	if req.ChainID == "" {
+		if req.ChainID, err = s.chainID(); err != nil {
+			return result, errors.Wrapf(err, "could not run code '%s'", query)
+		}
+	}
+	if req.UnparsingMode == "" {
+		req.UnparsingMode = Readable
+	}
+	if opts.Balance > 0 {
+		req.Balance = &opts.Balance
+	}
+	if !opts.Now.IsZero() {
+		req.Now = opts.Now.UTC().Format(time.RFC3339)
+	}
+	if opts.Level > 0 {
+		req.Level = strconv.Itoa(opts.Level)
+	}
+
+	args, err := json.Marshal(req)
+	if err != nil {
+		return result, errors.Wrapf(err, "could not run code '%s'", query)
+	}
+
+	resp, err := s.tzclient.Post(query, string(args))
+	if err != nil {
+		return result, errors.Wrapf(err, "could not run code '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return result, errors.Wrapf(err, "could not run code '%s'", query)
+	}
+	return result, nil
+}
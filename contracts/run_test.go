@@ -0,0 +1,68 @@
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_RunCode(t *testing.T) {
+	code := json.RawMessage(`[{"prim":"parameter","args":[{"prim":"int","annots":["%increment"]}]},{"prim":"storage","args":[{"prim":"int"}]},{"prim":"code","args":[[{"prim":"UNPAIR"},{"prim":"ADD"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`)
+
+	cases := []struct {
+		name     string
+		opts     RunCodeOptions
+		wantArgs string
+	}{
+		{
+			name:     "Default context",
+			wantArgs: `{"script":` + string(code) + `,"storage":{"int":"1"},"input":{"int":"2"},"amount":"0","chain_id":"NetXdQprcVkpaWU","unparsing_mode":"Readable"}`,
+		},
+		{
+			name: "Context",
+			opts: RunCodeOptions{
+				Entrypoint: "increment",
+				Amount:     tez.Mutez(1000000),
+				Balance:    tez.Mutez(5000000),
+				Source:     "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+				Self:       "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+				Now:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Level:      5000000,
+				Gas:        1040000,
+				ChainID:    "NetXnHfVqm9iesp",
+			},
+			wantArgs: `{"script":` + string(code) + `,"storage":{"int":"1"},"input":{"int":"2"},"amount":"1000000","balance":"5000000","chain_id":"NetXnHfVqm9iesp","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","self":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","entrypoint":"increment","unparsing_mode":"Readable","gas":"1040000","now":"2024-01-02T03:04:05Z","level":"5000000"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{Bodies: map[string][]byte{
+				"/chains/main/chain_id": []byte(`"NetXdQprcVkpaWU"`),
+				"/chains/main/blocks/head/helpers/scripts/run_code": []byte(`{
+					"storage": {"int": "3"},
+					"operations": [{"kind": "transaction", "source": "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t", "nonce": 0, "amount": "10", "destination": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}],
+					"lazy_storage_diff": [{"kind": "big_map", "id": "-1", "diff": {"action": "alloc", "updates": [], "key_type": {"prim": "nat"}, "value_type": {"prim": "nat"}}}]
+				}`),
+			}}
+
+			result, err := NewContractService(client).RunCode(blockid.Head(), code, 1, 2, tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/scripts/run_code")
+			assert.Equal(t, client.Args, tc.wantArgs)
+			assert.Equal(t, string(result.Storage), `{"int": "3"}`)
+			assert.Equal(t, len(result.Operations), 1)
+			assert.Equal(t, result.Operations[0].Amount, tez.Mutez(10))
+			assert.Equal(t, result.Operations[0].Destination, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+			assert.Equal(t, result.LazyStorageDiff[0].BigMap.Action, "alloc")
+		})
+	}
+
+	_, err := NewContractService(&clientMock{}).RunCode(blockid.Head(), code, struct{}{}, 2, RunCodeOptions{})
+	assert.ErrorContains(t, err, "invalid storage")
+}
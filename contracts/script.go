@@ -0,0 +1,84 @@
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// ScriptOptions are the optional settings of NormalizedContractScript.
+type ScriptOptions struct {
+	// UnparsingMode of the code and storage, Readable by default
+	UnparsingMode UnparsingMode
+	// NormalizeTypes unfolds the combs of pair in the types of the code
+	NormalizeTypes bool
+}
+
+// ContractScript gets the code and storage of the contract kt1 at the block id as Micheline expressions.
+func (s *ContractService) ContractScript(id blockid.BlockID, kt1 string) (block.Script, error) {
+	var script block.Script
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + kt1 + "/script"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return script, errors.Wrapf(err, "could not get script '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &script); err != nil {
+		return script, errors.Wrapf(err, "could not get script '%s'", query)
+	}
+	return script, nil
+}
+
+// NormalizedContractScript gets the code and storage of the contract kt1 at the block id in the canonical form
+// of opts, so scripts unparsed by different nodes or protocols compare equal.
+func (s *ContractService) NormalizedContractScript(id blockid.BlockID, kt1 string, opts ScriptOptions) (block.Script, error) {
+	var script block.Script
+	if opts.UnparsingMode == "" {
+		opts.UnparsingMode = Readable
+	}
+
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + kt1 + "/script/normalized"
+	args, err := json.Marshal(struct {
+		UnparsingMode  UnparsingMode `json:"unparsing_mode"`
+		NormalizeTypes bool          `json:"normalize_types,omitempty"`
+	}{opts.UnparsingMode, opts.NormalizeTypes})
+	if err != nil {
+		return script, errors.Wrapf(err, "could not get normalized script '%s'", query)
+	}
+
+	resp, err := s.tzclient.Post(query, string(args))
+	if err != nil {
+		return script, errors.Wrapf(err, "could not get normalized script '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &script); err != nil {
+		return script, errors.Wrapf(err, "could not get normalized script '%s'", query)
+	}
+	return script, nil
+}
+
+// storageType gets the type of the storage in the script of the contract kt1
+func (s *ContractService) storageType(id blockid.BlockID, kt1 string) (json.RawMessage, error) {
+	script, err := s.ContractScript(id, kt1)
+	if err != nil {
+		return nil, err
+	}
+
+	var code []struct {
+		Prim string            `json:"prim"`
+		Args []json.RawMessage `json:"args"`
+	}
+	if err := json.Unmarshal(script.Code, &code); err != nil {
+		return nil, errors.Wrapf(err, "could not get storage type of '%s'", kt1)
+	}
+
+	for _, section := range code {
+		if section.Prim == "storage" && len(section.Args) == 1 {
+			return section.Args[0], nil
+		}
+	}
+	return nil, errors.Errorf("could not get storage type of '%s', no storage section", kt1)
+}
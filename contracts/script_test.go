@@ -0,0 +1,49 @@
+package contracts
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_ContractScript(t *testing.T) {
+	client := &clientMock{ReturnBody: []byte(`{"code":[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}],"storage":{"int":"1"}}`)}
+
+	script, err := NewContractService(client).ContractScript(blockid.Level(100000), "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t")
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/blocks/100000/context/contracts/KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t/script")
+	assert.Equal(t, string(script.Code), `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`)
+	assert.Equal(t, string(script.Storage), `{"int":"1"}`)
+}
+
+func Test_NormalizedContractScript(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     ScriptOptions
+		wantArgs string
+	}{
+		{
+			name:     "Readable",
+			wantArgs: `{"unparsing_mode":"Readable"}`,
+		},
+		{
+			name:     "Optimized with normalized types",
+			opts:     ScriptOptions{UnparsingMode: Optimized, NormalizeTypes: true},
+			wantArgs: `{"unparsing_mode":"Optimized","normalize_types":true}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(`{"code":[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}],"storage":{"int":"1"}}`)}
+
+			script, err := NewContractService(client).NormalizedContractScript(blockid.Head(), "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t", tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, contractPath+"/script/normalized")
+			assert.Equal(t, client.Args, tc.wantArgs)
+			assert.Equal(t, string(script.Storage), `{"int":"1"}`)
+		})
+	}
+}
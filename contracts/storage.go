@@ -0,0 +1,89 @@
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// UnparsingMode is the form the node returns Micheline expressions in.
+type UnparsingMode string
+
+const (
+	// Readable expressions use strings for addresses, keys and timestamps
+	Readable UnparsingMode = "Readable"
+	// Optimized expressions use bytes and integers, and combs of Pair are folded into sequences
+	Optimized UnparsingMode = "Optimized"
+	// OptimizedLegacy expressions use bytes and integers, and keep right combs of Pair
+	OptimizedLegacy UnparsingMode = "Optimized_legacy"
+)
+
+// StorageOptions are the optional settings of ContractStorage.
+type StorageOptions struct {
+	// Normalize has the node normalize the storage with the storage type of the contract,
+	// unfolding the combs of Pair into nested Pairs in Readable mode
+	Normalize bool
+	// UnparsingMode of the normalized storage, Readable by default
+	UnparsingMode UnparsingMode
+}
+
+// ContractStorage gets the storage of the contract kt1 at the block id as a Micheline expression.
+func (s *ContractService) ContractStorage(id blockid.BlockID, kt1 string, opts StorageOptions) (json.RawMessage, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/contracts/" + kt1 + "/storage"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get storage '%s'", query)
+	}
+
+	var storage json.RawMessage
+	if err := json.Unmarshal(resp, &storage); err != nil {
+		return nil, errors.Wrapf(err, "could not get storage '%s'", query)
+	}
+
+	if !opts.Normalize {
+		return storage, nil
+	}
+
+	storageType, err := s.storageType(id, kt1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not normalize storage of '%s'", kt1)
+	}
+
+	normalized, err := s.normalizeData(id, storage, storageType, opts.UnparsingMode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not normalize storage of '%s'", kt1)
+	}
+	return normalized, nil
+}
+
+// normalizeData normalizes the Micheline data of type dataType with the node
+func (s *ContractService) normalizeData(id blockid.BlockID, data, dataType json.RawMessage, mode UnparsingMode) (json.RawMessage, error) {
+	if mode == "" {
+		mode = Readable
+	}
+
+	query := "/chains/main/blocks/" + id.String() + "/helpers/scripts/normalize_data"
+	args, err := json.Marshal(struct {
+		Data          json.RawMessage `json:"data"`
+		Type          json.RawMessage `json:"type"`
+		UnparsingMode UnparsingMode   `json:"unparsing_mode"`
+	}{data, dataType, mode})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not normalize data '%s'", query)
+	}
+
+	resp, err := s.tzclient.Post(query, string(args))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not normalize data '%s'", query)
+	}
+
+	var normalized struct {
+		Normalized json.RawMessage `json:"normalized"`
+	}
+	if err := json.Unmarshal(resp, &normalized); err != nil {
+		return nil, errors.Wrapf(err, "could not normalize data '%s'", query)
+	}
+	return normalized.Normalized, nil
+}
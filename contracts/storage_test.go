@@ -0,0 +1,52 @@
+package contracts
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+const contractPath = "/chains/main/blocks/head/context/contracts/KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t"
+
+func Test_ContractStorage(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     StorageOptions
+		want     string
+		wantArgs string
+	}{
+		{
+			name: "Storage",
+			want: `[{"int":"1"},{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}]`,
+		},
+		{
+			name:     "Normalized storage",
+			opts:     StorageOptions{Normalize: true},
+			want:     `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}]}]}`,
+			wantArgs: `{"data":[{"int":"1"},{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}],"type":{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"},{"prim":"nat"}]},"unparsing_mode":"Readable"}`,
+		},
+		{
+			name:     "Optimized storage",
+			opts:     StorageOptions{Normalize: true, UnparsingMode: Optimized},
+			want:     `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}]}]}`,
+			wantArgs: `{"data":[{"int":"1"},{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}],"type":{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"},{"prim":"nat"}]},"unparsing_mode":"Optimized"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{Bodies: map[string][]byte{
+				contractPath + "/storage":                                 []byte(`[{"int":"1"},{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}]`),
+				contractPath + "/script":                                  []byte(`{"code":[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"},{"prim":"nat"}]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}],"storage":[{"int":"1"},{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}]}`),
+				"/chains/main/blocks/head/helpers/scripts/normalize_data": []byte(`{"normalized":{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"2"}]}]}}`),
+			}}
+
+			storage, err := NewContractService(client).ContractStorage(blockid.Head(), "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t", tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, string(storage), tc.want)
+			assert.Equal(t, client.Args, tc.wantArgs)
+		})
+	}
+}
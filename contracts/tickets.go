@@ -0,0 +1,57 @@
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Ticket identifies the tickets created by a ticketer with the same type and content.
+type Ticket struct {
+	Ticketer    string          `json:"ticketer"`
+	ContentType json.RawMessage `json:"content_type"`
+	Content     json.RawMessage `json:"content"`
+}
+
+// TicketBalance is the amount of a ticket owned by a contract.
+type TicketBalance struct {
+	Ticket
+	Amount tez.Zarith `json:"amount"`
+}
+
+// GetTicketBalance gets the amount of ticket owned by contract, an implicit account or an originated contract.
+func (s *ContractService) GetTicketBalance(contract string, ticket Ticket) (tez.Zarith, error) {
+	var balance tez.Zarith
+	query := "/chains/main/blocks/head/context/contracts/" + contract + "/ticket_balance"
+	args, err := json.Marshal(ticket)
+	if err != nil {
+		return balance, errors.Wrapf(err, "could not get ticket balance '%s'", query)
+	}
+
+	resp, err := s.tzclient.Post(query, string(args))
+	if err != nil {
+		return balance, errors.Wrapf(err, "could not get ticket balance '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &balance); err != nil {
+		return balance, errors.Wrapf(err, "could not get ticket balance '%s'", query)
+	}
+	return balance, nil
+}
+
+// GetAllTicketBalances gets the amounts of every ticket owned by the originated contract.
+func (s *ContractService) GetAllTicketBalances(contract string) ([]TicketBalance, error) {
+	var balances []TicketBalance
+	query := "/chains/main/blocks/head/context/contracts/" + contract + "/all_ticket_balances"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return balances, errors.Wrapf(err, "could not get all ticket balances '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &balances); err != nil {
+		return balances, errors.Wrapf(err, "could not get all ticket balances '%s'", query)
+	}
+	return balances, nil
+}
@@ -0,0 +1,60 @@
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_GetTicketBalance(t *testing.T) {
+	cases := []struct {
+		name       string
+		returnBody string
+		want       int64
+		wantErr    bool
+	}{
+		{
+			name:       "Balance",
+			returnBody: `"100"`,
+			want:       100,
+		},
+		{
+			name:       "Malformed response",
+			returnBody: `{}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(tc.returnBody)}
+			balance, err := NewContractService(client).GetTicketBalance("tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", Ticket{
+				Ticketer:    "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+				ContentType: json.RawMessage(`{"prim":"string"}`),
+				Content:     json.RawMessage(`{"string":"ticket"}`),
+			})
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/context/contracts/tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1/ticket_balance")
+			assert.Equal(t, client.Args, `{"ticketer":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","content_type":{"prim":"string"},"content":{"string":"ticket"}}`)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, balance.Int64(), tc.want)
+		})
+	}
+}
+
+func Test_GetAllTicketBalances(t *testing.T) {
+	client := &clientMock{ReturnBody: []byte(`[{"ticketer":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","content_type":{"prim":"string"},"content":{"string":"ticket"},"amount":"100"},
+		{"ticketer":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","content_type":{"prim":"nat"},"content":{"int":"1"},"amount":"5"}]`)}
+
+	balances, err := NewContractService(client).GetAllTicketBalances("KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi")
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/blocks/head/context/contracts/KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi/all_ticket_balances")
+	assert.Equal(t, len(balances), 2)
+	assert.Equal(t, balances[0].Ticketer, "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t")
+	assert.Equal(t, string(balances[1].Content), `{"int":"1"}`)
+	assert.Equal(t, balances[1].Amount.Int64(), int64(5))
+}
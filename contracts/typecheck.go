@@ -0,0 +1,97 @@
+package contracts
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// TypecheckOptions are the optional settings of TypecheckCode and TypecheckData.
+type TypecheckOptions struct {
+	// Gas limits the gas of the typechecking, the node default applies when it is 0
+	Gas int64
+	// Legacy accepts the deprecated instructions and types of contracts originated by former protocols
+	Legacy bool
+	// ShowTypes has TypecheckCode return the stacks before and after each instruction
+	ShowTypes bool
+}
+
+// StackTypes are the types of the stack before and after the instruction at Location, the index of the
+// instruction in the Micheline expression of the code.
+type StackTypes struct {
+	Location    int               `json:"location"`
+	StackBefore []json.RawMessage `json:"stack_before"`
+	StackAfter  []json.RawMessage `json:"stack_after"`
+}
+
+// TypecheckResult is the result of TypecheckCode. Gas is the gas remaining after typechecking,
+// or "unaccounted".
+type TypecheckResult struct {
+	TypeMap []StackTypes `json:"type_map"`
+	Gas     string       `json:"gas"`
+}
+
+// TypecheckCode typechecks the Micheline code of a contract, its parameter, storage and code sections, with the
+// protocol of the block id. The node rejects ill-typed code with an error telling the location of the type error.
+func (s *ContractService) TypecheckCode(id blockid.BlockID, code json.RawMessage, opts TypecheckOptions) (TypecheckResult, error) {
+	var result TypecheckResult
+	query := "/chains/main/blocks/" + id.String() + "/helpers/scripts/typecheck_code"
+	args, err := json.Marshal(struct {
+		Program   json.RawMessage `json:"program"`
+		Gas       string          `json:"gas,omitempty"`
+		Legacy    bool            `json:"legacy,omitempty"`
+		ShowTypes bool            `json:"show_types,omitempty"`
+	}{code, gas(opts.Gas), opts.Legacy, opts.ShowTypes})
+	if err != nil {
+		return result, errors.Wrapf(err, "could not typecheck code '%s'", query)
+	}
+
+	resp, err := s.tzclient.Post(query, string(args))
+	if err != nil {
+		return result, errors.Wrapf(err, "could not typecheck code '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return result, errors.Wrapf(err, "could not typecheck code '%s'", query)
+	}
+	return result, nil
+}
+
+// TypecheckData typechecks the Micheline data, e.g. an initial storage, against the type dataType with the protocol
+// of the block id. It returns the gas remaining after typechecking, the node rejects ill-typed data with an error.
+func (s *ContractService) TypecheckData(id blockid.BlockID, data, dataType json.RawMessage, opts TypecheckOptions) (string, error) {
+	query := "/chains/main/blocks/" + id.String() + "/helpers/scripts/typecheck_data"
+	args, err := json.Marshal(struct {
+		Data   json.RawMessage `json:"data"`
+		Type   json.RawMessage `json:"type"`
+		Gas    string          `json:"gas,omitempty"`
+		Legacy bool            `json:"legacy,omitempty"`
+	}{data, dataType, gas(opts.Gas), opts.Legacy})
+	if err != nil {
+		return "", errors.Wrapf(err, "could not typecheck data '%s'", query)
+	}
+
+	resp, err := s.tzclient.Post(query, string(args))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not typecheck data '%s'", query)
+	}
+
+	var result struct {
+		Gas string `json:"gas"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", errors.Wrapf(err, "could not typecheck data '%s'", query)
+	}
+	return result.Gas, nil
+}
+
+// gas returns the gas limit of a request, empty for the node default
+func gas(limit int64) string {
+	if limit <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(limit, 10)
+}
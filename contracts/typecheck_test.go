@@ -0,0 +1,55 @@
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_TypecheckCode(t *testing.T) {
+	code := json.RawMessage(`[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"unit"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`)
+
+	cases := []struct {
+		name     string
+		opts     TypecheckOptions
+		wantArgs string
+	}{
+		{
+			name:     "Default options",
+			wantArgs: `{"program":` + string(code) + `}`,
+		},
+		{
+			name:     "Options",
+			opts:     TypecheckOptions{Gas: 1040000, Legacy: true, ShowTypes: true},
+			wantArgs: `{"program":` + string(code) + `,"gas":"1040000","legacy":true,"show_types":true}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(`{"type_map":[{"location":7,"stack_before":[{"prim":"pair","args":[{"prim":"unit"},{"prim":"unit"}]}],"stack_after":[{"prim":"unit"}]}],"gas":"1039960.715"}`)}
+
+			result, err := NewContractService(client).TypecheckCode(blockid.Head(), code, tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/scripts/typecheck_code")
+			assert.Equal(t, client.Args, tc.wantArgs)
+			assert.Equal(t, result.Gas, "1039960.715")
+			assert.Equal(t, len(result.TypeMap), 1)
+			assert.Equal(t, result.TypeMap[0].Location, 7)
+			assert.Equal(t, string(result.TypeMap[0].StackAfter[0]), `{"prim":"unit"}`)
+		})
+	}
+}
+
+func Test_TypecheckData(t *testing.T) {
+	client := &clientMock{ReturnBody: []byte(`{"gas":"1039998.525"}`)}
+
+	remaining, err := NewContractService(client).TypecheckData(blockid.Head(), json.RawMessage(`{"int":"1"}`), json.RawMessage(`{"prim":"nat"}`), TypecheckOptions{Legacy: true})
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/scripts/typecheck_data")
+	assert.Equal(t, client.Args, `{"data":{"int":"1"},"type":{"prim":"nat"},"legacy":true}`)
+	assert.Equal(t, remaining, "1039998.525")
+}
@@ -0,0 +1,118 @@
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// ViewOptions are the optional settings of RunScriptView and RunView.
+type ViewOptions struct {
+	// Source is the SOURCE of the view, the zero address by default
+	Source string
+	// Payer is the account paying for the view
+	Payer string
+	// Gas limits the gas of the view, the node default applies when it is 0
+	Gas int64
+	// UnlimitedGas lifts the gas limit of the view, it is only supported by run_script_view
+	UnlimitedGas bool
+	// UnparsingMode of the result, Readable by default
+	UnparsingMode UnparsingMode
+	// ChainID of the chain the view runs on, the chain of the node by default
+	ChainID string
+}
+
+type viewRequest struct {
+	Contract      string          `json:"contract"`
+	View          string          `json:"view,omitempty"`
+	Entrypoint    string          `json:"entrypoint,omitempty"`
+	Input         json.RawMessage `json:"input"`
+	ChainID       string          `json:"chain_id"`
+	Source        string          `json:"source,omitempty"`
+	Payer         string          `json:"payer,omitempty"`
+	Gas           string          `json:"gas,omitempty"`
+	UnlimitedGas  bool            `json:"unlimited_gas,omitempty"`
+	UnparsingMode UnparsingMode   `json:"unparsing_mode"`
+}
+
+// RunScriptView runs the on-chain view of the contract kt1 with the input, Micheline JSON or a Go value encoded
+// with Micheline, at the block id and returns its result without sending any operation.
+func (s *ContractService) RunScriptView(id blockid.BlockID, kt1, view string, input interface{}, opts ViewOptions) (json.RawMessage, error) {
+	query := "/chains/main/blocks/" + id.String() + "/helpers/scripts/run_script_view"
+	req := viewRequest{Contract: kt1, View: view, UnlimitedGas: opts.UnlimitedGas}
+
+	result, err := s.runView(query, req, input, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not run view '%s' of '%s'", view, kt1)
+	}
+	return result, nil
+}
+
+// RunView runs the legacy TZIP-4 view entrypoint of the contract kt1, an entrypoint taking a callback contract,
+// with the input at the block id and returns the value it would pass to the callback.
+func (s *ContractService) RunView(id blockid.BlockID, kt1, entrypoint string, input interface{}, opts ViewOptions) (json.RawMessage, error) {
+	query := "/chains/main/blocks/" + id.String() + "/helpers/scripts/run_view"
+	req := viewRequest{Contract: kt1, Entrypoint: entrypoint}
+
+	result, err := s.runView(query, req, input, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not run view '%s' of '%s'", entrypoint, kt1)
+	}
+	return result, nil
+}
+
+// runView completes req with the input and opts, and posts it to query
+func (s *ContractService) runView(query string, req viewRequest, input interface{}, opts ViewOptions) (json.RawMessage, error) {
+	var err error
+	if req.Input, err = Micheline(input); err != nil {
+		return nil, err
+	}
+
+	req.ChainID = opts.ChainID
+	if req.ChainID == "" {
+		if req.ChainID, err = s.chainID(); err != nil {
+			return nil, err
+		}
+	}
+
+	req.Source, req.Payer, req.UnparsingMode = opts.Source, opts.Payer, opts.UnparsingMode
+	if req.UnparsingMode == "" {
+		req.UnparsingMode = Readable
+	}
+	req.Gas = gas(opts.Gas)
+
+	args, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not run view '%s'", query)
+	}
+
+	resp, err := s.tzclient.Post(query, string(args))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not run view '%s'", query)
+	}
+
+	var result struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, errors.Wrapf(err, "could not run view '%s'", query)
+	}
+	return result.Data, nil
+}
+
+// chainID gets the id of the main chain
+func (s *ContractService) chainID() (string, error) {
+	query := "/chains/main/chain_id"
+	resp, err := s.tzclient.Get(query, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get chain ID '%s'", query)
+	}
+
+	var chainID string
+	if err := json.Unmarshal(resp, &chainID); err != nil {
+		return "", errors.Wrapf(err, "could not get chain ID '%s'", query)
+	}
+	return chainID, nil
+}
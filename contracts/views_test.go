@@ -0,0 +1,55 @@
+package contracts
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_RunScriptView(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     ViewOptions
+		wantArgs string
+	}{
+		{
+			name:     "Chain of the node",
+			wantArgs: `{"contract":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","view":"get_balance","input":{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"chain_id":"NetXdQprcVkpaWU","unparsing_mode":"Readable"}`,
+		},
+		{
+			name:     "Options",
+			opts:     ViewOptions{ChainID: "NetXnHfVqm9iesp", Source: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", Gas: 100000, UnlimitedGas: true, UnparsingMode: Optimized},
+			wantArgs: `{"contract":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","view":"get_balance","input":{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"chain_id":"NetXnHfVqm9iesp","source":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1","gas":"100000","unlimited_gas":true,"unparsing_mode":"Optimized"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{Bodies: map[string][]byte{
+				"/chains/main/chain_id": []byte(`"NetXdQprcVkpaWU"`),
+				"/chains/main/blocks/head/helpers/scripts/run_script_view": []byte(`{"data":{"int":"42"}}`),
+			}}
+
+			result, err := NewContractService(client).RunScriptView(blockid.Head(), "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t", "get_balance", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/scripts/run_script_view")
+			assert.Equal(t, client.Args, tc.wantArgs)
+			assert.Equal(t, string(result), `{"int":"42"}`)
+		})
+	}
+}
+
+func Test_RunView(t *testing.T) {
+	client := &clientMock{Bodies: map[string][]byte{
+		"/chains/main/chain_id":                             []byte(`"NetXdQprcVkpaWU"`),
+		"/chains/main/blocks/head/helpers/scripts/run_view": []byte(`{"data":{"int":"42"}}`),
+	}}
+
+	result, err := NewContractService(client).RunView(blockid.Head(), "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t", "getBalance", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", ViewOptions{UnlimitedGas: true})
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/scripts/run_view")
+	assert.Equal(t, client.Args, `{"contract":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","entrypoint":"getBalance","input":{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"chain_id":"NetXdQprcVkpaWU","unparsing_mode":"Readable"}`)
+	assert.Equal(t, string(result), `{"int":"42"}`)
+}
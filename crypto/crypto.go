@@ -1,25 +1,50 @@
 package crypto
 
 import (
-	"bytes"
-	"crypto/sha256"
-	"errors"
 	"math"
-	"math/big"
-	"reflect"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
 )
 
 type Prefix []byte
 
 var (
 	// For (de)constructing addresses
-	Prefix_tz1       Prefix = []byte{6, 161, 159}
-	Prefix_edsk      Prefix = []byte{43, 246, 78, 7}
-	Prefix_edsk2     Prefix = []byte{13, 15, 58, 7}
-	Prefix_edpk      Prefix = []byte{13, 15, 37, 217}
-	Prefix_edesk     Prefix = []byte{7, 90, 60, 179, 41}
-	Prefix_edsig     Prefix = []byte{9, 245, 205, 134, 18}
+	Prefix_tz1       Prefix = base58.Tz1.Bytes
+	Prefix_edsk      Prefix = base58.Edsk.Bytes
+	Prefix_edsk2     Prefix = base58.EdskSeed.Bytes
+	Prefix_edpk      Prefix = base58.Edpk.Bytes
+	Prefix_edesk     Prefix = base58.Edesk.Bytes
+	Prefix_spesk     Prefix = base58.Spesk.Bytes
+	Prefix_p2esk     Prefix = base58.P2esk.Bytes
+	Prefix_BLesk     Prefix = base58.BLesk.Bytes
+	Prefix_edsig     Prefix = base58.Edsig.Bytes
+	Prefix_BLsk      Prefix = base58.BLsk.Bytes
+	Prefix_spsk      Prefix = base58.Spsk.Bytes
+	Prefix_p2sk      Prefix = base58.P2sk.Bytes
 	Prefix_watermark Prefix = []byte{3}
+
+	// For validating hashes, addresses and public keys
+	Prefix_B    Prefix = base58.BlockHash.Bytes
+	Prefix_o    Prefix = base58.OperationHash.Bytes
+	Prefix_Net  Prefix = base58.ChainID.Bytes
+	Prefix_tz2  Prefix = base58.Tz2.Bytes
+	Prefix_tz3  Prefix = base58.Tz3.Bytes
+	Prefix_tz4  Prefix = base58.Tz4.Bytes
+	Prefix_KT1  Prefix = base58.KT1.Bytes
+	Prefix_sr1  Prefix = base58.Sr1.Bytes
+	Prefix_txr1 Prefix = base58.Txr1.Bytes
+	Prefix_sppk Prefix = base58.Sppk.Bytes
+	Prefix_p2pk Prefix = base58.P2pk.Bytes
+	Prefix_BLpk Prefix = base58.BLpk.Bytes
+	Prefix_expr Prefix = base58.ScriptExpr.Bytes
+	Prefix_nce  Prefix = base58.NonceHash.Bytes
+
+	// For decoding signatures
+	Prefix_sig   Prefix = base58.Sig.Bytes
+	Prefix_spsig Prefix = base58.Spsig.Bytes
+	Prefix_p2sig Prefix = base58.P2sig.Bytes
+	Prefix_BLsig Prefix = base58.BLsig.Bytes
 )
 
 //B58cencode encodes a byte array into base58 with prefix
@@ -50,104 +75,12 @@ func round(f float64) float64 {
 	return math.Floor(f + .5)
 }
 
-const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
-
+// Encode returns the base58check encoding of dataBytes, see base58.CheckEncode.
 func Encode(dataBytes []byte) string {
-
-	// Performing SHA256 twice
-	sha256hash := sha256.New()
-	sha256hash.Write(dataBytes)
-	middleHash := sha256hash.Sum(nil)
-	sha256hash = sha256.New()
-	sha256hash.Write(middleHash)
-	hash := sha256hash.Sum(nil)
-
-	checksum := hash[:4]
-	dataBytes = append(dataBytes, checksum...)
-
-	// For all the "00" versions or any prepended zeros as base58 removes them
-	zeroCount := 0
-	for i := 0; i < len(dataBytes); i++ {
-		if dataBytes[i] == 0 {
-			zeroCount++
-		} else {
-			break
-		}
-	}
-
-	// Performing base58 encoding
-	encoded := b58encode(dataBytes)
-
-	for i := 0; i < zeroCount; i++ {
-		encoded = "1" + encoded
-	}
-
-	return encoded
+	return base58.CheckEncode(dataBytes)
 }
 
+// Decode decodes the base58check string encoded, see base58.CheckDecode.
 func Decode(encoded string) ([]byte, error) {
-	zeroCount := 0
-	for i := 0; i < len(encoded); i++ {
-		if encoded[i] == 49 {
-			zeroCount++
-		} else {
-			break
-		}
-	}
-
-	dataBytes, err := b58decode(encoded)
-	if err != nil {
-		return []byte{}, err
-	}
-	data, checksum := dataBytes[:len(dataBytes)-4], dataBytes[len(dataBytes)-4:]
-
-	for i := 0; i < zeroCount; i++ {
-		data = append([]byte{0}, data...)
-	}
-
-	// Performing SHA256 twice to validate checksum
-	sha256hash := sha256.New()
-	sha256hash.Write(data)
-	middleHash := sha256hash.Sum(nil)
-	sha256hash = sha256.New()
-	sha256hash.Write(middleHash)
-	hash := sha256hash.Sum(nil)
-
-	if !reflect.DeepEqual(checksum, hash[:4]) {
-		return []byte{}, errors.New("data and checksum don't match")
-	}
-
-	return data, nil
-}
-
-func b58encode(data []byte) string {
-	var encoded string
-	decimalData := new(big.Int)
-	decimalData.SetBytes(data)
-	divisor, zero := big.NewInt(58), big.NewInt(0)
-
-	for decimalData.Cmp(zero) > 0 {
-		mod := new(big.Int)
-		decimalData.DivMod(decimalData, divisor, mod)
-		encoded = string(alphabet[mod.Int64()]) + encoded
-	}
-
-	return encoded
-}
-
-func b58decode(data string) ([]byte, error) {
-	decimalData := new(big.Int)
-	alphabetBytes := []byte(alphabet)
-	multiplier := big.NewInt(58)
-
-	for _, value := range data {
-		pos := bytes.IndexByte(alphabetBytes, byte(value))
-		if pos == -1 {
-			return nil, errors.New("character not found in alphabet")
-		}
-		decimalData.Mul(decimalData, multiplier)
-		decimalData.Add(decimalData, big.NewInt(int64(pos)))
-	}
-
-	return decimalData.Bytes(), nil
+	return base58.CheckDecode(encoded)
 }
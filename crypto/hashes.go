@@ -0,0 +1,145 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/pkg/errors"
+)
+
+// BlockHash is a base58check encoded block hash, e.g. BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT
+type BlockHash string
+
+// OperationHash is a base58check encoded operation hash, e.g. ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH
+type OperationHash string
+
+// ChainID is a base58check encoded chain ID, e.g. NetXdQprcVkpaWU
+type ChainID string
+
+// Address is a base58check encoded implicit account (tz1, tz2, tz3, tz4), originated contract (KT1)
+// or rollup (sr1, txr1) address.
+type Address string
+
+// PublicKey is a base58check encoded public key (edpk, sppk, p2pk, BLpk).
+type PublicKey string
+
+var (
+	blockHashEncodings     = []base58.Prefix{base58.BlockHash}
+	operationHashEncodings = []base58.Prefix{base58.OperationHash}
+	chainIDEncodings       = []base58.Prefix{base58.ChainID}
+	addressEncodings       = []base58.Prefix{
+		base58.Tz1, base58.Tz2, base58.Tz3, base58.Tz4, base58.KT1, base58.Sr1, base58.Txr1,
+	}
+	publicKeyEncodings = []base58.Prefix{base58.Edpk, base58.Sppk, base58.P2pk, base58.BLpk}
+)
+
+// ParseBlockHash returns s as a BlockHash if it is a valid block hash.
+func ParseBlockHash(s string) (BlockHash, error) {
+	if err := validate(s, blockHashEncodings); err != nil {
+		return "", errors.Wrapf(err, "invalid block hash '%s'", s)
+	}
+	return BlockHash(s), nil
+}
+
+// ParseOperationHash returns s as an OperationHash if it is a valid operation hash.
+func ParseOperationHash(s string) (OperationHash, error) {
+	if err := validate(s, operationHashEncodings); err != nil {
+		return "", errors.Wrapf(err, "invalid operation hash '%s'", s)
+	}
+	return OperationHash(s), nil
+}
+
+// ParseChainID returns s as a ChainID if it is a valid chain ID.
+func ParseChainID(s string) (ChainID, error) {
+	if err := validate(s, chainIDEncodings); err != nil {
+		return "", errors.Wrapf(err, "invalid chain id '%s'", s)
+	}
+	return ChainID(s), nil
+}
+
+// ParseAddress returns s as an Address if it is a valid address.
+func ParseAddress(s string) (Address, error) {
+	if err := validate(s, addressEncodings); err != nil {
+		return "", errors.Wrapf(err, "invalid address '%s'", s)
+	}
+	return Address(s), nil
+}
+
+// ParsePublicKey returns s as a PublicKey if it is a valid public key.
+func ParsePublicKey(s string) (PublicKey, error) {
+	if err := validate(s, publicKeyEncodings); err != nil {
+		return "", errors.Wrapf(err, "invalid public key '%s'", s)
+	}
+	return PublicKey(s), nil
+}
+
+// IsImplicit reports if a is the address of an implicit account, tz1, tz2, tz3 or tz4.
+func (a Address) IsImplicit() bool {
+	return len(a) > 3 && a[:2] == "tz"
+}
+
+// IsContract reports if a is the address of an originated contract, KT1.
+func (a Address) IsContract() bool {
+	return len(a) > 3 && a[:3] == "KT1"
+}
+
+// UnmarshalJSON unmarshals a BlockHash, failing if it is invalid.
+func (h *BlockHash) UnmarshalJSON(v []byte) error {
+	return unmarshalValidated(v, blockHashEncodings, "block hash", (*string)(h))
+}
+
+// UnmarshalJSON unmarshals an OperationHash, failing if it is invalid.
+func (h *OperationHash) UnmarshalJSON(v []byte) error {
+	return unmarshalValidated(v, operationHashEncodings, "operation hash", (*string)(h))
+}
+
+// UnmarshalJSON unmarshals a ChainID, failing if it is invalid.
+func (c *ChainID) UnmarshalJSON(v []byte) error {
+	return unmarshalValidated(v, chainIDEncodings, "chain id", (*string)(c))
+}
+
+// UnmarshalJSON unmarshals an Address, failing if it is invalid.
+func (a *Address) UnmarshalJSON(v []byte) error {
+	return unmarshalValidated(v, addressEncodings, "address", (*string)(a))
+}
+
+// UnmarshalJSON unmarshals a PublicKey, failing if it is invalid.
+func (k *PublicKey) UnmarshalJSON(v []byte) error {
+	return unmarshalValidated(v, publicKeyEncodings, "public key", (*string)(k))
+}
+
+func unmarshalValidated(v []byte, encodings []base58.Prefix, name string, dst *string) error {
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return errors.Wrapf(err, "could not unmarshal %s", name)
+	}
+	if err := validate(s, encodings); err != nil {
+		return errors.Wrapf(err, "invalid %s '%s'", name, s)
+	}
+	*dst = s
+	return nil
+}
+
+// validate checks that s is base58check encoded with one of encodings
+func validate(s string, encodings []base58.Prefix) error {
+	if len(s) < 6 {
+		return errors.New("too short")
+	}
+
+	decoded, err := base58.CheckDecode(s)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range encodings {
+		if bytes.HasPrefix(decoded, e.Bytes) {
+			if len(decoded) != len(e.Bytes)+e.Length {
+				return errors.Errorf("expected %d bytes, got %d", e.Length, len(decoded)-len(e.Bytes))
+			}
+			return nil
+		}
+	}
+
+	return errors.New("unexpected prefix")
+}
@@ -0,0 +1,83 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ParseHashes(t *testing.T) {
+	cases := []struct {
+		name    string
+		parse   func(s string) error
+		input   string
+		wantErr bool
+	}{
+		{name: "block hash", parse: parseBlockHash, input: "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT"},
+		{name: "block hash bad checksum", parse: parseBlockHash, input: "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoU", wantErr: true},
+		{name: "block hash with address", parse: parseBlockHash, input: "tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n", wantErr: true},
+		{name: "operation hash", parse: parseOperationHash, input: B58cencode(make([]byte, 32), Prefix_o)},
+		{name: "operation hash too short", parse: parseOperationHash, input: B58cencode(make([]byte, 31), Prefix_o), wantErr: true},
+		{name: "chain id", parse: parseChainID, input: "NetXdQprcVkpaWU"},
+		{name: "tz1 address", parse: parseAddress, input: "tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n"},
+		{name: "KT1 address", parse: parseAddress, input: B58cencode(make([]byte, 20), Prefix_KT1)},
+		{name: "empty address", parse: parseAddress, input: "", wantErr: true},
+		{name: "not base58", parse: parseAddress, input: "tz1-0OIl", wantErr: true},
+		{name: "zeros", parse: parseAddress, input: "111111", wantErr: true},
+		{name: "edpk public key", parse: parsePublicKey, input: B58cencode(make([]byte, 32), Prefix_edpk)},
+		{name: "sppk public key", parse: parsePublicKey, input: B58cencode(make([]byte, 33), Prefix_sppk)},
+		{name: "public key with address", parse: parsePublicKey, input: "tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parse(tc.input)
+			assert.Equal(t, err != nil, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_UnmarshalHashes(t *testing.T) {
+	var valid struct {
+		Hash    BlockHash `json:"hash"`
+		ChainID ChainID   `json:"chain_id"`
+		Baker   Address   `json:"baker"`
+	}
+	err := json.Unmarshal([]byte(`{"hash":"BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT","chain_id":"NetXdQprcVkpaWU","baker":"tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n"}`), &valid)
+	assert.NilError(t, err)
+	assert.Equal(t, valid.Baker, Address("tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n"))
+	assert.Assert(t, valid.Baker.IsImplicit())
+	assert.Assert(t, !valid.Baker.IsContract())
+
+	var invalid struct {
+		Baker Address `json:"baker"`
+	}
+	err = json.Unmarshal([]byte(`{"baker":"tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1"}`), &invalid)
+	assert.Assert(t, err != nil)
+}
+
+func parseBlockHash(s string) error {
+	_, err := ParseBlockHash(s)
+	return err
+}
+
+func parseOperationHash(s string) error {
+	_, err := ParseOperationHash(s)
+	return err
+}
+
+func parseChainID(s string) error {
+	_, err := ParseChainID(s)
+	return err
+}
+
+func parseAddress(s string) error {
+	_, err := ParseAddress(s)
+	return err
+}
+
+func parsePublicKey(s string) error {
+	_, err := ParsePublicKey(s)
+	return err
+}
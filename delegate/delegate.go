@@ -9,9 +9,11 @@ import (
 
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/snapshot"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
 )
 
 // DelegateService is a struct wrapper for delegate related functions
@@ -80,14 +82,38 @@ type Payment struct {
 
 // Delegate is representation of a delegate on the Tezos Network
 type Delegate struct {
-	Balance              string                 `json:"balance"`
-	FrozenBalance        string                 `json:"frozen_balance"`
-	FrozenBalanceByCycle []frozenBalanceByCycle `json:"frozen_balance_by_cycle"`
-	StakingBalance       string                 `json:"staking_balance"`
-	DelegateContracts    []string               `json:"delegated_contracts"`
-	DelegatedBalance     string                 `json:"delegated_balance"`
-	Deactivated          bool                   `json:"deactivated"`
-	GracePeriod          int                    `json:"grace_period"`
+	Balance               string                 `json:"balance"`
+	FullBalance           string                 `json:"full_balance"`
+	FrozenBalance         string                 `json:"frozen_balance"`
+	FrozenBalanceByCycle  []frozenBalanceByCycle `json:"frozen_balance_by_cycle"`
+	StakingBalance        string                 `json:"staking_balance"`
+	DelegateContracts     []string               `json:"delegated_contracts"`
+	DelegatedBalance      string                 `json:"delegated_balance"`
+	Deactivated           bool                   `json:"deactivated"`
+	GracePeriod           int                    `json:"grace_period"`
+	VotingPower           tez.Zarith             `json:"voting_power"`
+	FrozenDeposits        string                 `json:"frozen_deposits,omitempty"`
+	CurrentFrozenDeposits string                 `json:"current_frozen_deposits,omitempty"`
+	FrozenDepositsLimit   string                 `json:"frozen_deposits_limit,omitempty"`
+	ConsensusKey          *ConsensusKeys         `json:"consensus_key,omitempty"`
+}
+
+// ConsensusKeys are the active consensus key of a delegate and the keys it is updated to in the coming cycles
+type ConsensusKeys struct {
+	Active   ConsensusKey          `json:"active"`
+	Pendings []PendingConsensusKey `json:"pendings,omitempty"`
+}
+
+// ConsensusKey is a key a delegate bakes and attests with in place of its own key
+type ConsensusKey struct {
+	Pkh string `json:"pkh"`
+	Pk  string `json:"pk"`
+}
+
+// PendingConsensusKey is a consensus key that becomes active at Cycle
+type PendingConsensusKey struct {
+	Cycle int `json:"cycle"`
+	ConsensusKey
 }
 
 // FrozenBalanceByCycle a representation of frozen balance by cycle on the Tezos network
@@ -140,7 +166,7 @@ func (d *DelegateService) GetDelegationsAtCycle(delegatePhk string, cycle int) (
 		return rtnString, errors.Wrapf(err, "could not get delegations for %s at cycle %d", delegatePhk, cycle)
 	}
 
-	block, err := d.blockService.Get(snapShot.AssociatedBlock)
+	block, err := d.blockService.Get(blockid.Level(snapShot.AssociatedBlock))
 	if err != nil {
 		return rtnString, errors.Wrapf(err, "could not get delegations for %s at cycle %d", delegatePhk, cycle)
 	}
@@ -235,7 +261,7 @@ func (d *DelegateService) getDelegationReports(delegate string, delegations []st
 		return reports, 0, errors.Errorf("could not get snap shot at %d cycle: %v", cycle, err)
 	}
 
-	block, err := d.blockService.Get(snapShot.AssociatedBlock)
+	block, err := d.blockService.Get(blockid.Level(snapShot.AssociatedBlock))
 	if err != nil {
 		return reports, 0, errors.Errorf("could not get associated snap shot block at %d cycle: %v", cycle, err)
 	}
@@ -289,7 +315,7 @@ func (d *DelegateService) GetRewards(delegatePhk string, cycle int) (string, err
 	rewards := FrozenBalanceRewards{}
 	level := (cycle+1)*(d.constants.BlocksPerCycle) + 1
 
-	head, err := d.blockService.Get(level)
+	head, err := d.blockService.Get(blockid.Level(level))
 	if err != nil {
 		return "", errors.Wrapf(err, "could not get rewards for %s at %d cycle", delegatePhk, cycle)
 	}
@@ -313,7 +339,7 @@ func (d *DelegateService) GetRewards(delegatePhk string, cycle int) (string, err
 
 // getShareOfContract returns the share of a delegation for a specific cycle.
 func (d *DelegateService) getShareOfContract(delegationPhk, associatedBlockHash string, stakingBalance float64) (float64, float64, error) {
-	delegationBalance, err := d.accountService.GetBalanceAtBlock(delegationPhk, associatedBlockHash)
+	delegationBalance, err := d.accountService.GetBalanceAtBlock(delegationPhk, blockid.Hash(associatedBlockHash))
 	if err != nil {
 		return 0, 0, errors.Errorf("could not get share of contract %s: %v", delegationPhk, err)
 	}
@@ -323,8 +349,13 @@ func (d *DelegateService) getShareOfContract(delegationPhk, associatedBlockHash
 
 // GetDelegate retrieves information about a delegate at the head block
 func (d *DelegateService) GetDelegate(delegatePhk string) (Delegate, error) {
+	return d.Delegate(blockid.Head(), delegatePhk)
+}
+
+// Delegate retrieves information about a delegate at the block id
+func (d *DelegateService) Delegate(id blockid.BlockID, delegatePhk string) (Delegate, error) {
 	delegate := Delegate{}
-	get := "/chains/main/blocks/head/context/delegates/" + delegatePhk
+	get := "/chains/main/blocks/" + id.String() + "/context/delegates/" + delegatePhk
 	resp, err := d.tzclient.Get(get, nil)
 	if err != nil {
 		return delegate, errors.Wrapf(err, "could not get delegate '%s'", get)
@@ -337,6 +368,21 @@ func (d *DelegateService) GetDelegate(delegatePhk string) (Delegate, error) {
 	return delegate, nil
 }
 
+// GetConsensusKey retrieves the active and pending consensus keys of a delegate at the head block
+func (d *DelegateService) GetConsensusKey(delegatePhk string) (ConsensusKeys, error) {
+	keys := ConsensusKeys{}
+	get := "/chains/main/blocks/head/context/delegates/" + delegatePhk + "/consensus_key"
+	resp, err := d.tzclient.Get(get, nil)
+	if err != nil {
+		return keys, errors.Wrapf(err, "could not get consensus key '%s'", get)
+	}
+	if err := json.Unmarshal(resp, &keys); err != nil {
+		return keys, errors.Wrapf(err, "could not get consensus key '%s'", get)
+	}
+
+	return keys, nil
+}
+
 // GetStakingBalanceAtCycle gets the staking balance of a delegate at a specific cycle
 func (d *DelegateService) GetStakingBalanceAtCycle(delegateAddr string, cycle int) (string, error) {
 	balance := ""
@@ -479,6 +525,26 @@ func (d *DelegateService) GetAllDelegatesByHash(hash string) ([]string, error) {
 	return delList, nil
 }
 
+// Delegates gets the addresses of the delegates at the block id, only the active ones when activeOnly is set
+func (d *DelegateService) Delegates(id blockid.BlockID, activeOnly bool) ([]string, error) {
+	delList := []string{}
+	var params map[string]string
+	if activeOnly {
+		params = map[string]string{"active": "true"}
+	}
+
+	query := "/chains/main/blocks/" + id.String() + "/context/delegates"
+	resp, err := d.tzclient.Get(query, params)
+	if err != nil {
+		return delList, errors.Wrapf(err, "could not get delegates '%s'", query)
+	}
+	delList, err = unmarshalStringArray(resp)
+	if err != nil {
+		return delList, errors.Wrapf(err, "could not get delegates '%s'", query)
+	}
+	return delList, nil
+}
+
 // GetAllDelegates a list of all tz1 addresses at the head block
 func (d *DelegateService) GetAllDelegates() ([]string, error) {
 	delList := []string{}
@@ -502,7 +568,7 @@ func (d *DelegateService) GetStakingBalance(delegateAddr string, cycle int) (flo
 		return 0, errors.Wrapf(err, "could not get staking balance for %s at cycle %d", delegateAddr, cycle)
 	}
 
-	block, err := d.blockService.Get(snapShot.AssociatedBlock)
+	block, err := d.blockService.Get(blockid.Level(snapShot.AssociatedBlock))
 	if err != nil {
 		return 0, errors.Wrapf(err, "could not get staking balance for %s at cycle %d", delegateAddr, cycle)
 	}
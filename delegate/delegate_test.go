@@ -1 +1,97 @@
 package delegate
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
+)
+
+func Test_GetDelegateConsensusKey(t *testing.T) {
+	client := &clientMock{ReturnBody: goldenDelegate}
+	delegate, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).GetDelegate("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/blocks/head/context/delegates/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Equal(t, delegate.ConsensusKey.Active.Pkh, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Equal(t, len(delegate.ConsensusKey.Pendings), 1)
+	assert.Equal(t, delegate.ConsensusKey.Pendings[0].Cycle, 702)
+	assert.Equal(t, delegate.ConsensusKey.Pendings[0].Pk, "edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm")
+}
+
+func Test_GetConsensusKey(t *testing.T) {
+	cases := []struct {
+		name       string
+		returnBody string
+		wantActive string
+		wantErr    bool
+	}{
+		{
+			name:       "Active key only",
+			returnBody: `{"active":{"pkh":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","pk":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"}}`,
+			wantActive: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		},
+		{
+			name:       "Malformed response",
+			returnBody: `[]`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(tc.returnBody)}
+			keys, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).GetConsensusKey("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/context/delegates/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx/consensus_key")
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, keys.Active.Pkh, tc.wantActive)
+			assert.Equal(t, len(keys.Pendings), 0)
+		})
+	}
+}
+
+func Test_Delegate(t *testing.T) {
+	client := &clientMock{ReturnBody: goldenDelegate}
+	delegate, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).Delegate(blockid.Level(100000), "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/blocks/100000/context/delegates/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Equal(t, delegate.FullBalance, "15000000000")
+	assert.Equal(t, delegate.StakingBalance, "16000000000")
+	assert.DeepEqual(t, delegate.DelegateContracts, []string{"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"})
+	assert.Equal(t, delegate.DelegatedBalance, "1000000000")
+	assert.Equal(t, delegate.GracePeriod, 700)
+	assert.Equal(t, delegate.VotingPower.Int64(), int64(16000000000))
+}
+
+func Test_Delegates(t *testing.T) {
+	cases := []struct {
+		name       string
+		activeOnly bool
+		wantParams map[string]string
+	}{
+		{
+			name: "Every delegate",
+		},
+		{
+			name:       "Active delegates",
+			activeOnly: true,
+			wantParams: map[string]string{"active": "true"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(`["tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"]`)}
+			delegates, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).Delegates(blockid.Head(), tc.activeOnly)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/context/delegates")
+			assert.DeepEqual(t, client.Params, tc.wantParams)
+			assert.DeepEqual(t, delegates, []string{"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"})
+		})
+	}
+}
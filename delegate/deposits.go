@@ -0,0 +1,55 @@
+package delegate
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// FrozenDeposits are the deposits a delegate has frozen to bake and attest with.
+type FrozenDeposits struct {
+	// Frozen is the deposit frozen at the beginning of the cycle
+	Frozen tez.Mutez
+	// Current is the deposit frozen now, lower than Frozen when the delegate was slashed
+	Current tez.Mutez
+	// Limit is the limit the delegate set to its deposits, nil when it did not set one
+	Limit *tez.Mutez
+	// Unstaked are the deposits unstaked by cycle, frozen until they can be finalized
+	Unstaked []UnstakedFrozenDeposit
+}
+
+// UnstakedFrozenDeposit is a deposit unstaked at Cycle.
+type UnstakedFrozenDeposit struct {
+	Cycle   int       `json:"cycle"`
+	Deposit tez.Mutez `json:"deposit"`
+}
+
+// FrozenDeposits gets the frozen, current, limit and unstaked deposits of a delegate at the block id,
+// which tell if the delegate is overstaked or has fewer deposits than its stake requires.
+func (d *DelegateService) FrozenDeposits(id blockid.BlockID, delegatePhk string) (FrozenDeposits, error) {
+	var deposits FrozenDeposits
+	query := "/chains/main/blocks/" + id.String() + "/context/delegates/" + delegatePhk
+
+	for _, field := range []struct {
+		path  string
+		value interface{}
+	}{
+		{"/frozen_deposits", &deposits.Frozen},
+		{"/current_frozen_deposits", &deposits.Current},
+		{"/frozen_deposits_limit", &deposits.Limit},
+		{"/unstaked_frozen_deposits", &deposits.Unstaked},
+	} {
+		resp, err := d.tzclient.Get(query+field.path, nil)
+		if err != nil {
+			return deposits, errors.Wrapf(err, "could not get frozen deposits '%s'", query+field.path)
+		}
+		if err := json.Unmarshal(resp, field.value); err != nil {
+			return deposits, errors.Wrapf(err, "could not get frozen deposits '%s'", query+field.path)
+		}
+	}
+
+	return deposits, nil
+}
@@ -0,0 +1,57 @@
+package delegate
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_FrozenDeposits(t *testing.T) {
+	const delegatePath = "/chains/main/blocks/head/context/delegates/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	limit := tez.Mutez(8000000000)
+
+	cases := []struct {
+		name      string
+		limitBody string
+		want      FrozenDeposits
+	}{
+		{
+			name:      "Without limit",
+			limitBody: `null`,
+			want: FrozenDeposits{
+				Frozen:   6000000000,
+				Current:  5900000000,
+				Unstaked: []UnstakedFrozenDeposit{{Cycle: 700, Deposit: 100000000}},
+			},
+		},
+		{
+			name:      "With limit",
+			limitBody: `"8000000000"`,
+			want: FrozenDeposits{
+				Frozen:   6000000000,
+				Current:  5900000000,
+				Limit:    &limit,
+				Unstaked: []UnstakedFrozenDeposit{{Cycle: 700, Deposit: 100000000}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{Bodies: map[string][]byte{
+				delegatePath + "/frozen_deposits":          []byte(`"6000000000"`),
+				delegatePath + "/current_frozen_deposits":  []byte(`"5900000000"`),
+				delegatePath + "/frozen_deposits_limit":    []byte(tc.limitBody),
+				delegatePath + "/unstaked_frozen_deposits": []byte(`[{"cycle":700,"deposit":"100000000"}]`),
+			}}
+
+			deposits, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).FrozenDeposits(blockid.Head(), "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, deposits, tc.want)
+		})
+	}
+}
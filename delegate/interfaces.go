@@ -1,15 +1,26 @@
 package delegate
 
+import "github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+
 type TezosDelegateService interface {
 	GetDelegations(delegatePhk string) ([]string, error)
 	GetDelegationsAtCycle(delegatePhk string, cycle int) ([]string, error)
 	GetReport(delegatePhk string, cycle int, fee float64) (*DelegateReport, error)
 	// GetPayments(minimum int) []Payment
 	GetRewards(delegatePhk string, cycle int) (string, error)
+	CycleRewards(delegatePhk string, cycle int) (Rewards, error)
 	GetDelegate(delegatePhk string) (Delegate, error)
+	Delegate(id blockid.BlockID, delegatePhk string) (Delegate, error)
+	Delegates(id blockid.BlockID, activeOnly bool) ([]string, error)
+	FrozenDeposits(id blockid.BlockID, delegatePhk string) (FrozenDeposits, error)
+	Participation(id blockid.BlockID, delegatePhk string) (Participation, error)
+	GetConsensusKey(delegatePhk string) (ConsensusKeys, error)
 	GetStakingBalanceAtCycle(delegateAddr string, cycle int) (string, error)
+	BakingRights(id blockid.BlockID, opts BakingRightsOptions) ([]BakingRight, error)
 	GetBakingRights(cycle int) (BakingRights, error)
 	GetBakingRightsForDelegate(cycle int, delegatePhk string, priority int) (BakingRights, error)
+	AttestationRights(id blockid.BlockID, opts AttestationRightsOptions) ([]AttestationRight, error)
+	EndorsingRights(id blockid.BlockID, opts AttestationRightsOptions) ([]AttestationRight, error)
 	GetEndorsingRightsForDelegate(cycle int, delegatePhk string) (EndorsingRights, error)
 	GetEndorsingRights(cycle int) (EndorsingRights, error)
 	GetAllDelegatesByHash(hash string) ([]string, error)
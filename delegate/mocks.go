@@ -1 +1,36 @@
 package delegate
+
+var goldenDelegate = []byte(`{
+	"balance": "15000000000",
+	"full_balance": "15000000000",
+	"staking_balance": "16000000000",
+	"delegated_contracts": ["tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"],
+	"delegated_balance": "1000000000",
+	"deactivated": false,
+	"grace_period": 700,
+	"voting_power": "16000000000",
+	"consensus_key": {
+		"active": {"pkh": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "pk": "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"},
+		"pendings": [{"cycle": 702, "pkh": "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", "pk": "edpkvEoAbkdaGALxi2FfeefB8hUkMZ4J1UVwkzyumx2GvbVpkYUHnm"}]
+	}
+}`)
+
+type clientMock struct {
+	ReturnBody []byte
+	// Bodies are returned instead of ReturnBody for the paths they are keyed by
+	Bodies map[string][]byte
+	Path   string
+	Params map[string]string
+}
+
+func (c *clientMock) Post(path, args string) ([]byte, error) {
+	return c.ReturnBody, nil
+}
+
+func (c *clientMock) Get(path string, params map[string]string) ([]byte, error) {
+	c.Path, c.Params = path, params
+	if body, ok := c.Bodies[path]; ok {
+		return body, nil
+	}
+	return c.ReturnBody, nil
+}
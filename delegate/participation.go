@@ -0,0 +1,54 @@
+package delegate
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Participation is the attestation activity of a delegate in the current cycle. A delegate keeps its
+// attesting rewards for the cycle as long as it misses at most RemainingAllowedMissedSlots more slots.
+type Participation struct {
+	ExpectedCycleActivity       int       `json:"expected_cycle_activity"`
+	MinimalCycleActivity        int       `json:"minimal_cycle_activity"`
+	MissedSlots                 int       `json:"missed_slots"`
+	MissedLevels                int       `json:"missed_levels"`
+	RemainingAllowedMissedSlots int       `json:"remaining_allowed_missed_slots"`
+	ExpectedAttestingRewards    tez.Mutez `json:"expected_attesting_rewards"`
+}
+
+// UnmarshalJSON unmarshals the participation of any protocol, the attesting rewards were named endorsing rewards before Oxford.
+func (p *Participation) UnmarshalJSON(v []byte) error {
+	type participation Participation
+	var r struct {
+		*participation
+		ExpectedEndorsingRewards *tez.Mutez `json:"expected_endorsing_rewards"`
+	}
+	r.participation = (*participation)(p)
+	if err := json.Unmarshal(v, &r); err != nil {
+		return err
+	}
+
+	if r.ExpectedEndorsingRewards != nil {
+		p.ExpectedAttestingRewards = *r.ExpectedEndorsingRewards
+	}
+	return nil
+}
+
+// Participation gets the attestation activity of a delegate in the cycle of the block id.
+func (d *DelegateService) Participation(id blockid.BlockID, delegatePhk string) (Participation, error) {
+	var participation Participation
+	query := "/chains/main/blocks/" + id.String() + "/context/delegates/" + delegatePhk + "/participation"
+	resp, err := d.tzclient.Get(query, nil)
+	if err != nil {
+		return participation, errors.Wrapf(err, "could not get participation '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &participation); err != nil {
+		return participation, errors.Wrapf(err, "could not get participation '%s'", query)
+	}
+	return participation, nil
+}
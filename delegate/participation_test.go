@@ -0,0 +1,51 @@
+package delegate
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
+)
+
+func Test_Participation(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want Participation
+	}{
+		{
+			name: "Attesting rewards",
+			body: `{"expected_cycle_activity":1200,"minimal_cycle_activity":800,"missed_slots":50,"missed_levels":4,"remaining_allowed_missed_slots":350,"expected_attesting_rewards":"45000000"}`,
+			want: Participation{
+				ExpectedCycleActivity:       1200,
+				MinimalCycleActivity:        800,
+				MissedSlots:                 50,
+				MissedLevels:                4,
+				RemainingAllowedMissedSlots: 350,
+				ExpectedAttestingRewards:    45000000,
+			},
+		},
+		{
+			name: "Endorsing rewards",
+			body: `{"expected_cycle_activity":1200,"minimal_cycle_activity":800,"missed_slots":400,"missed_levels":30,"remaining_allowed_missed_slots":0,"expected_endorsing_rewards":"0"}`,
+			want: Participation{
+				ExpectedCycleActivity: 1200,
+				MinimalCycleActivity:  800,
+				MissedSlots:           400,
+				MissedLevels:          30,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(tc.body)}
+			participation, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).Participation(blockid.Head(), "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/context/delegates/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx/participation")
+			assert.DeepEqual(t, participation, tc.want)
+		})
+	}
+}
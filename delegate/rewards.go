@@ -0,0 +1,213 @@
+package delegate
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Categories of the balance updates the rewards of a delegate are read from
+const (
+	categoryBakingRewards        = "baking rewards"
+	categoryBakingBonuses        = "baking bonuses"
+	categoryBlockFees            = "block fees"
+	categoryLostAttestingRewards = "lost attesting rewards"
+)
+
+// Rewards are the earnings of a delegate in a cycle. Theoretical are the earnings of the delegate had it baked the
+// blocks of all its round 0 rights with every attestation of the committee and attested all its slots, Actual are
+// the earnings it got.
+type Rewards struct {
+	Delegate    string
+	Cycle       int
+	Theoretical Earnings
+	Actual      Earnings
+	// BakingRights are the round 0 rights of the delegate
+	BakingRights int
+	// BlocksBaked are the blocks whose payload the delegate proposed, at any round
+	BlocksBaked int
+	// MissedBlocks are the round 0 rights of the delegate whose payload it did not propose
+	MissedBlocks int
+	// AttestationPower is the number of slots of the delegate in the cycle
+	AttestationPower int
+	// MissedSlots are the slots the delegate did not attest
+	MissedSlots int
+}
+
+// Earnings of a delegate, the fees are only known for the actual earnings.
+type Earnings struct {
+	BakingRewards    tez.Mutez
+	BakingBonuses    tez.Mutez
+	AttestingRewards tez.Mutez
+	Fees             tez.Mutez
+}
+
+// Total returns the sum of the earnings.
+func (e Earnings) Total() tez.Mutez {
+	return e.BakingRewards + e.BakingBonuses + e.AttestingRewards + e.Fees
+}
+
+// CycleRewards computes the theoretical and actual earnings of a delegate in a past cycle from the node alone, for
+// payout tools to pay delegators without an indexer. The rewards per slot are the expected issuance of the cycle
+// and the baking bonuses assume the consensus committee and threshold of the constants of the service. The metadata
+// of every block the delegate has a baking right at is fetched to find the blocks it baked and their fees. It
+// supports protocols from Oxford onward, rights are kept by the node for a limited number of cycles.
+func (d *DelegateService) CycleRewards(delegatePhk string, cycle int) (Rewards, error) {
+	rewards := Rewards{Delegate: delegatePhk, Cycle: cycle}
+
+	last, err := d.lastLevel(cycle)
+	if err != nil {
+		return rewards, errors.Wrapf(err, "could not get rewards of %s at cycle %d", delegatePhk, cycle)
+	}
+	id := blockid.Level(last)
+
+	issuance, err := d.cycleIssuance(id, cycle)
+	if err != nil {
+		return rewards, errors.Wrapf(err, "could not get rewards of %s at cycle %d", delegatePhk, cycle)
+	}
+
+	bakingRights, err := d.BakingRights(id, BakingRightsOptions{Delegate: delegatePhk, Cycle: cycle})
+	if err != nil {
+		return rewards, errors.Wrapf(err, "could not get rewards of %s at cycle %d", delegatePhk, cycle)
+	}
+	bonusSlots := d.constants.ConsensusCommitteeSize - d.constants.ConsensusThreshold
+	for _, right := range bakingRights {
+		metadata, err := d.metadata(blockid.Level(right.Level))
+		if err != nil {
+			return rewards, errors.Wrapf(err, "could not get rewards of %s at cycle %d", delegatePhk, cycle)
+		}
+
+		if right.Round == 0 {
+			rewards.BakingRights++
+			rewards.Theoretical.BakingRewards += issuance.BakingRewardFixedPortion
+			rewards.Theoretical.BakingBonuses += issuance.BakingRewardBonusPerSlot * tez.Mutez(bonusSlots)
+			if metadata.Proposer != delegatePhk {
+				rewards.MissedBlocks++
+			}
+		}
+		if metadata.Proposer == delegatePhk {
+			rewards.BlocksBaked++
+			rewards.Actual.BakingRewards += debited(metadata.BalanceUpdates, categoryBakingRewards)
+			rewards.Actual.Fees += debited(metadata.BalanceUpdates, categoryBlockFees)
+		}
+		if metadata.Baker == delegatePhk {
+			rewards.Actual.BakingBonuses += debited(metadata.BalanceUpdates, categoryBakingBonuses)
+		}
+	}
+
+	attestationRights, err := d.AttestationRights(id, AttestationRightsOptions{Delegate: delegatePhk, Cycle: cycle})
+	if err != nil {
+		return rewards, errors.Wrapf(err, "could not get rewards of %s at cycle %d", delegatePhk, cycle)
+	}
+	for _, right := range attestationRights {
+		for _, delegate := range right.Delegates {
+			if delegate.Delegate == delegatePhk {
+				rewards.AttestationPower += delegate.AttestationPower
+			}
+		}
+	}
+	rewards.Theoretical.AttestingRewards = issuance.AttestingRewardPerSlot * tez.Mutez(rewards.AttestationPower)
+
+	participation, err := d.Participation(id, delegatePhk)
+	if err != nil {
+		return rewards, errors.Wrapf(err, "could not get rewards of %s at cycle %d", delegatePhk, cycle)
+	}
+	rewards.MissedSlots = participation.MissedSlots
+
+	// the attesting rewards are paid, or lost, at the end of the cycle
+	metadata, err := d.metadata(id)
+	if err != nil {
+		return rewards, errors.Wrapf(err, "could not get rewards of %s at cycle %d", delegatePhk, cycle)
+	}
+	rewards.Actual.AttestingRewards = rewards.Theoretical.AttestingRewards
+	for _, update := range metadata.BalanceUpdates {
+		if update.Category == categoryLostAttestingRewards && update.Delegate == delegatePhk && update.Change > 0 {
+			rewards.Actual.AttestingRewards -= update.Change
+		}
+	}
+	if rewards.Actual.AttestingRewards < 0 {
+		rewards.Actual.AttestingRewards = 0
+	}
+
+	return rewards, nil
+}
+
+// lastLevel returns the last level of a cycle, computed by the node from the current cycle
+func (d *DelegateService) lastLevel(cycle int) (int, error) {
+	query := "/chains/main/blocks/head/helpers/current_level"
+	resp, err := d.tzclient.Get(query, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get current level '%s'", query)
+	}
+	var current block.Level
+	if err := json.Unmarshal(resp, &current); err != nil {
+		return 0, errors.Wrapf(err, "could not get current level '%s'", query)
+	}
+	if cycle >= current.Cycle {
+		return 0, errors.Errorf("cycle %d is not over, the current cycle is %d", cycle, current.Cycle)
+	}
+
+	query = "/chains/main/blocks/head/helpers/levels_in_current_cycle"
+	resp, err = d.tzclient.Get(query, map[string]string{"offset": strconv.Itoa(cycle - current.Cycle)})
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get levels of cycle %d '%s'", cycle, query)
+	}
+	var levels struct {
+		Last int `json:"last"`
+	}
+	if err := json.Unmarshal(resp, &levels); err != nil {
+		return 0, errors.Wrapf(err, "could not get levels of cycle %d '%s'", cycle, query)
+	}
+	return levels.Last, nil
+}
+
+// cycleIssuance returns the rewards of a cycle from the expected issuance at the block id
+func (d *DelegateService) cycleIssuance(id blockid.BlockID, cycle int) (network.ExpectedIssuance, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/issuance/expected_issuance"
+	resp, err := d.tzclient.Get(query, nil)
+	if err != nil {
+		return network.ExpectedIssuance{}, errors.Wrapf(err, "could not get expected issuance '%s'", query)
+	}
+
+	var issuance []network.ExpectedIssuance
+	if err := json.Unmarshal(resp, &issuance); err != nil {
+		return network.ExpectedIssuance{}, errors.Wrapf(err, "could not get expected issuance '%s'", query)
+	}
+	for _, i := range issuance {
+		if i.Cycle == cycle {
+			return i, nil
+		}
+	}
+	return network.ExpectedIssuance{}, errors.Errorf("could not get expected issuance '%s', no issuance for cycle %d", query, cycle)
+}
+
+// metadata gets the metadata of the block id, without its operations
+func (d *DelegateService) metadata(id blockid.BlockID) (block.Metadata, error) {
+	var metadata block.Metadata
+	query := "/chains/main/blocks/" + id.String() + "/metadata"
+	resp, err := d.tzclient.Get(query, nil)
+	if err != nil {
+		return metadata, errors.Wrapf(err, "could not get block metadata '%s'", query)
+	}
+	if err := json.Unmarshal(resp, &metadata); err != nil {
+		return metadata, errors.Wrapf(err, "could not get block metadata '%s'", query)
+	}
+	return metadata, nil
+}
+
+// debited returns the tez debited from the balances of a category, e.g. the baking rewards minted in a block
+func debited(updates []block.BalanceUpdates, category string) tez.Mutez {
+	var total tez.Mutez
+	for _, update := range updates {
+		if update.Category == category && update.Change < 0 {
+			total -= update.Change
+		}
+	}
+	return total
+}
@@ -0,0 +1,101 @@
+package delegate
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
+)
+
+func Test_CycleRewards(t *testing.T) {
+	const delegate = "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	const other = "tz1S8MNvuFEUsWgjHvi3AxibRBf388NhT1q2"
+
+	bodies := func(lastBlock string) map[string][]byte {
+		return map[string][]byte{
+			"/chains/main/blocks/head/helpers/current_level":                           []byte(`{"level":300,"cycle":702}`),
+			"/chains/main/blocks/head/helpers/levels_in_current_cycle":                 []byte(`{"first":1,"last":100}`),
+			"/chains/main/blocks/100/context/issuance/expected_issuance":               []byte(`[{"cycle":700,"baking_reward_fixed_portion":"300","baking_reward_bonus_per_slot":"1","attesting_reward_per_slot":"10","seed_nonce_revelation_tip":"0","vdf_revelation_tip":"0"}]`),
+			"/chains/main/blocks/100/helpers/baking_rights":                            []byte(`[{"level":91,"delegate":"` + delegate + `","round":0},{"level":95,"delegate":"` + delegate + `","round":0},{"level":98,"delegate":"` + delegate + `","round":1}]`),
+			"/chains/main/blocks/100/helpers/attestation_rights":                       []byte(`[{"level":91,"delegates":[{"delegate":"` + delegate + `","first_slot":0,"attestation_power":20}]},{"level":92,"delegates":[{"delegate":"` + delegate + `","first_slot":4,"attestation_power":30}]}]`),
+			"/chains/main/blocks/100/context/delegates/" + delegate + "/participation": []byte(`{"missed_slots":5}`),
+			"/chains/main/blocks/91/metadata": []byte(`{"proposer":"` + delegate + `","baker":"` + delegate + `","balance_updates":[
+				{"kind":"accumulator","category":"block fees","change":"-1200","origin":"block"},
+				{"kind":"contract","contract":"` + delegate + `","change":"1200","origin":"block"},
+				{"kind":"minted","category":"baking rewards","change":"-300","origin":"block"},
+				{"kind":"contract","contract":"` + delegate + `","change":"300","origin":"block"},
+				{"kind":"minted","category":"baking bonuses","change":"-2000","origin":"block"},
+				{"kind":"contract","contract":"` + delegate + `","change":"2000","origin":"block"}]}`),
+			"/chains/main/blocks/95/metadata": []byte(`{"proposer":"` + other + `","baker":"` + other + `","balance_updates":[
+				{"kind":"minted","category":"baking rewards","change":"-300","origin":"block"},
+				{"kind":"contract","contract":"` + other + `","change":"300","origin":"block"}]}`),
+			"/chains/main/blocks/98/metadata": []byte(`{"proposer":"` + delegate + `","baker":"` + other + `","balance_updates":[
+				{"kind":"accumulator","category":"block fees","change":"-800","origin":"block"},
+				{"kind":"contract","contract":"` + delegate + `","change":"800","origin":"block"},
+				{"kind":"minted","category":"baking rewards","change":"-300","origin":"block"},
+				{"kind":"contract","contract":"` + delegate + `","change":"300","origin":"block"},
+				{"kind":"minted","category":"baking bonuses","change":"-1500","origin":"block"},
+				{"kind":"contract","contract":"` + other + `","change":"1500","origin":"block"}]}`),
+			"/chains/main/blocks/100/metadata": []byte(lastBlock),
+		}
+	}
+
+	theoretical := Earnings{BakingRewards: 600, BakingBonuses: 2 * 2333, AttestingRewards: 500}
+	cases := []struct {
+		name      string
+		lastBlock string
+		want      Rewards
+	}{
+		{
+			name: "Attesting rewards paid",
+			lastBlock: `{"proposer":"` + other + `","baker":"` + other + `","balance_updates":[
+				{"kind":"minted","category":"attesting rewards","change":"-500","origin":"block"},
+				{"kind":"contract","contract":"` + delegate + `","change":"500","origin":"block"}]}`,
+			want: Rewards{
+				Delegate:         delegate,
+				Cycle:            700,
+				Theoretical:      theoretical,
+				Actual:           Earnings{BakingRewards: 600, BakingBonuses: 2000, AttestingRewards: 500, Fees: 2000},
+				BakingRights:     2,
+				BlocksBaked:      2,
+				MissedBlocks:     1,
+				AttestationPower: 50,
+				MissedSlots:      5,
+			},
+		},
+		{
+			name: "Attesting rewards lost",
+			lastBlock: `{"proposer":"` + other + `","baker":"` + other + `","balance_updates":[
+				{"kind":"minted","category":"attesting rewards","change":"-500","origin":"block"},
+				{"kind":"burned","category":"lost attesting rewards","delegate":"` + delegate + `","participation":false,"revelation":true,"change":"500","origin":"block"}]}`,
+			want: Rewards{
+				Delegate:         delegate,
+				Cycle:            700,
+				Theoretical:      theoretical,
+				Actual:           Earnings{BakingRewards: 600, BakingBonuses: 2000, Fees: 2000},
+				BakingRights:     2,
+				BlocksBaked:      2,
+				MissedBlocks:     1,
+				AttestationPower: 50,
+				MissedSlots:      5,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{Bodies: bodies(tc.lastBlock)}
+			constants := network.Constants{ConsensusCommitteeSize: 7000, ConsensusThreshold: 4667}
+			rewards, err := NewDelegateService(client, nil, nil, nil, constants).CycleRewards(delegate, 700)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, rewards, tc.want)
+		})
+	}
+
+	t.Run("Cycle not over", func(t *testing.T) {
+		client := &clientMock{Bodies: bodies("{}")}
+		_, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).CycleRewards(delegate, 702)
+		assert.ErrorContains(t, err, "cycle 702 is not over")
+	})
+}
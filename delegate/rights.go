@@ -0,0 +1,194 @@
+package delegate
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// BakingRight is the right of a delegate to bake the block of a level at a round, the delegate baking at the lowest
+// round with a block gets the rewards.
+type BakingRight struct {
+	Level    int    `json:"level"`
+	Delegate string `json:"delegate"`
+	Round    int    `json:"round"`
+	// ConsensusKey is the address of the key signing the block for the delegate
+	ConsensusKey string `json:"consensus_key"`
+	// EstimatedTime is when the block can be baked, it is zero for levels before the block queried
+	EstimatedTime time.Time `json:"estimated_time"`
+}
+
+// BakingRightsOptions filter the rights returned by BakingRights, the zero values do not filter.
+type BakingRightsOptions struct {
+	// Delegate only returns the rights of a delegate
+	Delegate string
+	// ConsensusKey only returns the rights of the delegate with this consensus key
+	ConsensusKey string
+	// Cycle returns the rights of a cycle when it is set, the rights of the level following the block otherwise
+	Cycle int
+	// Level returns the rights of a level when it is set
+	Level int
+	// MaxRound is the highest round returned when it is set, the node returns rounds up to 64 otherwise
+	MaxRound *int
+	// All returns every round of a delegate at a level, instead of its lowest round only
+	All bool
+}
+
+// BakingRights gets the rights to bake blocks computed at the block id, e.g. the rights of a baker in the next
+// cycles to plan maintenances between its blocks.
+func (d *DelegateService) BakingRights(id blockid.BlockID, opts BakingRightsOptions) ([]BakingRight, error) {
+	var rights []BakingRight
+	query := "/chains/main/blocks/" + id.String() + "/helpers/baking_rights"
+
+	params := rightsParams(opts.Delegate, opts.ConsensusKey, opts.Cycle, opts.Level)
+	if opts.MaxRound != nil {
+		params["max_round"] = strconv.Itoa(*opts.MaxRound)
+	}
+	if opts.All {
+		params["all"] = "true"
+	}
+
+	resp, err := d.tzclient.Get(query, params)
+	if err != nil {
+		return rights, errors.Wrapf(err, "could not get baking rights '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &rights); err != nil {
+		return rights, errors.Wrapf(err, "could not get baking rights '%s'", query)
+	}
+	return rights, nil
+}
+
+// AttestationRight is the rights of the delegates to attest the block of a level, the slots of a delegate are the
+// FirstSlot of its rights and the following AttestationPower-1 slots.
+type AttestationRight struct {
+	Level     int                 `json:"level"`
+	Delegates []AttestingDelegate `json:"delegates"`
+	// EstimatedTime is when the block can be baked, it is zero for levels before the block queried
+	EstimatedTime time.Time `json:"estimated_time"`
+}
+
+// AttestingDelegate is a delegate attesting a level. Slots is only set before Ithaca, when the slots of a delegate
+// were not consecutive, FirstSlot is then the lowest one and AttestationPower the number of slots.
+type AttestingDelegate struct {
+	Delegate         string `json:"delegate"`
+	FirstSlot        int    `json:"first_slot"`
+	AttestationPower int    `json:"attestation_power"`
+	// ConsensusKey is the address of the key signing the attestations for the delegate
+	ConsensusKey string `json:"consensus_key"`
+	Slots        []int  `json:"slots,omitempty"`
+}
+
+// UnmarshalJSON unmarshals the delegate of any protocol, the attestation power was named endorsing power before Oxford.
+func (a *AttestingDelegate) UnmarshalJSON(v []byte) error {
+	type attestingDelegate AttestingDelegate
+	var r struct {
+		*attestingDelegate
+		EndorsingPower *int `json:"endorsing_power"`
+	}
+	r.attestingDelegate = (*attestingDelegate)(a)
+	if err := json.Unmarshal(v, &r); err != nil {
+		return err
+	}
+
+	if r.EndorsingPower != nil {
+		a.AttestationPower = *r.EndorsingPower
+	}
+	return nil
+}
+
+// AttestationRightsOptions filter the rights returned by AttestationRights, the zero values do not filter.
+type AttestationRightsOptions struct {
+	// Delegate only returns the rights of a delegate
+	Delegate string
+	// ConsensusKey only returns the rights of the delegate with this consensus key
+	ConsensusKey string
+	// Cycle returns the rights of a cycle when it is set, the rights of the level following the block otherwise
+	Cycle int
+	// Level returns the rights of a level when it is set
+	Level int
+}
+
+// AttestationRights gets the rights to attest blocks computed at the block id, from Oxford onward.
+func (d *DelegateService) AttestationRights(id blockid.BlockID, opts AttestationRightsOptions) ([]AttestationRight, error) {
+	query := "/chains/main/blocks/" + id.String() + "/helpers/attestation_rights"
+	rights, err := d.attestationRights(query, opts)
+	if err != nil {
+		return rights, errors.Wrapf(err, "could not get attestation rights '%s'", query)
+	}
+	return rights, nil
+}
+
+// EndorsingRights gets the rights to attest blocks computed at the block id before Oxford, when attestations were
+// named endorsements. The rights of the protocols before Ithaca, given per delegate with their slots, are grouped
+// by level like the rights of later protocols.
+func (d *DelegateService) EndorsingRights(id blockid.BlockID, opts AttestationRightsOptions) ([]AttestationRight, error) {
+	query := "/chains/main/blocks/" + id.String() + "/helpers/endorsing_rights"
+	rights, err := d.attestationRights(query, opts)
+	if err != nil {
+		return rights, errors.Wrapf(err, "could not get endorsing rights '%s'", query)
+	}
+	return rights, nil
+}
+
+func (d *DelegateService) attestationRights(query string, opts AttestationRightsOptions) ([]AttestationRight, error) {
+	resp, err := d.tzclient.Get(query, rightsParams(opts.Delegate, opts.ConsensusKey, opts.Cycle, opts.Level))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		AttestationRight
+		// the rights before Ithaca are given per delegate
+		Delegate string `json:"delegate"`
+		Slots    []int  `json:"slots"`
+	}
+	if err := json.Unmarshal(resp, &entries); err != nil {
+		return nil, err
+	}
+
+	var rights []AttestationRight
+	levels := make(map[int]int)
+	for _, entry := range entries {
+		if entry.Delegate == "" {
+			rights = append(rights, entry.AttestationRight)
+			continue
+		}
+
+		delegate := AttestingDelegate{Delegate: entry.Delegate, AttestationPower: len(entry.Slots), Slots: entry.Slots}
+		for i, slot := range entry.Slots {
+			if i == 0 || slot < delegate.FirstSlot {
+				delegate.FirstSlot = slot
+			}
+		}
+		if i, ok := levels[entry.Level]; ok {
+			rights[i].Delegates = append(rights[i].Delegates, delegate)
+			continue
+		}
+		levels[entry.Level] = len(rights)
+		rights = append(rights, AttestationRight{Level: entry.Level, Delegates: []AttestingDelegate{delegate}, EstimatedTime: entry.EstimatedTime})
+	}
+	return rights, nil
+}
+
+// rightsParams returns the query parameters filtering rights
+func rightsParams(delegate, consensusKey string, cycle, level int) map[string]string {
+	params := make(map[string]string)
+	if delegate != "" {
+		params["delegate"] = delegate
+	}
+	if consensusKey != "" {
+		params["consensus_key"] = consensusKey
+	}
+	if cycle > 0 {
+		params["cycle"] = strconv.Itoa(cycle)
+	}
+	if level > 0 {
+		params["level"] = strconv.Itoa(level)
+	}
+	return params
+}
@@ -0,0 +1,153 @@
+package delegate
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
+)
+
+func Test_BakingRights(t *testing.T) {
+	maxRound := 0
+	cases := []struct {
+		name       string
+		opts       BakingRightsOptions
+		body       string
+		wantParams map[string]string
+		want       []BakingRight
+	}{
+		{
+			name:       "Default",
+			body:       `[{"level":5000001,"delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","round":0,"estimated_time":"2024-01-01T00:00:08Z","consensus_key":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}]`,
+			wantParams: map[string]string{},
+			want: []BakingRight{
+				{
+					Level:         5000001,
+					Delegate:      "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+					Round:         0,
+					ConsensusKey:  "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+					EstimatedTime: time.Date(2024, 1, 1, 0, 0, 8, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "Filters",
+			opts: BakingRightsOptions{
+				Delegate:     "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+				ConsensusKey: "tz4Quq6VcCeJVmCknjzTX5kcrhUzcMruoavF",
+				Cycle:        750,
+				Level:        5000001,
+				MaxRound:     &maxRound,
+				All:          true,
+			},
+			body: `[{"level":5000001,"delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","round":0,"consensus_key":"tz4Quq6VcCeJVmCknjzTX5kcrhUzcMruoavF"}]`,
+			wantParams: map[string]string{
+				"delegate":      "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+				"consensus_key": "tz4Quq6VcCeJVmCknjzTX5kcrhUzcMruoavF",
+				"cycle":         "750",
+				"level":         "5000001",
+				"max_round":     "0",
+				"all":           "true",
+			},
+			want: []BakingRight{
+				{
+					Level:        5000001,
+					Delegate:     "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+					ConsensusKey: "tz4Quq6VcCeJVmCknjzTX5kcrhUzcMruoavF",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(tc.body)}
+			rights, err := NewDelegateService(client, nil, nil, nil, network.Constants{}).BakingRights(blockid.Head(), tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/baking_rights")
+			assert.DeepEqual(t, client.Params, tc.wantParams)
+			assert.DeepEqual(t, rights, tc.want)
+		})
+	}
+}
+
+func Test_AttestationRights(t *testing.T) {
+	cases := []struct {
+		name      string
+		endorsing bool
+		body      string
+		wantPath  string
+		want      []AttestationRight
+	}{
+		{
+			name:     "Attestation rights",
+			body:     `[{"level":5000001,"delegates":[{"delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","first_slot":3,"attestation_power":12,"consensus_key":"tz4Quq6VcCeJVmCknjzTX5kcrhUzcMruoavF"}],"estimated_time":"2024-01-01T00:00:08Z"}]`,
+			wantPath: "/chains/main/blocks/head/helpers/attestation_rights",
+			want: []AttestationRight{
+				{
+					Level: 5000001,
+					Delegates: []AttestingDelegate{
+						{Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", FirstSlot: 3, AttestationPower: 12, ConsensusKey: "tz4Quq6VcCeJVmCknjzTX5kcrhUzcMruoavF"},
+					},
+					EstimatedTime: time.Date(2024, 1, 1, 0, 0, 8, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:      "Endorsing rights from Ithaca",
+			endorsing: true,
+			body:      `[{"level":2500001,"delegates":[{"delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","first_slot":3,"endorsing_power":12}]}]`,
+			wantPath:  "/chains/main/blocks/head/helpers/endorsing_rights",
+			want: []AttestationRight{
+				{
+					Level: 2500001,
+					Delegates: []AttestingDelegate{
+						{Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", FirstSlot: 3, AttestationPower: 12},
+					},
+				},
+			},
+		},
+		{
+			name:      "Endorsing rights before Ithaca",
+			endorsing: true,
+			body:      `[{"level":1500001,"delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","slots":[7,2,30]},{"level":1500001,"delegate":"tz1S8MNvuFEUsWgjHvi3AxibRBf388NhT1q2","slots":[0]},{"level":1500002,"delegate":"tz1S8MNvuFEUsWgjHvi3AxibRBf388NhT1q2","slots":[5,1]}]`,
+			wantPath:  "/chains/main/blocks/head/helpers/endorsing_rights",
+			want: []AttestationRight{
+				{
+					Level: 1500001,
+					Delegates: []AttestingDelegate{
+						{Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", FirstSlot: 2, AttestationPower: 3, Slots: []int{7, 2, 30}},
+						{Delegate: "tz1S8MNvuFEUsWgjHvi3AxibRBf388NhT1q2", FirstSlot: 0, AttestationPower: 1, Slots: []int{0}},
+					},
+				},
+				{
+					Level: 1500002,
+					Delegates: []AttestingDelegate{
+						{Delegate: "tz1S8MNvuFEUsWgjHvi3AxibRBf388NhT1q2", FirstSlot: 1, AttestationPower: 2, Slots: []int{5, 1}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: []byte(tc.body)}
+			service := NewDelegateService(client, nil, nil, nil, network.Constants{})
+			opts := AttestationRightsOptions{Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Cycle: 750}
+
+			get := service.AttestationRights
+			if tc.endorsing {
+				get = service.EndorsingRights
+			}
+			rights, err := get(blockid.Head(), opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, tc.wantPath)
+			assert.DeepEqual(t, client.Params, map[string]string{"delegate": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "cycle": "750"})
+			assert.DeepEqual(t, rights, tc.want)
+		})
+	}
+}
@@ -0,0 +1,433 @@
+// Package forge encodes operations to the binary format their source signs and the node injects,
+// so operations can be signed without trusting the forge RPC of a node.
+package forge
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Tags of the kinds of operation contents
+const (
+	tagSeedNonceRevelation = 1
+	tagActivateAccount     = 4
+	tagDrainDelegate       = 9
+
+	tagReveal      = 107
+	tagTransaction = 108
+	tagOrigination = 109
+	tagDelegation  = 110
+
+	tagRegisterGlobalConstant = 111
+	tagIncreasePaidStorage    = 113
+	tagUpdateConsensusKey     = 114
+	tagTransferTicket         = 158
+)
+
+var (
+	// Prefixes of the public key hashes, public keys and contracts other than implicit accounts, in the order of their tags
+	publicKeyHashPrefixes = []base58.Prefix{base58.Tz1, base58.Tz2, base58.Tz3, base58.Tz4}
+	publicKeyPrefixes     = []base58.Prefix{base58.Edpk, base58.Sppk, base58.P2pk, base58.BLpk}
+	contractPrefixes      = []base58.Prefix{base58.KT1, base58.Txr1, base58.Sr1}
+
+	// Entrypoints with a dedicated tag, every other entrypoint is encoded by name
+	entrypointTags = map[string]byte{
+		"default":                 0,
+		"root":                    1,
+		"do":                      2,
+		"set_delegate":            3,
+		"remove_delegate":         4,
+		"deposit":                 5,
+		"stake":                   6,
+		"unstake":                 7,
+		"finalize_unstake":        8,
+		"set_delegate_parameters": 9,
+	}
+)
+
+// Operation forges the operation made of contents on top of branch, the bytes to sign.
+// Only reveal, transaction, origination, delegation, register_global_constant, increase_paid_storage,
+// update_consensus_key, drain_delegate, activate_account, seed_nonce_revelation and transfer_ticket contents are supported.
+func Operation(branch string, contents ...block.Contents) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeBranch(&buf, branch); err != nil {
+		return nil, errors.Wrap(err, "could not forge operation")
+	}
+
+	for _, c := range contents {
+		forged, err := Contents(c)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not forge operation")
+		}
+		buf.Write(forged)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Contents forges a single content of an operation.
+func Contents(c block.Contents) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	switch c.Kind {
+	case block.KindReveal:
+		buf.WriteByte(tagReveal)
+		if err = writeManager(&buf, c); err == nil {
+			err = writePublicKey(&buf, c.PublicKey)
+		}
+	case block.KindTransaction:
+		buf.WriteByte(tagTransaction)
+		if err = writeManager(&buf, c); err == nil {
+			err = writeTransaction(&buf, c)
+		}
+	case block.KindOrigination:
+		buf.WriteByte(tagOrigination)
+		if err = writeManager(&buf, c); err == nil {
+			err = writeOrigination(&buf, c)
+		}
+	case block.KindDelegation:
+		buf.WriteByte(tagDelegation)
+		if err = writeManager(&buf, c); err == nil {
+			err = writeOptionalPublicKeyHash(&buf, c.Delegate)
+		}
+	case block.KindRegisterGlobalConstant:
+		buf.WriteByte(tagRegisterGlobalConstant)
+		if err = writeManager(&buf, c); err == nil {
+			err = errors.Wrap(writeSizedMicheline(&buf, c.Value), "invalid value")
+		}
+	case block.KindIncreasePaidStorage:
+		buf.WriteByte(tagIncreasePaidStorage)
+		if err = writeManager(&buf, c); err == nil {
+			writeInt(&buf, big.NewInt(int64(c.Amount)))
+			err = errors.Wrap(writeOriginatedContract(&buf, c.Destination), "invalid destination")
+		}
+	case block.KindUpdateConsensusKey:
+		buf.WriteByte(tagUpdateConsensusKey)
+		if err = writeManager(&buf, c); err == nil {
+			err = writeUpdateConsensusKey(&buf, c)
+		}
+	case block.KindDrainDelegate:
+		buf.WriteByte(tagDrainDelegate)
+		err = writeDrainDelegate(&buf, c)
+	case block.KindActivateAccount:
+		buf.WriteByte(tagActivateAccount)
+		err = writeActivateAccount(&buf, c)
+	case block.KindSeedNonceRevelation:
+		buf.WriteByte(tagSeedNonceRevelation)
+		err = writeSeedNonceRevelation(&buf, c)
+	case block.KindTransferTicket:
+		buf.WriteByte(tagTransferTicket)
+		if err = writeManager(&buf, c); err == nil {
+			err = writeTransferTicket(&buf, c)
+		}
+	default:
+		err = errors.Errorf("unsupported kind '%s'", c.Kind)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not forge %s", c.Kind)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeManager(buf *bytes.Buffer, c block.Contents) error {
+	if err := writePublicKeyHash(buf, c.Source); err != nil {
+		return errors.Wrap(err, "invalid source")
+	}
+	if err := writeMutez(buf, c.Fee); err != nil {
+		return errors.Wrap(err, "invalid fee")
+	}
+	for _, n := range []struct {
+		name  string
+		value tez.Zarith
+	}{{"counter", c.Counter}, {"gas limit", c.GasLimit}, {"storage limit", c.StorageLimit}} {
+		if err := writeNat(buf, n.value.Big()); err != nil {
+			return errors.Wrapf(err, "invalid %s", n.name)
+		}
+	}
+	return nil
+}
+
+func writeTransaction(buf *bytes.Buffer, c block.Contents) error {
+	if err := writeMutez(buf, c.Amount); err != nil {
+		return errors.Wrap(err, "invalid amount")
+	}
+	if err := writeDestination(buf, c.Destination); err != nil {
+		return errors.Wrap(err, "invalid destination")
+	}
+
+	if c.Parameters == nil || isDefaultUnit(c.Parameters) {
+		buf.WriteByte(0)
+		return nil
+	}
+	buf.WriteByte(255)
+
+	if tag, ok := entrypointTags[c.Parameters.Entrypoint]; ok {
+		buf.WriteByte(tag)
+	} else {
+		if len(c.Parameters.Entrypoint) == 0 || len(c.Parameters.Entrypoint) > 31 {
+			return errors.Errorf("invalid entrypoint '%s'", c.Parameters.Entrypoint)
+		}
+		buf.WriteByte(255)
+		buf.WriteByte(byte(len(c.Parameters.Entrypoint)))
+		buf.WriteString(c.Parameters.Entrypoint)
+	}
+
+	return errors.Wrap(writeSizedMicheline(buf, c.Parameters.Value), "invalid parameters")
+}
+
+func writeOrigination(buf *bytes.Buffer, c block.Contents) error {
+	if err := writeMutez(buf, c.Balance); err != nil {
+		return errors.Wrap(err, "invalid balance")
+	}
+	if err := writeOptionalPublicKeyHash(buf, c.Delegate); err != nil {
+		return errors.Wrap(err, "invalid delegate")
+	}
+	if c.Script == nil {
+		return errors.New("missing script")
+	}
+	if err := writeSizedMicheline(buf, c.Script.Code); err != nil {
+		return errors.Wrap(err, "invalid code")
+	}
+	return errors.Wrap(writeSizedMicheline(buf, c.Script.Storage), "invalid storage")
+}
+
+func writeUpdateConsensusKey(buf *bytes.Buffer, c block.Contents) error {
+	if err := writePublicKey(buf, c.Pk); err != nil {
+		return err
+	}
+
+	// The proof of possession is only given for BLS keys
+	if c.Proof == "" {
+		buf.WriteByte(0)
+		return nil
+	}
+	proof, err := base58.Decode(c.Proof, base58.BLsig)
+	if err != nil {
+		return errors.Wrapf(err, "invalid proof '%s'", c.Proof)
+	}
+	buf.WriteByte(255)
+	buf.Write(proof)
+	return nil
+}
+
+func writeDrainDelegate(buf *bytes.Buffer, c block.Contents) error {
+	if err := writePublicKeyHash(buf, c.ConsensusKey); err != nil {
+		return errors.Wrap(err, "invalid consensus key")
+	}
+	if err := writePublicKeyHash(buf, c.Delegate); err != nil {
+		return errors.Wrap(err, "invalid delegate")
+	}
+	return errors.Wrap(writePublicKeyHash(buf, c.Destination), "invalid destination")
+}
+
+// writeActivateAccount writes the ed25519 public key hash of the account to activate, without its tag, and its secret
+func writeActivateAccount(buf *bytes.Buffer, c block.Contents) error {
+	pkh, err := base58.Decode(c.Pkh, base58.Tz1)
+	if err != nil {
+		return errors.Wrapf(err, "invalid public key hash '%s'", c.Pkh)
+	}
+	secret, err := hex.DecodeString(c.Secret)
+	if err != nil || len(secret) != 20 {
+		return errors.Errorf("invalid secret '%s'", c.Secret)
+	}
+	buf.Write(pkh)
+	buf.Write(secret)
+	return nil
+}
+
+// writeSeedNonceRevelation writes the level of the block committing to the nonce and the nonce
+func writeSeedNonceRevelation(buf *bytes.Buffer, c block.Contents) error {
+	nonce, err := hex.DecodeString(c.Nonce)
+	if err != nil || len(nonce) != nonceLength {
+		return errors.Errorf("invalid nonce '%s'", c.Nonce)
+	}
+	var level [4]byte
+	binary.BigEndian.PutUint32(level[:], uint32(c.Level))
+	buf.Write(level[:])
+	buf.Write(nonce)
+	return nil
+}
+
+func writeTransferTicket(buf *bytes.Buffer, c block.Contents) error {
+	if err := writeSizedMicheline(buf, c.TicketContents); err != nil {
+		return errors.Wrap(err, "invalid ticket contents")
+	}
+	if err := writeSizedMicheline(buf, c.TicketType); err != nil {
+		return errors.Wrap(err, "invalid ticket type")
+	}
+	if err := writeContractID(buf, c.TicketTicketer); err != nil {
+		return errors.Wrap(err, "invalid ticketer")
+	}
+	if err := writeNat(buf, c.TicketAmount.Big()); err != nil {
+		return errors.Wrap(err, "invalid ticket amount")
+	}
+	if err := writeContractID(buf, c.Destination); err != nil {
+		return errors.Wrap(err, "invalid destination")
+	}
+
+	entrypoint := c.Entrypoint
+	if entrypoint == "" {
+		entrypoint = "default"
+	}
+	writeSized(buf, []byte(entrypoint))
+	return nil
+}
+
+// isDefaultUnit reports if p are the parameters of a plain transfer, which are left out of the bytes
+func isDefaultUnit(p *block.Parameters) bool {
+	if p.Entrypoint != "" && p.Entrypoint != "default" {
+		return false
+	}
+	var value struct {
+		Prim   string            `json:"prim"`
+		Args   []json.RawMessage `json:"args"`
+		Annots []string          `json:"annots"`
+	}
+	if json.Unmarshal(p.Value, &value) != nil {
+		return false
+	}
+	return value.Prim == "Unit" && len(value.Args) == 0 && len(value.Annots) == 0
+}
+
+func writeBranch(buf *bytes.Buffer, branch string) error {
+	decoded, err := base58.Decode(branch, base58.BlockHash)
+	if err != nil {
+		return errors.Wrapf(err, "invalid branch '%s'", branch)
+	}
+	buf.Write(decoded)
+	return nil
+}
+
+func writePublicKeyHash(buf *bytes.Buffer, address string) error {
+	pkh, err := PublicKeyHash(address)
+	if err != nil {
+		return err
+	}
+	buf.Write(pkh)
+	return nil
+}
+
+// PublicKeyHash forges the address of an implicit account, the tag of the curve of its key followed by its hash,
+// e.g. to build the bytes a remote signer authenticates.
+func PublicKeyHash(address string) ([]byte, error) {
+	for tag, prefix := range publicKeyHashPrefixes {
+		if decoded, err := base58.Decode(address, prefix); err == nil {
+			return append([]byte{byte(tag)}, decoded...), nil
+		}
+	}
+	return nil, errors.Errorf("invalid public key hash '%s'", address)
+}
+
+func writeOptionalPublicKeyHash(buf *bytes.Buffer, address string) error {
+	if address == "" {
+		buf.WriteByte(0)
+		return nil
+	}
+	buf.WriteByte(255)
+	return writePublicKeyHash(buf, address)
+}
+
+func writePublicKey(buf *bytes.Buffer, key string) error {
+	for tag, prefix := range publicKeyPrefixes {
+		if decoded, err := base58.Decode(key, prefix); err == nil {
+			buf.WriteByte(byte(tag))
+			buf.Write(decoded)
+			return nil
+		}
+	}
+	return errors.Errorf("invalid public key '%s'", key)
+}
+
+// writeContractID writes an implicit account or an originated contract
+func writeContractID(buf *bytes.Buffer, address string) error {
+	return writeContract(buf, address, contractPrefixes[:1])
+}
+
+// writeDestination writes an implicit account, an originated contract or a rollup, the destinations of
+// transactions and the addresses of Michelson
+func writeDestination(buf *bytes.Buffer, address string) error {
+	return writeContract(buf, address, contractPrefixes)
+}
+
+// writeContract writes an implicit account or an address of prefixes, tagged with their position
+func writeContract(buf *bytes.Buffer, address string, prefixes []base58.Prefix) error {
+	if pkh, err := PublicKeyHash(address); err == nil {
+		buf.WriteByte(0)
+		buf.Write(pkh)
+		return nil
+	}
+
+	for tag, prefix := range prefixes {
+		if decoded, err := base58.Decode(address, prefix); err == nil {
+			buf.WriteByte(byte(tag + 1))
+			buf.Write(decoded)
+			buf.WriteByte(0)
+			return nil
+		}
+	}
+	return errors.Errorf("invalid contract '%s'", address)
+}
+
+// writeOriginatedContract writes an originated contract, the contract ID of a KT1 address
+func writeOriginatedContract(buf *bytes.Buffer, address string) error {
+	if !strings.HasPrefix(address, "KT1") {
+		return errors.Errorf("invalid originated contract '%s'", address)
+	}
+	return writeContractID(buf, address)
+}
+
+func writeMutez(buf *bytes.Buffer, m tez.Mutez) error {
+	return writeNat(buf, big.NewInt(int64(m)))
+}
+
+// writeNat writes n as a zarith natural, 7 bits per byte with the high bit set on every byte but the last
+func writeNat(buf *bytes.Buffer, n *big.Int) error {
+	if n.Sign() < 0 {
+		return errors.Errorf("negative value %s", n)
+	}
+	writeUnsigned(buf, new(big.Int).Set(n))
+	return nil
+}
+
+func writeUnsigned(buf *bytes.Buffer, n *big.Int) {
+	low := new(big.Int)
+	for {
+		b := byte(low.And(n, big.NewInt(0x7f)).Int64())
+		n.Rsh(n, 7)
+		if n.Sign() == 0 {
+			buf.WriteByte(b)
+			return
+		}
+		buf.WriteByte(b | 0x80)
+	}
+}
+
+// writeSizedMicheline writes the binary encoding of the micheline expression v preceded by its size
+func writeSizedMicheline(buf *bytes.Buffer, v json.RawMessage) error {
+	var expr bytes.Buffer
+	if err := writeMicheline(&expr, v); err != nil {
+		return err
+	}
+	writeSized(buf, expr.Bytes())
+	return nil
+}
+
+func writeSized(buf *bytes.Buffer, v []byte) {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(v)))
+	buf.Write(size[:])
+	buf.Write(v)
+}
@@ -0,0 +1,195 @@
+package forge
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+)
+
+const branch = "BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"
+
+// The expected bytes follow the binary operation encoding of octez-codec (alpha.operation.unsigned),
+// a plain transfer leaves out its default Unit parameters like octez does.
+var vectors = []struct {
+	name     string
+	contents string
+	want     string
+}{
+	{
+		name:     "Reveal",
+		contents: `[{"kind":"reveal","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1268","counter":"1","gas_limit":"10000","storage_limit":"0","public_key":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6b0002298c03ed7d454a101eb7022bc95f7e5f41ac78f40901904e00004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f",
+	},
+	{
+		name:     "Transaction",
+		contents: `[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1420","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"1000000","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c0002298c03ed7d454a101eb7022bc95f7e5f41ac788c0b02c35000c0843d000038896346da37c3ea531638153423a5632bd4b2c200",
+	},
+	{
+		name:     "Transaction with default unit parameters",
+		contents: `[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1420","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"1000000","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1","parameters":{"entrypoint":"default","value":{"prim":"Unit"}}}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c0002298c03ed7d454a101eb7022bc95f7e5f41ac788c0b02c35000c0843d000038896346da37c3ea531638153423a5632bd4b2c200",
+	},
+	{
+		name:     "Transaction from tz3 with a large amount",
+		contents: `[{"kind":"transaction","source":"tz3WXYtyDUNL91qfiCJtVUX746QpNv5i5ve5","fee":"0","counter":"123456789","gas_limit":"1040000","storage_limit":"60000","amount":"1099511627776","destination":"tz2BFTyPeYRzxd5aiBchbXN3WCZhx7BqbMBq"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c026fde46af0356a0476dae4e4600172dc9309b3aa400959aef3a80bd3fe0d40380808080802000012031d34105bb1243b973e06139193221110a0ca100",
+	},
+	{
+		name:     "Contract call with named entrypoint",
+		contents: `[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"3000","counter":"7","gas_limit":"20000","storage_limit":"300","amount":"0","destination":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","parameters":{"entrypoint":"transfer","value":{"prim":"Pair","args":[{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},{"prim":"Pair","args":[{"string":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},{"int":"100"}]}]}}}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78b81707a09c01ac020001e3ac156340d2f0e92e2f9734be187fd05fe1c34a00ffff087472616e736665720000005907070100000024747a314b715470455a37596f62375162504534487934576f38664847384c684b785a537807070100000024747a31516e79376a564d4769775272503946696b524b39356a544e624a6366665470783100a401",
+	},
+	{
+		name:     "Contract call with tagged entrypoint",
+		contents: `[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"3000","counter":"8","gas_limit":"20000","storage_limit":"0","amount":"0","destination":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","parameters":{"entrypoint":"do","value":[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PUSH","args":[{"prim":"mutez","annots":[":fee"]},{"int":"-1"}]},{"prim":"PUSH","args":[{"prim":"bytes"},{"bytes":"cafe"}]}]}}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c0002298c03ed7d454a101eb7022bc95f7e5f41ac78b81708a09c01000001e3ac156340d2f0e92e2f9734be187fd05fe1c34a00ff0200000024020000001f0320053d036d0743046a000000043a6665650041074303690a00000002cafe",
+	},
+	{
+		name:     "Delegation",
+		contents: `[{"kind":"delegation","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1257","counter":"3","gas_limit":"1100","storage_limit":"0","delegate":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6e0002298c03ed7d454a101eb7022bc95f7e5f41ac78e90903cc0800ff0038896346da37c3ea531638153423a5632bd4b2c2",
+	},
+	{
+		name:     "Delegation withdrawal",
+		contents: `[{"kind":"delegation","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1257","counter":"4","gas_limit":"1100","storage_limit":"0"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6e0002298c03ed7d454a101eb7022bc95f7e5f41ac78e90904cc080000",
+	},
+	{
+		name:     "Origination",
+		contents: `[{"kind":"origination","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1500","counter":"5","gas_limit":"2000","storage_limit":"500","balance":"2500000","delegate":"tz2BFTyPeYRzxd5aiBchbXN3WCZhx7BqbMBq","script":{"code":[{"prim":"parameter","args":[{"prim":"nat","annots":["%amount"]}]},{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"int"},{"prim":"int"},{"prim":"int"}]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}],"storage":{"prim":"Pair","args":[{"int":"1"},{"int":"-42"},{"int":"4096"}]}}}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6d0002298c03ed7d454a101eb7022bc95f7e5f41ac78dc0b05d00ff403a0cb9801ff012031d34105bb1243b973e06139193221110a0ca1000000350200000030050004620000000725616d6f756e740501096500000006035b035b035b00000000050202000000080317053d036d0342000000110907000000070001006a00804000000000",
+	},
+	{
+		name:     "Paid storage increase",
+		contents: `[{"kind":"increase_paid_storage","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"500","counter":"8","gas_limit":"1100","storage_limit":"200","amount":"200","destination":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df710002298c03ed7d454a101eb7022bc95f7e5f41ac78f40308cc08c801880301e3ac156340d2f0e92e2f9734be187fd05fe1c34a00",
+	},
+	{
+		name:     "Consensus key update",
+		contents: `[{"kind":"update_consensus_key","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"400","counter":"9","gas_limit":"1000","storage_limit":"0","pk":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df720002298c03ed7d454a101eb7022bc95f7e5f41ac78900309e80700004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f00",
+	},
+	{
+		// the public key of the BLS test vector of the Ethereum consensus specs and its proof of possession
+		name:     "Consensus key update to a BLS key with its proof",
+		contents: `[{"kind":"update_consensus_key","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"400","counter":"9","gas_limit":"1000","storage_limit":"0","pk":"BLpk1uRSGT38VAQFPjMZdWA5YsAiq9fJPF7rPizQzWckTw4cjMQy8rNrpWBf3PrmzrzeTLrEyHto","proof":"BLsigBgaNxEx8KiEN6KPbZ38F5qGfoTPXWLwiKog9phqGB1uAwfB8Vyf1vKMNTQKpQCLQYz4574frGrh5cMseEtaxGknKP9z7H6SekAP8mfNNGxfYNHNFYK5b13nTdAuDNPpGPiq21kmRN"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df720002298c03ed7d454a101eb7022bc95f7e5f41ac78900309e8070003a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79affb803eb0ed93ea10224a73b6b9c725796be9f5fefd215ef7a5b97234cc956cf6870db6127b7e4d824ec62276078e787db05584ce1adbf076bc0808ca0f15b73d59060254b25393d95dfc7abe3cda566842aaedf50bbb062aae1bbb6ef3b1f77e1",
+	},
+	{
+		name:     "Delegate drain",
+		contents: `[{"kind":"drain_delegate","consensus_key":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1","delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df090038896346da37c3ea531638153423a5632bd4b2c20002298c03ed7d454a101eb7022bc95f7e5f41ac780038896346da37c3ea531638153423a5632bd4b2c2",
+	},
+	{
+		name:     "Account activation",
+		contents: `[{"kind":"activate_account","pkh":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1","secret":"41f98b15efc63fa893d61d7d6eee4a2ce9427ac4"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df0438896346da37c3ea531638153423a5632bd4b2c241f98b15efc63fa893d61d7d6eee4a2ce9427ac4",
+	},
+	{
+		name:     "Global constant registration",
+		contents: `[{"kind":"register_global_constant","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1000","counter":"5","gas_limit":"2000","storage_limit":"100","value":[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]}]}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6f0002298c03ed7d454a101eb7022bc95f7e5f41ac78e80705d00f640000000b02000000060320053d036d",
+	},
+	{
+		name:     "Seed nonce revelation",
+		contents: `[{"kind":"seed_nonce_revelation","level":5000000,"nonce":"2e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df01004c4b402e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1",
+	},
+	{
+		name:     "Ticket transfer",
+		contents: `[{"kind":"transfer_ticket","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1500","counter":"7","gas_limit":"5000","storage_limit":"100","ticket_contents":{"string":"ticket"},"ticket_ty":{"prim":"string"},"ticket_ticketer":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","ticket_amount":"100","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1","entrypoint":"default"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df9e0002298c03ed7d454a101eb7022bc95f7e5f41ac78dc0b078827640000000b01000000067469636b657400000002036801e3ac156340d2f0e92e2f9734be187fd05fe1c34a0064000038896346da37c3ea531638153423a5632bd4b2c20000000764656661756c74",
+	},
+	{
+		name:     "Reveal and transaction batch",
+		contents: `[{"kind":"reveal","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1268","counter":"1","gas_limit":"10000","storage_limit":"0","public_key":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"},{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1420","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"1000000","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}]`,
+		want:     "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6b0002298c03ed7d454a101eb7022bc95f7e5f41ac78f40901904e00004798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f6c0002298c03ed7d454a101eb7022bc95f7e5f41ac788c0b02c35000c0843d000038896346da37c3ea531638153423a5632bd4b2c200",
+	},
+}
+
+func Test_Operation(t *testing.T) {
+	for _, tc := range vectors {
+		t.Run(tc.name, func(t *testing.T) {
+			var contents []block.Contents
+			assert.NilError(t, json.Unmarshal([]byte(tc.contents), &contents))
+
+			forged, err := Operation(branch, contents...)
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(forged), tc.want)
+		})
+	}
+}
+
+func Test_OperationErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		branch   string
+		contents string
+	}{
+		{
+			name:     "Invalid branch",
+			branch:   "BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrZ",
+			contents: `[]`,
+		},
+		{
+			name:     "Unsupported kind",
+			branch:   branch,
+			contents: `[{"kind":"ballot","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","period":1,"proposal":"PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo","ballot":"yay"}]`,
+		},
+		{
+			name:     "Contract as source",
+			branch:   branch,
+			contents: `[{"kind":"delegation","source":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","fee":"1257","counter":"4","gas_limit":"1100","storage_limit":"0"}]`,
+		},
+		{
+			name:     "Negative fee",
+			branch:   branch,
+			contents: `[{"kind":"delegation","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"-1","counter":"4","gas_limit":"1100","storage_limit":"0"}]`,
+		},
+		{
+			name:     "Unknown primitive",
+			branch:   branch,
+			contents: `[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"0","counter":"1","gas_limit":"0","storage_limit":"0","amount":"0","destination":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","parameters":{"entrypoint":"default","value":{"prim":"Nothing"}}}]`,
+		},
+		{
+			name:     "Paid storage increase of an implicit account",
+			branch:   branch,
+			contents: `[{"kind":"increase_paid_storage","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"500","counter":"8","gas_limit":"1100","storage_limit":"200","amount":"200","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}]`,
+		},
+		{
+			name:     "Activation of a tz2 account",
+			branch:   branch,
+			contents: `[{"kind":"activate_account","pkh":"tz2BFTyPeYRzxd5aiBchbXN3WCZhx7BqbMBq","secret":"41f98b15efc63fa893d61d7d6eee4a2ce9427ac4"}]`,
+		},
+		{
+			name:     "Seed nonce revelation with a short nonce",
+			branch:   branch,
+			contents: `[{"kind":"seed_nonce_revelation","level":5000000,"nonce":"2e5e5f3b"}]`,
+		},
+		{
+			name:     "Ticket transfer to a rollup",
+			branch:   branch,
+			contents: `[{"kind":"transfer_ticket","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1500","counter":"7","gas_limit":"5000","storage_limit":"100","ticket_contents":{"string":"ticket"},"ticket_ty":{"prim":"string"},"ticket_ticketer":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","ticket_amount":"100","destination":"sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf","entrypoint":"default"}]`,
+		},
+		{
+			name:     "Origination without script",
+			branch:   branch,
+			contents: `[{"kind":"origination","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"0","counter":"1","gas_limit":"0","storage_limit":"0","balance":"0"}]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var contents []block.Contents
+			assert.NilError(t, json.Unmarshal([]byte(tc.contents), &contents))
+
+			_, err := Operation(tc.branch, contents...)
+			assert.Assert(t, err != nil)
+		})
+	}
+}
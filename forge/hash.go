@@ -0,0 +1,31 @@
+package forge
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+// OperationHash computes the hash of the signed operation bytes signed, the hash the node returns on injection.
+// It is known before the operation is injected, so an injection can be retried or tracked in the mempool by its hash.
+func OperationHash(signed []byte) string {
+	hash := blake2b.Sum256(signed)
+	return crypto.B58cencode(hash[:], crypto.Prefix_o)
+}
+
+// nonceLength is the length of the seed nonces bakers commit to
+const nonceLength = 32
+
+// SeedNonceHash computes the hash of the seed nonce in hexadecimal, the seed_nonce_hash a baker commits to in the
+// header of a block. The nonce must be revealed with a seed_nonce_revelation during the next cycle.
+func SeedNonceHash(nonce string) (string, error) {
+	b, err := hex.DecodeString(nonce)
+	if err != nil || len(b) != nonceLength {
+		return "", errors.Errorf("invalid nonce '%s'", nonce)
+	}
+	hash := blake2b.Sum256(b)
+	return crypto.B58cencode(hash[:], crypto.Prefix_nce), nil
+}
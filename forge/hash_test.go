@@ -0,0 +1,62 @@
+package forge
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_OperationHash(t *testing.T) {
+	cases := []struct {
+		name   string
+		signed string
+		want   string
+	}{
+		{
+			name:   "Signed transaction",
+			signed: "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c0002298c03ed7d454a101eb7022bc95f7e5f41ac788c0b02c35000c0843d000038896346da37c3ea531638153423a5632bd4b2c200000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+			want:   "op6uodLJk1ZzRCZq7mV7rJhX8T2TdZV5GRex6jWtiZkneexBiaL",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			signed, err := hex.DecodeString(tc.signed)
+			assert.NilError(t, err)
+			assert.Equal(t, OperationHash(signed), tc.want)
+		})
+	}
+}
+
+func Test_SeedNonceHash(t *testing.T) {
+	cases := []struct {
+		name    string
+		nonce   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "Nonce",
+			nonce: "2e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1",
+			want:  "nceVPRVUY9uAu3bUPdNkxyC1uKbvYqY5B3HGGTSXGv2u6P9P9LceT",
+		},
+		{
+			name:    "Short nonce",
+			nonce:   "2e5e5f3b",
+			wantErr: "invalid nonce '2e5e5f3b'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := SeedNonceHash(tc.nonce)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, tc.want)
+		})
+	}
+}
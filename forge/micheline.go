@@ -0,0 +1,49 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+// node is a micheline expression in its JSON form, a sequence when it is a JSON array
+type node struct {
+	Prim   string            `json:"prim"`
+	Args   []json.RawMessage `json:"args"`
+	Annots []string          `json:"annots"`
+	Int    *string           `json:"int"`
+	String *string           `json:"string"`
+	Bytes  *string           `json:"bytes"`
+}
+
+// writeMicheline writes the binary encoding of the micheline expression v
+func writeMicheline(buf *bytes.Buffer, v json.RawMessage) error {
+	n, err := micheline.Unmarshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := micheline.MarshalBinary(n)
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
+
+// writeInt writes i as a zarith integer, the first byte holds the sign and 6 bits, the others 7 bits
+func writeInt(buf *bytes.Buffer, i *big.Int) {
+	abs := new(big.Int).Abs(i)
+	first := byte(new(big.Int).And(abs, big.NewInt(0x3f)).Int64())
+	if i.Sign() < 0 {
+		first |= 0x40
+	}
+	abs.Rsh(abs, 6)
+	if abs.Sign() == 0 {
+		buf.WriteByte(first)
+		return
+	}
+	buf.WriteByte(first | 0x80)
+	writeUnsigned(buf, abs)
+}
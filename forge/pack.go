@@ -0,0 +1,289 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+// packPrefix is the tag of data packed by the PACK instruction
+const packPrefix = 5
+
+// signaturePrefixes are the prefixes of the signatures packed as bytes
+var signaturePrefixes = []base58.Prefix{base58.Edsig, base58.Spsig, base58.P2sig, base58.Sig, base58.BLsig}
+
+// Pack packs the Micheline data of type typ like the PACK instruction does. The data is converted to the
+// optimized form first: addresses, keys, key hashes, signatures and chain ids become bytes, timestamps
+// become integers and combs of pairs become nested pairs.
+func Pack(data, typ json.RawMessage) ([]byte, error) {
+	optimized, err := optimize(data, typ)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not pack data")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(packPrefix)
+	if err := writeMicheline(&buf, optimized); err != nil {
+		return nil, errors.Wrap(err, "could not pack data")
+	}
+	return buf.Bytes(), nil
+}
+
+// ScriptExprHash computes the expr hash of packed data, the hash big maps index their values by.
+func ScriptExprHash(packed []byte) string {
+	hash := blake2b.Sum256(packed)
+	return crypto.B58cencode(hash[:], crypto.Prefix_expr)
+}
+
+// GlobalConstantHash computes the address of the global constant registering the Micheline value, the
+// expr hash of its binary encoding. Constants are referenced in scripts by their address with the constant
+// primitive, e.g. {"prim":"constant","args":[{"string":"expr..."}]}.
+func GlobalConstantHash(value json.RawMessage) (string, error) {
+	var buf bytes.Buffer
+	if err := writeMicheline(&buf, value); err != nil {
+		return "", errors.Wrap(err, "could not hash global constant")
+	}
+	return ScriptExprHash(buf.Bytes()), nil
+}
+
+// optimize converts the data of type typ to the optimized form packed by the node
+func optimize(data, typ json.RawMessage) (json.RawMessage, error) {
+	var t node
+	if err := json.Unmarshal(typ, &t); err != nil {
+		return nil, errors.Wrapf(err, "invalid type '%s'", string(typ))
+	}
+
+	data = bytes.TrimSpace(data)
+	var items []json.RawMessage
+	var d node
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, errors.Wrapf(err, "invalid data '%s'", string(data))
+		}
+	} else if err := json.Unmarshal(data, &d); err != nil {
+		return nil, errors.Wrapf(err, "invalid data '%s'", string(data))
+	}
+
+	switch t.Prim {
+	case "pair":
+		return optimizePair(data, items, d, t)
+	case "option":
+		if len(t.Args) != 1 || (d.Prim != "Some" && d.Prim != "None") {
+			return nil, optimizeMismatch(data, t)
+		}
+		if d.Prim == "None" {
+			return data, nil
+		}
+		if len(d.Args) != 1 {
+			return nil, optimizeMismatch(data, t)
+		}
+		arg, err := optimize(d.Args[0], t.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return primExpr(d.Prim, arg)
+	case "or":
+		if len(t.Args) != 2 || len(d.Args) != 1 || (d.Prim != "Left" && d.Prim != "Right") {
+			return nil, optimizeMismatch(data, t)
+		}
+		argType := t.Args[0]
+		if d.Prim == "Right" {
+			argType = t.Args[1]
+		}
+		arg, err := optimize(d.Args[0], argType)
+		if err != nil {
+			return nil, err
+		}
+		return primExpr(d.Prim, arg)
+	case "list", "set":
+		if items == nil || len(t.Args) != 1 {
+			return nil, optimizeMismatch(data, t)
+		}
+		return optimizeItems(items, func(item json.RawMessage) (json.RawMessage, error) {
+			return optimize(item, t.Args[0])
+		})
+	case "map", "big_map":
+		if d.Int != nil && t.Prim == "big_map" {
+			return data, nil
+		}
+		if items == nil || len(t.Args) != 2 {
+			return nil, optimizeMismatch(data, t)
+		}
+		return optimizeItems(items, func(item json.RawMessage) (json.RawMessage, error) {
+			var elt node
+			if err := json.Unmarshal(item, &elt); err != nil || elt.Prim != "Elt" || len(elt.Args) != 2 {
+				return nil, errors.Errorf("invalid map element '%s'", string(item))
+			}
+			key, err := optimize(elt.Args[0], t.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			value, err := optimize(elt.Args[1], t.Args[1])
+			if err != nil {
+				return nil, err
+			}
+			return primExpr("Elt", key, value)
+		})
+	case "int", "nat", "mutez":
+		if d.Int == nil || (t.Prim != "int" && strings.HasPrefix(*d.Int, "-")) {
+			return nil, optimizeMismatch(data, t)
+		}
+		return data, nil
+	case "string":
+		if d.String == nil {
+			return nil, optimizeMismatch(data, t)
+		}
+		return data, nil
+	case "bytes":
+		if d.Bytes == nil {
+			return nil, optimizeMismatch(data, t)
+		}
+		return data, nil
+	case "bool":
+		if d.Prim != "True" && d.Prim != "False" {
+			return nil, optimizeMismatch(data, t)
+		}
+		return data, nil
+	case "unit":
+		if d.Prim != "Unit" {
+			return nil, optimizeMismatch(data, t)
+		}
+		return data, nil
+	case "address", "contract", "key_hash", "key", "signature", "chain_id", "timestamp":
+		// data already in the optimized form is packed as it is
+		if d.Bytes != nil || (d.Int != nil && t.Prim == "timestamp") {
+			return data, nil
+		}
+		if d.String == nil {
+			return nil, optimizeMismatch(data, t)
+		}
+	default:
+		// lambdas, tickets and the other types are packed as they are
+		return data, nil
+	}
+	s := *d.String
+
+	var buf bytes.Buffer
+	switch t.Prim {
+	case "address", "contract":
+		address, entrypoint := s, ""
+		if i := strings.Index(s, "%"); i >= 0 {
+			address, entrypoint = s[:i], s[i+1:]
+		}
+		if err := writeDestination(&buf, address); err != nil {
+			return nil, err
+		}
+		if entrypoint != "" && entrypoint != "default" {
+			buf.WriteString(entrypoint)
+		}
+	case "key_hash":
+		if err := writePublicKeyHash(&buf, s); err != nil {
+			return nil, err
+		}
+	case "key":
+		if err := writePublicKey(&buf, s); err != nil {
+			return nil, err
+		}
+	case "signature":
+		if err := writeSignature(&buf, s); err != nil {
+			return nil, err
+		}
+	case "chain_id":
+		decoded, err := base58.Decode(s, base58.ChainID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid chain id '%s'", s)
+		}
+		buf.Write(decoded)
+	case "timestamp":
+		timestamp, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid timestamp '%s'", s)
+		}
+		return json.Marshal(map[string]string{"int": strconv.FormatInt(timestamp.Unix(), 10)})
+	}
+	return json.Marshal(map[string]string{"bytes": hex.EncodeToString(buf.Bytes())})
+}
+
+// optimizeMismatch returns the error of data not matching the type t, see mismatch
+func optimizeMismatch(data json.RawMessage, t node) error {
+	n, err := micheline.Unmarshal(data)
+	if err != nil {
+		return errors.Wrapf(err, "invalid data '%s'", string(data))
+	}
+	return mismatch(n, micheline.NewPrim(t.Prim))
+}
+
+// optimizePair converts a pair, written as a Pair or a sequence, to nested pairs of two elements
+func optimizePair(data json.RawMessage, items []json.RawMessage, d node, t node) (json.RawMessage, error) {
+	args := items
+	if items == nil {
+		if d.Prim != "Pair" {
+			return nil, optimizeMismatch(data, t)
+		}
+		args = d.Args
+	}
+	if len(args) < 2 || len(t.Args) < 2 {
+		return nil, optimizeMismatch(data, t)
+	}
+
+	left, err := optimize(args[0], t.Args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rightData, rightType := args[1], t.Args[1]
+	if len(args) > 2 {
+		if rightData, err = json.Marshal(args[1:]); err != nil {
+			return nil, err
+		}
+	}
+	if len(t.Args) > 2 {
+		if rightType, err = primExpr("pair", t.Args[1:]...); err != nil {
+			return nil, err
+		}
+	}
+	right, err := optimize(rightData, rightType)
+	if err != nil {
+		return nil, err
+	}
+	return primExpr("Pair", left, right)
+}
+
+func optimizeItems(items []json.RawMessage, optimizeItem func(json.RawMessage) (json.RawMessage, error)) (json.RawMessage, error) {
+	optimized := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		o, err := optimizeItem(item)
+		if err != nil {
+			return nil, err
+		}
+		optimized = append(optimized, o)
+	}
+	return json.Marshal(optimized)
+}
+
+func primExpr(prim string, args ...json.RawMessage) (json.RawMessage, error) {
+	return json.Marshal(struct {
+		Prim string            `json:"prim"`
+		Args []json.RawMessage `json:"args,omitempty"`
+	}{prim, args})
+}
+
+func writeSignature(buf *bytes.Buffer, signature string) error {
+	for _, prefix := range signaturePrefixes {
+		if decoded, err := base58.Decode(signature, prefix); err == nil {
+			buf.Write(decoded)
+			return nil
+		}
+	}
+	return errors.Errorf("invalid signature '%s'", signature)
+}
@@ -0,0 +1,233 @@
+package forge
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Pack(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		typ      string
+		want     string
+		wantHash string
+	}{
+		{
+			name:     "Nat",
+			data:     `{"int":"0"}`,
+			typ:      `{"prim":"nat"}`,
+			want:     "050000",
+			wantHash: "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC",
+		},
+		{
+			name:     "String",
+			data:     `{"string":"hello"}`,
+			typ:      `{"prim":"string"}`,
+			want:     "05010000000568656c6c6f",
+			wantHash: "exprtsjEVVZk3Gm82U9wEs8kvwRiQwUT7zipJwvCeFMNsApe2tQ15s",
+		},
+		{
+			name:     "Address",
+			data:     `{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}`,
+			typ:      `{"prim":"address"}`,
+			want:     "050a00000016000002298c03ed7d454a101eb7022bc95f7e5f41ac78",
+			wantHash: "expruH3qgknRBJVLVkwdzf6wfBxd7Y1uqNxr7zuMFxTC12e5PacLfv",
+		},
+		{
+			name:     "Address with entrypoint",
+			data:     `{"string":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t%transfer"}`,
+			typ:      `{"prim":"address"}`,
+			want:     "050a0000001e01e3ac156340d2f0e92e2f9734be187fd05fe1c34a007472616e73666572",
+			wantHash: "exprufbU8Z8WauPqGnyZ9MZoUmTBHbiYuxJcyFrAcbmEU6DUwC5V35",
+		},
+		{
+			name:     "Key hash",
+			data:     `{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}`,
+			typ:      `{"prim":"key_hash"}`,
+			want:     "050a000000150002298c03ed7d454a101eb7022bc95f7e5f41ac78",
+			wantHash: "expru6fotvwsd3SnSHp5qhcUTHAmd1hqQA24srgXcGRRaCA2gPLYkf",
+		},
+		{
+			name:     "Pair",
+			data:     `{"prim":"Pair","args":[{"string":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t"},{"int":"7"}]}`,
+			typ:      `{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}`,
+			want:     "0507070a0000001601e3ac156340d2f0e92e2f9734be187fd05fe1c34a000007",
+			wantHash: "exprtiCqgPSwWEYMfz4xff6CHLc47373eFcnMQqTgDa3Y44j9nTonh",
+		},
+		{
+			name:     "Comb of pairs",
+			data:     `[{"int":"1"},{"string":"a"},{"bytes":"ff"}]`,
+			typ:      `{"prim":"pair","args":[{"prim":"nat"},{"prim":"string"},{"prim":"bytes"}]}`,
+			want:     "050707000107070100000001610a00000001ff",
+			wantHash: "expruUHkDUxAhzdi6Bt4zk4r5WHzpdarQ3cSLkYKM7ALUBZrKBceCM",
+		},
+		{
+			name:     "Timestamp",
+			data:     `{"string":"2019-09-26T10:59:51Z"}`,
+			typ:      `{"prim":"timestamp"}`,
+			want:     "0500a7e8e4d80b",
+			wantHash: "expruPr6RXBVA966PMQk4kFKyiph9smByS7tFn1hKE6RwAv77VxPes",
+		},
+		{
+			name:     "Lambda with IS_IMPLICIT_ACCOUNT",
+			data:     `[{"prim":"IS_IMPLICIT_ACCOUNT"}]`,
+			typ:      `{"prim":"lambda","args":[{"prim":"address"},{"prim":"option","args":[{"prim":"key_hash"}]}]}`,
+			want:     "050200000002039e",
+			wantHash: "expru3zbQGVtd4UxSRhdrNbtdQDXnhtASz6ymPywoEWhFudF2yVLNq",
+		},
+		{
+			name:     "Lambda with INDEX_ADDRESS",
+			data:     `[{"prim":"INDEX_ADDRESS"}]`,
+			typ:      `{"prim":"lambda","args":[{"prim":"address"},{"prim":"option","args":[{"prim":"nat"}]}]}`,
+			want:     "050200000002039f",
+			wantHash: "expruHAnL7Nu37qeYjguFFAeGBbYcVgnfPz9bLSz91zq9KHqZxDC9H",
+		},
+		{
+			name:     "Lambda with GET_TOTAL_VOTING_POWER",
+			data:     `[{"prim":"GET_TOTAL_VOTING_POWER"}]`,
+			typ:      `{"prim":"lambda","args":[{"prim":"unit"},{"prim":"nat"}]}`,
+			want:     "05020000000203a0",
+			wantHash: "exprv32Mu2qcyNP4pA7iyc441fRifR4MUDDcDMdwR8Tn3CRLkSiiyA",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			packed, err := Pack([]byte(tc.data), []byte(tc.typ))
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(packed), tc.want)
+			assert.Equal(t, ScriptExprHash(packed), tc.wantHash)
+		})
+	}
+}
+
+func Test_PackErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    string
+		typ     string
+		wantErr string
+	}{
+		{
+			name: "Invalid address",
+			data: `{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSy"}`,
+			typ:  `{"prim":"address"}`,
+		},
+		{
+			name:    "Pair of one element",
+			data:    `{"prim":"Pair","args":[{"int":"1"}]}`,
+			typ:     `{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"}]}`,
+			wantErr: "Pair does not match type pair",
+		},
+		{
+			name:    "Pair of another primitive",
+			data:    `{"prim":"Left","args":[{"int":"1"}]}`,
+			typ:     `{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"}]}`,
+			wantErr: "Left does not match type pair",
+		},
+		{
+			name:    "Option without argument",
+			data:    `{"prim":"Some"}`,
+			typ:     `{"prim":"option","args":[{"prim":"nat"}]}`,
+			wantErr: "Some does not match type option",
+		},
+		{
+			name:    "Or of two arguments",
+			data:    `{"prim":"Left","args":[{"int":"1"},{"int":"2"}]}`,
+			typ:     `{"prim":"or","args":[{"prim":"nat"},{"prim":"nat"}]}`,
+			wantErr: "Left does not match type or",
+		},
+		{
+			name:    "List of a single element",
+			data:    `{"int":"1"}`,
+			typ:     `{"prim":"list","args":[{"prim":"nat"}]}`,
+			wantErr: "int 1 does not match type list",
+		},
+		{
+			name:    "Map of a single element",
+			data:    `{"prim":"Elt","args":[{"int":"1"},{"int":"2"}]}`,
+			typ:     `{"prim":"map","args":[{"prim":"nat"},{"prim":"nat"}]}`,
+			wantErr: "Elt does not match type map",
+		},
+		{
+			name:    "Address as an int",
+			data:    `{"int":"1"}`,
+			typ:     `{"prim":"address"}`,
+			wantErr: "int 1 does not match type address",
+		},
+		{
+			name:    "Key as a sequence",
+			data:    `[]`,
+			typ:     `{"prim":"key"}`,
+			wantErr: "sequence does not match type key",
+		},
+		{
+			name:    "Signature as a primitive",
+			data:    `{"prim":"Unit"}`,
+			typ:     `{"prim":"signature"}`,
+			wantErr: "Unit does not match type signature",
+		},
+		{
+			name:    "Negative nat",
+			data:    `{"int":"-1"}`,
+			typ:     `{"prim":"nat"}`,
+			wantErr: "int -1 does not match type nat",
+		},
+		{
+			name:    "Bool as a string",
+			data:    `{"string":"True"}`,
+			typ:     `{"prim":"bool"}`,
+			wantErr: "string does not match type bool",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Pack([]byte(tc.data), []byte(tc.typ))
+			assert.Assert(t, err != nil)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_GlobalConstantHash(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Code",
+			value: `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]}]`,
+			want:  "exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF",
+		},
+		{
+			name:  "Type",
+			value: `{"prim":"int"}`,
+			want:  "expruu5BTdW7ajqJ9XPTF3kgcV78pRiaBW3Gq31mgp3WSYjjUBYxre",
+		},
+		{
+			name:    "Unknown primitive",
+			value:   `{"prim":"Nothing"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := GlobalConstantHash([]byte(tc.value))
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "could not hash global constant")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, tc.want)
+		})
+	}
+}
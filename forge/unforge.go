@@ -0,0 +1,550 @@
+package forge
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Lengths of the signatures of operations, BLS signatures of tz4 accounts are longer than the others
+const (
+	signatureLength    = 64
+	blsSignatureLength = 96
+)
+
+// Unforge decodes the bytes of an unsigned operation, as forged by Operation, into its branch and contents.
+// Only reveal, transaction, origination, delegation, register_global_constant, increase_paid_storage,
+// update_consensus_key, drain_delegate, activate_account, seed_nonce_revelation and transfer_ticket contents are supported.
+func Unforge(b []byte) (string, []block.Contents, error) {
+	r := reader{b: b}
+	branch, err := r.next(32)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not unforge operation, invalid branch")
+	}
+
+	var contents []block.Contents
+	for r.len() > 0 {
+		c, err := r.contents()
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "could not unforge content %d", len(contents))
+		}
+		contents = append(contents, c)
+	}
+
+	encoded, err := base58.Encode(base58.BlockHash, branch)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not unforge operation, invalid branch")
+	}
+	return encoded, contents, nil
+}
+
+// UnforgeSigned decodes the bytes of a signed operation, e.g. read from the mempool, into its branch,
+// contents and signature. The signature is a generic signature, or a BLS signature when the operation
+// is signed by a tz4 account, which is told by the source of the contents.
+func UnforgeSigned(b []byte) (string, []block.Contents, string, error) {
+	if len(b) < signatureLength {
+		return "", nil, "", errors.New("could not unforge signed operation, too short")
+	}
+
+	// the error of the generic signature is returned when neither length matches
+	var err error
+	for _, length := range []int{signatureLength, blsSignatureLength} {
+		if len(b) < length {
+			continue
+		}
+		branch, contents, unforgeErr := Unforge(b[:len(b)-length])
+		if unforgeErr != nil {
+			if err == nil {
+				err = unforgeErr
+			}
+			continue
+		}
+
+		bls := strings.HasPrefix(signerOf(contents), "tz4")
+		if bls != (length == blsSignatureLength) {
+			if err == nil {
+				err = errors.Errorf("could not unforge signed operation, signature of %d bytes for '%s'", length, signerOf(contents))
+			}
+			continue
+		}
+		prefix := base58.Sig
+		if bls {
+			prefix = base58.BLsig
+		}
+		signature, err := base58.Encode(prefix, b[len(b)-length:])
+		if err != nil {
+			return "", nil, "", errors.Wrap(err, "could not unforge signed operation")
+		}
+		return branch, contents, signature, nil
+	}
+	return "", nil, "", err
+}
+
+// signerOf returns the address signing contents, the consensus key of a drain_delegate or the source of the
+// first manager operation, empty when the contents have neither
+func signerOf(contents []block.Contents) string {
+	for _, c := range contents {
+		if c.Kind == block.KindDrainDelegate {
+			return c.ConsensusKey
+		}
+		if c.Source != "" {
+			return c.Source
+		}
+	}
+	return ""
+}
+
+// reader reads the binary encoding of an operation
+type reader struct {
+	b   []byte
+	pos int
+}
+
+func (r *reader) len() int {
+	return len(r.b) - r.pos
+}
+
+func (r *reader) next(n int) ([]byte, error) {
+	if n < 0 || r.len() < n {
+		return nil, errors.Errorf("unexpected end of data at byte %d", r.pos)
+	}
+	v := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return v, nil
+}
+
+func (r *reader) readByte() (byte, error) {
+	v, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return v[0], nil
+}
+
+func (r *reader) contents() (block.Contents, error) {
+	tag, err := r.readByte()
+	if err != nil {
+		return block.Contents{}, err
+	}
+
+	// Contents without the fields of manager operations
+	var c block.Contents
+	switch tag {
+	case tagDrainDelegate:
+		c.Kind = block.KindDrainDelegate
+		err = r.drainDelegate(&c)
+	case tagActivateAccount:
+		c.Kind = block.KindActivateAccount
+		err = r.activateAccount(&c)
+	case tagSeedNonceRevelation:
+		c.Kind = block.KindSeedNonceRevelation
+		err = r.seedNonceRevelation(&c)
+	}
+	if c.Kind != "" {
+		if err != nil {
+			return c, errors.Wrapf(err, "invalid %s", c.Kind)
+		}
+		return c, nil
+	}
+
+	switch tag {
+	case tagReveal:
+		c.Kind = block.KindReveal
+	case tagTransaction:
+		c.Kind = block.KindTransaction
+	case tagOrigination:
+		c.Kind = block.KindOrigination
+	case tagDelegation:
+		c.Kind = block.KindDelegation
+	case tagRegisterGlobalConstant:
+		c.Kind = block.KindRegisterGlobalConstant
+	case tagIncreasePaidStorage:
+		c.Kind = block.KindIncreasePaidStorage
+	case tagUpdateConsensusKey:
+		c.Kind = block.KindUpdateConsensusKey
+	case tagTransferTicket:
+		c.Kind = block.KindTransferTicket
+	default:
+		return c, errors.Errorf("unsupported tag %d", tag)
+	}
+
+	if err := r.manager(&c); err != nil {
+		return c, err
+	}
+
+	switch tag {
+	case tagReveal:
+		c.PublicKey, err = r.publicKey()
+	case tagTransaction:
+		err = r.transaction(&c)
+	case tagOrigination:
+		err = r.origination(&c)
+	case tagDelegation:
+		c.Delegate, err = r.optionalPublicKeyHash()
+	case tagRegisterGlobalConstant:
+		c.Value, err = r.sizedMicheline()
+	case tagIncreasePaidStorage:
+		err = r.increasePaidStorage(&c)
+	case tagUpdateConsensusKey:
+		err = r.updateConsensusKey(&c)
+	case tagTransferTicket:
+		err = r.transferTicket(&c)
+	}
+	if err != nil {
+		return c, errors.Wrapf(err, "invalid %s", c.Kind)
+	}
+
+	return c, nil
+}
+
+func (r *reader) manager(c *block.Contents) error {
+	var err error
+	if c.Source, err = r.publicKeyHash(); err != nil {
+		return errors.Wrap(err, "invalid source")
+	}
+	if c.Fee, err = r.mutez(); err != nil {
+		return errors.Wrap(err, "invalid fee")
+	}
+	for _, n := range []struct {
+		name  string
+		value *tez.Zarith
+	}{{"counter", &c.Counter}, {"gas limit", &c.GasLimit}, {"storage limit", &c.StorageLimit}} {
+		i, err := r.nat()
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s", n.name)
+		}
+		*n.value = tez.NewZarithFromBig(i)
+	}
+	return nil
+}
+
+func (r *reader) transaction(c *block.Contents) error {
+	var err error
+	if c.Amount, err = r.mutez(); err != nil {
+		return errors.Wrap(err, "invalid amount")
+	}
+	if c.Destination, err = r.destination(); err != nil {
+		return errors.Wrap(err, "invalid destination")
+	}
+
+	present, err := r.readByte()
+	if err != nil || present == 0 {
+		return err
+	}
+
+	var params block.Parameters
+	tag, err := r.readByte()
+	if err != nil {
+		return errors.Wrap(err, "invalid entrypoint")
+	}
+	if tag == 255 {
+		size, err := r.readByte()
+		if err != nil {
+			return errors.Wrap(err, "invalid entrypoint")
+		}
+		name, err := r.next(int(size))
+		if err != nil {
+			return errors.Wrap(err, "invalid entrypoint")
+		}
+		params.Entrypoint = string(name)
+	} else {
+		for name, t := range entrypointTags {
+			if t == tag {
+				params.Entrypoint = name
+			}
+		}
+		if params.Entrypoint == "" {
+			return errors.Errorf("unknown entrypoint tag %d", tag)
+		}
+	}
+
+	if params.Value, err = r.sizedMicheline(); err != nil {
+		return errors.Wrap(err, "invalid parameters")
+	}
+	c.Parameters = &params
+	return nil
+}
+
+func (r *reader) origination(c *block.Contents) error {
+	var err error
+	if c.Balance, err = r.mutez(); err != nil {
+		return errors.Wrap(err, "invalid balance")
+	}
+	if c.Delegate, err = r.optionalPublicKeyHash(); err != nil {
+		return errors.Wrap(err, "invalid delegate")
+	}
+
+	var script block.Script
+	if script.Code, err = r.sizedMicheline(); err != nil {
+		return errors.Wrap(err, "invalid code")
+	}
+	if script.Storage, err = r.sizedMicheline(); err != nil {
+		return errors.Wrap(err, "invalid storage")
+	}
+	c.Script = &script
+	return nil
+}
+
+func (r *reader) increasePaidStorage(c *block.Contents) error {
+	amount, err := r.integer()
+	if err != nil {
+		return errors.Wrap(err, "invalid amount")
+	}
+	if !amount.IsInt64() {
+		return errors.Errorf("amount %s out of range", amount)
+	}
+	c.Amount = tez.Mutez(amount.Int64())
+
+	if c.Destination, err = r.contractID(); err != nil {
+		return errors.Wrap(err, "invalid destination")
+	}
+	if !strings.HasPrefix(c.Destination, "KT1") {
+		return errors.Errorf("invalid destination '%s', not an originated contract", c.Destination)
+	}
+	return nil
+}
+
+func (r *reader) updateConsensusKey(c *block.Contents) error {
+	var err error
+	if c.Pk, err = r.publicKey(); err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+
+	present, err := r.readByte()
+	if err != nil || present == 0 {
+		return err
+	}
+	proof, err := r.next(base58.BLsig.Length)
+	if err != nil {
+		return errors.Wrap(err, "invalid proof")
+	}
+	c.Proof, err = base58.Encode(base58.BLsig, proof)
+	return errors.Wrap(err, "invalid proof")
+}
+
+func (r *reader) drainDelegate(c *block.Contents) error {
+	var err error
+	if c.ConsensusKey, err = r.publicKeyHash(); err != nil {
+		return errors.Wrap(err, "invalid consensus key")
+	}
+	if c.Delegate, err = r.publicKeyHash(); err != nil {
+		return errors.Wrap(err, "invalid delegate")
+	}
+	if c.Destination, err = r.publicKeyHash(); err != nil {
+		return errors.Wrap(err, "invalid destination")
+	}
+	return nil
+}
+
+func (r *reader) activateAccount(c *block.Contents) error {
+	pkh, err := r.next(base58.Tz1.Length)
+	if err != nil {
+		return errors.Wrap(err, "invalid public key hash")
+	}
+	if c.Pkh, err = base58.Encode(base58.Tz1, pkh); err != nil {
+		return errors.Wrap(err, "invalid public key hash")
+	}
+
+	secret, err := r.next(20)
+	if err != nil {
+		return errors.Wrap(err, "invalid secret")
+	}
+	c.Secret = hex.EncodeToString(secret)
+	return nil
+}
+
+func (r *reader) seedNonceRevelation(c *block.Contents) error {
+	level, err := r.next(4)
+	if err != nil {
+		return errors.Wrap(err, "invalid level")
+	}
+	c.Level = int(int32(binary.BigEndian.Uint32(level)))
+
+	nonce, err := r.next(nonceLength)
+	if err != nil {
+		return errors.Wrap(err, "invalid nonce")
+	}
+	c.Nonce = hex.EncodeToString(nonce)
+	return nil
+}
+
+func (r *reader) transferTicket(c *block.Contents) error {
+	var err error
+	if c.TicketContents, err = r.sizedMicheline(); err != nil {
+		return errors.Wrap(err, "invalid ticket contents")
+	}
+	if c.TicketType, err = r.sizedMicheline(); err != nil {
+		return errors.Wrap(err, "invalid ticket type")
+	}
+	if c.TicketTicketer, err = r.contractID(); err != nil {
+		return errors.Wrap(err, "invalid ticketer")
+	}
+	amount, err := r.nat()
+	if err != nil {
+		return errors.Wrap(err, "invalid ticket amount")
+	}
+	c.TicketAmount = tez.NewZarithFromBig(amount)
+	if c.Destination, err = r.contractID(); err != nil {
+		return errors.Wrap(err, "invalid destination")
+	}
+
+	entrypoint, err := r.sized()
+	if err != nil || !utf8.Valid(entrypoint) {
+		return errors.New("invalid entrypoint")
+	}
+	c.Entrypoint = string(entrypoint)
+	return nil
+}
+
+func (r *reader) publicKeyHash() (string, error) {
+	tag, err := r.readByte()
+	if err != nil {
+		return "", err
+	}
+	if int(tag) >= len(publicKeyHashPrefixes) {
+		return "", errors.Errorf("unknown public key hash tag %d", tag)
+	}
+	prefix := publicKeyHashPrefixes[tag]
+	hash, err := r.next(prefix.Length)
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(prefix, hash)
+}
+
+func (r *reader) optionalPublicKeyHash() (string, error) {
+	present, err := r.readByte()
+	if err != nil || present == 0 {
+		return "", err
+	}
+	return r.publicKeyHash()
+}
+
+func (r *reader) publicKey() (string, error) {
+	tag, err := r.readByte()
+	if err != nil {
+		return "", err
+	}
+	if int(tag) >= len(publicKeyPrefixes) {
+		return "", errors.Errorf("unknown public key tag %d", tag)
+	}
+	prefix := publicKeyPrefixes[tag]
+	key, err := r.next(prefix.Length)
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(prefix, key)
+}
+
+// contractID reads an implicit account or an originated contract
+func (r *reader) contractID() (string, error) {
+	return r.contract(contractPrefixes[:1])
+}
+
+// destination reads an implicit account, an originated contract or a rollup
+func (r *reader) destination() (string, error) {
+	return r.contract(contractPrefixes)
+}
+
+// contract reads an implicit account or an address of prefixes, tagged with their position
+func (r *reader) contract(prefixes []base58.Prefix) (string, error) {
+	tag, err := r.readByte()
+	if err != nil {
+		return "", err
+	}
+	if tag == 0 {
+		return r.publicKeyHash()
+	}
+
+	if int(tag) > len(prefixes) {
+		return "", errors.Errorf("unexpected contract tag %d", tag)
+	}
+	// the hash is followed by a padding byte
+	prefix := prefixes[tag-1]
+	hash, err := r.next(prefix.Length + 1)
+	if err != nil {
+		return "", err
+	}
+	if hash[prefix.Length] != 0 {
+		return "", errors.Errorf("invalid padding %d of contract", hash[prefix.Length])
+	}
+	return base58.Encode(prefix, hash[:prefix.Length])
+}
+
+func (r *reader) mutez() (tez.Mutez, error) {
+	n, err := r.nat()
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsInt64() {
+		return 0, errors.Errorf("mutez %s out of range", n)
+	}
+	return tez.Mutez(n.Int64()), nil
+}
+
+// nat reads a zarith natural written by writeNat
+func (r *reader) nat() (*big.Int, error) {
+	n := new(big.Int)
+	for shift := uint(0); ; shift += 7 {
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		n.Or(n, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+		if b&0x80 == 0 {
+			return n, nil
+		}
+	}
+}
+
+// integer reads a zarith integer written by writeInt
+func (r *reader) integer() (*big.Int, error) {
+	first, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	i := big.NewInt(int64(first & 0x3f))
+	if first&0x80 != 0 {
+		rest, err := r.nat()
+		if err != nil {
+			return nil, err
+		}
+		i.Or(i, rest.Lsh(rest, 6))
+	}
+	if first&0x40 != 0 {
+		i.Neg(i)
+	}
+	return i, nil
+}
+
+func (r *reader) sized() ([]byte, error) {
+	size, err := r.next(4)
+	if err != nil {
+		return nil, err
+	}
+	return r.next(int(binary.BigEndian.Uint32(size)))
+}
+
+func (r *reader) sizedMicheline() (json.RawMessage, error) {
+	expr, err := r.sized()
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := micheline.UnmarshalBinary(expr)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(n)
+}
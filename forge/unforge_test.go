@@ -0,0 +1,156 @@
+package forge
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+func Test_Unforge(t *testing.T) {
+	for _, tc := range vectors {
+		t.Run(tc.name, func(t *testing.T) {
+			var want []block.Contents
+			assert.NilError(t, json.Unmarshal([]byte(tc.contents), &want))
+			for i := range want {
+				// Plain transfers are forged without their parameters
+				if want[i].Parameters != nil && isDefaultUnit(want[i].Parameters) {
+					want[i].Parameters = nil
+				}
+			}
+
+			forged, err := hex.DecodeString(tc.want)
+			assert.NilError(t, err)
+
+			unforgedBranch, contents, err := Unforge(forged)
+			assert.NilError(t, err)
+			assert.Equal(t, unforgedBranch, branch)
+
+			got, err := json.Marshal(contents)
+			assert.NilError(t, err)
+			expected, err := json.Marshal(want)
+			assert.NilError(t, err)
+			assert.Equal(t, string(got), string(expected))
+
+			reforged, err := Operation(unforgedBranch, contents...)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, reforged, forged)
+		})
+	}
+}
+
+func Test_UnforgeSigned(t *testing.T) {
+	forged, err := hex.DecodeString(vectors[1].want)
+	assert.NilError(t, err)
+	signed := append(forged, make([]byte, signatureLength)...)
+
+	unforgedBranch, contents, signature, err := UnforgeSigned(signed)
+	assert.NilError(t, err)
+	assert.Equal(t, unforgedBranch, branch)
+	assert.Equal(t, len(contents), 1)
+	assert.Equal(t, contents[0].Destination, "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1")
+	assert.Equal(t, signature[:3], "sig")
+
+	_, _, _, err = UnforgeSigned(forged[:10])
+	assert.Assert(t, err != nil)
+
+	_, _, _, err = UnforgeSigned(append(forged, make([]byte, blsSignatureLength)...))
+	assert.ErrorContains(t, err, "could not unforge")
+}
+
+func Test_UnforgeSignedBLS(t *testing.T) {
+	// a transaction from tz4TpX5Qb3w7xnnnwSpjFs7Kq35GC4qr3uMg, signed with a BLS signature of 96 bytes
+	forged, err := hex.DecodeString("eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c03ce62bf0b8c7812735e4e900845ea958f87b7c1238c0b02c35000c0843d000038896346da37c3ea531638153423a5632bd4b2c200")
+	assert.NilError(t, err)
+	sig := make([]byte, blsSignatureLength)
+	for i := range sig {
+		sig[i] = byte(i)
+	}
+
+	unforgedBranch, contents, signature, err := UnforgeSigned(append(forged, sig...))
+	assert.NilError(t, err)
+	assert.Equal(t, unforgedBranch, branch)
+	assert.Equal(t, len(contents), 1)
+	assert.Equal(t, contents[0].Source, "tz4TpX5Qb3w7xnnnwSpjFs7Kq35GC4qr3uMg")
+	assert.Equal(t, contents[0].Destination, "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1")
+	assert.Equal(t, signature, crypto.B58cencode(sig, crypto.Prefix_BLsig))
+
+	_, _, _, err = UnforgeSigned(append(forged, sig[:signatureLength]...))
+	assert.ErrorContains(t, err, "could not unforge")
+}
+
+func Test_UnforgeErrors(t *testing.T) {
+	forged, err := hex.DecodeString(vectors[1].want)
+	assert.NilError(t, err)
+
+	// the originated contract e3ac...c34a followed by its padding byte
+	contract := "01e3ac156340d2f0e92e2f9734be187fd05fe1c34a00"
+	call := vectorByName(t, "Contract call with named entrypoint")
+	ticket := vectorByName(t, "Ticket transfer")
+
+	cases := []struct {
+		name    string
+		input   []byte
+		wantErr string
+	}{
+		{name: "Short branch", input: forged[:20]},
+		{name: "Truncated content", input: forged[:len(forged)-5]},
+		{name: "Unsupported tag", input: append(append([]byte{}, forged[:32]...), 0x14)},
+		{name: "Trailing data", input: append(append([]byte{}, forged...), 0x6c)},
+		{
+			name:    "Contract padding",
+			input:   replaceHex(t, call, contract, contract[:len(contract)-2]+"01"),
+			wantErr: "invalid padding 1 of contract",
+		},
+		{
+			name:    "Rollup ticketer",
+			input:   replaceHex(t, ticket, contract, "03"+contract[2:]),
+			wantErr: "unexpected contract tag 3",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := Unforge(tc.input)
+			assert.Assert(t, err != nil)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_UnforgeRollupDestination(t *testing.T) {
+	// transactions may be sent to smart rollups, unlike tickets
+	var contents []block.Contents
+	assert.NilError(t, json.Unmarshal([]byte(`[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1420","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"0","destination":"sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf"}]`), &contents))
+	forged, err := Operation(branch, contents...)
+	assert.NilError(t, err)
+
+	_, unforged, err := Unforge(forged)
+	assert.NilError(t, err)
+	assert.Equal(t, unforged[0].Destination, "sr1Ghq66tYK9y3r8CC1Tf8i8m5nxh8nTvZEf")
+}
+
+func vectorByName(t *testing.T, name string) string {
+	for _, tc := range vectors {
+		if tc.name == name {
+			return tc.want
+		}
+	}
+	t.Fatalf("no vector %s", name)
+	return ""
+}
+
+// replaceHex returns the bytes of the hex string s with the first old replaced with new
+func replaceHex(t *testing.T, s, old, new string) []byte {
+	assert.Assert(t, strings.Contains(s, old))
+	b, err := hex.DecodeString(strings.Replace(s, old, new, 1))
+	assert.NilError(t, err)
+	return b
+}
@@ -0,0 +1,264 @@
+package forge
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+)
+
+// Unpack unpacks the data of type typ packed by Pack or the PACK instruction, like the UNPACK instruction does.
+// The data is returned in the readable form: addresses, keys, key hashes, signatures and chain ids are base58
+// strings and timestamps are RFC3339 strings.
+func Unpack(packed []byte, typ json.RawMessage) (json.RawMessage, error) {
+	if len(packed) == 0 || packed[0] != packPrefix {
+		return nil, errors.New("could not unpack data, not packed data")
+	}
+
+	data, err := micheline.UnmarshalBinary(packed[1:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unpack data")
+	}
+	t, err := micheline.Unmarshal(typ)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unpack data, invalid type")
+	}
+
+	readable, err := unoptimize(data, t)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unpack data")
+	}
+	return json.Marshal(readable)
+}
+
+// unoptimize converts the data of type typ from the optimized form to the readable form
+func unoptimize(data, typ micheline.Node) (micheline.Node, error) {
+	t, ok := typ.(micheline.Prim)
+	if !ok {
+		return nil, errors.Errorf("invalid type %T", typ)
+	}
+
+	switch t.Prim {
+	case "pair":
+		return unoptimizePair(data, t)
+	case "option":
+		d, ok := data.(micheline.Prim)
+		if !ok || len(t.Args) != 1 || (d.Prim != "Some" && d.Prim != "None") {
+			return nil, mismatch(data, t)
+		}
+		if d.Prim == "None" {
+			return d, nil
+		}
+		if len(d.Args) != 1 {
+			return nil, mismatch(data, t)
+		}
+		arg, err := unoptimize(d.Args[0], t.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return micheline.NewPrim("Some", arg), nil
+	case "or":
+		d, ok := data.(micheline.Prim)
+		if !ok || len(t.Args) != 2 || len(d.Args) != 1 || (d.Prim != "Left" && d.Prim != "Right") {
+			return nil, mismatch(data, t)
+		}
+		argType := t.Args[0]
+		if d.Prim == "Right" {
+			argType = t.Args[1]
+		}
+		arg, err := unoptimize(d.Args[0], argType)
+		if err != nil {
+			return nil, err
+		}
+		return micheline.NewPrim(d.Prim, arg), nil
+	case "list", "set":
+		items, ok := data.(micheline.Seq)
+		if !ok || len(t.Args) != 1 {
+			return nil, mismatch(data, t)
+		}
+		readable := make(micheline.Seq, 0, len(items))
+		for _, item := range items {
+			r, err := unoptimize(item, t.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			readable = append(readable, r)
+		}
+		return readable, nil
+	case "map", "big_map":
+		if _, ok := data.(micheline.Int); ok && t.Prim == "big_map" {
+			return data, nil
+		}
+		items, ok := data.(micheline.Seq)
+		if !ok || len(t.Args) != 2 {
+			return nil, mismatch(data, t)
+		}
+		readable := make(micheline.Seq, 0, len(items))
+		for _, item := range items {
+			elt, ok := item.(micheline.Prim)
+			if !ok || elt.Prim != "Elt" || len(elt.Args) != 2 {
+				return nil, errors.New("invalid map element")
+			}
+			key, err := unoptimize(elt.Args[0], t.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			value, err := unoptimize(elt.Args[1], t.Args[1])
+			if err != nil {
+				return nil, err
+			}
+			readable = append(readable, micheline.NewPrim("Elt", key, value))
+		}
+		return readable, nil
+	case "int", "nat", "mutez":
+		i, ok := data.(micheline.Int)
+		if !ok || (t.Prim != "int" && i.Value.Sign() < 0) {
+			return nil, mismatch(data, t)
+		}
+		return data, nil
+	case "string":
+		if _, ok := data.(micheline.String); !ok {
+			return nil, mismatch(data, t)
+		}
+		return data, nil
+	case "bytes":
+		if _, ok := data.(micheline.Bytes); !ok {
+			return nil, mismatch(data, t)
+		}
+		return data, nil
+	case "bool":
+		if d, ok := data.(micheline.Prim); !ok || (d.Prim != "True" && d.Prim != "False") {
+			return nil, mismatch(data, t)
+		}
+		return data, nil
+	case "unit":
+		if d, ok := data.(micheline.Prim); !ok || d.Prim != "Unit" {
+			return nil, mismatch(data, t)
+		}
+		return data, nil
+	case "timestamp":
+		switch d := data.(type) {
+		case micheline.String:
+			return d, nil
+		case micheline.Int:
+			if !d.Value.IsInt64() {
+				return d, nil
+			}
+			return micheline.String(time.Unix(d.Value.Int64(), 0).UTC().Format(time.RFC3339)), nil
+		}
+		return nil, mismatch(data, t)
+	case "address", "contract", "key_hash", "key", "signature", "chain_id":
+		switch d := data.(type) {
+		case micheline.String:
+			return d, nil
+		case micheline.Bytes:
+			s, err := readableBytes(d, t.Prim)
+			if err != nil {
+				return nil, err
+			}
+			return micheline.String(s), nil
+		}
+		return nil, mismatch(data, t)
+	}
+
+	// lambdas, tickets and the other types are returned as they are packed
+	return data, nil
+}
+
+// unoptimizePair converts a pair, packed as nested pairs or as a sequence for combs, to nested pairs
+func unoptimizePair(data micheline.Node, t micheline.Prim) (micheline.Node, error) {
+	var args []micheline.Node
+	switch d := data.(type) {
+	case micheline.Prim:
+		if d.Prim != "Pair" {
+			return nil, mismatch(data, t)
+		}
+		args = d.Args
+	case micheline.Seq:
+		args = d
+	}
+	if len(args) < 2 || len(t.Args) < 2 {
+		return nil, mismatch(data, t)
+	}
+
+	left, err := unoptimize(args[0], t.Args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rightData, rightType := args[1], t.Args[1]
+	if len(args) > 2 {
+		rightData = micheline.NewPrim("Pair", args[1:]...)
+	}
+	if len(t.Args) > 2 {
+		rightType = micheline.NewPrim("pair", t.Args[1:]...)
+	}
+	right, err := unoptimize(rightData, rightType)
+	if err != nil {
+		return nil, err
+	}
+	return micheline.NewPrim("Pair", left, right), nil
+}
+
+// readableBytes returns the base58 form of the optimized bytes of a value of type typ
+func readableBytes(b []byte, typ string) (string, error) {
+	r := reader{b: b}
+	var (
+		s   string
+		err error
+	)
+	switch typ {
+	case "address", "contract":
+		if s, err = r.destination(); err == nil && r.len() > 0 {
+			entrypoint, _ := r.next(r.len())
+			s += "%" + string(entrypoint)
+		}
+	case "key_hash":
+		s, err = r.publicKeyHash()
+	case "key":
+		s, err = r.publicKey()
+	case "signature":
+		switch len(b) {
+		case base58.Sig.Length:
+			s, err = base58.Encode(base58.Sig, b)
+		case base58.BLsig.Length:
+			s, err = base58.Encode(base58.BLsig, b)
+		default:
+			err = errors.Errorf("invalid length %d", len(b))
+		}
+		r.pos = len(b)
+	case "chain_id":
+		var id []byte
+		if id, err = r.next(base58.ChainID.Length); err == nil {
+			s, err = base58.Encode(base58.ChainID, id)
+		}
+	}
+	if err == nil && r.len() > 0 {
+		err = errors.Errorf("unexpected data at byte %d", r.pos)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %s", typ)
+	}
+	return s, nil
+}
+
+// mismatch returns the error of data not matching the type t
+func mismatch(data micheline.Node, t micheline.Prim) error {
+	kind := "data"
+	switch d := data.(type) {
+	case micheline.Int:
+		kind = "int " + d.Value.String()
+	case micheline.String:
+		kind = "string"
+	case micheline.Bytes:
+		kind = "bytes"
+	case micheline.Seq:
+		kind = "sequence"
+	case micheline.Prim:
+		kind = d.Prim
+	}
+	return errors.Errorf("%s does not match type %s", kind, t.Prim)
+}
@@ -0,0 +1,145 @@
+package forge
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Unpack(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		typ  string
+		want string
+	}{
+		{
+			name: "Nat",
+			data: `{"int":"0"}`,
+			typ:  `{"prim":"nat"}`,
+			want: `{"int":"0"}`,
+		},
+		{
+			name: "Address with entrypoint",
+			data: `{"string":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t%transfer"}`,
+			typ:  `{"prim":"address"}`,
+			want: `{"string":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t%transfer"}`,
+		},
+		{
+			name: "Key",
+			data: `{"string":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"}`,
+			typ:  `{"prim":"key"}`,
+			want: `{"string":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"}`,
+		},
+		{
+			name: "Chain id",
+			data: `{"string":"NetXdQprcVkpaWU"}`,
+			typ:  `{"prim":"chain_id"}`,
+			want: `{"string":"NetXdQprcVkpaWU"}`,
+		},
+		{
+			name: "Timestamp",
+			data: `{"string":"2019-09-26T10:59:51Z"}`,
+			typ:  `{"prim":"timestamp"}`,
+			want: `{"string":"2019-09-26T10:59:51Z"}`,
+		},
+		{
+			name: "Comb of pairs",
+			data: `[{"int":"1"},{"string":"a"},{"bytes":"ff"}]`,
+			typ:  `{"prim":"pair","args":[{"prim":"nat"},{"prim":"string"},{"prim":"bytes"}]}`,
+			want: `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"a"},{"bytes":"ff"}]}]}`,
+		},
+		{
+			name: "Map of key hashes",
+			data: `[{"prim":"Elt","args":[{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},{"prim":"Some","args":[{"prim":"Unit"}]}]}]`,
+			typ:  `{"prim":"map","args":[{"prim":"key_hash"},{"prim":"option","args":[{"prim":"unit"}]}]}`,
+			want: `[{"prim":"Elt","args":[{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},{"prim":"Some","args":[{"prim":"Unit"}]}]}]`,
+		},
+		{
+			name: "Or",
+			data: `{"prim":"Right","args":[{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}]}`,
+			typ:  `{"prim":"or","args":[{"prim":"nat"},{"prim":"address"}]}`,
+			want: `{"prim":"Right","args":[{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}]}`,
+		},
+		{
+			name: "Lambda with instructions since Oxford",
+			data: `[{"prim":"INDEX_ADDRESS"},{"prim":"ISNAT"}]`,
+			typ:  `{"prim":"lambda","args":[{"prim":"address"},{"prim":"option","args":[{"prim":"nat"}]}]}`,
+			want: `[{"prim":"INDEX_ADDRESS"},{"prim":"ISNAT"}]`,
+		},
+		{
+			name: "Lambda with GET_TOTAL_VOTING_POWER and IS_IMPLICIT_ACCOUNT",
+			data: `[{"prim":"DROP"},{"prim":"GET_TOTAL_VOTING_POWER"},{"prim":"DROP"},{"prim":"SENDER"},{"prim":"IS_IMPLICIT_ACCOUNT"}]`,
+			typ:  `{"prim":"lambda","args":[{"prim":"unit"},{"prim":"option","args":[{"prim":"key_hash"}]}]}`,
+			want: `[{"prim":"DROP"},{"prim":"GET_TOTAL_VOTING_POWER"},{"prim":"DROP"},{"prim":"SENDER"},{"prim":"IS_IMPLICIT_ACCOUNT"}]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			packed, err := Pack([]byte(tc.data), []byte(tc.typ))
+			assert.NilError(t, err)
+
+			data, err := Unpack(packed, []byte(tc.typ))
+			assert.NilError(t, err)
+			assert.Equal(t, string(data), tc.want)
+		})
+	}
+}
+
+func Test_UnpackBytes(t *testing.T) {
+	cases := []struct {
+		name    string
+		packed  string
+		typ     string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "Comb packed as a sequence",
+			packed: "05020000001000010100000001610a00000001ff0002",
+			typ:    `{"prim":"pair","args":[{"prim":"nat"},{"prim":"string"},{"prim":"bytes"},{"prim":"int"}]}`,
+			want:   `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"a"},{"prim":"Pair","args":[{"bytes":"ff"},{"int":"2"}]}]}]}`,
+		},
+		{
+			name:    "Negative nat",
+			packed:  "0505090043",
+			typ:     `{"prim":"option","args":[{"prim":"nat"}]}`,
+			wantErr: "could not unpack data: int -3 does not match type nat",
+		},
+		{
+			name:    "String as nat",
+			packed:  "05010000000568656c6c6f",
+			typ:     `{"prim":"nat"}`,
+			wantErr: "could not unpack data: string does not match type nat",
+		},
+		{
+			name:    "Truncated address",
+			packed:  "050a00000015000002298c03ed7d454a101eb7022bc95f7e5f41ac",
+			typ:     `{"prim":"address"}`,
+			wantErr: "could not unpack data: invalid address",
+		},
+		{
+			name:    "Not packed",
+			packed:  "0000",
+			typ:     `{"prim":"nat"}`,
+			wantErr: "could not unpack data, not packed data",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			packed, err := hex.DecodeString(tc.packed)
+			assert.NilError(t, err)
+
+			data, err := Unpack(packed, []byte(tc.typ))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(data), tc.want)
+		})
+	}
+}
@@ -1,3 +1,17 @@
 module github.com/DefinitelyNotAGoat/go-tezos/v2
 
 go 1.13
+
+require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
+	github.com/google/go-cmp v0.5.9 // indirect
+	github.com/kilic/bls12-381 v0.1.0
+	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.14.0
+	go.opentelemetry.io/otel v1.7.0
+	go.opentelemetry.io/otel/sdk v1.7.0
+	go.opentelemetry.io/otel/trace v1.7.0
+	golang.org/x/crypto v0.14.0
+	golang.org/x/sync v0.3.0
+	gotest.tools v2.2.0+incompatible
+)
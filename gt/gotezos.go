@@ -7,6 +7,7 @@ import (
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/contracts"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/cycle"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/delegate"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/mempool"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/node"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/operations"
@@ -15,7 +16,7 @@ import (
 )
 
 // GoTezos is the driver of the library, it inludes the several RPC services
-// like Block, SnapSHot, Cycle, Account, Delegate, Operations, Contract, and Network
+// like Block, SnapSHot, Cycle, Account, Delegate, Operations, Contract, Network, Node and Mempool
 type GoTezos struct {
 	Client    tzc.TezosClient
 	Constants network.Constants
@@ -28,13 +29,38 @@ type GoTezos struct {
 	Operation operations.TezosOperationsService
 	Contract  contracts.TezosContractsService
 	Node      node.TezosNodeService
+	Mempool   mempool.TezosMempoolService
 }
 
-// NewGoTezos is a constructor that returns a GoTezos object
-func NewGoTezos(URL string) (*GoTezos, error) {
+// NewGoTezos is a constructor that returns a GoTezos object, the client is configured with opts
+func NewGoTezos(URL string, opts ...tzc.Option) (*GoTezos, error) {
+	return newGoTezos(tzc.New(URL, opts...))
+}
+
+// NewGoTezosPool is a constructor that returns a GoTezos object backed by a pool of nodes.
+// Requests fail over between the nodes provided, see client.Pool.
+func NewGoTezosPool(URLs []string, opts ...tzc.Option) (*GoTezos, error) {
+	pool, err := tzc.NewPool(URLs, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create node pool")
+	}
+	return newGoTezos(pool)
+}
+
+// ForChain returns a GoTezos querying chain, e.g. "test" or a chain ID, through the same nodes.
+// The client must implement client.ChainSelector, which Client and Pool do.
+func (gt *GoTezos) ForChain(chain string) (*GoTezos, error) {
+	selector, ok := gt.Client.(tzc.ChainSelector)
+	if !ok {
+		return nil, errors.New("could not select chain, client does not support chain selection")
+	}
+	return newGoTezos(selector.ForChain(chain))
+}
+
+func newGoTezos(client tzc.TezosClient) (*GoTezos, error) {
 	gotezos := GoTezos{}
 
-	gotezos.Client = tzc.NewClient(URL)
+	gotezos.Client = client
 	gotezos.Network = network.NewNetworkService(gotezos.Client)
 	var err error
 	gotezos.Constants, err = gotezos.Network.GetConstants()
@@ -64,6 +90,7 @@ func NewGoTezos(URL string) (*GoTezos, error) {
 	gotezos.Operation = operations.NewOperationService(gotezos.Block, gotezos.Client)
 	gotezos.Contract = contracts.NewContractService(gotezos.Client)
 	gotezos.Node = node.NewNodeService(gotezos.Client)
+	gotezos.Mempool = mempool.NewMempoolService(gotezos.Client)
 
 	return &gotezos, nil
 }
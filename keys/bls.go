@@ -0,0 +1,232 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+// Domain separation tags of the BLS signatures of Tezos, the minimal public key size variant with the message
+// augmentation scheme for signatures and the proof of possession scheme for proofs.
+const (
+	blsSignatureDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_"
+	blsProofDST     = "BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+// Sizes of BLS keys and signatures
+const (
+	BLSSecretKeySize = 32
+	BLSPublicKeySize = 48
+	BLSSignatureSize = 96
+)
+
+// BLSPrivateKey is the BLS12-381 secret key of a tz4 account, a scalar of the curve.
+type BLSPrivateKey struct {
+	scalar *big.Int
+}
+
+// BLSPublicKey is the BLS12-381 public key of a tz4 account, a compressed point of G1.
+type BLSPublicKey []byte
+
+// GenerateBLS generates a new BLS key from a seed read from crypto/rand.
+func GenerateBLS() (BLSPrivateKey, error) {
+	seed := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return BLSPrivateKey{}, errors.Wrap(err, "could not generate BLS key")
+	}
+	return NewBLSFromSeed(seed)
+}
+
+// NewBLSFromSeed derives the BLS key of a seed of at least 32 bytes with the KeyGen of the BLS signature draft,
+// like octez-client does.
+func NewBLSFromSeed(seed []byte) (BLSPrivateKey, error) {
+	if len(seed) < 32 {
+		return BLSPrivateKey{}, errors.Errorf("could not create BLS key, expected a seed of at least 32 bytes, got %d", len(seed))
+	}
+
+	order := bls12381.NewG1().Q()
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	scalar := new(big.Int)
+	for scalar.Sign() == 0 {
+		hash := sha256.Sum256(salt)
+		salt = hash[:]
+
+		okm := make([]byte, 48)
+		kdf := hkdf.New(sha256.New, append(append([]byte{}, seed...), 0), salt, []byte{0, 48})
+		if _, err := io.ReadFull(kdf, okm); err != nil {
+			return BLSPrivateKey{}, errors.Wrap(err, "could not create BLS key")
+		}
+		scalar.SetBytes(okm).Mod(scalar, order)
+	}
+	return BLSPrivateKey{scalar: scalar}, nil
+}
+
+// ParseBLSPrivateKey decodes a BLsk secret key.
+func ParseBLSPrivateKey(s string) (BLSPrivateKey, error) {
+	secret, err := base58.Decode(s, base58.BLsk)
+	if err != nil {
+		return BLSPrivateKey{}, errors.Wrapf(err, "could not parse BLS secret key '%s'", truncate(s))
+	}
+	key, err := blsFromSecret(secret)
+	if err != nil {
+		return BLSPrivateKey{}, errors.Wrapf(err, "could not parse BLS secret key '%s'", truncate(s))
+	}
+	return key, nil
+}
+
+// blsFromSecret returns the key of the scalar encoded in little endian like Tezos does
+func blsFromSecret(secret []byte) (BLSPrivateKey, error) {
+	scalar := new(big.Int).SetBytes(reverse(secret))
+	if scalar.Sign() == 0 || scalar.Cmp(bls12381.NewG1().Q()) >= 0 {
+		return BLSPrivateKey{}, errors.New("invalid scalar")
+	}
+	return BLSPrivateKey{scalar: scalar}, nil
+}
+
+// ParseBLSPublicKey decodes a BLpk public key, checking it is a point of G1.
+func ParseBLSPublicKey(s string) (BLSPublicKey, error) {
+	key, err := base58.Decode(s, base58.BLpk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse BLS public key '%s'", s)
+	}
+	if _, err := g1Point(key); err != nil {
+		return nil, errors.Wrapf(err, "could not parse BLS public key '%s'", s)
+	}
+	return BLSPublicKey(key), nil
+}
+
+// Public returns the BLpk public key of k.
+func (k BLSPrivateKey) Public() PublicKey {
+	g1 := bls12381.NewG1()
+	return BLSPublicKey(g1.ToCompressed(g1.MulScalarBig(g1.New(), g1.One(), k.scalar)))
+}
+
+// Sign signs message, the public key of k followed by message as required by the augmentation scheme, and returns
+// the signature of 96 bytes. Unlike other keys, Tezos does not hash the message signed with BLS keys.
+func (k BLSPrivateKey) Sign(message []byte) ([]byte, error) {
+	if k.scalar == nil {
+		return nil, errors.New("could not sign, invalid BLS secret key")
+	}
+	augmented := append(k.Public().Bytes(), message...)
+	return k.sign(augmented, blsSignatureDST)
+}
+
+// ProofOfPossession returns the proof of possession of k, the signature of its public key, which a tz4 account
+// gives when it becomes the consensus key of a delegate.
+func (k BLSPrivateKey) ProofOfPossession() ([]byte, error) {
+	if k.scalar == nil {
+		return nil, errors.New("could not prove possession, invalid BLS secret key")
+	}
+	return k.sign(k.Public().Bytes(), blsProofDST)
+}
+
+func (k BLSPrivateKey) sign(message []byte, dst string) ([]byte, error) {
+	g2 := bls12381.NewG2()
+	point, err := g2.HashToCurve(message, []byte(dst))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign")
+	}
+	return g2.ToCompressed(g2.MulScalarBig(g2.New(), point, k.scalar)), nil
+}
+
+// String returns the BLsk encoding of k.
+func (k BLSPrivateKey) String() string {
+	secret := make([]byte, BLSSecretKeySize)
+	if k.scalar != nil {
+		b := k.scalar.Bytes()
+		copy(secret[len(secret)-len(b):], b)
+	}
+	return crypto.B58cencode(reverse(secret), crypto.Prefix_BLsk)
+}
+
+// Address returns the tz4 address of k.
+func (k BLSPublicKey) Address() string {
+	return address(k, crypto.Prefix_tz4)
+}
+
+// Bytes returns the 48 bytes of k.
+func (k BLSPublicKey) Bytes() []byte {
+	return append([]byte{}, k...)
+}
+
+// String returns the BLpk encoding of k.
+func (k BLSPublicKey) String() string {
+	return crypto.B58cencode(k, crypto.Prefix_BLpk)
+}
+
+// Verify reports whether signature is a signature of message by the secret key of k, see BLSPrivateKey.Sign.
+func (k BLSPublicKey) Verify(message, signature []byte) bool {
+	return k.verify(append(k.Bytes(), message...), signature, blsSignatureDST)
+}
+
+// VerifyProofOfPossession reports whether proof is the proof of possession of the secret key of k.
+func (k BLSPublicKey) VerifyProofOfPossession(proof []byte) bool {
+	return k.verify(k.Bytes(), proof, blsProofDST)
+}
+
+func (k BLSPublicKey) verify(message, signature []byte, dst string) bool {
+	public, err := g1Point(k)
+	if err != nil {
+		return false
+	}
+
+	g2 := bls12381.NewG2()
+	sig, err := g2.FromCompressed(signature)
+	if err != nil || !g2.InCorrectSubgroup(sig) {
+		return false
+	}
+	point, err := g2.HashToCurve(message, []byte(dst))
+	if err != nil {
+		return false
+	}
+
+	// e(pk, H(m)) == e(g1, sig)
+	engine := bls12381.NewEngine()
+	engine.AddPair(public, point)
+	engine.AddPairInv(engine.G1.One(), sig)
+	return engine.Check()
+}
+
+// EncodeBLSSignature returns the BLsig encoding of a BLS signature.
+func EncodeBLSSignature(signature []byte) string {
+	return crypto.B58cencode(signature, crypto.Prefix_BLsig)
+}
+
+// ParseBLSSignature decodes a BLsig signature.
+func ParseBLSSignature(s string) ([]byte, error) {
+	signature, err := base58.Decode(s, base58.BLsig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse BLS signature '%s'", s)
+	}
+	return signature, nil
+}
+
+// g1Point decodes the compressed point of a public key, which must be in G1 and not the identity
+func g1Point(key []byte) (*bls12381.PointG1, error) {
+	g1 := bls12381.NewG1()
+	point, err := g1.FromCompressed(key)
+	if err != nil {
+		return nil, err
+	}
+	if g1.IsZero(point) || !g1.InCorrectSubgroup(point) {
+		return nil, errors.New("not a point of G1")
+	}
+	return point, nil
+}
+
+// reverse returns the bytes of b in the reverse order
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
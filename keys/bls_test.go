@@ -0,0 +1,72 @@
+package keys
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+func Test_NewBLSFromSeed(t *testing.T) {
+	// master key of the first test vector of EIP-2333, which derives it with the same KeyGen
+	seed, _ := hex.DecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+	key, err := NewBLSFromSeed(seed)
+	assert.NilError(t, err)
+	assert.Equal(t, key.scalar.String(), "6083874454709270928345386274498605044986640685124978867557563392430687146096")
+
+	_, err = NewBLSFromSeed(seed[:16])
+	assert.Error(t, err, "could not create BLS key, expected a seed of at least 32 bytes, got 16")
+}
+
+func Test_BLSSign(t *testing.T) {
+	// test vector of the Ethereum consensus specs, the same ciphersuite with the tag of the proof of possession scheme
+	scalar, _ := new(big.Int).SetString("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3", 16)
+	key := BLSPrivateKey{scalar: scalar}
+	assert.Equal(t, hex.EncodeToString(key.Public().Bytes()), "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a")
+
+	signature, err := key.sign(make([]byte, 32), "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	assert.NilError(t, err)
+	assert.Equal(t, hex.EncodeToString(signature), "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55")
+
+	public := key.Public().(BLSPublicKey)
+	signature, err = key.Sign([]byte("message"))
+	assert.NilError(t, err)
+	assert.Assert(t, public.Verify([]byte("message"), signature))
+	assert.Assert(t, !public.Verify([]byte("other message"), signature))
+
+	proof, err := key.ProofOfPossession()
+	assert.NilError(t, err)
+	assert.Assert(t, public.VerifyProofOfPossession(proof))
+	assert.Assert(t, !public.VerifyProofOfPossession(signature))
+
+	encoded := EncodeBLSSignature(proof)
+	assert.Assert(t, strings.HasPrefix(encoded, "BLsig"))
+	decoded, err := ParseBLSSignature(encoded)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decoded, proof)
+}
+
+func Test_GenerateBLS(t *testing.T) {
+	key, err := GenerateBLS()
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(key.String(), "BLsk"))
+	assert.Assert(t, strings.HasPrefix(key.Public().String(), "BLpk"))
+	assert.Assert(t, strings.HasPrefix(key.Public().Address(), "tz4"))
+
+	parsed, err := ParsePrivateKey(key.String())
+	assert.NilError(t, err)
+	assert.Equal(t, parsed.Public().Address(), key.Public().Address())
+
+	public, err := ParsePublicKey(key.Public().String())
+	assert.NilError(t, err)
+	assert.Equal(t, public.Address(), key.Public().Address())
+
+	invalid := make([]byte, BLSPublicKeySize)
+	invalid[0] = 0x9f
+	_, err = ParseBLSPublicKey(crypto.B58cencode(invalid, crypto.Prefix_BLpk))
+	assert.ErrorContains(t, err, "could not parse BLS public key")
+}
@@ -0,0 +1,104 @@
+package keys
+
+import (
+	"crypto/rand"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+// Ed25519PrivateKey is the ed25519 secret key of a tz1 account.
+type Ed25519PrivateKey ed25519.PrivateKey
+
+// Ed25519PublicKey is the ed25519 public key of a tz1 account.
+type Ed25519PublicKey ed25519.PublicKey
+
+// GenerateEd25519 generates a new ed25519 key with crypto/rand.
+func GenerateEd25519() (Ed25519PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate ed25519 key")
+	}
+	return Ed25519PrivateKey(key), nil
+}
+
+// NewEd25519FromSeed returns the ed25519 key of a seed of 32 bytes.
+func NewEd25519FromSeed(seed []byte) (Ed25519PrivateKey, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.Errorf("could not create ed25519 key, expected a seed of %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	return Ed25519PrivateKey(ed25519.NewKeyFromSeed(seed)), nil
+}
+
+// ParseEd25519PrivateKey decodes an edsk secret key, either the seed of 54 characters or the secret key of 98
+// characters holding the seed and the public key.
+func ParseEd25519PrivateKey(s string) (Ed25519PrivateKey, error) {
+	if seed, err := base58.Decode(s, base58.EdskSeed); err == nil {
+		return NewEd25519FromSeed(seed)
+	}
+
+	secret, err := base58.Decode(s, base58.Edsk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse ed25519 secret key '%s'", truncate(s))
+	}
+	key := ed25519.NewKeyFromSeed(secret[:ed25519.SeedSize])
+	if !ed25519.PublicKey(secret[ed25519.SeedSize:]).Equal(key.Public()) {
+		return nil, errors.Errorf("could not parse ed25519 secret key '%s', public key does not match seed", truncate(s))
+	}
+	return Ed25519PrivateKey(key), nil
+}
+
+// ParseEd25519PublicKey decodes an edpk public key.
+func ParseEd25519PublicKey(s string) (Ed25519PublicKey, error) {
+	key, err := base58.Decode(s, base58.Edpk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse ed25519 public key '%s'", s)
+	}
+	return Ed25519PublicKey(key), nil
+}
+
+// Public returns the edpk public key of k.
+func (k Ed25519PrivateKey) Public() PublicKey {
+	return Ed25519PublicKey(ed25519.PrivateKey(k).Public().(ed25519.PublicKey))
+}
+
+// Seed returns the seed of 32 bytes k is derived from.
+func (k Ed25519PrivateKey) Seed() []byte {
+	return ed25519.PrivateKey(k).Seed()
+}
+
+// Sign signs the blake2b digest of message and returns the signature of 64 bytes.
+func (k Ed25519PrivateKey) Sign(message []byte) ([]byte, error) {
+	if len(k) != ed25519.PrivateKeySize {
+		return nil, errors.New("could not sign, invalid ed25519 secret key")
+	}
+	return ed25519.Sign(ed25519.PrivateKey(k), digest(message)), nil
+}
+
+// String returns the edsk encoding of the seed of k, the form of 54 characters octez-client uses.
+func (k Ed25519PrivateKey) String() string {
+	return crypto.B58cencode(k.Seed(), crypto.Prefix_edsk2)
+}
+
+// Address returns the tz1 address of k.
+func (k Ed25519PublicKey) Address() string {
+	return address(k, crypto.Prefix_tz1)
+}
+
+// Bytes returns the 32 bytes of k.
+func (k Ed25519PublicKey) Bytes() []byte {
+	return append([]byte{}, k...)
+}
+
+// String returns the edpk encoding of k.
+func (k Ed25519PublicKey) String() string {
+	return crypto.B58cencode(k, crypto.Prefix_edpk)
+}
+
+// Verify reports whether signature is a signature of the blake2b digest of message by the secret key of k.
+func (k Ed25519PublicKey) Verify(message, signature []byte) bool {
+	return len(k) == ed25519.PublicKeySize && ed25519.Verify(ed25519.PublicKey(k), digest(message), signature)
+}
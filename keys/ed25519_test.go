@@ -0,0 +1,56 @@
+package keys
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+	"gotest.tools/assert"
+)
+
+func Test_ParseEd25519PrivateKey(t *testing.T) {
+	cases := []struct {
+		name    string
+		secret  string
+		wantErr string
+	}{
+		{name: "Seed", secret: "edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X"},
+		{name: "Secret key", secret: "edskRjBSseEx9bSRSJJpbypJe5ZXucTtApb6qjechMB1BzEYwcEZyfLooo22Nwk33mPPJ3xZniFoa3o8Js7nNXDdqK9nNjFDi7"},
+		{name: "Invalid checksum", secret: "edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6Y", wantErr: "could not parse ed25519 secret key 'edsk362Y...'"},
+		{name: "Public key", secret: "edpkunwa7a3Y5vDr9eoKy4E21pzonuhqvNjscT9XG27aQV4gXq4dNm", wantErr: "unexpected prefix"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseEd25519PrivateKey(tc.secret)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, key.String(), "edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X")
+			assert.Equal(t, key.Public().String(), "edpkunwa7a3Y5vDr9eoKy4E21pzonuhqvNjscT9XG27aQV4gXq4dNm")
+			assert.Equal(t, key.Public().Address(), "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ")
+		})
+	}
+}
+
+func Test_GenerateEd25519(t *testing.T) {
+	key, err := GenerateEd25519()
+	assert.NilError(t, err)
+
+	parsed, err := ParsePrivateKey(key.String())
+	assert.NilError(t, err)
+	assert.Equal(t, parsed.Public().Address(), key.Public().Address())
+	assert.Equal(t, key.Public().Address()[:3], "tz1")
+
+	public, err := ParsePublicKey(key.Public().String())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, public.Bytes(), key.Public().Bytes())
+
+	signature, err := key.Sign([]byte("message"))
+	assert.NilError(t, err)
+	assert.Assert(t, ed25519.Verify(ed25519.PublicKey(public.Bytes()), digest([]byte("message")), signature))
+
+	_, err = NewEd25519FromSeed([]byte{1, 2, 3})
+	assert.Error(t, err, "could not create ed25519 key, expected a seed of 32 bytes, got 3")
+}
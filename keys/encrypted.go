@@ -0,0 +1,115 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+)
+
+// Parameters of the encryption of secret keys by octez-client: the key of a secretbox with a zero nonce is derived
+// from the passphrase and a random salt with PBKDF2.
+const (
+	encryptionSaltSize   = 8
+	encryptionIterations = 32768
+)
+
+// EncryptPrivateKey encrypts key with passphrase like octez-client does and returns the edesk, spesk, p2esk or BLesk
+// encrypted secret key, which octez-client imports with the "encrypted:" scheme.
+func EncryptPrivateKey(key PrivateKey, passphrase string) (string, error) {
+	secret, prefix, err := rawSecret(key)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encrypt secret key")
+	}
+
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", errors.Wrap(err, "could not encrypt secret key")
+	}
+
+	var nonce [24]byte
+	encrypted := secretbox.Seal(salt, secret, &nonce, encryptionKey(passphrase, salt))
+	encoded, err := base58.Encode(prefix, encrypted)
+	return encoded, errors.Wrap(err, "could not encrypt secret key")
+}
+
+// ParseEncryptedPrivateKey decrypts an edesk, spesk, p2esk or BLesk encrypted secret key with passphrase, e.g. a key
+// exported from octez-client without its "encrypted:" scheme.
+func ParseEncryptedPrivateKey(s, passphrase string) (PrivateKey, error) {
+	var prefix base58.Prefix
+	var parse func([]byte) (PrivateKey, error)
+	switch {
+	case strings.HasPrefix(s, "edesk"):
+		prefix = base58.Edesk
+		parse = func(secret []byte) (PrivateKey, error) { return NewEd25519FromSeed(secret) }
+	case strings.HasPrefix(s, "spesk"):
+		prefix = base58.Spesk
+		parse = func(secret []byte) (PrivateKey, error) { return NewSecp256k1FromSecret(secret) }
+	case strings.HasPrefix(s, "p2esk"):
+		prefix = base58.P2esk
+		parse = func(secret []byte) (PrivateKey, error) { return NewP256FromSecret(secret) }
+	case strings.HasPrefix(s, "BLesk"):
+		prefix = base58.BLesk
+		parse = func(secret []byte) (PrivateKey, error) { return blsFromSecret(secret) }
+	default:
+		return nil, errors.Errorf("could not parse encrypted secret key '%s', unknown prefix", truncate(s))
+	}
+
+	// the salt followed by the secret of 32 bytes and the tag of the secretbox
+	encrypted, err := base58.Decode(s, prefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse encrypted secret key '%s'", truncate(s))
+	}
+
+	var nonce [24]byte
+	salt := encrypted[:encryptionSaltSize]
+	secret, ok := secretbox.Open(nil, encrypted[encryptionSaltSize:], &nonce, encryptionKey(passphrase, salt))
+	if !ok {
+		return nil, errors.Errorf("could not decrypt secret key '%s', invalid passphrase", truncate(s))
+	}
+
+	key, err := parse(secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse encrypted secret key '%s'", truncate(s))
+	}
+	return key, nil
+}
+
+// encryptionKey derives the key of the secretbox of an encrypted secret key
+func encryptionKey(passphrase string, salt []byte) *[32]byte {
+	var key [32]byte
+	copy(key[:], pbkdf2.Key([]byte(passphrase), salt, encryptionIterations, 32, sha512.New))
+	return &key
+}
+
+// rawSecret returns the 32 bytes of the secret of key that octez-client encrypts and the prefix of the encrypted key
+func rawSecret(key PrivateKey) ([]byte, base58.Prefix, error) {
+	switch k := key.(type) {
+	case Ed25519PrivateKey:
+		if len(k) == ed25519.PrivateKeySize {
+			return k.Seed(), base58.Edesk, nil
+		}
+	case Secp256k1PrivateKey:
+		if k.key != nil {
+			return k.key.Serialize(), base58.Spesk, nil
+		}
+	case P256PrivateKey:
+		if k.key != nil {
+			return scalarBytes(k.key.D), base58.P2esk, nil
+		}
+	case BLSPrivateKey:
+		if k.scalar != nil {
+			return reverse(scalarBytes(k.scalar)), base58.BLesk, nil
+		}
+	default:
+		return nil, base58.Prefix{}, errors.Errorf("unsupported secret key %T", key)
+	}
+	return nil, base58.Prefix{}, errors.New("invalid secret key")
+}
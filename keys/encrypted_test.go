@@ -0,0 +1,84 @@
+package keys
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ParseEncryptedPrivateKey(t *testing.T) {
+	cases := []struct {
+		name       string
+		encrypted  string
+		passphrase string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "Octez key",
+			encrypted:  "edesk1fddn27MaLcQVEdZpAYiyGQNm6UjtWiBfNP2ZenTy3CFsoSVJgeHM9pP9cvLJ2r5Xp2quQ5mYexW1LRKee2",
+			passphrase: "password12345##",
+			want:       "tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo",
+		},
+		{
+			name:       "Invalid passphrase",
+			encrypted:  "edesk1fddn27MaLcQVEdZpAYiyGQNm6UjtWiBfNP2ZenTy3CFsoSVJgeHM9pP9cvLJ2r5Xp2quQ5mYexW1LRKee2",
+			passphrase: "password",
+			wantErr:    "could not decrypt secret key 'edesk1fd...', invalid passphrase",
+		},
+		{
+			name:      "Unencrypted key",
+			encrypted: "edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X",
+			wantErr:   "could not parse encrypted secret key 'edsk362Y...', unknown prefix",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseEncryptedPrivateKey(tc.encrypted, tc.passphrase)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, key.Public().Address(), tc.want)
+		})
+	}
+}
+
+func Test_EncryptPrivateKey(t *testing.T) {
+	ed, err := GenerateEd25519()
+	assert.NilError(t, err)
+	secp, err := GenerateSecp256k1()
+	assert.NilError(t, err)
+	p256, err := GenerateP256()
+	assert.NilError(t, err)
+	bls, err := GenerateBLS()
+	assert.NilError(t, err)
+
+	cases := []struct {
+		key    PrivateKey
+		prefix string
+	}{
+		{key: ed, prefix: "edesk"},
+		{key: secp, prefix: "spesk"},
+		{key: p256, prefix: "p2esk"},
+		{key: bls, prefix: "BLesk"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			encrypted, err := EncryptPrivateKey(tc.key, "passphrase")
+			assert.NilError(t, err)
+			assert.Equal(t, encrypted[:5], tc.prefix)
+			assert.Equal(t, len(encrypted), 88)
+
+			key, err := ParseEncryptedPrivateKey(encrypted, "passphrase")
+			assert.NilError(t, err)
+			assert.Equal(t, key.String(), tc.key.String())
+		})
+	}
+
+	_, err = EncryptPrivateKey(Ed25519PrivateKey(nil), "passphrase")
+	assert.Error(t, err, "could not encrypt secret key: invalid secret key")
+}
@@ -0,0 +1,158 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/pkg/errors"
+)
+
+// DefaultDerivationPath is the derivation path of the first account of Temple, Kukai and Ledger wallets, the
+// account index is the third segment.
+const DefaultDerivationPath = "m/44'/1729'/0'/0'"
+
+// hardened is the offset of the indexes of hardened derivations
+const hardened = 1 << 31
+
+// DeriveEd25519 derives the ed25519 key at path from the BIP39 seed of 64 bytes of a mnemonic with SLIP-10, e.g. at
+// DefaultDerivationPath like Temple does. Every segment of path must be hardened, e.g. 44' or 44h.
+func DeriveEd25519(seed []byte, path string) (Ed25519PrivateKey, error) {
+	indexes, err := parsePath(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not derive ed25519 key")
+	}
+
+	key, chainCode := slip10(hmacSHA512([]byte("ed25519 seed"), seed))
+	for _, index := range indexes {
+		if index < hardened {
+			return nil, errors.Errorf("could not derive ed25519 key, segment %d of '%s' is not hardened", index, path)
+		}
+		data := make([]byte, 0, 37)
+		data = append(append(append(data, 0), key...), 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(data[33:], index)
+		key, chainCode = slip10(hmacSHA512(chainCode, data))
+	}
+	return NewEd25519FromSeed(key)
+}
+
+// DeriveSecp256k1 derives the secp256k1 key at path from the BIP39 seed of a mnemonic with BIP32, e.g. at
+// DefaultDerivationPath like Ledger wallets do for tz2 accounts. Segments of path may be hardened or not.
+func DeriveSecp256k1(seed []byte, path string) (Secp256k1PrivateKey, error) {
+	secret, err := deriveECDSA(seed, path, "Bitcoin seed", secp256k1.Params().N, func(secret []byte) []byte {
+		key, _ := NewSecp256k1FromSecret(secret)
+		return key.Public().Bytes()
+	})
+	if err != nil {
+		return Secp256k1PrivateKey{}, errors.Wrap(err, "could not derive secp256k1 key")
+	}
+	return NewSecp256k1FromSecret(secret)
+}
+
+// DeriveP256 derives the P-256 key at path from the BIP39 seed of a mnemonic with SLIP-10, e.g. at
+// DefaultDerivationPath like Ledger wallets do for tz3 accounts. Segments of path may be hardened or not.
+func DeriveP256(seed []byte, path string) (P256PrivateKey, error) {
+	secret, err := deriveECDSA(seed, path, "Nist256p1 seed", elliptic.P256().Params().N, func(secret []byte) []byte {
+		key, _ := NewP256FromSecret(secret)
+		return key.Public().Bytes()
+	})
+	if err != nil {
+		return P256PrivateKey{}, errors.Wrap(err, "could not derive P-256 key")
+	}
+	return NewP256FromSecret(secret)
+}
+
+// deriveECDSA derives the secret scalar at path of a curve of order n with SLIP-10, which is BIP32 for secp256k1.
+// curve is the HMAC key of the master key and public returns the compressed public key of a secret.
+func deriveECDSA(seed []byte, path, curve string, n *big.Int, public func([]byte) []byte) ([]byte, error) {
+	indexes, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// valid reports whether the key of i is a scalar of the curve, and sets it
+	scalar := new(big.Int)
+	valid := func(i []byte, parent *big.Int) bool {
+		scalar.SetBytes(i[:32])
+		if scalar.Cmp(n) >= 0 {
+			return false
+		}
+		if parent != nil {
+			scalar.Add(scalar, parent).Mod(scalar, n)
+		}
+		return scalar.Sign() != 0
+	}
+
+	i := hmacSHA512([]byte(curve), seed)
+	for !valid(i, nil) {
+		i = hmacSHA512([]byte(curve), i)
+	}
+	key, chainCode := scalarBytes(scalar), i[32:]
+
+	for _, index := range indexes {
+		data := make([]byte, 0, 37)
+		if index >= hardened {
+			data = append(append(data, 0), key...)
+		} else {
+			data = append(data, public(key)...)
+		}
+		data = append(data, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(data[33:], index)
+
+		parent := new(big.Int).SetBytes(key)
+		i = hmacSHA512(chainCode, data)
+		for !valid(i, parent) {
+			data = append(append([]byte{1}, i[32:]...), data[33:]...)
+			i = hmacSHA512(chainCode, data)
+		}
+		key, chainCode = scalarBytes(scalar), i[32:]
+	}
+	return key, nil
+}
+
+// scalarBytes returns the 32 bytes big endian encoding of a scalar
+func scalarBytes(scalar *big.Int) []byte {
+	b := make([]byte, 32)
+	s := scalar.Bytes()
+	copy(b[32-len(s):], s)
+	return b
+}
+
+// parsePath returns the indexes of the segments of a derivation path like m/44'/1729'/0'/0'
+func parsePath(path string) ([]uint32, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if segments[0] != "m" {
+		return nil, errors.Errorf("invalid derivation path '%s', it does not start with m", path)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		offset := uint64(0)
+		if strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H") {
+			offset = hardened
+			segment = segment[:len(segment)-1]
+		}
+		index, err := strconv.ParseUint(segment, 10, 31)
+		if err != nil {
+			return nil, errors.Errorf("invalid derivation path '%s', invalid segment '%s'", path, segment)
+		}
+		indexes = append(indexes, uint32(index+offset))
+	}
+	return indexes, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// slip10 splits the output of HMAC-SHA512 in a key and a chain code
+func slip10(i []byte) ([]byte, []byte) {
+	return i[:32], i[32:]
+}
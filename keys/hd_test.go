@@ -0,0 +1,90 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_DeriveEd25519(t *testing.T) {
+	// test vector 1 of SLIP-10 for ed25519
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	cases := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "m", want: "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{path: "m/0'", want: "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{path: "m/0h/1H", want: "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{path: "m/0'/1", wantErr: "could not derive ed25519 key, segment 1 of 'm/0'/1' is not hardened"},
+		{path: "44'/1729'", wantErr: "could not derive ed25519 key: invalid derivation path '44'/1729'', it does not start with m"},
+		{path: "m/x'", wantErr: "could not derive ed25519 key: invalid derivation path 'm/x'', invalid segment 'x'"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			key, err := DeriveEd25519(seed, tc.path)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(key.Seed()), tc.want)
+		})
+	}
+}
+
+func Test_DeriveSecp256k1(t *testing.T) {
+	// test vector 1 of BIP32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	cases := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "m", want: "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{path: "m/0'", want: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{path: "m/0'/1", want: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{path: "m/0'/1/2'/2/1000000000", want: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+		{path: "m/0/", wantErr: "could not derive secp256k1 key: invalid derivation path 'm/0/', invalid segment ''"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			key, err := DeriveSecp256k1(seed, tc.path)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(key.key.Serialize()), tc.want)
+		})
+	}
+}
+
+func Test_DeriveP256(t *testing.T) {
+	// test vector 1 of SLIP-10 for nist256p1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	cases := []struct {
+		path string
+		want string
+	}{
+		{path: "m", want: "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2"},
+		{path: "m/0'", want: "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+		{path: "m/0'/1", want: "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129"},
+		{path: "m/0'/1/2'/2/1000000000", want: "21c4f269ef0a5fd1badf47eeacebeeaa3de22eb8e5b0adcd0f27dd99d34d0119"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			key, err := DeriveP256(seed, tc.path)
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(scalarBytes(key.key.D)), tc.want)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package keys creates and encodes the keys of Tezos accounts.
+package keys
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+// PrivateKey is the secret key of a Tezos account.
+type PrivateKey interface {
+	// Public returns the public key of the secret key
+	Public() PublicKey
+	// Sign signs message as Tezos signs operations, the blake2b digest of message except with BLS keys, and
+	// returns the signature bytes
+	Sign(message []byte) ([]byte, error)
+	// String returns the base58 encoded secret key, e.g. edsk..., spsk..., p2sk... or BLsk...
+	String() string
+}
+
+// PublicKey is the public key of a Tezos account.
+type PublicKey interface {
+	// Address returns the address of the account, the hash of the public key, e.g. tz1..., tz2..., tz3... or tz4...
+	Address() string
+	// Bytes returns the raw public key
+	Bytes() []byte
+	// String returns the base58 encoded public key, e.g. edpk..., sppk..., p2pk... or BLpk...
+	String() string
+	// Verify reports whether signature is a signature of message by the secret key, see PrivateKey.Sign
+	Verify(message, signature []byte) bool
+}
+
+// ParsePrivateKey decodes a base58 encoded secret key, an edsk secret key or seed, an spsk, a p2sk or a BLsk secret
+// key.
+func ParsePrivateKey(s string) (PrivateKey, error) {
+	switch {
+	case strings.HasPrefix(s, "edsk"):
+		return ParseEd25519PrivateKey(s)
+	case strings.HasPrefix(s, "spsk"):
+		return ParseSecp256k1PrivateKey(s)
+	case strings.HasPrefix(s, "p2sk"):
+		return ParseP256PrivateKey(s)
+	case strings.HasPrefix(s, "BLsk"):
+		return ParseBLSPrivateKey(s)
+	}
+	return nil, errors.Errorf("could not parse secret key '%s', unknown prefix", truncate(s))
+}
+
+// ParsePublicKey decodes a base58 encoded public key, an edpk, an sppk, a p2pk or a BLpk.
+func ParsePublicKey(s string) (PublicKey, error) {
+	switch {
+	case strings.HasPrefix(s, "edpk"):
+		return ParseEd25519PublicKey(s)
+	case strings.HasPrefix(s, "sppk"):
+		return ParseSecp256k1PublicKey(s)
+	case strings.HasPrefix(s, "p2pk"):
+		return ParseP256PublicKey(s)
+	case strings.HasPrefix(s, "BLpk"):
+		return ParseBLSPublicKey(s)
+	}
+	return nil, errors.Errorf("could not parse public key '%s', unknown prefix", s)
+}
+
+// EncodeSignature returns the base58 encoding of a signature by the secret key of public, edsig, spsig, p2sig or
+// BLsig depending on its curve.
+func EncodeSignature(public PublicKey, signature []byte) string {
+	switch public.(type) {
+	case Ed25519PublicKey:
+		return crypto.B58cencode(signature, crypto.Prefix_edsig)
+	case Secp256k1PublicKey:
+		return crypto.B58cencode(signature, crypto.Prefix_spsig)
+	case P256PublicKey:
+		return crypto.B58cencode(signature, crypto.Prefix_p2sig)
+	case BLSPublicKey:
+		return EncodeBLSSignature(signature)
+	}
+	return crypto.B58cencode(signature, crypto.Prefix_sig)
+}
+
+// ParseSignature decodes a base58 encoded signature, an edsig, spsig, p2sig, BLsig or a generic sig, and returns the
+// signature bytes.
+func ParseSignature(s string) ([]byte, error) {
+	_, signature, err := base58.DecodeOneOf(s, base58.Edsig, base58.Spsig, base58.P2sig, base58.BLsig, base58.Sig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse signature '%s'", s)
+	}
+	return signature, nil
+}
+
+// address returns the address of publicKey, its blake2b hash of 20 bytes encoded with prefix
+func address(publicKey []byte, prefix crypto.Prefix) string {
+	hash, _ := blake2b.New(20, nil)
+	hash.Write(publicKey)
+	return crypto.B58cencode(hash.Sum(nil), prefix)
+}
+
+// digest returns the blake2b hash of 32 bytes of message that Tezos signs
+func digest(message []byte) []byte {
+	hash := blake2b.Sum256(message)
+	return hash[:]
+}
+
+// truncate keeps secret keys out of error messages
+func truncate(secret string) string {
+	if len(secret) <= 8 {
+		return secret
+	}
+	return secret[:8] + "..."
+}
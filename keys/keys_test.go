@@ -0,0 +1,64 @@
+package keys
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ParseSignature(t *testing.T) {
+	cases := []struct {
+		name      string
+		signature string
+		want      int
+		wantErr   string
+	}{
+		{
+			name:      "Ed25519",
+			signature: "edsigtXomBKi5CTRf5cjATJWSyaRvhfYNHqSUGrn4SdbYRcGwQrUGjzEfQDTuqHhuA8b2d8NarZjz8TRf65WkpQmo423BtomS8Q",
+			want:      64,
+		},
+		{
+			name:      "Generic",
+			signature: "sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe",
+			want:      64,
+		},
+		{
+			name:      "Not a signature",
+			signature: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+			wantErr:   "could not parse signature 'tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1': could not decode, unknown prefix",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sig, err := ParseSignature(tc.signature)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, len(sig), tc.want)
+		})
+	}
+}
+
+func Test_Verify(t *testing.T) {
+	ed, err := GenerateEd25519()
+	assert.NilError(t, err)
+	secp, err := GenerateSecp256k1()
+	assert.NilError(t, err)
+	p256, err := GenerateP256()
+	assert.NilError(t, err)
+	bls, err := GenerateBLS()
+	assert.NilError(t, err)
+
+	for _, key := range []PrivateKey{ed, secp, p256, bls} {
+		t.Run(key.Public().Address()[:3], func(t *testing.T) {
+			signature, err := key.Sign([]byte("message"))
+			assert.NilError(t, err)
+			assert.Assert(t, key.Public().Verify([]byte("message"), signature))
+			assert.Assert(t, !key.Public().Verify([]byte("other message"), signature))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// NewMnemonic generates a BIP39 mnemonic of words words, 12, 15, 18, 21 or 24, from entropy read from crypto/rand.
+func NewMnemonic(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", errors.Errorf("could not generate mnemonic, invalid number of words %d", words)
+	}
+
+	entropy := make([]byte, words*4/3)
+	if _, err := io.ReadFull(rand.Reader, entropy); err != nil {
+		return "", errors.Wrap(err, "could not generate mnemonic")
+	}
+	return mnemonic(entropy), nil
+}
+
+// mnemonic returns the BIP39 mnemonic of entropy, its bits followed by the first bits of its sha256 as checksum
+// split in words of 11 bits
+func mnemonic(entropy []byte) string {
+	checksumBits := uint(len(entropy) / 4)
+	hash := sha256.Sum256(entropy)
+
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, checksumBits)
+	data.Or(data, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	words := make([]string, (uint(len(entropy))*8+checksumBits)/11)
+	mask := big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = english[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, 11)
+	}
+	return strings.Join(words, " ")
+}
+
+// ValidateMnemonic checks that mnemonic is made of 12 to 24 words of the English list of BIP39 with a valid checksum.
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return errors.Errorf("invalid mnemonic, invalid number of words %d", len(words))
+	}
+
+	data := new(big.Int)
+	for _, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return errors.Errorf("invalid mnemonic, unknown word '%s'", word)
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(index)))
+	}
+
+	checksumBits := uint(len(words) / 3)
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1)).Int64()
+	entropy := make([]byte, len(words)*4/3)
+	b := data.Rsh(data, checksumBits).Bytes()
+	copy(entropy[len(entropy)-len(b):], b)
+
+	hash := sha256.Sum256(entropy)
+	if int64(hash[0]>>(8-checksumBits)) != checksum {
+		return errors.New("invalid mnemonic, invalid checksum")
+	}
+	return nil
+}
+
+// wordIndex is the index of each word of the English list
+var wordIndex = func() map[string]int {
+	index := make(map[string]int, len(english))
+	for i, word := range english {
+		index[word] = i
+	}
+	return index
+}()
+
+// MnemonicSeed validates mnemonic and returns its BIP39 seed of 64 bytes with the optional passphrase. The
+// passphrase is used as is, without the Unicode normalization of BIP39, which only matters for non ASCII passphrases.
+func MnemonicSeed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), 2048, 64, sha512.New), nil
+}
+
+// NewEd25519FromMnemonic returns the ed25519 key of a mnemonic the way octez-client, fundraiser accounts and the
+// legacy wallets of Kukai derive it, from the first 32 bytes of the seed. Temple and the HD wallets of Kukai derive
+// their keys with DeriveEd25519 instead.
+func NewEd25519FromMnemonic(mnemonic, passphrase string) (Ed25519PrivateKey, error) {
+	seed, err := MnemonicSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create ed25519 key")
+	}
+	return NewEd25519FromSeed(seed[:32])
+}
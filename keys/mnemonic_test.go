@@ -0,0 +1,62 @@
+package keys
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Mnemonic(t *testing.T) {
+	// test vectors of BIP39 with the passphrase TREZOR
+	cases := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			seed:     "dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.mnemonic[:12], func(t *testing.T) {
+			entropy, _ := hex.DecodeString(tc.entropy)
+			assert.Equal(t, mnemonic(entropy), tc.mnemonic)
+
+			seed, err := MnemonicSeed(tc.mnemonic, "TREZOR")
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(seed), tc.seed)
+		})
+	}
+
+	generated, err := NewMnemonic(24)
+	assert.NilError(t, err)
+	assert.Equal(t, len(strings.Fields(generated)), 24)
+	assert.NilError(t, ValidateMnemonic(generated))
+
+	_, err = NewMnemonic(13)
+	assert.Error(t, err, "could not generate mnemonic, invalid number of words 13")
+	assert.Error(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"), "invalid mnemonic, invalid checksum")
+	assert.Error(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon tezos"), "invalid mnemonic, unknown word 'tezos'")
+}
+
+func Test_NewEd25519FromMnemonic(t *testing.T) {
+	// fundraiser account, the passphrase is its email followed by its password
+	key, err := NewEd25519FromMnemonic("normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout", "vksbjweo.qsrgfvbw@tezos.example.orgPYh8nXDQLB")
+	assert.NilError(t, err)
+	assert.Equal(t, key.Public().Address(), "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1")
+}
@@ -0,0 +1,121 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+// P256PrivateKey is the NIST P-256 secret key of a tz3 account.
+type P256PrivateKey struct {
+	key *ecdsa.PrivateKey
+}
+
+// P256PublicKey is the NIST P-256 public key of a tz3 account, a compressed point of 33 bytes.
+type P256PublicKey []byte
+
+// GenerateP256 generates a new P-256 key with crypto/rand.
+func GenerateP256() (P256PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return P256PrivateKey{}, errors.Wrap(err, "could not generate P-256 key")
+	}
+	return P256PrivateKey{key: key}, nil
+}
+
+// NewP256FromSecret returns the P-256 key of a secret scalar of 32 bytes.
+func NewP256FromSecret(secret []byte) (P256PrivateKey, error) {
+	curve := elliptic.P256()
+	d := new(big.Int).SetBytes(secret)
+	if len(secret) != 32 || d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return P256PrivateKey{}, errors.New("could not create P-256 key, invalid secret")
+	}
+
+	key := &ecdsa.PrivateKey{D: d}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(secret)
+	return P256PrivateKey{key: key}, nil
+}
+
+// ParseP256PrivateKey decodes a p2sk secret key.
+func ParseP256PrivateKey(s string) (P256PrivateKey, error) {
+	secret, err := base58.Decode(s, base58.P2sk)
+	if err != nil {
+		return P256PrivateKey{}, errors.Wrapf(err, "could not parse P-256 secret key '%s'", truncate(s))
+	}
+	return NewP256FromSecret(secret)
+}
+
+// ParseP256PublicKey decodes a p2pk public key.
+func ParseP256PublicKey(s string) (P256PublicKey, error) {
+	key, err := base58.Decode(s, base58.P2pk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse P-256 public key '%s'", s)
+	}
+	if x, _ := elliptic.UnmarshalCompressed(elliptic.P256(), key); x == nil {
+		return nil, errors.Errorf("could not parse P-256 public key '%s', not a point of the curve", s)
+	}
+	return P256PublicKey(key), nil
+}
+
+// Public returns the p2pk public key of k.
+func (k P256PrivateKey) Public() PublicKey {
+	return P256PublicKey(elliptic.MarshalCompressed(k.key.Curve, k.key.X, k.key.Y))
+}
+
+// Sign signs the blake2b digest of message and returns the signature of 64 bytes, r and s.
+func (k P256PrivateKey) Sign(message []byte) ([]byte, error) {
+	if k.key == nil {
+		return nil, errors.New("could not sign, invalid P-256 secret key")
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, k.key, digest(message))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign")
+	}
+	signature := make([]byte, 64)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(signature[32-len(rb):32], rb)
+	copy(signature[64-len(sb):], sb)
+	return signature, nil
+}
+
+// String returns the p2sk encoding of k.
+func (k P256PrivateKey) String() string {
+	secret := make([]byte, 32)
+	if k.key != nil {
+		d := k.key.D.Bytes()
+		copy(secret[len(secret)-len(d):], d)
+	}
+	return crypto.B58cencode(secret, crypto.Prefix_p2sk)
+}
+
+// Address returns the tz3 address of k.
+func (k P256PublicKey) Address() string {
+	return address(k, crypto.Prefix_tz3)
+}
+
+// Bytes returns the 33 bytes of k.
+func (k P256PublicKey) Bytes() []byte {
+	return append([]byte{}, k...)
+}
+
+// String returns the p2pk encoding of k.
+func (k P256PublicKey) String() string {
+	return crypto.B58cencode(k, crypto.Prefix_p2pk)
+}
+
+// Verify reports whether signature is a signature of the blake2b digest of message by the secret key of k.
+func (k P256PublicKey) Verify(message, signature []byte) bool {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), k)
+	if x == nil || len(signature) != 64 {
+		return false
+	}
+	public := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	return ecdsa.Verify(public, digest(message), new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:]))
+}
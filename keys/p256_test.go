@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_NewP256FromSecret(t *testing.T) {
+	one := make([]byte, 32)
+	one[31] = 1
+
+	cases := []struct {
+		name    string
+		secret  []byte
+		want    string
+		wantErr string
+	}{
+		{name: "Generator", secret: one, want: "036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296"},
+		{name: "Zero", secret: make([]byte, 32), wantErr: "could not create P-256 key, invalid secret"},
+		{name: "Order", secret: elliptic.P256().Params().N.Bytes(), wantErr: "could not create P-256 key, invalid secret"},
+		{name: "Short", secret: []byte{1}, wantErr: "could not create P-256 key, invalid secret"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := NewP256FromSecret(tc.secret)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(key.Public().Bytes()), tc.want)
+		})
+	}
+}
+
+func Test_GenerateP256(t *testing.T) {
+	key, err := GenerateP256()
+	assert.NilError(t, err)
+	assert.Equal(t, key.String()[:4], "p2sk")
+
+	parsed, err := ParsePrivateKey(key.String())
+	assert.NilError(t, err)
+	assert.Equal(t, parsed.Public().Address(), key.Public().Address())
+	assert.Equal(t, key.Public().Address()[:3], "tz3")
+
+	public, err := ParsePublicKey(key.Public().String())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, public.Bytes(), key.Public().Bytes())
+
+	signature, err := key.Sign([]byte("message"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(signature), 64)
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), public.Bytes())
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	assert.Assert(t, ecdsa.Verify(pub, digest([]byte("message")), r, s))
+
+	_, err = ParseP256PublicKey(key.Public().String()[:40])
+	assert.ErrorContains(t, err, "could not parse P-256 public key")
+}
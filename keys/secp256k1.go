@@ -0,0 +1,110 @@
+package keys
+
+import (
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+)
+
+// Secp256k1PrivateKey is the secp256k1 secret key of a tz2 account.
+type Secp256k1PrivateKey struct {
+	key *secp256k1.PrivateKey
+}
+
+// Secp256k1PublicKey is the secp256k1 public key of a tz2 account, a compressed point of 33 bytes.
+type Secp256k1PublicKey []byte
+
+// GenerateSecp256k1 generates a new secp256k1 key with crypto/rand.
+func GenerateSecp256k1() (Secp256k1PrivateKey, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return Secp256k1PrivateKey{}, errors.Wrap(err, "could not generate secp256k1 key")
+	}
+	return Secp256k1PrivateKey{key: key}, nil
+}
+
+// NewSecp256k1FromSecret returns the secp256k1 key of a secret scalar of 32 bytes.
+func NewSecp256k1FromSecret(secret []byte) (Secp256k1PrivateKey, error) {
+	var scalar secp256k1.ModNScalar
+	if len(secret) != 32 || scalar.SetByteSlice(secret) || scalar.IsZero() {
+		return Secp256k1PrivateKey{}, errors.New("could not create secp256k1 key, invalid secret")
+	}
+	return Secp256k1PrivateKey{key: secp256k1.NewPrivateKey(&scalar)}, nil
+}
+
+// ParseSecp256k1PrivateKey decodes an spsk secret key.
+func ParseSecp256k1PrivateKey(s string) (Secp256k1PrivateKey, error) {
+	secret, err := base58.Decode(s, base58.Spsk)
+	if err != nil {
+		return Secp256k1PrivateKey{}, errors.Wrapf(err, "could not parse secp256k1 secret key '%s'", truncate(s))
+	}
+	return NewSecp256k1FromSecret(secret)
+}
+
+// ParseSecp256k1PublicKey decodes an sppk public key.
+func ParseSecp256k1PublicKey(s string) (Secp256k1PublicKey, error) {
+	key, err := base58.Decode(s, base58.Sppk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse secp256k1 public key '%s'", s)
+	}
+	if _, err := secp256k1.ParsePubKey(key); err != nil {
+		return nil, errors.Wrapf(err, "could not parse secp256k1 public key '%s'", s)
+	}
+	return Secp256k1PublicKey(key), nil
+}
+
+// Public returns the sppk public key of k.
+func (k Secp256k1PrivateKey) Public() PublicKey {
+	return Secp256k1PublicKey(k.key.PubKey().SerializeCompressed())
+}
+
+// Sign signs the blake2b digest of message with deterministic nonces and returns the signature of 64 bytes, r and s
+// with s in the lower half of the order of the curve.
+func (k Secp256k1PrivateKey) Sign(message []byte) ([]byte, error) {
+	if k.key == nil {
+		return nil, errors.New("could not sign, invalid secp256k1 secret key")
+	}
+	// the compact signature is the recovery code followed by r and s
+	return ecdsa.SignCompact(k.key, digest(message), true)[1:], nil
+}
+
+// String returns the spsk encoding of k.
+func (k Secp256k1PrivateKey) String() string {
+	secret := make([]byte, 32)
+	if k.key != nil {
+		secret = k.key.Serialize()
+	}
+	return crypto.B58cencode(secret, crypto.Prefix_spsk)
+}
+
+// Address returns the tz2 address of k.
+func (k Secp256k1PublicKey) Address() string {
+	return address(k, crypto.Prefix_tz2)
+}
+
+// Bytes returns the 33 bytes of k.
+func (k Secp256k1PublicKey) Bytes() []byte {
+	return append([]byte{}, k...)
+}
+
+// String returns the sppk encoding of k.
+func (k Secp256k1PublicKey) String() string {
+	return crypto.B58cencode(k, crypto.Prefix_sppk)
+}
+
+// Verify reports whether signature is a signature of the blake2b digest of message by the secret key of k. Like
+// Tezos, it rejects signatures which s is in the upper half of the order of the curve.
+func (k Secp256k1PublicKey) Verify(message, signature []byte) bool {
+	public, err := secp256k1.ParsePubKey(k)
+	if err != nil || len(signature) != 64 {
+		return false
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) || r.IsZero() || s.IsZero() || s.IsOverHalfOrder() {
+		return false
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(digest(message), public)
+}
@@ -0,0 +1,88 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"gotest.tools/assert"
+)
+
+func Test_NewSecp256k1FromSecret(t *testing.T) {
+	one := make([]byte, 32)
+	one[31] = 1
+
+	cases := []struct {
+		name    string
+		secret  []byte
+		want    string
+		wantErr string
+	}{
+		{name: "Generator", secret: one, want: "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+		{name: "Zero", secret: make([]byte, 32), wantErr: "could not create secp256k1 key, invalid secret"},
+		{name: "Order", secret: secp256k1.Params().N.Bytes(), wantErr: "could not create secp256k1 key, invalid secret"},
+		{name: "Short", secret: []byte{1}, wantErr: "could not create secp256k1 key, invalid secret"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := NewSecp256k1FromSecret(tc.secret)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(key.Public().Bytes()), tc.want)
+		})
+	}
+}
+
+func Test_GenerateSecp256k1(t *testing.T) {
+	key, err := GenerateSecp256k1()
+	assert.NilError(t, err)
+	assert.Equal(t, key.String()[:4], "spsk")
+
+	parsed, err := ParsePrivateKey(key.String())
+	assert.NilError(t, err)
+	assert.Equal(t, parsed.Public().Address(), key.Public().Address())
+	assert.Equal(t, key.Public().Address()[:3], "tz2")
+
+	public, err := ParsePublicKey(key.Public().String())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, public.Bytes(), key.Public().Bytes())
+
+	signature, err := key.Sign([]byte("message"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(signature), 64)
+
+	var r, s secp256k1.ModNScalar
+	r.SetByteSlice(signature[:32])
+	s.SetByteSlice(signature[32:])
+	assert.Assert(t, !s.IsOverHalfOrder())
+	pub, err := secp256k1.ParsePubKey(public.Bytes())
+	assert.NilError(t, err)
+	assert.Assert(t, ecdsa.NewSignature(&r, &s).Verify(digest([]byte("message")), pub))
+	assert.Assert(t, public.Verify([]byte("message"), signature))
+
+	// the same signature with a high s is rejected
+	var high [32]byte
+	s.Negate().PutBytes(&high)
+	assert.Assert(t, !public.Verify([]byte("message"), append(signature[:32:32], high[:]...)))
+
+	other, err := GenerateP256()
+	assert.NilError(t, err)
+	_, err = ParseSecp256k1PublicKey(other.Public().String())
+	assert.ErrorContains(t, err, "unexpected prefix")
+	_, err = ParseSecp256k1PrivateKey(key.String()[:50])
+	assert.ErrorContains(t, err, "could not parse secp256k1 secret key 'spsk")
+}
+
+func Test_Secp256k1ZeroValue(t *testing.T) {
+	// the zero value encodes a secret of zeros, like P256PrivateKey and BLSPrivateKey
+	var key Secp256k1PrivateKey
+	assert.Equal(t, key.String(), "spsk1RZgUW68mN4kVJsjdEUCsDMhEGDXkp5yryy2Ca6uS4vwh7GEKh")
+
+	_, err := key.Sign([]byte("message"))
+	assert.Error(t, err, "could not sign, invalid secp256k1 secret key")
+}
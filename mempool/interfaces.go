@@ -0,0 +1,8 @@
+package mempool
+
+import "context"
+
+type TezosMempoolService interface {
+	GetPendingOperations(opts PendingOptions) (PendingOperations, error)
+	MonitorOperations(ctx context.Context, opts MonitorOptions) (<-chan Operation, error)
+}
@@ -0,0 +1,117 @@
+package mempool
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// MempoolService is a service for mempool related functions
+type MempoolService struct {
+	tzclient tzc.TezosClient
+}
+
+// PendingOperations are the operations of the mempool of a node, grouped by their validation status.
+// Applied holds the operations the node validated, named validated in the version 2 of the RPC output.
+type PendingOperations struct {
+	Applied       []Operation `json:"applied"`
+	Refused       []Operation `json:"refused"`
+	Outdated      []Operation `json:"outdated"`
+	BranchRefused []Operation `json:"branch_refused"`
+	BranchDelayed []Operation `json:"branch_delayed"`
+	Unprocessed   []Operation `json:"unprocessed"`
+}
+
+// Operation is an operation of the mempool. Errors are the errors that made the node refuse or delay it.
+type Operation struct {
+	Hash      string           `json:"hash"`
+	Protocol  string           `json:"protocol,omitempty"`
+	Branch    string           `json:"branch"`
+	Contents  []block.Contents `json:"contents"`
+	Signature string           `json:"signature,omitempty"`
+	Errors    []*tzc.RPCError  `json:"error,omitempty"`
+}
+
+// PendingOptions are the query parameters of GetPendingOperations. The zero value keeps the node defaults.
+type PendingOptions struct {
+	// Version is the version of the RPC output, e.g. "2"
+	Version string
+}
+
+// params returns the query parameters for opts
+func (o PendingOptions) params() map[string]string {
+	if o.Version == "" {
+		return nil
+	}
+	return map[string]string{"version": o.Version}
+}
+
+// NewMempoolService returns a new MempoolService
+func NewMempoolService(tzclient tzc.TezosClient) *MempoolService {
+	return &MempoolService{tzclient: tzclient}
+}
+
+// GetPendingOperations gets the operations of the mempool of the node
+func (m *MempoolService) GetPendingOperations(opts PendingOptions) (PendingOperations, error) {
+	var pending PendingOperations
+	query := "/chains/main/mempool/pending_operations"
+	resp, err := m.tzclient.Get(query, opts.params())
+	if err != nil {
+		return pending, errors.Wrapf(err, "could not get pending operations '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &pending); err != nil {
+		return pending, errors.Wrapf(err, "could not get pending operations '%s'", query)
+	}
+
+	return pending, nil
+}
+
+// UnmarshalJSON unmarshals PendingOperations of every version of the RPC output.
+func (p *PendingOperations) UnmarshalJSON(v []byte) error {
+	type pendingOperations PendingOperations
+	var pending struct {
+		pendingOperations
+		Validated []Operation `json:"validated"`
+	}
+	if err := json.Unmarshal(v, &pending); err != nil {
+		return err
+	}
+
+	*p = PendingOperations(pending.pendingOperations)
+	if len(p.Applied) == 0 {
+		p.Applied = pending.Validated
+	}
+	return nil
+}
+
+// UnmarshalJSON unmarshals an Operation, either an object or a pair of its hash and the operation
+// as in the groups of refused operations of the version 0 of the RPC output.
+func (o *Operation) UnmarshalJSON(v []byte) error {
+	type operation Operation
+	var op operation
+
+	var pair []json.RawMessage
+	if json.Unmarshal(v, &pair) == nil {
+		if len(pair) != 2 {
+			return errors.Errorf("could not unmarshal operation, expected a pair, got %d values", len(pair))
+		}
+		if err := json.Unmarshal(pair[1], &op); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(pair[0], &op.Hash); err != nil {
+			return err
+		}
+		*o = Operation(op)
+		return nil
+	}
+
+	if err := json.Unmarshal(v, &op); err != nil {
+		return err
+	}
+	*o = Operation(op)
+	return nil
+}
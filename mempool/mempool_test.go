@@ -0,0 +1,62 @@
+package mempool
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_GetPendingOperations(t *testing.T) {
+	cases := []struct {
+		name       string
+		opts       PendingOptions
+		returnBody []byte
+		wantParams map[string]string
+		wantErr    bool
+	}{
+		{
+			name:       "Version 0",
+			returnBody: goldenPendingOperationsV0,
+		},
+		{
+			name:       "Version 2",
+			opts:       PendingOptions{Version: "2"},
+			returnBody: goldenPendingOperationsV2,
+			wantParams: map[string]string{"version": "2"},
+		},
+		{
+			name:       "Malformed response",
+			returnBody: []byte(`{"refused":[["opCvZ9sPUeRW2A2LhqDfpPqHcxtxwkvUJFrvDR4BxQGG2LUbgpg"]]}`),
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &clientMock{ReturnBody: tc.returnBody}
+			pending, err := NewMempoolService(client).GetPendingOperations(tc.opts)
+			assert.Equal(t, client.Path, "/chains/main/mempool/pending_operations")
+			assert.DeepEqual(t, client.Params, tc.wantParams)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+
+			assert.Equal(t, len(pending.Applied), 1)
+			assert.Equal(t, pending.Applied[0].Hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+			assert.Equal(t, pending.Applied[0].Contents[0].Amount, tez.Mutez(1000000))
+
+			assert.Equal(t, len(pending.Refused), 1)
+			assert.Equal(t, pending.Refused[0].Hash, "opCvZ9sPUeRW2A2LhqDfpPqHcxtxwkvUJFrvDR4BxQGG2LUbgpg")
+			assert.Equal(t, pending.Refused[0].Errors[0].ID, "node.prevalidation.fees_too_low")
+
+			assert.Equal(t, len(pending.BranchRefused), 1)
+			assert.Equal(t, pending.BranchRefused[0].Contents[0].Kind, "delegation")
+			assert.Equal(t, pending.BranchRefused[0].Errors[0].Kind, "branch")
+			assert.Equal(t, len(pending.Outdated), 0)
+		})
+	}
+}
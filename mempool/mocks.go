@@ -0,0 +1,89 @@
+package mempool
+
+import (
+	"context"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+type clientMock struct {
+	ReturnBody []byte
+	Path       string
+	Params     map[string]string
+}
+
+func (c *clientMock) Post(path, args string) ([]byte, error) {
+	return c.ReturnBody, nil
+}
+
+func (c *clientMock) Get(path string, params map[string]string) ([]byte, error) {
+	c.Path, c.Params = path, params
+	return c.ReturnBody, nil
+}
+
+// streamClientMock serves one of Connections every time the stream is opened, then blocks until ctx is done
+type streamClientMock struct {
+	clientMock
+	Connections [][][]byte
+	Opened      int
+}
+
+func (c *streamClientMock) Stream(ctx context.Context, path string, params map[string]string, fn tzc.StreamHandler) error {
+	for ctx.Err() == nil {
+		if err := c.StreamOnce(ctx, path, params, fn); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (c *streamClientMock) StreamOnce(ctx context.Context, path string, params map[string]string, fn tzc.StreamHandler) error {
+	c.Path, c.Params = path, params
+	if c.Opened == len(c.Connections) {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	values := c.Connections[c.Opened]
+	c.Opened++
+	for _, value := range values {
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var goldenPendingOperationsV0 = []byte(`{
+	"applied": [{"hash":"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH","branch":"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
+		"contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1420","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"1000000","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}],
+		"signature":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe"}],
+	"refused": [["opCvZ9sPUeRW2A2LhqDfpPqHcxtxwkvUJFrvDR4BxQGG2LUbgpg",{"protocol":"PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf","branch":"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
+		"contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"0","counter":"3","gas_limit":"10307","storage_limit":"0","amount":"1","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}],
+		"signature":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe",
+		"error":[{"kind":"permanent","id":"node.prevalidation.fees_too_low"}]}]],
+	"outdated": [],
+	"branch_refused": [["onvPYDCDpv2nX7orHrq6Fw1rJRyxsQjJVXnDJE7FhJGgT1jwGXP",{"protocol":"PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf","branch":"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
+		"contents":[{"kind":"delegation","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1257","counter":"1","gas_limit":"1100","storage_limit":"0"}],
+		"signature":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe",
+		"error":[{"kind":"branch","id":"proto.017-PtNairob.contract.counter_in_the_past"}]}]],
+	"branch_delayed": [],
+	"unprocessed": []
+}`)
+
+var goldenPendingOperationsV2 = []byte(`{
+	"validated": [{"hash":"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH","branch":"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
+		"contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1420","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"1000000","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}],
+		"signature":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe"}],
+	"refused": [{"hash":"opCvZ9sPUeRW2A2LhqDfpPqHcxtxwkvUJFrvDR4BxQGG2LUbgpg","protocol":"PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf","branch":"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
+		"contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"0","counter":"3","gas_limit":"10307","storage_limit":"0","amount":"1","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}],
+		"signature":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe",
+		"error":[{"kind":"permanent","id":"node.prevalidation.fees_too_low"}]}],
+	"outdated": [],
+	"branch_refused": [{"hash":"onvPYDCDpv2nX7orHrq6Fw1rJRyxsQjJVXnDJE7FhJGgT1jwGXP","protocol":"PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf","branch":"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
+		"contents":[{"kind":"delegation","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1257","counter":"1","gas_limit":"1100","storage_limit":"0"}],
+		"signature":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe",
+		"error":[{"kind":"branch","id":"proto.017-PtNairob.contract.counter_in_the_past"}]}],
+	"branch_delayed": [],
+	"unprocessed": []
+}`)
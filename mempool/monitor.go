@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// monitorBackoff is the time waited before reconnecting after the monitor stream failed
+var monitorBackoff = time.Second
+
+// MonitorOptions are the query parameters of MonitorOperations. Validated operations are always received,
+// operations of the other groups only when asked for.
+type MonitorOptions struct {
+	Refused       bool
+	Outdated      bool
+	BranchRefused bool
+	BranchDelayed bool
+	// Version is the version of the RPC output, e.g. "2"
+	Version string
+}
+
+// params returns the query parameters for opts
+func (o MonitorOptions) params() map[string]string {
+	params := map[string]string{}
+	for name, set := range map[string]bool{
+		"refused":        o.Refused,
+		"outdated":       o.Outdated,
+		"branch_refused": o.BranchRefused,
+		"branch_delayed": o.BranchDelayed,
+	} {
+		if set {
+			params[name] = strconv.FormatBool(set)
+		}
+	}
+	if o.Version != "" {
+		params["version"] = o.Version
+	}
+
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+// MonitorOperations returns a channel receiving the operations arriving in the mempool of the node, read from
+// /chains/main/mempool/monitor_operations. The node ends the stream on every new head and sends the whole mempool
+// again after reconnecting, operations already received are not sent twice. The channel is closed once ctx is done.
+func (m *MempoolService) MonitorOperations(ctx context.Context, opts MonitorOptions) (<-chan Operation, error) {
+	streamer, ok := m.tzclient.(tzc.Streamer)
+	if !ok {
+		return nil, errors.New("could not monitor operations, client does not support streaming")
+	}
+
+	operations := make(chan Operation)
+	go func() {
+		defer close(operations)
+
+		// Operations of the previous connection, the ones included in the new head are not sent again
+		// so only the operations of the last two connections are remembered.
+		previous := map[string]bool{}
+		for ctx.Err() == nil {
+			current := map[string]bool{}
+			err := streamer.StreamOnce(ctx, "/chains/main/mempool/monitor_operations", opts.params(), func(value json.RawMessage) error {
+				var ops []Operation
+				if err := json.Unmarshal(value, &ops); err != nil {
+					return nil
+				}
+
+				for _, op := range ops {
+					seen := previous[op.Hash] || current[op.Hash]
+					current[op.Hash] = true
+					if seen {
+						continue
+					}
+
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case operations <- op:
+					}
+				}
+				return nil
+			})
+			if len(current) > 0 {
+				previous = current
+			}
+
+			if err != nil {
+				select {
+				case <-ctx.Done():
+				case <-time.After(monitorBackoff):
+				}
+			}
+		}
+	}()
+
+	return operations, nil
+}
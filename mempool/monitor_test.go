@@ -0,0 +1,52 @@
+package mempool
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_MonitorOperations(t *testing.T) {
+	client := &streamClientMock{
+		Connections: [][][]byte{
+			{
+				[]byte(`[{"hash":"ooa","branch":"BLa","contents":[{"kind":"transaction"}]},{"hash":"oob","branch":"BLa","contents":[{"kind":"delegation"}]}]`),
+				[]byte(`[{"hash":"ooc","branch":"BLa","contents":[{"kind":"reveal"}]}]`),
+			},
+			// After a new head the node sends the operations still in the mempool again
+			{
+				[]byte(`[{"hash":"ooc","branch":"BLa","contents":[{"kind":"reveal"}]},{"hash":"ood","branch":"BLb","contents":[{"kind":"transaction"}]}]`),
+				[]byte(`not an array`),
+				[]byte(`[{"hash":"ooe","branch":"BLb","contents":[{"kind":"transaction"}],"error":[{"kind":"permanent","id":"node.prevalidation.fees_too_low"}]}]`),
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	operations, err := NewMempoolService(client).MonitorOperations(ctx, MonitorOptions{Refused: true, Version: "2"})
+	assert.NilError(t, err)
+
+	var hashes []string
+	for len(hashes) < 5 {
+		op := <-operations
+		hashes = append(hashes, op.Hash)
+		if op.Hash == "ooe" {
+			assert.Equal(t, op.Errors[0].ID, "node.prevalidation.fees_too_low")
+		}
+	}
+	assert.DeepEqual(t, hashes, []string{"ooa", "oob", "ooc", "ood", "ooe"})
+
+	cancel()
+	for range operations {
+	}
+	assert.Equal(t, client.Path, "/chains/main/mempool/monitor_operations")
+	assert.DeepEqual(t, client.Params, map[string]string{"refused": "true", "version": "2"})
+}
+
+func Test_MonitorOperationsNoStreaming(t *testing.T) {
+	_, err := NewMempoolService(&clientMock{}).MonitorOperations(context.Background(), MonitorOptions{})
+	assert.ErrorContains(t, err, "does not support streaming")
+}
@@ -0,0 +1,104 @@
+// Package metrics exposes Prometheus metrics about the RPC calls made by the Tezos client.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// Collector records request counts, latencies, errors and node failovers of the Tezos client.
+// It implements prometheus.Collector so it can be registered with any prometheus.Registerer.
+type Collector struct {
+	requests  *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	failovers *prometheus.CounterVec
+}
+
+// NewCollector returns a new Collector, metric names are prefixed with namespace when it is not empty.
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tezos_rpc",
+			Name:      "requests_total",
+			Help:      "Number of RPC requests sent to Tezos nodes, by endpoint and status code.",
+		}, []string{"method", "endpoint", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tezos_rpc",
+			Name:      "errors_total",
+			Help:      "Number of RPC requests to Tezos nodes that failed, by endpoint.",
+		}, []string{"method", "endpoint"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "tezos_rpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of RPC requests to Tezos nodes, by endpoint.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "endpoint"}),
+		failovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tezos_rpc",
+			Name:      "node_failovers_total",
+			Help:      "Number of times a request failed over away from a node in a pool, by node.",
+		}, []string{"node"}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.latency.Describe(ch)
+	c.failovers.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.latency.Collect(ch)
+	c.failovers.Collect(ch)
+}
+
+// Option is a client option that records every RPC call made by the client, see Middleware.
+func (c *Collector) Option() tzc.Option {
+	return tzc.WithMiddleware(c.Middleware())
+}
+
+// Middleware returns client middleware that records every RPC call. Requests are labelled
+// by endpoint, see client.Endpoint. Requests that never got a response are counted with code "none".
+func (c *Collector) Middleware() tzc.Middleware {
+	return func(next tzc.Handler) tzc.Handler {
+		return func(req *tzc.Request) (*tzc.Response, error) {
+			endpoint := tzc.Endpoint(req.Path)
+
+			start := time.Now()
+			resp, err := next(req)
+			c.latency.WithLabelValues(req.Method, endpoint).Observe(time.Since(start).Seconds())
+
+			code := "none"
+			if resp != nil {
+				code = strconv.Itoa(resp.StatusCode)
+			}
+			c.requests.WithLabelValues(req.Method, endpoint, code).Inc()
+			if err != nil {
+				c.errors.WithLabelValues(req.Method, endpoint).Inc()
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// ObservePool counts the node failovers of pool.
+func (c *Collector) ObservePool(pool *tzc.Pool) {
+	pool.OnFailover(func(node string, err error) {
+		c.failovers.WithLabelValues(node).Inc()
+	})
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/assert"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+func Test_Middleware(t *testing.T) {
+	collector := NewCollector("")
+	registry := prometheus.NewRegistry()
+	assert.NilError(t, registry.Register(collector))
+
+	ok := collector.Middleware()(func(req *tzc.Request) (*tzc.Response, error) {
+		return &tzc.Response{StatusCode: http.StatusOK}, nil
+	})
+	failed := collector.Middleware()(func(req *tzc.Request) (*tzc.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	_, err := ok(&tzc.Request{Method: http.MethodGet, Path: "/chains/main/blocks/head"})
+	assert.NilError(t, err)
+	_, err = ok(&tzc.Request{Method: http.MethodGet, Path: "/chains/main/blocks/524067"})
+	assert.NilError(t, err)
+	_, err = failed(&tzc.Request{Method: http.MethodGet, Path: "/chains/main/blocks/head"})
+	assert.Assert(t, err != nil)
+
+	want := `
+# HELP tezos_rpc_requests_total Number of RPC requests sent to Tezos nodes, by endpoint and status code.
+# TYPE tezos_rpc_requests_total counter
+tezos_rpc_requests_total{code="200",endpoint="/chains/{chain}/blocks/{block_id}",method="GET"} 2
+tezos_rpc_requests_total{code="none",endpoint="/chains/{chain}/blocks/{block_id}",method="GET"} 1
+# HELP tezos_rpc_errors_total Number of RPC requests to Tezos nodes that failed, by endpoint.
+# TYPE tezos_rpc_errors_total counter
+tezos_rpc_errors_total{endpoint="/chains/{chain}/blocks/{block_id}",method="GET"} 1
+`
+	err = testutil.GatherAndCompare(registry, strings.NewReader(want), "tezos_rpc_requests_total", "tezos_rpc_errors_total")
+	assert.NilError(t, err)
+	assert.Equal(t, testutil.CollectAndCount(collector, "tezos_rpc_request_duration_seconds"), 1)
+}
+
+func Test_ObservePool(t *testing.T) {
+	collector := NewCollector("baker")
+	pool, err := tzc.NewPool([]string{"http://127.0.0.1:1", "http://127.0.0.1:2"}, tzc.WithTimeout(0))
+	assert.NilError(t, err)
+	collector.ObservePool(pool)
+
+	_, err = pool.Get("/chains/main/blocks/head", nil)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, testutil.ToFloat64(collector.failovers.WithLabelValues("http://127.0.0.1:1")), float64(1))
+	assert.Equal(t, testutil.ToFloat64(collector.failovers.WithLabelValues("http://127.0.0.1:2")), float64(1))
+}
@@ -0,0 +1,354 @@
+package micheline
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Tags of the binary encoding of micheline expressions
+const (
+	tagInt            = 0
+	tagString         = 1
+	tagSequence       = 2
+	tagPrim           = 3
+	tagPrimAnnots     = 4
+	tagPrimArg        = 5
+	tagPrimArgAnnots  = 6
+	tagPrimArgs       = 7
+	tagPrimArgsAnnots = 8
+	tagPrimGeneric    = 9
+	tagBytes          = 10
+)
+
+// primitives are the Michelson primitives in the order of their binary code
+var primitives = []string{
+	"parameter", "storage", "code", "False", "Elt", "Left", "None", "Pair", "Right", "Some",
+	"True", "Unit", "PACK", "UNPACK", "BLAKE2B", "SHA256", "SHA512", "ABS", "ADD", "AMOUNT",
+	"AND", "BALANCE", "CAR", "CDR", "CHECK_SIGNATURE", "COMPARE", "CONCAT", "CONS", "CREATE_ACCOUNT", "CREATE_CONTRACT",
+	"IMPLICIT_ACCOUNT", "DIP", "DROP", "DUP", "EDIV", "EMPTY_MAP", "EMPTY_SET", "EQ", "EXEC", "FAILWITH",
+	"GE", "GET", "GT", "HASH_KEY", "IF", "IF_CONS", "IF_LEFT", "IF_NONE", "INT", "LAMBDA",
+	"LE", "LEFT", "LOOP", "LSL", "LSR", "LT", "MAP", "MEM", "MUL", "NEG",
+	"NEQ", "NIL", "NONE", "NOT", "NOW", "OR", "PAIR", "PUSH", "RIGHT", "SIZE",
+	"SOME", "SOURCE", "SENDER", "SELF", "STEPS_TO_QUOTA", "SUB", "SWAP", "TRANSFER_TOKENS", "SET_DELEGATE", "UNIT",
+	"UPDATE", "XOR", "ITER", "LOOP_LEFT", "ADDRESS", "CONTRACT", "ISNAT", "CAST", "RENAME", "bool",
+	"contract", "int", "key", "key_hash", "lambda", "list", "map", "big_map", "nat", "option",
+	"or", "pair", "set", "signature", "string", "bytes", "mutez", "timestamp", "unit", "operation",
+	"address", "SLICE", "DIG", "DUG", "EMPTY_BIG_MAP", "APPLY", "chain_id", "CHAIN_ID", "LEVEL", "SELF_ADDRESS",
+	"never", "NEVER", "UNPAIR", "VOTING_POWER", "TOTAL_VOTING_POWER", "KECCAK", "SHA3", "PAIRING_CHECK", "bls12_381_g1", "bls12_381_g2",
+	"bls12_381_fr", "sapling_state", "sapling_transaction_deprecated", "SAPLING_EMPTY_STATE", "SAPLING_VERIFY_UPDATE", "ticket", "TICKET_DEPRECATED", "READ_TICKET", "SPLIT_TICKET", "JOIN_TICKETS",
+	"GET_AND_UPDATE", "chest", "chest_key", "OPEN_CHEST", "VIEW", "view", "constant", "SUB_MUTEZ", "tx_rollup_l2_address", "MIN_BLOCK_TIME",
+	"sapling_transaction", "EMIT", "Lambda_rec", "LAMBDA_REC", "TICKET", "BYTES", "NAT",
+	"Ticket", "IS_IMPLICIT_ACCOUNT", "INDEX_ADDRESS", "GET_TOTAL_VOTING_POWER",
+}
+
+var primitiveCodes = func() map[string]byte {
+	codes := make(map[string]byte, len(primitives))
+	for code, prim := range primitives {
+		codes[prim] = byte(code)
+	}
+	return codes
+}()
+
+// IsPrimitive reports whether prim is a Michelson primitive known to the binary encoding.
+func IsPrimitive(prim string) bool {
+	_, ok := primitiveCodes[prim]
+	return ok
+}
+
+// MarshalBinary encodes n to the binary format of Micheline, the format of the expressions of forged operations
+// and, prefixed by 0x05, of packed data.
+func MarshalBinary(n Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeNode(&buf, n); err != nil {
+		return nil, errors.Wrap(err, "could not marshal micheline")
+	}
+	return buf.Bytes(), nil
+}
+
+func writeNode(buf *bytes.Buffer, n Node) error {
+	switch v := n.(type) {
+	case Int:
+		if v.Value == nil {
+			return errors.New("int without value")
+		}
+		buf.WriteByte(tagInt)
+		writeInt(buf, v.Value)
+	case String:
+		buf.WriteByte(tagString)
+		writeSized(buf, []byte(v))
+	case Bytes:
+		buf.WriteByte(tagBytes)
+		writeSized(buf, v)
+	case Seq:
+		buf.WriteByte(tagSequence)
+		return writeSizedNodes(buf, v)
+	case Prim:
+		return writePrim(buf, v)
+	default:
+		return errors.Errorf("unsupported node %T", n)
+	}
+	return nil
+}
+
+func writePrim(buf *bytes.Buffer, p Prim) error {
+	code, ok := primitiveCodes[p.Prim]
+	if !ok {
+		return errors.Errorf("unknown primitive '%s'", p.Prim)
+	}
+
+	annotated := len(p.Annots) > 0
+	if len(p.Args) > 2 {
+		buf.WriteByte(tagPrimGeneric)
+		buf.WriteByte(code)
+		if err := writeSizedNodes(buf, p.Args); err != nil {
+			return err
+		}
+		writeSized(buf, []byte(strings.Join(p.Annots, " ")))
+		return nil
+	}
+
+	tag := tagPrim + 2*len(p.Args)
+	if annotated {
+		tag++
+	}
+	buf.WriteByte(byte(tag))
+	buf.WriteByte(code)
+	for _, arg := range p.Args {
+		if err := writeNode(buf, arg); err != nil {
+			return err
+		}
+	}
+	if annotated {
+		writeSized(buf, []byte(strings.Join(p.Annots, " ")))
+	}
+	return nil
+}
+
+func writeSizedNodes(buf *bytes.Buffer, nodes []Node) error {
+	var content bytes.Buffer
+	for _, n := range nodes {
+		if err := writeNode(&content, n); err != nil {
+			return err
+		}
+	}
+	writeSized(buf, content.Bytes())
+	return nil
+}
+
+func writeSized(buf *bytes.Buffer, v []byte) {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(v)))
+	buf.Write(size[:])
+	buf.Write(v)
+}
+
+// writeInt writes i as a zarith integer, the first byte holds the sign and 6 bits, the others 7 bits
+func writeInt(buf *bytes.Buffer, i *big.Int) {
+	abs := new(big.Int).Abs(i)
+	first := byte(new(big.Int).And(abs, big.NewInt(0x3f)).Int64())
+	if i.Sign() < 0 {
+		first |= 0x40
+	}
+	abs.Rsh(abs, 6)
+	if abs.Sign() == 0 {
+		buf.WriteByte(first)
+		return
+	}
+	buf.WriteByte(first | 0x80)
+	for {
+		b := byte(new(big.Int).And(abs, big.NewInt(0x7f)).Int64())
+		abs.Rsh(abs, 7)
+		if abs.Sign() == 0 {
+			buf.WriteByte(b)
+			return
+		}
+		buf.WriteByte(b | 0x80)
+	}
+}
+
+// UnmarshalBinary decodes the binary Micheline expression b, b must hold a single expression.
+func UnmarshalBinary(b []byte) (Node, error) {
+	r := reader{b: b}
+	n, err := r.node()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal binary micheline")
+	}
+	if r.len() != 0 {
+		return nil, errors.Errorf("could not unmarshal binary micheline, unexpected data at byte %d", r.pos)
+	}
+	return n, nil
+}
+
+// reader reads binary micheline
+type reader struct {
+	b   []byte
+	pos int
+}
+
+func (r *reader) len() int {
+	return len(r.b) - r.pos
+}
+
+func (r *reader) next(n int) ([]byte, error) {
+	if n < 0 || r.len() < n {
+		return nil, errors.Errorf("unexpected end of data at byte %d", r.pos)
+	}
+	v := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return v, nil
+}
+
+func (r *reader) readByte() (byte, error) {
+	v, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return v[0], nil
+}
+
+func (r *reader) sized() ([]byte, error) {
+	size, err := r.next(4)
+	if err != nil {
+		return nil, err
+	}
+	return r.next(int(binary.BigEndian.Uint32(size)))
+}
+
+func (r *reader) node() (Node, error) {
+	tag, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case tagInt:
+		i, err := r.integer()
+		if err != nil {
+			return nil, err
+		}
+		return Int{Value: i}, nil
+	case tagString:
+		s, err := r.sized()
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(s) {
+			return nil, errors.New("invalid micheline string")
+		}
+		return String(s), nil
+	case tagBytes:
+		b, err := r.sized()
+		if err != nil {
+			return nil, err
+		}
+		return Bytes(append([]byte{}, b...)), nil
+	case tagSequence:
+		nodes, err := r.sizedNodes()
+		if err != nil {
+			return nil, err
+		}
+		return Seq(nodes), nil
+	case tagPrimGeneric:
+		p, err := r.prim()
+		if err != nil {
+			return nil, err
+		}
+		if p.Args, err = r.sizedNodes(); err != nil {
+			return nil, err
+		}
+		annots, err := r.sized()
+		if err != nil {
+			return nil, err
+		}
+		if len(annots) > 0 {
+			p.Annots = strings.Split(string(annots), " ")
+		}
+		return p, nil
+	}
+
+	if tag < tagPrim || tag > tagPrimArgsAnnots {
+		return nil, errors.Errorf("unknown micheline tag %d", tag)
+	}
+
+	p, err := r.prim()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(tag-tagPrim)/2; i++ {
+		arg, err := r.node()
+		if err != nil {
+			return nil, err
+		}
+		p.Args = append(p.Args, arg)
+	}
+	if (tag-tagPrim)%2 == 1 {
+		annots, err := r.sized()
+		if err != nil {
+			return nil, err
+		}
+		p.Annots = strings.Split(string(annots), " ")
+	}
+	return p, nil
+}
+
+func (r *reader) prim() (Prim, error) {
+	code, err := r.readByte()
+	if err != nil {
+		return Prim{}, err
+	}
+	if int(code) >= len(primitives) {
+		return Prim{}, errors.Errorf("unknown primitive %d", code)
+	}
+	return Prim{Prim: primitives[code]}, nil
+}
+
+func (r *reader) sizedNodes() ([]Node, error) {
+	content, err := r.sized()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{}
+	sub := reader{b: content}
+	for sub.len() > 0 {
+		n, err := sub.node()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// integer reads a zarith integer
+func (r *reader) integer() (*big.Int, error) {
+	first, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	i := big.NewInt(int64(first & 0x3f))
+	if first&0x80 != 0 {
+		var shift uint = 6
+		for {
+			b, err := r.readByte()
+			if err != nil {
+				return nil, err
+			}
+			i.Or(i, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+			shift += 7
+			if b&0x80 == 0 {
+				break
+			}
+		}
+	}
+	if first&0x40 != 0 {
+		i.Neg(i)
+	}
+	return i, nil
+}
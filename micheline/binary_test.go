@@ -0,0 +1,251 @@
+package micheline
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// The expected bytes follow the binary encoding of octez-codec (alpha.script.expr).
+var binaryVectors = []struct {
+	name string
+	json string
+	want string
+}{
+	{
+		name: "Zero",
+		json: `{"int":"0"}`,
+		want: "0000",
+	},
+	{
+		name: "Negative int",
+		json: `{"int":"-1"}`,
+		want: "0041",
+	},
+	{
+		name: "Largest one byte int",
+		json: `{"int":"63"}`,
+		want: "003f",
+	},
+	{
+		name: "Two bytes int",
+		json: `{"int":"64"}`,
+		want: "008001",
+	},
+	{
+		name: "Negative two bytes int",
+		json: `{"int":"-64"}`,
+		want: "00c001",
+	},
+	{
+		name: "Big int",
+		json: `{"int":"123456789012345678901234567890"}`,
+		want: "0092abf8e3c9bbf0f386dbff90dd63",
+	},
+	{
+		name: "String",
+		json: `{"string":"hello"}`,
+		want: "010000000568656c6c6f",
+	},
+	{
+		name: "Unicode string",
+		json: `{"string":"tézos"}`,
+		want: "010000000674c3a97a6f73",
+	},
+	{
+		name: "Empty bytes",
+		json: `{"bytes":""}`,
+		want: "0a00000000",
+	},
+	{
+		name: "Bytes",
+		json: `{"bytes":"050a0bff"}`,
+		want: "0a00000004050a0bff",
+	},
+	{
+		name: "Empty sequence",
+		json: `[]`,
+		want: "0200000000",
+	},
+	{
+		name: "Primitive",
+		json: `{"prim":"Unit"}`,
+		want: "030b",
+	},
+	{
+		name: "Annotated primitive",
+		json: `{"prim":"nat","annots":["%amount",":a"]}`,
+		want: "04620000000a25616d6f756e74203a61",
+	},
+	{
+		name: "Primitive with an argument",
+		json: `{"prim":"Some","args":[{"int":"1"}]}`,
+		want: "05090001",
+	},
+	{
+		name: "Annotated primitive with an argument",
+		json: `{"prim":"option","args":[{"prim":"nat"}],"annots":["%o"]}`,
+		want: "0663036200000002256f",
+	},
+	{
+		name: "Primitive with two arguments",
+		json: `{"prim":"Pair","args":[{"string":"a"},{"int":"-5"}]}`,
+		want: "07070100000001610045",
+	},
+	{
+		name: "Annotated primitive with two arguments",
+		json: `{"prim":"pair","args":[{"prim":"nat","annots":["%n"]},{"prim":"string"}],"annots":[":p"]}`,
+		want: "0865046200000002256e0368000000023a70",
+	},
+	{
+		name: "Primitive with three arguments",
+		json: `{"prim":"pair","args":[{"prim":"nat"},{"prim":"string"},{"prim":"bytes"}]}`,
+		want: "09650000000603620368036900000000",
+	},
+	{
+		name: "Annotated primitive with three arguments",
+		json: `{"prim":"pair","args":[{"prim":"nat"},{"prim":"string"},{"prim":"bytes"}],"annots":["%triple"]}`,
+		want: "0965000000060362036803690000000725747269706c65",
+	},
+	{
+		name: "Code",
+		json: `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"int"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"PUSH","args":[{"prim":"int"},{"int":"1"}]},{"prim":"ADD"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`,
+		want: "020000001f0500036c0501035b0502020000001003170743035b00010312053d036d0342",
+	},
+	{
+		name: "Map",
+		json: `[{"prim":"Elt","args":[{"string":"a"},{"int":"1"}]},{"prim":"Elt","args":[{"string":"b"},{"int":"2"}]}]`,
+		want: "02000000140704010000000161000107040100000001620002",
+	},
+	{
+		name: "Lambda_rec",
+		json: `{"prim":"Lambda_rec","args":[[{"prim":"DROP"}]]}`,
+		want: "059802000000020320",
+	},
+	{
+		name: "Ticket",
+		json: `{"prim":"Ticket","args":[{"string":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t"},{"prim":"nat"},{"int":"1"},{"int":"10"}]}`,
+		want: "099d0000002f01000000244b5431564c6236744a4c676d63575453783775643455326e33634e48524251677861317403620001000a00000000",
+	},
+	{
+		name: "Instructions since Oxford",
+		json: `[{"prim":"IS_IMPLICIT_ACCOUNT"},{"prim":"INDEX_ADDRESS"},{"prim":"GET_TOTAL_VOTING_POWER"}]`,
+		want: "0200000006039e039f03a0",
+	},
+	{
+		name: "Packed pair",
+		json: `{"prim":"Pair","args":[{"int":"1"},{"prim":"Pair","args":[{"string":"a"},{"bytes":"ff"}]}]}`,
+		want: "0707000107070100000001610a00000001ff",
+	},
+}
+
+func Test_MarshalBinary(t *testing.T) {
+	for _, tc := range binaryVectors {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := Unmarshal([]byte(tc.json))
+			assert.NilError(t, err)
+
+			b, err := MarshalBinary(n)
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(b), tc.want)
+		})
+	}
+}
+
+func Test_UnmarshalBinary(t *testing.T) {
+	for _, tc := range binaryVectors {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.want)
+			assert.NilError(t, err)
+
+			n, err := UnmarshalBinary(b)
+			assert.NilError(t, err)
+
+			data, err := json.Marshal(n)
+			assert.NilError(t, err)
+			assert.Equal(t, string(data), tc.json)
+		})
+	}
+}
+
+func Test_MarshalBinaryErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		node    Node
+		wantErr string
+	}{
+		{
+			name:    "Unknown primitive",
+			node:    NewPrim("Nothing"),
+			wantErr: "could not marshal micheline: unknown primitive 'Nothing'",
+		},
+		{
+			name:    "Unknown primitive in a sequence",
+			node:    Seq{NewPrim("DROP"), NewPrim("drop")},
+			wantErr: "could not marshal micheline: unknown primitive 'drop'",
+		},
+		{
+			name:    "Int without value",
+			node:    Int{},
+			wantErr: "could not marshal micheline: int without value",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := MarshalBinary(tc.node)
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func Test_UnmarshalBinaryErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		bytes   string
+		wantErr string
+	}{
+		{
+			name:    "Empty",
+			bytes:   "",
+			wantErr: "could not unmarshal binary micheline: unexpected end of data at byte 0",
+		},
+		{
+			name:    "Unknown tag",
+			bytes:   "0b",
+			wantErr: "could not unmarshal binary micheline: unknown micheline tag 11",
+		},
+		{
+			name:    "Unknown primitive",
+			bytes:   "03ff",
+			wantErr: "could not unmarshal binary micheline: unknown primitive 255",
+		},
+		{
+			name:    "Truncated string",
+			bytes:   "010000000568656c6c",
+			wantErr: "could not unmarshal binary micheline: unexpected end of data at byte 5",
+		},
+		{
+			name:    "Invalid string",
+			bytes:   "0100000001ff",
+			wantErr: "could not unmarshal binary micheline: invalid micheline string",
+		},
+		{
+			name:    "Trailing data",
+			bytes:   "030b030b",
+			wantErr: "could not unmarshal binary micheline, unexpected data at byte 2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.bytes)
+			assert.NilError(t, err)
+
+			_, err = UnmarshalBinary(b)
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
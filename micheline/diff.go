@@ -0,0 +1,226 @@
+package micheline
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Change is a value that differs between two data of the same type. Path locates the value in the data, its
+// segments joined by "/" are the field annotations of the type, or the position of the leaf in its pair when it
+// has none, the positions of list elements and the keys of maps printed as Michelson, e.g. `ledger/"tz1..."`. Before is nil for a value added
+// to a map and After is nil for a value removed from it.
+type Change struct {
+	Path   string
+	Before Node
+	After  Node
+}
+
+// Diff returns the changes between the data before and after of type typ. Pairs, ors in the same branch, options
+// holding values and maps are compared value by value, any other data, e.g. lists or big map ids, is a single
+// change when it differs.
+func Diff(before, after, typ Node) ([]Change, error) {
+	var changes []Change
+	if err := diff(nil, before, after, typ, &changes); err != nil {
+		return nil, errors.Wrap(err, "could not diff micheline")
+	}
+	return changes, nil
+}
+
+func diff(path []string, before, after, typ Node, changes *[]Change) error {
+	if Equal(before, after) {
+		return nil
+	}
+	t, ok := typ.(Prim)
+	if !ok {
+		return errors.Errorf("invalid type %T", typ)
+	}
+
+	switch t.Prim {
+	case "pair":
+		leaves := pairLeaves(t)
+		b, err := pairValues(before, t)
+		if err != nil {
+			return err
+		}
+		a, err := pairValues(after, t)
+		if err != nil {
+			return err
+		}
+		for i, l := range leaves {
+			if err := diff(append(path, leafName(l, i)), b[i], a[i], l.typ, changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "or":
+		b, bok := before.(Prim)
+		a, aok := after.(Prim)
+		if !bok || !aok || b.Prim != a.Prim || len(b.Args) != 1 || len(a.Args) != 1 || len(t.Args) != 2 {
+			break
+		}
+		branch := t.Args[0]
+		if a.Prim == "Right" {
+			branch = t.Args[1]
+		}
+		if p, ok := branch.(Prim); ok && p.Annot(FieldAnnot) != "" {
+			path = append(path, p.Annot(FieldAnnot))
+		}
+		return diff(path, b.Args[0], a.Args[0], branch, changes)
+	case "option":
+		b, bok := before.(Prim)
+		a, aok := after.(Prim)
+		if !bok || !aok || b.Prim != "Some" || a.Prim != "Some" || len(b.Args) != 1 || len(a.Args) != 1 || len(t.Args) != 1 {
+			break
+		}
+		return diff(path, b.Args[0], a.Args[0], t.Args[0], changes)
+	case "map", "big_map":
+		b, bok := before.(Seq)
+		a, aok := after.(Seq)
+		if !bok || !aok || len(t.Args) != 2 {
+			break
+		}
+		return diffMap(path, b, a, t, changes)
+	}
+
+	*changes = append(*changes, Change{Path: strings.Join(path, "/"), Before: before, After: after})
+	return nil
+}
+
+// leafName returns the path segment of the leaf l at the position i of its pair
+func leafName(l leaf, i int) string {
+	if p, ok := l.typ.(Prim); ok && p.Annot(FieldAnnot) != "" {
+		return p.Annot(FieldAnnot)
+	}
+	return strconv.Itoa(i)
+}
+
+// diffMap appends the changes between the elements of the maps before and after, the changed and added elements
+// in the order of after followed by the removed elements
+func diffMap(path []string, before, after Seq, t Prim, changes *[]Change) error {
+	elts := func(m Seq) ([]string, map[string]Prim, error) {
+		keys := make([]string, 0, len(m))
+		values := make(map[string]Prim, len(m))
+		for _, item := range m {
+			elt, ok := item.(Prim)
+			if !ok || elt.Prim != "Elt" || len(elt.Args) != 2 {
+				return nil, nil, errors.New("invalid map element")
+			}
+			key := Print(elt.Args[0])
+			keys = append(keys, key)
+			values[key] = elt
+		}
+		return keys, values, nil
+	}
+
+	beforeKeys, beforeElts, err := elts(before)
+	if err != nil {
+		return err
+	}
+	afterKeys, afterElts, err := elts(after)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range afterKeys {
+		a := afterElts[key]
+		b, ok := beforeElts[key]
+		if !ok {
+			*changes = append(*changes, Change{Path: strings.Join(append(path, key), "/"), After: a.Args[1]})
+			continue
+		}
+		if err := diff(append(path, key), b.Args[1], a.Args[1], t.Args[1], changes); err != nil {
+			return err
+		}
+	}
+	for _, key := range beforeKeys {
+		if _, ok := afterElts[key]; !ok {
+			*changes = append(*changes, Change{Path: strings.Join(append(path, key), "/"), Before: beforeElts[key].Args[1]})
+		}
+	}
+	return nil
+}
+
+// BigMap is a big map of data, Path locates its id in the data like the Path of a Change does.
+type BigMap struct {
+	Path string
+	ID   int64
+}
+
+// BigMaps returns the big maps of the data of type typ, e.g. of the storage of a contract, in the order of the data.
+// Big maps given by their elements rather than by an id, like in the storage of a contract being originated, are
+// ignored.
+func BigMaps(data, typ Node) ([]BigMap, error) {
+	var bigMaps []BigMap
+	if err := findBigMaps(nil, data, typ, &bigMaps); err != nil {
+		return nil, errors.Wrap(err, "could not get big maps")
+	}
+	return bigMaps, nil
+}
+
+func findBigMaps(path []string, data, typ Node, bigMaps *[]BigMap) error {
+	t, ok := typ.(Prim)
+	if !ok {
+		return errors.Errorf("invalid type %T", typ)
+	}
+
+	switch t.Prim {
+	case "big_map":
+		if id, ok := data.(Int); ok && id.Value != nil && id.Value.IsInt64() {
+			*bigMaps = append(*bigMaps, BigMap{Path: strings.Join(path, "/"), ID: id.Value.Int64()})
+		}
+	case "pair":
+		values, err := pairValues(data, t)
+		if err != nil {
+			return err
+		}
+		for i, l := range pairLeaves(t) {
+			if err := findBigMaps(append(path, leafName(l, i)), values[i], l.typ, bigMaps); err != nil {
+				return err
+			}
+		}
+	case "or":
+		d, ok := data.(Prim)
+		if !ok || (d.Prim != "Left" && d.Prim != "Right") || len(d.Args) != 1 || len(t.Args) != 2 {
+			return typeMismatch(data, t)
+		}
+		branch := t.Args[0]
+		if d.Prim == "Right" {
+			branch = t.Args[1]
+		}
+		if p, ok := branch.(Prim); ok && p.Annot(FieldAnnot) != "" {
+			path = append(path, p.Annot(FieldAnnot))
+		}
+		return findBigMaps(path, d.Args[0], branch, bigMaps)
+	case "option":
+		if d, ok := data.(Prim); ok && d.Prim == "Some" && len(d.Args) == 1 && len(t.Args) == 1 {
+			return findBigMaps(path, d.Args[0], t.Args[0], bigMaps)
+		}
+	case "list", "set":
+		items, ok := data.(Seq)
+		if !ok || len(t.Args) != 1 {
+			return typeMismatch(data, t)
+		}
+		for i, item := range items {
+			if err := findBigMaps(append(path, strconv.Itoa(i)), item, t.Args[0], bigMaps); err != nil {
+				return err
+			}
+		}
+	case "map":
+		items, ok := data.(Seq)
+		if !ok || len(t.Args) != 2 {
+			return typeMismatch(data, t)
+		}
+		for _, item := range items {
+			elt, ok := item.(Prim)
+			if !ok || elt.Prim != "Elt" || len(elt.Args) != 2 {
+				return errors.New("invalid map element")
+			}
+			if err := findBigMaps(append(path, Print(elt.Args[0])), elt.Args[1], t.Args[1], bigMaps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
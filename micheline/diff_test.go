@@ -0,0 +1,75 @@
+package micheline
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Diff(t *testing.T) {
+	typ := mustParse(t, `pair (big_map %ledger address nat) (map %operators string (pair (nat %limit) bool)) (option %admin address) (or %state (unit %paused) (nat %round)) (list nat)`)
+
+	cases := []struct {
+		name   string
+		before string
+		after  string
+		want   []string
+	}{
+		{
+			name:   "Unchanged",
+			before: `Pair 7 { Elt "a" (Pair 1 True) } None (Left Unit) { 1 }`,
+			after:  `Pair 7 { Elt "a" (Pair 1 True) } None (Left Unit) { 1 }`,
+			want:   nil,
+		},
+		{
+			name:   "Leaves",
+			before: `Pair 7 {} (Some "tz1a") (Right 1) { 1 }`,
+			after:  `Pair 8 {} (Some "tz1b") (Right 2) { 1 ; 2 }`,
+			want:   []string{`ledger: 7 -> 8`, `admin: "tz1a" -> "tz1b"`, `state/round: 1 -> 2`, `4: { 1 } -> { 1 ; 2 }`},
+		},
+		{
+			name:   "Maps",
+			before: `Pair 7 { Elt "a" (Pair 1 True) ; Elt "b" (Pair 2 True) } None (Left Unit) {}`,
+			after:  `Pair 7 { Elt "a" (Pair 1 False) ; Elt "c" (Pair 3 True) } None (Left Unit) {}`,
+			want:   []string{`operators/"a"/1: True -> False`, `operators/"c": <nil> -> Pair 3 True`, `operators/"b": Pair 2 True -> <nil>`},
+		},
+		{
+			name:   "Branches",
+			before: `Pair 7 {} None (Left Unit) {}`,
+			after:  `Pair 7 {} (Some "tz1a") (Right 1) {}`,
+			want:   []string{`admin: None -> Some "tz1a"`, `state: Left Unit -> Right 1`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := Diff(mustParse(t, tc.before), mustParse(t, tc.after), typ)
+			assert.NilError(t, err)
+
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.Path+": "+printOrNil(c.Before)+" -> "+printOrNil(c.After))
+			}
+			assert.DeepEqual(t, got, tc.want)
+		})
+	}
+
+	_, err := Diff(mustParse(t, `Pair 1 2`), mustParse(t, `Unit`), mustParse(t, `pair nat nat`))
+	assert.Error(t, err, "could not diff micheline: Unit does not match type pair")
+}
+
+func Test_BigMaps(t *testing.T) {
+	typ := mustParse(t, `pair (big_map %ledger address nat) (option %metadata (big_map string bytes)) (map string (big_map nat nat)) (big_map nat nat)`)
+	data := mustParse(t, `Pair 7 (Some 8) { Elt "x" 9 } {}`)
+
+	bigMaps, err := BigMaps(data, typ)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, bigMaps, []BigMap{{Path: "ledger", ID: 7}, {Path: "metadata", ID: 8}, {Path: `2/"x"`, ID: 9}})
+}
+
+func printOrNil(n Node) string {
+	if n == nil {
+		return "<nil>"
+	}
+	return Print(n)
+}
@@ -0,0 +1,149 @@
+package micheline
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// comparisons are the suffixes of the comparison macros, e.g. CMPEQ or IFCMPLT
+var comparisons = map[string]bool{"EQ": true, "NEQ": true, "LT": true, "GT": true, "LE": true, "GE": true}
+
+var (
+	carCdrMacro = regexp.MustCompile(`^C[AD]{2,}R$`)
+	dupMacro    = regexp.MustCompile(`^DU{2,}P$`)
+	dipMacro    = regexp.MustCompile(`^DI{2,}P$`)
+)
+
+// Expand returns n with the macros of octez-client replaced by the instructions they stand for, as octez-client
+// does before sending a script. The expanded macros are FAIL, ASSERT, ASSERT_{EQ,NEQ,LT,GT,LE,GE},
+// ASSERT_CMP{EQ,...}, ASSERT_{NONE,SOME,LEFT,RIGHT}, CMP{EQ,...}, IF{EQ,...}, IFCMP{EQ,...}, IF_SOME, IF_RIGHT,
+// C[AD]+R, DUU+P and DII+P. n is left unchanged.
+func Expand(n Node) (Node, error) {
+	return Rewrite(n, func(n Node) (Node, error) {
+		p, ok := n.(Prim)
+		if !ok {
+			return n, nil
+		}
+		expanded, ok, err := expandMacro(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid macro '%s'", p.Prim)
+		}
+		if !ok {
+			return n, nil
+		}
+		// macros expand to other macros, e.g. ASSERT to FAIL
+		return Expand(expanded)
+	})
+}
+
+// expandMacro expands p, it returns false when p is not a macro
+func expandMacro(p Prim) (Node, bool, error) {
+	name := p.Prim
+	switch {
+	case name == "FAIL":
+		if err := arity(p, 0); err != nil {
+			return nil, true, err
+		}
+		return Seq{NewPrim("UNIT"), NewPrim("FAILWITH")}, true, nil
+	case name == "ASSERT":
+		if err := arity(p, 0); err != nil {
+			return nil, true, err
+		}
+		return Seq{Prim{Prim: "IF", Args: failFalse(nil), Annots: p.Annots}}, true, nil
+	case name == "ASSERT_NONE" || name == "ASSERT_SOME" || name == "ASSERT_LEFT" || name == "ASSERT_RIGHT":
+		if err := arity(p, 0); err != nil {
+			return nil, true, err
+		}
+		branches := failFalse(p.Annots)
+		if name == "ASSERT_SOME" || name == "ASSERT_RIGHT" {
+			branches[0], branches[1] = branches[1], branches[0]
+		}
+		prim := "IF_NONE"
+		if name == "ASSERT_LEFT" || name == "ASSERT_RIGHT" {
+			prim = "IF_LEFT"
+		}
+		return Seq{NewPrim(prim, branches...)}, true, nil
+	case strings.HasPrefix(name, "ASSERT_"):
+		op := strings.TrimPrefix(name, "ASSERT_")
+		if !comparisons[strings.TrimPrefix(op, "CMP")] {
+			return nil, false, nil
+		}
+		if err := arity(p, 0); err != nil {
+			return nil, true, err
+		}
+		return Seq{NewPrim(op), Prim{Prim: "IF", Args: failFalse(nil), Annots: p.Annots}}, true, nil
+	case strings.HasPrefix(name, "CMP") && comparisons[strings.TrimPrefix(name, "CMP")]:
+		if err := arity(p, 0); err != nil {
+			return nil, true, err
+		}
+		return Seq{NewPrim("COMPARE"), Prim{Prim: strings.TrimPrefix(name, "CMP"), Annots: p.Annots}}, true, nil
+	case strings.HasPrefix(name, "IFCMP") && comparisons[strings.TrimPrefix(name, "IFCMP")]:
+		if err := arity(p, 2); err != nil {
+			return nil, true, err
+		}
+		op := strings.TrimPrefix(name, "IFCMP")
+		return Seq{NewPrim("COMPARE"), NewPrim(op), Prim{Prim: "IF", Args: p.Args, Annots: p.Annots}}, true, nil
+	case strings.HasPrefix(name, "IF") && comparisons[strings.TrimPrefix(name, "IF")]:
+		if err := arity(p, 2); err != nil {
+			return nil, true, err
+		}
+		op := strings.TrimPrefix(name, "IF")
+		return Seq{NewPrim(op), Prim{Prim: "IF", Args: p.Args, Annots: p.Annots}}, true, nil
+	case name == "IF_SOME" || name == "IF_RIGHT":
+		if err := arity(p, 2); err != nil {
+			return nil, true, err
+		}
+		prim := "IF_NONE"
+		if name == "IF_RIGHT" {
+			prim = "IF_LEFT"
+		}
+		return Seq{Prim{Prim: prim, Args: []Node{p.Args[1], p.Args[0]}, Annots: p.Annots}}, true, nil
+	case carCdrMacro.MatchString(name):
+		if err := arity(p, 0); err != nil {
+			return nil, true, err
+		}
+		path := name[1 : len(name)-1]
+		seq := make(Seq, len(path))
+		for i, c := range path {
+			prim := Prim{Prim: "CAR"}
+			if c == 'D' {
+				prim.Prim = "CDR"
+			}
+			if i == len(path)-1 {
+				prim.Annots = p.Annots
+			}
+			seq[i] = prim
+		}
+		return seq, true, nil
+	case dupMacro.MatchString(name):
+		if err := arity(p, 0); err != nil {
+			return nil, true, err
+		}
+		return Seq{Prim{Prim: "DUP", Args: []Node{NewInt(int64(len(name) - 2))}, Annots: p.Annots}}, true, nil
+	case dipMacro.MatchString(name):
+		if err := arity(p, 1); err != nil {
+			return nil, true, err
+		}
+		return Seq{Prim{Prim: "DIP", Args: []Node{NewInt(int64(len(name) - 2)), p.Args[0]}, Annots: p.Annots}}, true, nil
+	}
+	return nil, false, nil
+}
+
+// failFalse returns the branches of a conditional failing when the condition is false, the first branch renames
+// the value when annots are given
+func failFalse(annots []string) []Node {
+	keep := Seq{}
+	if len(annots) > 0 {
+		keep = Seq{Prim{Prim: "RENAME", Annots: annots}}
+	}
+	return []Node{keep, Seq{NewPrim("FAIL")}}
+}
+
+func arity(p Prim, n int) error {
+	if len(p.Args) != n {
+		return errors.Errorf("expected %d arguments, got %d", n, len(p.Args))
+	}
+	return nil
+}
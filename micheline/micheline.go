@@ -0,0 +1,316 @@
+// Package micheline represents Micheline expressions, the format of Michelson code, types and data,
+// with their JSON encoding as returned by the RPC API.
+package micheline
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Node is a Micheline expression: an Int, a String, a Bytes, a Prim or a Seq.
+type Node interface {
+	isNode()
+}
+
+// Int is an integer literal, e.g. {"int":"42"}.
+type Int struct {
+	Value *big.Int
+}
+
+// String is a string literal, e.g. {"string":"tz1..."}.
+type String string
+
+// Bytes is a bytes literal, e.g. {"bytes":"0a0b"}.
+type Bytes []byte
+
+// Seq is a sequence of expressions, e.g. a block of instructions or the elements of a list.
+type Seq []Node
+
+// Prim is the application of a primitive to its arguments, e.g. {"prim":"pair","args":[{"prim":"nat"},{"prim":"string"}]}.
+type Prim struct {
+	Prim   string
+	Args   []Node
+	Annots []string
+}
+
+func (Int) isNode()    {}
+func (String) isNode() {}
+func (Bytes) isNode()  {}
+func (Seq) isNode()    {}
+func (Prim) isNode()   {}
+
+// NewInt returns the Int of i.
+func NewInt(i int64) Int {
+	return Int{Value: big.NewInt(i)}
+}
+
+// NewPrim returns the Prim applying prim to args.
+func NewPrim(prim string, args ...Node) Prim {
+	return Prim{Prim: prim, Args: args}
+}
+
+// Annotations prefixes
+const (
+	TypeAnnot  = ":"
+	FieldAnnot = "%"
+	VarAnnot   = "@"
+)
+
+// Annot returns the first annotation of p with prefix, e.g. FieldAnnot, without its prefix.
+// It returns an empty string when p has no such annotation.
+func (p Prim) Annot(prefix string) string {
+	for _, annot := range p.Annots {
+		if strings.HasPrefix(annot, prefix) {
+			return strings.TrimPrefix(annot, prefix)
+		}
+	}
+	return ""
+}
+
+// HasAnnot reports whether p is annotated with annot, prefix included, e.g. "%transfer".
+func (p Prim) HasAnnot(annot string) bool {
+	for _, a := range p.Annots {
+		if a == annot {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutAnnots returns p without its annotations, its arguments keep theirs.
+func (p Prim) WithoutAnnots() Prim {
+	return Prim{Prim: p.Prim, Args: p.Args}
+}
+
+// MarshalJSON encodes i as {"int":"..."}.
+func (i Int) MarshalJSON() ([]byte, error) {
+	if i.Value == nil {
+		return nil, errors.New("could not marshal micheline int, no value")
+	}
+	return json.Marshal(map[string]string{"int": i.Value.String()})
+}
+
+// MarshalJSON encodes s as {"string":"..."}.
+func (s String) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"string": string(s)})
+}
+
+// MarshalJSON encodes b as {"bytes":"..."}.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"bytes": hex.EncodeToString(b)})
+}
+
+// MarshalJSON encodes s as a JSON array, an empty sequence is [].
+func (s Seq) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Node(s))
+}
+
+// MarshalJSON encodes p as {"prim":"...","args":[...],"annots":[...]}, leaving out empty arguments and annotations.
+func (p Prim) MarshalJSON() ([]byte, error) {
+	if p.Prim == "" {
+		return nil, errors.New("could not marshal micheline primitive, no primitive")
+	}
+	return json.Marshal(struct {
+		Prim   string   `json:"prim"`
+		Args   []Node   `json:"args,omitempty"`
+		Annots []string `json:"annots,omitempty"`
+	}{p.Prim, p.Args, p.Annots})
+}
+
+// Unmarshal decodes the Micheline JSON data.
+func Unmarshal(data []byte) (Node, error) {
+	n, err := unmarshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal micheline")
+	}
+	return n, nil
+}
+
+func unmarshal(data []byte) (Node, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	if data[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, errors.Wrap(err, "invalid sequence")
+		}
+		seq := make(Seq, 0, len(items))
+		for _, item := range items {
+			n, err := unmarshal(item)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, n)
+		}
+		return seq, nil
+	}
+
+	var expr struct {
+		Prim   *string           `json:"prim"`
+		Args   []json.RawMessage `json:"args"`
+		Annots []string          `json:"annots"`
+		Int    *string           `json:"int"`
+		String *string           `json:"string"`
+		Bytes  *string           `json:"bytes"`
+	}
+	if err := json.Unmarshal(data, &expr); err != nil {
+		return nil, errors.Wrap(err, "invalid expression")
+	}
+
+	switch {
+	case expr.Int != nil:
+		i, ok := new(big.Int).SetString(*expr.Int, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid int '%s'", *expr.Int)
+		}
+		return Int{Value: i}, nil
+	case expr.String != nil:
+		return String(*expr.String), nil
+	case expr.Bytes != nil:
+		b, err := hex.DecodeString(*expr.Bytes)
+		if err != nil {
+			return nil, errors.Errorf("invalid bytes '%s'", *expr.Bytes)
+		}
+		return Bytes(b), nil
+	case expr.Prim != nil && *expr.Prim != "":
+		p := Prim{Prim: *expr.Prim, Annots: expr.Annots}
+		for _, arg := range expr.Args {
+			n, err := unmarshal(arg)
+			if err != nil {
+				return nil, err
+			}
+			p.Args = append(p.Args, n)
+		}
+		return p, nil
+	}
+	return nil, errors.Errorf("invalid expression '%s'", string(data))
+}
+
+// Expr holds a Node in structs decoded from JSON, e.g. the parameters of an operation.
+type Expr struct {
+	Node
+}
+
+// MarshalJSON encodes the Node of e.
+func (e Expr) MarshalJSON() ([]byte, error) {
+	if e.Node == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(e.Node)
+}
+
+// UnmarshalJSON decodes the Micheline JSON v into the Node of e.
+func (e *Expr) UnmarshalJSON(v []byte) error {
+	if string(bytes.TrimSpace(v)) == "null" {
+		e.Node = nil
+		return nil
+	}
+	n, err := Unmarshal(v)
+	if err != nil {
+		return err
+	}
+	e.Node = n
+	return nil
+}
+
+// Walk calls fn for n and its descendants, parents first. The descendants of a node are skipped when fn returns false.
+func Walk(n Node, fn func(Node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	switch v := n.(type) {
+	case Seq:
+		for _, item := range v {
+			Walk(item, fn)
+		}
+	case Prim:
+		for _, arg := range v.Args {
+			Walk(arg, fn)
+		}
+	}
+}
+
+// Rewrite returns n with every node replaced by the result of fn, children first so fn sees the rewritten
+// children of a node. n is left unchanged.
+func Rewrite(n Node, fn func(Node) (Node, error)) (Node, error) {
+	switch v := n.(type) {
+	case Seq:
+		seq := make(Seq, len(v))
+		for i, item := range v {
+			rewritten, err := Rewrite(item, fn)
+			if err != nil {
+				return nil, err
+			}
+			seq[i] = rewritten
+		}
+		n = seq
+	case Prim:
+		p := Prim{Prim: v.Prim, Annots: v.Annots}
+		if v.Args != nil {
+			p.Args = make([]Node, len(v.Args))
+		}
+		for i, arg := range v.Args {
+			rewritten, err := Rewrite(arg, fn)
+			if err != nil {
+				return nil, err
+			}
+			p.Args[i] = rewritten
+		}
+		n = p
+	}
+	return fn(n)
+}
+
+// Equal reports whether a and b are the same expression, annotations included.
+func Equal(a, b Node) bool {
+	switch x := a.(type) {
+	case Int:
+		y, ok := b.(Int)
+		return ok && x.Value != nil && y.Value != nil && x.Value.Cmp(y.Value) == 0
+	case String:
+		y, ok := b.(String)
+		return ok && x == y
+	case Bytes:
+		y, ok := b.(Bytes)
+		return ok && bytes.Equal(x, y)
+	case Seq:
+		y, ok := b.(Seq)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !Equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case Prim:
+		y, ok := b.(Prim)
+		if !ok || x.Prim != y.Prim || len(x.Args) != len(y.Args) || len(x.Annots) != len(y.Annots) {
+			return false
+		}
+		for i := range x.Annots {
+			if x.Annots[i] != y.Annots[i] {
+				return false
+			}
+		}
+		for i := range x.Args {
+			if !Equal(x.Args[i], y.Args[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == nil && b == nil
+}
@@ -0,0 +1,159 @@
+package micheline
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Unmarshal(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    string
+		want    Node
+		wantErr string
+	}{
+		{
+			name: "Int",
+			data: `{"int":"-123456789012345678901234567890"}`,
+			want: Int{Value: func() *big.Int { i, _ := new(big.Int).SetString("-123456789012345678901234567890", 10); return i }()},
+		},
+		{
+			name: "String",
+			data: `{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}`,
+			want: String("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		},
+		{
+			name: "Bytes",
+			data: `{"bytes":"0a0bff"}`,
+			want: Bytes{0x0a, 0x0b, 0xff},
+		},
+		{
+			name: "Empty sequence",
+			data: `[]`,
+			want: Seq{},
+		},
+		{
+			name: "Annotated primitives",
+			data: `{"prim":"pair","args":[{"prim":"address","annots":["%owner"]},{"prim":"nat","annots":["%balance",":b"]}],"annots":[":account"]}`,
+			want: Prim{
+				Prim: "pair",
+				Args: []Node{
+					Prim{Prim: "address", Annots: []string{"%owner"}},
+					Prim{Prim: "nat", Annots: []string{"%balance", ":b"}},
+				},
+				Annots: []string{":account"},
+			},
+		},
+		{
+			name: "Code",
+			data: `[{"prim":"CDR"},{"prim":"PUSH","args":[{"prim":"int"},{"int":"1"}]},{"prim":"ADD"}]`,
+			want: Seq{NewPrim("CDR"), NewPrim("PUSH", NewPrim("int"), NewInt(1)), NewPrim("ADD")},
+		},
+		{
+			name:    "Invalid int",
+			data:    `{"int":"1.5"}`,
+			wantErr: "could not unmarshal micheline: invalid int '1.5'",
+		},
+		{
+			name:    "Invalid bytes",
+			data:    `{"bytes":"0g"}`,
+			wantErr: "could not unmarshal micheline: invalid bytes '0g'",
+		},
+		{
+			name:    "Unknown expression",
+			data:    `{"nat":"1"}`,
+			wantErr: `could not unmarshal micheline: invalid expression '{"nat":"1"}'`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := Unmarshal([]byte(tc.data))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, Equal(n, tc.want))
+
+			data, err := json.Marshal(n)
+			assert.NilError(t, err)
+			assert.Equal(t, string(data), tc.data)
+		})
+	}
+}
+
+func Test_Expr(t *testing.T) {
+	var parameters struct {
+		Entrypoint string `json:"entrypoint"`
+		Value      Expr   `json:"value"`
+	}
+	data := `{"entrypoint":"transfer","value":{"prim":"Pair","args":[{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},{"int":"10"}]}}`
+	assert.NilError(t, json.Unmarshal([]byte(data), &parameters))
+	assert.Assert(t, Equal(parameters.Value.Node, NewPrim("Pair", String("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"), NewInt(10))))
+
+	marshaled, err := json.Marshal(parameters)
+	assert.NilError(t, err)
+	assert.Equal(t, string(marshaled), data)
+}
+
+func Test_Annot(t *testing.T) {
+	p := Prim{Prim: "nat", Annots: []string{":amount", "%value", "@v"}}
+	assert.Equal(t, p.Annot(TypeAnnot), "amount")
+	assert.Equal(t, p.Annot(FieldAnnot), "value")
+	assert.Equal(t, p.Annot(VarAnnot), "v")
+	assert.Assert(t, p.HasAnnot("%value"))
+	assert.Assert(t, !p.HasAnnot("value"))
+	assert.Assert(t, Equal(p.WithoutAnnots(), NewPrim("nat")))
+}
+
+func Test_Walk(t *testing.T) {
+	code := Seq{NewPrim("DIP", Seq{NewPrim("DROP")}), NewPrim("PUSH", NewPrim("nat"), NewInt(1))}
+
+	var prims []string
+	Walk(code, func(n Node) bool {
+		p, ok := n.(Prim)
+		if ok {
+			prims = append(prims, p.Prim)
+		}
+		return !ok || p.Prim != "DIP"
+	})
+	assert.DeepEqual(t, prims, []string{"DIP", "PUSH", "nat"})
+}
+
+func Test_Rewrite(t *testing.T) {
+	code := Seq{NewPrim("PUSH", NewPrim("nat"), NewInt(1)), NewPrim("PUSH", NewPrim("nat"), NewInt(2))}
+
+	rewritten, err := Rewrite(code, func(n Node) (Node, error) {
+		if i, ok := n.(Int); ok {
+			return Int{Value: new(big.Int).Mul(i.Value, big.NewInt(10))}, nil
+		}
+		return n, nil
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, Equal(rewritten, Seq{NewPrim("PUSH", NewPrim("nat"), NewInt(10)), NewPrim("PUSH", NewPrim("nat"), NewInt(20))}))
+	assert.Assert(t, Equal(code, Seq{NewPrim("PUSH", NewPrim("nat"), NewInt(1)), NewPrim("PUSH", NewPrim("nat"), NewInt(2))}))
+}
+
+func Test_Equal(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b Node
+		want bool
+	}{
+		{name: "Same ints", a: NewInt(1), b: NewInt(1), want: true},
+		{name: "Int and string", a: NewInt(1), b: String("1"), want: false},
+		{name: "Different annotations", a: Prim{Prim: "nat", Annots: []string{"%a"}}, b: Prim{Prim: "nat", Annots: []string{"%b"}}, want: false},
+		{name: "Nil sequence and empty sequence", a: Seq(nil), b: Seq{}, want: true},
+		{name: "Nested sequences", a: Seq{Seq{NewPrim("DROP")}}, b: Seq{Seq{NewPrim("DROP")}}, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, Equal(tc.a, tc.b), tc.want)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package micheline
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Parse parses Michelson source, the format of .tz files and of octez-client arguments, into a Micheline expression.
+// A script, e.g. "parameter unit; storage int; code { ... }", is parsed into the sequence of its sections, and
+// data or a type, e.g. "Pair 1 \"a\"", into a single expression. The macros of octez-client listed by Expand are
+// expanded.
+func Parse(src string) (Node, error) {
+	p := parser{lexer: lexer{src: src, line: 1, col: 1}}
+	if err := p.advance(); err != nil {
+		return nil, errors.Wrap(err, "could not parse michelson")
+	}
+
+	var (
+		nodes     []Node
+		separated bool
+	)
+	for p.tok.kind != tokenEOF {
+		n, err := p.expr()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse michelson")
+		}
+		nodes = append(nodes, n)
+
+		if p.tok.kind == tokenEOF {
+			break
+		}
+		if p.tok.kind != tokenSemicolon {
+			return nil, errors.Wrap(p.unexpected(), "could not parse michelson")
+		}
+		separated = true
+		if err := p.advance(); err != nil {
+			return nil, errors.Wrap(err, "could not parse michelson")
+		}
+	}
+
+	var n Node
+	switch {
+	case len(nodes) == 0:
+		return nil, errors.New("could not parse michelson, empty source")
+	case len(nodes) == 1 && !separated:
+		n = nodes[0]
+	default:
+		n = Seq(nodes)
+	}
+
+	expanded, err := Expand(n)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse michelson")
+	}
+	return expanded, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenInt
+	tokenString
+	tokenBytes
+	tokenIdent
+	tokenAnnot
+	tokenOpenBrace
+	tokenCloseBrace
+	tokenOpenParen
+	tokenCloseParen
+	tokenSemicolon
+)
+
+type token struct {
+	kind      tokenKind
+	text      string
+	line, col int
+}
+
+// lexer splits Michelson source into tokens, skipping blanks and comments
+type lexer struct {
+	src       string
+	pos       int
+	line, col int
+}
+
+func (l *lexer) peek() byte {
+	if l.pos >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos]
+}
+
+func (l *lexer) read() byte {
+	c := l.src[l.pos]
+	l.pos++
+	if c == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return c
+}
+
+func (l *lexer) skipBlanks() error {
+	for l.pos < len(l.src) {
+		switch c := l.peek(); {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			l.read()
+		case c == '#':
+			for l.pos < len(l.src) && l.peek() != '\n' {
+				l.read()
+			}
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			line, col := l.line, l.col
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end < 0 {
+				return errors.Errorf("unterminated comment at line %d, column %d", line, col)
+			}
+			for n := end + 4; n > 0; n-- {
+				l.read()
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipBlanks(); err != nil {
+		return token{}, err
+	}
+
+	tok := token{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		tok.kind = tokenEOF
+		return tok, nil
+	}
+
+	start := l.pos
+	switch c := l.peek(); {
+	case c == '{' || c == '}' || c == '(' || c == ')' || c == ';':
+		l.read()
+		tok.kind = map[byte]tokenKind{
+			'{': tokenOpenBrace, '}': tokenCloseBrace, '(': tokenOpenParen, ')': tokenCloseParen, ';': tokenSemicolon,
+		}[c]
+	case c == '"':
+		s, err := l.string()
+		if err != nil {
+			return tok, errors.Wrapf(err, "invalid string at line %d, column %d", tok.line, tok.col)
+		}
+		tok.kind, tok.text = tokenString, s
+		return tok, nil
+	case strings.HasPrefix(l.src[l.pos:], "0x"):
+		l.read()
+		l.read()
+		for isHex(l.peek()) {
+			l.read()
+		}
+		tok.kind = tokenBytes
+	case c == '-' || isDigit(c):
+		l.read()
+		for isDigit(l.peek()) {
+			l.read()
+		}
+		if l.src[start:l.pos] == "-" {
+			return tok, errors.Errorf("invalid int at line %d, column %d", tok.line, tok.col)
+		}
+		tok.kind = tokenInt
+	case c == '@' || c == ':' || c == '%':
+		for l.pos < len(l.src) && isAnnotChar(l.peek()) {
+			l.read()
+		}
+		tok.kind = tokenAnnot
+	case isLetter(c) || c == '_':
+		for l.pos < len(l.src) && (isLetter(l.peek()) || isDigit(l.peek()) || l.peek() == '_') {
+			l.read()
+		}
+		tok.kind = tokenIdent
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return tok, errors.Errorf("unexpected character '%c' at line %d, column %d", r, tok.line, tok.col)
+	}
+	tok.text = l.src[start:l.pos]
+	return tok, nil
+}
+
+// string reads a string literal and its escape sequences
+func (l *lexer) string() (string, error) {
+	l.read()
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return "", errors.New("unterminated string")
+		}
+		c := l.read()
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\n':
+			return "", errors.New("unescaped new line")
+		case '\\':
+			if l.pos >= len(l.src) {
+				return "", errors.New("unterminated string")
+			}
+			switch e := l.read(); e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case '\\', '"':
+				b.WriteByte(e)
+			default:
+				return "", errors.Errorf("unknown escape sequence '\\%c'", e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isAnnotChar(c byte) bool {
+	return isLetter(c) || isDigit(c) || c == '_' || c == '.' || c == '%' || c == '@' || c == ':'
+}
+
+// parser builds expressions from the tokens of its lexer
+type parser struct {
+	lexer lexer
+	tok   token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return errors.Errorf("unexpected end of source at line %d, column %d", p.tok.line, p.tok.col)
+	}
+	return errors.Errorf("unexpected '%s' at line %d, column %d", p.tok.text, p.tok.line, p.tok.col)
+}
+
+// expr parses an expression, a primitive applied to its arguments without parentheses included
+func (p *parser) expr() (Node, error) {
+	if p.tok.kind == tokenIdent {
+		return p.application()
+	}
+	return p.atom()
+}
+
+// application parses a primitive with its annotations and arguments
+func (p *parser) application() (Node, error) {
+	prim := Prim{Prim: p.tok.text}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokenAnnot {
+		prim.Annots = append(prim.Annots, p.tok.text)
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		switch p.tok.kind {
+		case tokenInt, tokenString, tokenBytes, tokenOpenBrace, tokenOpenParen, tokenIdent:
+			arg, err := p.atom()
+			if err != nil {
+				return nil, err
+			}
+			prim.Args = append(prim.Args, arg)
+		default:
+			return prim, nil
+		}
+	}
+}
+
+// atom parses a literal, a sequence, a parenthesized expression or a primitive without arguments
+func (p *parser) atom() (Node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenInt:
+		i, ok := new(big.Int).SetString(tok.text, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid int '%s' at line %d, column %d", tok.text, tok.line, tok.col)
+		}
+		return Int{Value: i}, p.advance()
+	case tokenString:
+		return String(tok.text), p.advance()
+	case tokenBytes:
+		b, err := hex.DecodeString(tok.text[2:])
+		if err != nil {
+			return nil, errors.Errorf("invalid bytes '%s' at line %d, column %d", tok.text, tok.line, tok.col)
+		}
+		return Bytes(b), p.advance()
+	case tokenIdent:
+		return Prim{Prim: tok.text}, p.advance()
+	case tokenOpenParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenCloseParen {
+			return nil, p.unexpected()
+		}
+		return n, p.advance()
+	case tokenOpenBrace:
+		return p.seq()
+	}
+	return nil, p.unexpected()
+}
+
+// seq parses a sequence of expressions separated by semicolons, a trailing semicolon is allowed
+func (p *parser) seq() (Node, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	seq := Seq{}
+	for p.tok.kind != tokenCloseBrace {
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, n)
+
+		if p.tok.kind == tokenSemicolon {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		} else if p.tok.kind != tokenCloseBrace {
+			return nil, p.unexpected()
+		}
+	}
+	return seq, p.advance()
+}
@@ -0,0 +1,118 @@
+package micheline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Parse(t *testing.T) {
+	cases := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "Script",
+			src: `# a counter
+parameter (or (int %increment) (unit %reset));
+storage int;
+code { UNPAIR ;
+       IF_LEFT { ADD } { DROP 2 ; PUSH int 0 } ; /* back to zero */
+       NIL operation ;
+       PAIR }`,
+			want: `[{"prim":"parameter","args":[{"prim":"or","args":[{"prim":"int","annots":["%increment"]},{"prim":"unit","annots":["%reset"]}]}]},` +
+				`{"prim":"storage","args":[{"prim":"int"}]},` +
+				`{"prim":"code","args":[[{"prim":"UNPAIR"},{"prim":"IF_LEFT","args":[[{"prim":"ADD"}],[{"prim":"DROP","args":[{"int":"2"}]},{"prim":"PUSH","args":[{"prim":"int"},{"int":"0"}]}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`,
+		},
+		{
+			name: "Data",
+			src:  `Pair 1 "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx" { Elt 0x0aff (Some -3) ; Elt 0x None }`,
+			want: `{"prim":"Pair","args":[{"int":"1"},{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},[{"prim":"Elt","args":[{"bytes":"0aff"},{"prim":"Some","args":[{"int":"-3"}]}]},{"prim":"Elt","args":[{"bytes":""},{"prim":"None"}]}]]}`,
+		},
+		{
+			name: "Type",
+			src:  `(pair :account (address %owner) (nat %balance :b))`,
+			want: `{"prim":"pair","args":[{"prim":"address","annots":["%owner"]},{"prim":"nat","annots":["%balance",":b"]}],"annots":[":account"]}`,
+		},
+		{
+			name: "String escapes",
+			src:  `"say \"hi\"\n\\"`,
+			want: `{"string":"say \"hi\"\n\\"}`,
+		},
+		{
+			name: "Empty sequence",
+			src:  `{}`,
+			want: `[]`,
+		},
+		{
+			name: "Comparison macros",
+			src:  `{ ASSERT_CMPEQ ; IFGT { DROP } {} }`,
+			want: `[[[{"prim":"COMPARE"},{"prim":"EQ"}],{"prim":"IF","args":[[],[[{"prim":"UNIT"},{"prim":"FAILWITH"}]]]}],` +
+				`[{"prim":"GT"},{"prim":"IF","args":[[{"prim":"DROP"}],[]]}]]`,
+		},
+		{
+			name: "Option macros",
+			src:  `{ IF_SOME { DROP } { FAIL } ; ASSERT_NONE }`,
+			want: `[[{"prim":"IF_NONE","args":[[[{"prim":"UNIT"},{"prim":"FAILWITH"}]],[{"prim":"DROP"}]]}],` +
+				`[{"prim":"IF_NONE","args":[[],[[{"prim":"UNIT"},{"prim":"FAILWITH"}]]]}]]`,
+		},
+		{
+			name: "Stack macros",
+			src:  `{ CADR @x ; DUUP ; DIIIP { DROP } }`,
+			want: `[[{"prim":"CAR"},{"prim":"CDR","annots":["@x"]}],[{"prim":"DUP","args":[{"int":"2"}]}],[{"prim":"DIP","args":[{"int":"3"},[{"prim":"DROP"}]]}]]`,
+		},
+		{
+			name:    "Unclosed sequence",
+			src:     `code { DROP ;`,
+			wantErr: "could not parse michelson: unexpected end of source at line 1, column 14",
+		},
+		{
+			name:    "Unbalanced parentheses",
+			src:     "{ DROP\n  ) }",
+			wantErr: "could not parse michelson: unexpected ')' at line 2, column 3",
+		},
+		{
+			name:    "Unterminated string",
+			src:     `PUSH string "abc`,
+			wantErr: "could not parse michelson: invalid string at line 1, column 13: unterminated string",
+		},
+		{
+			name:    "Unterminated comment",
+			src:     `DROP /* `,
+			wantErr: "could not parse michelson: unterminated comment at line 1, column 6",
+		},
+		{
+			name:    "Invalid bytes",
+			src:     `0xabc`,
+			wantErr: "could not parse michelson: invalid bytes '0xabc' at line 1, column 1",
+		},
+		{
+			name:    "Invalid macro",
+			src:     `IF_SOME {}`,
+			wantErr: "could not parse michelson: invalid macro 'IF_SOME': expected 2 arguments, got 1",
+		},
+		{
+			name:    "Empty source",
+			src:     ` # nothing`,
+			wantErr: "could not parse michelson, empty source",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := Parse(tc.src)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+
+			data, err := json.Marshal(n)
+			assert.NilError(t, err)
+			assert.Equal(t, string(data), tc.want)
+		})
+	}
+}
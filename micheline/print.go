@@ -0,0 +1,157 @@
+package micheline
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// printWidth is the width past which Print breaks expressions over several lines
+const printWidth = 80
+
+// scriptSections are the primitives of the top-level sections of a script
+var scriptSections = map[string]bool{"parameter": true, "storage": true, "code": true, "view": true}
+
+// Print renders n as indented Michelson source, the format Parse reads. Expressions fitting in 80 columns are kept
+// on one line, the others are broken with the elements of sequences and the arguments of primitives aligned. A
+// script is rendered as the sections of a .tz file.
+func Print(n Node) string {
+	if isScript(n) {
+		sections := make([]string, len(n.(Seq)))
+		for i, section := range n.(Seq) {
+			sections[i] = pretty(section, 0, false)
+		}
+		return strings.Join(sections, " ;\n")
+	}
+	return pretty(n, 0, false)
+}
+
+func isScript(n Node) bool {
+	seq, ok := n.(Seq)
+	if !ok || len(seq) == 0 {
+		return false
+	}
+	for _, item := range seq {
+		if p, ok := item.(Prim); !ok || !scriptSections[p.Prim] {
+			return false
+		}
+	}
+	return true
+}
+
+// pretty renders n starting at column col, arg tells whether n is the argument of a primitive
+func pretty(n Node, col int, arg bool) string {
+	s := inline(n, arg)
+	if col+len(s) <= printWidth {
+		return s
+	}
+
+	switch v := n.(type) {
+	case Seq:
+		var b strings.Builder
+		b.WriteString("{ ")
+		for i, item := range v {
+			if i > 0 {
+				b.WriteString(" ;\n" + strings.Repeat(" ", col+2))
+			}
+			b.WriteString(pretty(item, col+2, false))
+		}
+		b.WriteString(" }")
+		return b.String()
+	case Prim:
+		if len(v.Args) == 0 {
+			return s
+		}
+		var b strings.Builder
+		parens := arg && needsParens(v)
+		if parens {
+			b.WriteString("(")
+		}
+		b.WriteString(head(v) + " ")
+		argCol := col + len(head(v)) + 1
+		if parens {
+			argCol++
+		}
+		for i, a := range v.Args {
+			if i > 0 {
+				b.WriteString("\n" + strings.Repeat(" ", argCol))
+			}
+			b.WriteString(pretty(a, argCol, true))
+		}
+		if parens {
+			b.WriteString(")")
+		}
+		return b.String()
+	}
+	return s
+}
+
+// inline renders n on a single line
+func inline(n Node, arg bool) string {
+	switch v := n.(type) {
+	case Int:
+		if v.Value == nil {
+			return "0"
+		}
+		return v.Value.String()
+	case String:
+		return quote(string(v))
+	case Bytes:
+		return "0x" + hex.EncodeToString(v)
+	case Seq:
+		if len(v) == 0 {
+			return "{}"
+		}
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = inline(item, false)
+		}
+		return "{ " + strings.Join(items, " ; ") + " }"
+	case Prim:
+		s := head(v)
+		for _, a := range v.Args {
+			s += " " + inline(a, true)
+		}
+		if arg && needsParens(v) {
+			return "(" + s + ")"
+		}
+		return s
+	}
+	return ""
+}
+
+// head renders the primitive of p and its annotations
+func head(p Prim) string {
+	if len(p.Annots) == 0 {
+		return p.Prim
+	}
+	return p.Prim + " " + strings.Join(p.Annots, " ")
+}
+
+func needsParens(p Prim) bool {
+	return len(p.Args) > 0 || len(p.Annots) > 0
+}
+
+// quote renders s as a Michelson string literal
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\b':
+			b.WriteString(`\b`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
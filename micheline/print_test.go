@@ -0,0 +1,85 @@
+package micheline
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Print(t *testing.T) {
+	cases := []struct {
+		name string
+		n    Node
+		want string
+	}{
+		{
+			name: "Data",
+			n:    NewPrim("Pair", NewInt(-1), String("say \"hi\"\n"), Bytes{0x0a, 0xff}, Seq{}),
+			want: `Pair -1 "say \"hi\"\n" 0x0aff {}`,
+		},
+		{
+			name: "Annotated type",
+			n: Prim{
+				Prim:   "pair",
+				Args:   []Node{Prim{Prim: "address", Annots: []string{"%owner"}}, NewPrim("option", NewPrim("nat"))},
+				Annots: []string{":account"},
+			},
+			want: `pair :account (address %owner) (option nat)`,
+		},
+		{
+			name: "Long sequence",
+			n: Seq{
+				NewPrim("PUSH", NewPrim("string"), String("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")),
+				NewPrim("PUSH", NewPrim("string"), String("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")),
+				NewPrim("IF_LEFT", Seq{NewPrim("DROP")}, Seq{NewPrim("FAILWITH")}),
+			},
+			want: `{ PUSH string "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx" ;
+  PUSH string "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx" ;
+  IF_LEFT { DROP } { FAILWITH } }`,
+		},
+		{
+			name: "Long arguments",
+			n: NewPrim("Pair",
+				String("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+				NewPrim("Pair", String("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"), String("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")),
+			),
+			want: `Pair "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+     (Pair "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+           "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")`,
+		},
+		{
+			name: "Script",
+			n: Seq{
+				NewPrim("parameter", Prim{Prim: "int", Annots: []string{"%increment"}}),
+				NewPrim("storage", NewPrim("int")),
+				NewPrim("code", Seq{
+					NewPrim("UNPAIR"),
+					NewPrim("ADD"),
+					NewPrim("NIL", NewPrim("operation")),
+					NewPrim("PAIR"),
+					NewPrim("PUSH", NewPrim("string"), String("a long string making the code too wide")),
+					NewPrim("DROP"),
+				}),
+			},
+			want: `parameter (int %increment) ;
+storage int ;
+code { UNPAIR ;
+       ADD ;
+       NIL operation ;
+       PAIR ;
+       PUSH string "a long string making the code too wide" ;
+       DROP }`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := Print(tc.n)
+			assert.Equal(t, src, tc.want)
+
+			parsed, err := Parse(src)
+			assert.NilError(t, err)
+			assert.Assert(t, Equal(parsed, tc.n))
+		})
+	}
+}
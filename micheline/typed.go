@@ -0,0 +1,645 @@
+package micheline
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	nodeType   = reflect.TypeOf((*Node)(nil)).Elem()
+	bigIntType = reflect.TypeOf(big.Int{})
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// Decode stores the Micheline data of type typ in the value pointed to by v, like json.Unmarshal does with JSON.
+// The data is expected in the readable form, e.g. addresses as strings.
+//
+// Pairs are decoded into structs: the leaves of the pair, its nested pairs without field annotation flattened,
+// are stored in the struct fields named by their field annotation, or in the field at their position when they
+// have none. A field is named by its `micheline:"name"` tag, or else by its name compared without case and
+// underscores, e.g. TokenID for %token_id. Ors are decoded into structs the same way, only the field of the branch
+// of the data is set, so these fields are usually pointers. Options are decoded into pointers, nil for None, lists
+// and sets into slices, and maps and big maps into Go maps. Ints, nats and mutez are decoded into integers or
+// big.Int, as are the ids of big maps and sapling states found in storages, strings, addresses, keys, key hashes,
+// signatures and chain ids into strings, bytes, chests, chest keys and sapling transactions into []byte, bools
+// into bool and timestamps into time.Time. Any data, e.g. a lambda, can be decoded into a Node.
+func Decode(data, typ Node, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.Errorf("could not decode micheline, %T is not a non-nil pointer", v)
+	}
+	if err := decode(data, typ, rv.Elem()); err != nil {
+		return errors.Wrap(err, "could not decode micheline")
+	}
+	return nil
+}
+
+func decode(data, typ Node, rv reflect.Value) error {
+	if rv.Type() == nodeType {
+		rv.Set(reflect.ValueOf(data))
+		return nil
+	}
+	t, ok := typ.(Prim)
+	if !ok {
+		return errors.Errorf("invalid type %T", typ)
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if t.Prim == "option" {
+			if d, ok := data.(Prim); ok && d.Prim == "None" {
+				rv.Set(reflect.Zero(rv.Type()))
+				return nil
+			}
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decode(data, typ, rv.Elem())
+	}
+
+	switch t.Prim {
+	case "pair":
+		return decodeStruct(data, t, rv, pairLeaves)
+	case "or":
+		return decodeStruct(data, t, rv, orLeaves)
+	case "option":
+		d, ok := data.(Prim)
+		if !ok || len(t.Args) != 1 || (d.Prim != "Some" && d.Prim != "None") {
+			return typeMismatch(data, t)
+		}
+		if d.Prim == "None" {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if len(d.Args) != 1 {
+			return typeMismatch(data, t)
+		}
+		return decode(d.Args[0], t.Args[0], rv)
+	case "list", "set":
+		items, ok := data.(Seq)
+		if !ok || len(t.Args) != 1 {
+			return typeMismatch(data, t)
+		}
+		if rv.Kind() != reflect.Slice {
+			return intoMismatch(t, rv)
+		}
+		slice := reflect.MakeSlice(rv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decode(item, t.Args[0], slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+		return nil
+	case "sapling_state":
+		// the id of a sapling state, like big maps
+		return decodeInt(data, t, rv)
+	case "map", "big_map":
+		if _, ok := data.(Int); ok && t.Prim == "big_map" {
+			// the id of a big map, its values are not part of the data
+			return decodeInt(data, t, rv)
+		}
+		items, ok := data.(Seq)
+		if !ok || len(t.Args) != 2 {
+			return typeMismatch(data, t)
+		}
+		if rv.Kind() != reflect.Map {
+			return intoMismatch(t, rv)
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), len(items))
+		for _, item := range items {
+			elt, ok := item.(Prim)
+			if !ok || elt.Prim != "Elt" || len(elt.Args) != 2 {
+				return errors.New("invalid map element")
+			}
+			key := reflect.New(rv.Type().Key()).Elem()
+			if err := decode(elt.Args[0], t.Args[0], key); err != nil {
+				return err
+			}
+			value := reflect.New(rv.Type().Elem()).Elem()
+			if err := decode(elt.Args[1], t.Args[1], value); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		rv.Set(m)
+		return nil
+	case "int", "nat", "mutez":
+		return decodeInt(data, t, rv)
+	case "string", "address", "contract", "key", "key_hash", "signature", "chain_id":
+		s, ok := data.(String)
+		if !ok {
+			return typeMismatch(data, t)
+		}
+		if rv.Kind() != reflect.String {
+			return intoMismatch(t, rv)
+		}
+		rv.SetString(string(s))
+		return nil
+	case "bytes", "chest", "chest_key", "sapling_transaction", "sapling_transaction_deprecated":
+		b, ok := data.(Bytes)
+		if !ok {
+			return typeMismatch(data, t)
+		}
+		if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() != reflect.Uint8 {
+			return intoMismatch(t, rv)
+		}
+		rv.SetBytes(append([]byte{}, b...))
+		return nil
+	case "bool":
+		d, ok := data.(Prim)
+		if !ok || (d.Prim != "True" && d.Prim != "False") {
+			return typeMismatch(data, t)
+		}
+		if rv.Kind() != reflect.Bool {
+			return intoMismatch(t, rv)
+		}
+		rv.SetBool(d.Prim == "True")
+		return nil
+	case "unit":
+		if d, ok := data.(Prim); !ok || d.Prim != "Unit" {
+			return typeMismatch(data, t)
+		}
+		return nil
+	case "timestamp":
+		if rv.Type() != timeType {
+			return intoMismatch(t, rv)
+		}
+		switch d := data.(type) {
+		case String:
+			ts, err := time.Parse(time.RFC3339, string(d))
+			if err != nil {
+				return errors.Wrapf(err, "invalid timestamp '%s'", string(d))
+			}
+			rv.Set(reflect.ValueOf(ts))
+			return nil
+		case Int:
+			if !d.Value.IsInt64() {
+				return errors.Errorf("invalid timestamp %s", d.Value.String())
+			}
+			rv.Set(reflect.ValueOf(time.Unix(d.Value.Int64(), 0).UTC()))
+			return nil
+		}
+		return typeMismatch(data, t)
+	}
+	return errors.Errorf("type %s can only be decoded into a Node", t.Prim)
+}
+
+func decodeInt(data Node, t Prim, rv reflect.Value) error {
+	i, ok := data.(Int)
+	if !ok || i.Value == nil {
+		return typeMismatch(data, t)
+	}
+
+	switch {
+	case rv.Type() == bigIntType:
+		rv.Set(reflect.ValueOf(*new(big.Int).Set(i.Value)))
+		return nil
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		if !i.Value.IsInt64() || rv.OverflowInt(i.Value.Int64()) {
+			return errors.Errorf("%s overflows %s", i.Value.String(), rv.Type())
+		}
+		rv.SetInt(i.Value.Int64())
+		return nil
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uint64:
+		if !i.Value.IsUint64() || rv.OverflowUint(i.Value.Uint64()) {
+			return errors.Errorf("%s overflows %s", i.Value.String(), rv.Type())
+		}
+		rv.SetUint(i.Value.Uint64())
+		return nil
+	}
+	return intoMismatch(t, rv)
+}
+
+// leaf is a type stored in a struct field, with the path of Left and Right leading to it in an or
+type leaf struct {
+	typ  Node
+	path []string
+}
+
+// pairLeaves returns the leaves of the pair type t, its nested pairs without field annotation flattened
+func pairLeaves(t Prim) []leaf {
+	var leaves []leaf
+	for _, arg := range combArgs(t, "pair") {
+		if p, ok := arg.(Prim); ok && p.Prim == "pair" && p.Annot(FieldAnnot) == "" {
+			leaves = append(leaves, pairLeaves(p)...)
+			continue
+		}
+		leaves = append(leaves, leaf{typ: arg})
+	}
+	return leaves
+}
+
+// orLeaves returns the branches of the or type t, its nested ors without field annotation flattened
+func orLeaves(t Prim) []leaf {
+	var leaves []leaf
+	for i, arg := range t.Args {
+		side := "Left"
+		if i == 1 {
+			side = "Right"
+		}
+		if p, ok := arg.(Prim); ok && p.Prim == "or" && p.Annot(FieldAnnot) == "" && len(p.Args) == 2 {
+			for _, l := range orLeaves(p) {
+				leaves = append(leaves, leaf{typ: l.typ, path: append([]string{side}, l.path...)})
+			}
+			continue
+		}
+		leaves = append(leaves, leaf{typ: arg, path: []string{side}})
+	}
+	return leaves
+}
+
+// combArgs returns the arguments of the comb t, a pair of more than two arguments is nested pairs of two
+func combArgs(t Prim, prim string) []Node {
+	if len(t.Args) <= 2 {
+		return t.Args
+	}
+	return []Node{t.Args[0], NewPrim(prim, t.Args[1:]...)}
+}
+
+// pairValues returns the values of the pair data of type t matching the leaves of t
+func pairValues(data Node, t Prim) ([]Node, error) {
+	var args []Node
+	switch d := data.(type) {
+	case Prim:
+		if d.Prim != "Pair" {
+			return nil, typeMismatch(data, t)
+		}
+		args = d.Args
+	case Seq:
+		args = d
+	}
+	types := combArgs(t, "pair")
+	if len(args) < 2 || len(types) != 2 {
+		return nil, typeMismatch(data, t)
+	}
+	if len(args) > 2 {
+		args = []Node{args[0], NewPrim("Pair", args[1:]...)}
+	}
+
+	var values []Node
+	for i, arg := range types {
+		if p, ok := arg.(Prim); ok && p.Prim == "pair" && p.Annot(FieldAnnot) == "" {
+			nested, err := pairValues(args[i], p)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, nested...)
+			continue
+		}
+		values = append(values, args[i])
+	}
+	return values, nil
+}
+
+func decodeStruct(data Node, t Prim, rv reflect.Value, leavesOf func(Prim) []leaf) error {
+	if rv.Kind() != reflect.Struct {
+		return intoMismatch(t, rv)
+	}
+	leaves := leavesOf(t)
+
+	if t.Prim == "pair" {
+		values, err := pairValues(data, t)
+		if err != nil {
+			return err
+		}
+		for i, l := range leaves {
+			field, ok := structField(rv, l.typ, i)
+			if !ok {
+				continue
+			}
+			if err := decode(values[i], l.typ, field); err != nil {
+				return errors.Wrapf(err, "invalid field %s", rv.Type().Field(fieldIndex(rv, l.typ, i)).Name)
+			}
+		}
+		return nil
+	}
+
+	value, path, err := orValue(data, t)
+	if err != nil {
+		return err
+	}
+	for i, l := range leaves {
+		if len(l.path) > len(path) || strings.Join(l.path, " ") != strings.Join(path[:len(l.path)], " ") {
+			continue
+		}
+		field, ok := structField(rv, l.typ, i)
+		if !ok {
+			return errors.Errorf("no field of %s for the branch %s", rv.Type(), strings.Join(l.path, " "))
+		}
+		for _, side := range path[len(l.path):] {
+			value = NewPrim(side, value)
+		}
+		return decode(value, l.typ, field)
+	}
+	return typeMismatch(data, t)
+}
+
+// orValue returns the value in the branches of the or data, and the path of Left and Right leading to it
+func orValue(data Node, t Prim) (Node, []string, error) {
+	var path []string
+	for {
+		d, ok := data.(Prim)
+		if !ok || (d.Prim != "Left" && d.Prim != "Right") || len(d.Args) != 1 {
+			if len(path) == 0 {
+				return nil, nil, typeMismatch(data, t)
+			}
+			return data, path, nil
+		}
+		path = append(path, d.Prim)
+		data = d.Args[0]
+	}
+}
+
+// structField returns the field of the struct rv storing the leaf of type typ at the position i
+func structField(rv reflect.Value, typ Node, i int) (reflect.Value, bool) {
+	index := fieldIndex(rv, typ, i)
+	if index < 0 {
+		return reflect.Value{}, false
+	}
+	return rv.Field(index), true
+}
+
+func fieldIndex(rv reflect.Value, typ Node, i int) int {
+	name := ""
+	if p, ok := typ.(Prim); ok {
+		name = p.Annot(FieldAnnot)
+	}
+
+	position := 0
+	for index := 0; index < rv.NumField(); index++ {
+		f := rv.Type().Field(index)
+		tag := f.Tag.Get("micheline")
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		if name == "" {
+			if position == i {
+				return index
+			}
+			position++
+			continue
+		}
+		if tag == name || (tag == "" && strings.EqualFold(strings.Replace(name, "_", "", -1), f.Name)) {
+			return index
+		}
+	}
+	return -1
+}
+
+// Encode returns the Micheline data of type typ of the Go value v, the inverse of Decode.
+func Encode(v interface{}, typ Node) (Node, error) {
+	n, err := encode(reflect.ValueOf(v), typ)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode micheline")
+	}
+	return n, nil
+}
+
+func encode(rv reflect.Value, typ Node) (Node, error) {
+	if !rv.IsValid() || (rv.Kind() == reflect.Interface && rv.IsNil()) {
+		return nil, errors.New("invalid nil value")
+	}
+	if rv.Kind() == reflect.Interface {
+		return encode(rv.Elem(), typ)
+	}
+	if rv.Type().Implements(nodeType) {
+		return rv.Interface().(Node), nil
+	}
+	t, ok := typ.(Prim)
+	if !ok {
+		return nil, errors.Errorf("invalid type %T", typ)
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			if t.Prim == "option" {
+				return NewPrim("None"), nil
+			}
+			return nil, errors.Errorf("invalid nil %s", rv.Type())
+		}
+		return encode(rv.Elem(), typ)
+	}
+
+	switch t.Prim {
+	case "pair":
+		return encodePair(rv, t)
+	case "or":
+		return encodeOr(rv, t)
+	case "option":
+		if len(t.Args) != 1 {
+			return nil, errors.New("invalid option type")
+		}
+		arg, err := encode(rv, t.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return NewPrim("Some", arg), nil
+	case "list", "set":
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || len(t.Args) != 1 {
+			return nil, fromMismatch(rv, t)
+		}
+		seq := make(Seq, rv.Len())
+		for i := range seq {
+			item, err := encode(rv.Index(i), t.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			seq[i] = item
+		}
+		return seq, nil
+	case "sapling_state":
+		return encodeInt(rv, t)
+	case "map", "big_map":
+		if t.Prim == "big_map" && (rv.Kind() != reflect.Map) {
+			return encodeInt(rv, t)
+		}
+		if rv.Kind() != reflect.Map || len(t.Args) != 2 {
+			return nil, fromMismatch(rv, t)
+		}
+		seq := make(Seq, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			k, err := encode(key, t.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			v, err := encode(rv.MapIndex(key), t.Args[1])
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, NewPrim("Elt", k, v))
+		}
+		// Go maps are unordered, Michelson maps are sorted by keys
+		sortElts(seq)
+		return seq, nil
+	case "int", "nat", "mutez":
+		return encodeInt(rv, t)
+	case "string", "address", "contract", "key", "key_hash", "signature", "chain_id":
+		if rv.Kind() != reflect.String {
+			return nil, fromMismatch(rv, t)
+		}
+		return String(rv.String()), nil
+	case "bytes", "chest", "chest_key", "sapling_transaction", "sapling_transaction_deprecated":
+		if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() != reflect.Uint8 {
+			return nil, fromMismatch(rv, t)
+		}
+		return Bytes(append([]byte{}, rv.Bytes()...)), nil
+	case "bool":
+		if rv.Kind() != reflect.Bool {
+			return nil, fromMismatch(rv, t)
+		}
+		if rv.Bool() {
+			return NewPrim("True"), nil
+		}
+		return NewPrim("False"), nil
+	case "unit":
+		return NewPrim("Unit"), nil
+	case "timestamp":
+		if rv.Type() != timeType {
+			return nil, fromMismatch(rv, t)
+		}
+		return String(rv.Interface().(time.Time).UTC().Format(time.RFC3339)), nil
+	}
+	return nil, errors.Errorf("type %s can only be encoded from a Node", t.Prim)
+}
+
+func encodeInt(rv reflect.Value, t Prim) (Node, error) {
+	var i *big.Int
+	switch {
+	case rv.Type() == bigIntType:
+		v := rv.Interface().(big.Int)
+		i = new(big.Int).Set(&v)
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		i = big.NewInt(rv.Int())
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uint64:
+		i = new(big.Int).SetUint64(rv.Uint())
+	default:
+		return nil, fromMismatch(rv, t)
+	}
+	if t.Prim != "int" && i.Sign() < 0 {
+		return nil, errors.Errorf("invalid negative %s %s", t.Prim, i.String())
+	}
+	return Int{Value: i}, nil
+}
+
+func encodePair(rv reflect.Value, t Prim) (Node, error) {
+	if rv.Kind() != reflect.Struct {
+		return nil, fromMismatch(rv, t)
+	}
+	leaves := pairLeaves(t)
+	values := make([]Node, len(leaves))
+	for i, l := range leaves {
+		field, ok := structField(rv, l.typ, i)
+		if !ok {
+			return nil, errors.Errorf("no field of %s for the leaf %d of the pair", rv.Type(), i)
+		}
+		v, err := encode(field, l.typ)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid field %s", rv.Type().Field(fieldIndex(rv, l.typ, i)).Name)
+		}
+		values[i] = v
+	}
+	pair, _ := buildPair(values, t)
+	return pair, nil
+}
+
+// buildPair nests values in Pairs following the shape of the pair type t, it returns the values it did not use
+func buildPair(values []Node, t Prim) (Node, []Node) {
+	args := make([]Node, 0, 2)
+	for _, arg := range combArgs(t, "pair") {
+		if p, ok := arg.(Prim); ok && p.Prim == "pair" && p.Annot(FieldAnnot) == "" {
+			var nested Node
+			nested, values = buildPair(values, p)
+			args = append(args, nested)
+			continue
+		}
+		args = append(args, values[0])
+		values = values[1:]
+	}
+	return NewPrim("Pair", args...), values
+}
+
+func encodeOr(rv reflect.Value, t Prim) (Node, error) {
+	if rv.Kind() != reflect.Struct {
+		return nil, fromMismatch(rv, t)
+	}
+
+	var (
+		value Node
+		path  []string
+	)
+	for i, l := range orLeaves(t) {
+		field, ok := structField(rv, l.typ, i)
+		if !ok || field.IsZero() {
+			continue
+		}
+		if value != nil {
+			return nil, errors.Errorf("more than one branch of %s set", rv.Type())
+		}
+		v, err := encode(field, l.typ)
+		if err != nil {
+			return nil, err
+		}
+		value, path = v, l.path
+	}
+	if value == nil {
+		return nil, errors.Errorf("no branch of %s set", rv.Type())
+	}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		value = NewPrim(path[i], value)
+	}
+	return value, nil
+}
+
+// sortElts sorts the elements of a map by their keys: ints by value, strings and bytes by their bytes
+func sortElts(elts Seq) {
+	sort.SliceStable(elts, func(i, j int) bool {
+		switch a := elts[i].(Prim).Args[0].(type) {
+		case Int:
+			if b, ok := elts[j].(Prim).Args[0].(Int); ok {
+				return a.Value.Cmp(b.Value) < 0
+			}
+		case String:
+			if b, ok := elts[j].(Prim).Args[0].(String); ok {
+				return a < b
+			}
+		case Bytes:
+			if b, ok := elts[j].(Prim).Args[0].(Bytes); ok {
+				return string(a) < string(b)
+			}
+		}
+		return false
+	})
+}
+
+// typeMismatch returns the error of data not matching the type t
+func typeMismatch(data Node, t Prim) error {
+	kind := "data"
+	switch d := data.(type) {
+	case Int:
+		kind = "int"
+	case String:
+		kind = "string"
+	case Bytes:
+		kind = "bytes"
+	case Seq:
+		kind = "sequence"
+	case Prim:
+		kind = d.Prim
+	}
+	return errors.Errorf("%s does not match type %s", kind, t.Prim)
+}
+
+func intoMismatch(t Prim, rv reflect.Value) error {
+	return errors.Errorf("could not decode %s into %s", t.Prim, rv.Type())
+}
+
+func fromMismatch(rv reflect.Value, t Prim) error {
+	return errors.Errorf("could not encode %s as %s", rv.Type(), t.Prim)
+}
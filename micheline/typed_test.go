@@ -0,0 +1,195 @@
+package micheline
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+type transfer struct {
+	From string        `micheline:"from_"`
+	To   []destination `micheline:"txs"`
+}
+
+type destination struct {
+	To      string
+	TokenID uint64
+	Amount  big.Int
+}
+
+type action struct {
+	Transfer *[]transfer `micheline:"transfer"`
+	Update   *bool       `micheline:"update"`
+	Burn     *int64      `micheline:"burn"`
+}
+
+type ledger struct {
+	Owners   map[string]uint64
+	Admin    *string
+	Paused   bool
+	Updated  time.Time
+	Metadata int64
+	Code     Node
+}
+
+func mustParse(t *testing.T, src string) Node {
+	n, err := Parse(src)
+	assert.NilError(t, err)
+	return n
+}
+
+func Test_Decode(t *testing.T) {
+	transferType := mustParse(t, `(or (list %transfer (pair (address %from_) (list %txs (pair (address %to_) (nat %token_id) (nat %amount)))))
+	                               (or (bool %update) (int %burn)))`)
+
+	t.Run("Transfer", func(t *testing.T) {
+		data := mustParse(t, `Left { Pair "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx" { Pair "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN" 1 100 } }`)
+		var a action
+		assert.NilError(t, Decode(data, transferType, &a))
+		assert.Assert(t, a.Update == nil && a.Burn == nil)
+		assert.Equal(t, len(*a.Transfer), 1)
+		assert.Equal(t, (*a.Transfer)[0].From, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+		assert.Equal(t, len((*a.Transfer)[0].To), 1)
+		assert.Equal(t, (*a.Transfer)[0].To[0].To, "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN")
+		assert.Equal(t, (*a.Transfer)[0].To[0].TokenID, uint64(1))
+		assert.Equal(t, (*a.Transfer)[0].To[0].Amount.String(), "100")
+
+		encoded, err := Encode(a, transferType)
+		assert.NilError(t, err)
+		assert.Equal(t, Print(encoded), `Left { Pair "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+            { Pair "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN" (Pair 1 100) } }`)
+	})
+
+	t.Run("Nested branch", func(t *testing.T) {
+		var a action
+		assert.NilError(t, Decode(mustParse(t, `Right (Right -5)`), transferType, &a))
+		assert.Assert(t, a.Transfer == nil && a.Update == nil)
+		assert.Equal(t, *a.Burn, int64(-5))
+
+		encoded, err := Encode(&a, transferType)
+		assert.NilError(t, err)
+		assert.Equal(t, Print(encoded), `Right (Right -5)`)
+	})
+
+	t.Run("Storage", func(t *testing.T) {
+		typ := mustParse(t, `pair (map string nat) (option address) bool timestamp (big_map nat bytes) (lambda unit unit)`)
+		data := mustParse(t, `Pair { Elt "b" 2 ; Elt "a" 1 } None True "2019-09-26T10:59:51Z" 42 { DROP ; UNIT }`)
+
+		var l ledger
+		assert.NilError(t, Decode(data, typ, &l))
+		assert.DeepEqual(t, l.Owners, map[string]uint64{"a": 1, "b": 2})
+		assert.Assert(t, l.Admin == nil)
+		assert.Assert(t, l.Paused)
+		assert.Assert(t, l.Updated.Equal(time.Date(2019, 9, 26, 10, 59, 51, 0, time.UTC)))
+		assert.Equal(t, l.Metadata, int64(42))
+		assert.Assert(t, Equal(l.Code, Seq{NewPrim("DROP"), NewPrim("UNIT")}))
+
+		admin := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+		l.Admin = &admin
+		encoded, err := Encode(l, typ)
+		assert.NilError(t, err)
+		assert.Equal(t, Print(encoded), `Pair { Elt "a" 1 ; Elt "b" 2 }
+     (Pair (Some "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+           (Pair True (Pair "2019-09-26T10:59:51Z" (Pair 42 { DROP ; UNIT }))))`)
+	})
+
+	t.Run("Timelock and sapling", func(t *testing.T) {
+		typ := mustParse(t, `pair (chest %chest) (chest_key %key) (sapling_state %pool 8) (option %tx (sapling_transaction 8))`)
+		data := mustParse(t, `Pair 0xc0ffee 0xbeef 17 (Some 0x00aa)`)
+
+		var v struct {
+			Chest []byte
+			Key   []byte
+			Pool  int64
+			Tx    *[]byte
+		}
+		assert.NilError(t, Decode(data, typ, &v))
+		assert.DeepEqual(t, v.Chest, []byte{0xc0, 0xff, 0xee})
+		assert.DeepEqual(t, v.Key, []byte{0xbe, 0xef})
+		assert.Equal(t, v.Pool, int64(17))
+		assert.DeepEqual(t, *v.Tx, []byte{0x00, 0xaa})
+
+		encoded, err := Encode(v, typ)
+		assert.NilError(t, err)
+		assert.Equal(t, Print(encoded), `Pair 0xc0ffee (Pair 0xbeef (Pair 17 (Some 0x00aa)))`)
+	})
+
+	errCases := []struct {
+		name    string
+		data    string
+		typ     string
+		v       interface{}
+		wantErr string
+	}{
+		{
+			name:    "Type mismatch",
+			data:    `"a"`,
+			typ:     `nat`,
+			v:       new(uint64),
+			wantErr: "could not decode micheline: string does not match type nat",
+		},
+		{
+			name:    "Go type mismatch",
+			data:    `1`,
+			typ:     `nat`,
+			v:       new(string),
+			wantErr: "could not decode micheline: could not decode nat into string",
+		},
+		{
+			name:    "Overflow",
+			data:    `256`,
+			typ:     `nat`,
+			v:       new(uint8),
+			wantErr: "could not decode micheline: 256 overflows uint8",
+		},
+		{
+			name:    "Invalid field",
+			data:    `Pair "a" 1`,
+			typ:     `pair (address %from_) (string %to_)`,
+			v:       &destination{},
+			wantErr: "could not decode micheline: invalid field To: int does not match type string",
+		},
+		{
+			name:    "Not a pointer",
+			data:    `1`,
+			typ:     `nat`,
+			v:       uint64(0),
+			wantErr: "could not decode micheline, uint64 is not a non-nil pointer",
+		},
+	}
+
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Error(t, Decode(mustParse(t, tc.data), mustParse(t, tc.typ), tc.v), tc.wantErr)
+		})
+	}
+}
+
+func Test_Encode(t *testing.T) {
+	cases := []struct {
+		name    string
+		v       interface{}
+		typ     string
+		want    string
+		wantErr string
+	}{
+		{name: "Option", v: (*int)(nil), typ: `option int`, want: `None`},
+		{name: "Negative nat", v: -1, typ: `nat`, wantErr: "could not encode micheline: invalid negative nat -1"},
+		{name: "No branch", v: action{}, typ: `or (bool %update) (int %burn)`, wantErr: "could not encode micheline: no branch of micheline.action set"},
+		{name: "Wrong Go type", v: "1", typ: `int`, wantErr: "could not encode micheline: could not encode string as int"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := Encode(tc.v, mustParse(t, tc.typ))
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, Print(n), tc.want)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package network
+
+import "encoding/json"
+
+// UnmarshalJSON unmarshals the constants of any protocol into Constants. The rewards paid per priority
+// before Tenderbake are unmarshaled as the reward of priority 0, and renamed constants are set under
+// both of their names.
+func (c *Constants) UnmarshalJSON(v []byte) error {
+	type constants Constants
+	var r struct {
+		*constants
+		// Lists of rewards per priority since Babylon
+		BlockReward       json.RawMessage `json:"block_reward"`
+		EndorsementReward json.RawMessage `json:"endorsement_reward"`
+		// Renamed by Oxford
+		EndorsingRewardPerSlot string `json:"endorsing_reward_per_slot"`
+	}
+	r.constants = (*constants)(c)
+	if err := json.Unmarshal(v, &r); err != nil {
+		return err
+	}
+
+	var err error
+	if c.BlockReward, err = firstReward(r.BlockReward); err != nil {
+		return err
+	}
+	if c.EndorsementReward, err = firstReward(r.EndorsementReward); err != nil {
+		return err
+	}
+	if c.AttestingRewardPerSlot == "" {
+		c.AttestingRewardPerSlot = r.EndorsingRewardPerSlot
+	}
+
+	c.normalize()
+	return nil
+}
+
+// normalize sets the constants renamed by a protocol under both of their names
+func (c *Constants) normalize() {
+	renamed := []struct{ old, new *int }{
+		{&c.PreservedCycles, &c.ConsensusRightsDelay},
+		{&c.BlocksPerRollSnapshot, &c.BlocksPerStakeSnapshot},
+		{&c.EndorsersPerBlock, &c.ConsensusCommitteeSize},
+	}
+	for _, r := range renamed {
+		if *r.new == 0 {
+			*r.new = *r.old
+		}
+		if *r.old == 0 {
+			*r.old = *r.new
+		}
+	}
+
+	if c.MinimalStake == "" {
+		c.MinimalStake = c.TokensPerRoll
+	}
+	if c.TokensPerRoll == "" {
+		c.TokensPerRoll = c.MinimalStake
+	}
+
+	if c.MinimalBlockDelay == "" && len(c.TimeBetweenBlocks) > 0 {
+		c.MinimalBlockDelay = c.TimeBetweenBlocks[0]
+	}
+	if len(c.TimeBetweenBlocks) == 0 && c.MinimalBlockDelay != "" {
+		c.TimeBetweenBlocks = []string{c.MinimalBlockDelay}
+	}
+
+	if c.BlocksPerCycle > 0 {
+		if c.CyclesPerVotingPeriod == 0 {
+			c.CyclesPerVotingPeriod = c.BlocksPerVotingPeriod / c.BlocksPerCycle
+		}
+		if c.BlocksPerVotingPeriod == 0 {
+			c.BlocksPerVotingPeriod = c.CyclesPerVotingPeriod * c.BlocksPerCycle
+		}
+	}
+}
+
+// firstReward unmarshals a reward, or the first of a list of rewards per priority
+func firstReward(v json.RawMessage) (string, error) {
+	if len(v) == 0 || string(v) == "null" {
+		return "", nil
+	}
+
+	var rewards []string
+	if err := json.Unmarshal(v, &rewards); err == nil {
+		if len(rewards) == 0 {
+			return "", nil
+		}
+		return rewards[0], nil
+	}
+
+	var reward string
+	if err := json.Unmarshal(v, &reward); err != nil {
+		return "", err
+	}
+	return reward, nil
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_Constants(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     []byte
+		block    blockid.BlockID
+		wantPath string
+		want     func(t *testing.T, c Constants)
+	}{
+		{
+			name:     "Athens",
+			body:     goldenConstants,
+			block:    blockid.Level(100000),
+			wantPath: "/chains/main/blocks/100000/context/constants",
+			want: func(t *testing.T, c Constants) {
+				assert.Equal(t, c.PreservedCycles, 3)
+				assert.Equal(t, c.ConsensusRightsDelay, 3)
+				assert.Equal(t, c.ConsensusCommitteeSize, 32)
+				assert.Equal(t, c.BlocksPerStakeSnapshot, 256)
+				assert.Equal(t, c.MinimalBlockDelay, "30")
+				assert.Equal(t, c.MinimalStake, "10000000000")
+				assert.Equal(t, c.CyclesPerVotingPeriod, 4)
+				assert.Equal(t, c.EndorsementReward, "0")
+			},
+		},
+		{
+			name:     "Babylon",
+			body:     goldenConstantsBabylon,
+			block:    blockid.Head(),
+			wantPath: "/chains/main/blocks/head/context/constants",
+			want: func(t *testing.T, c Constants) {
+				assert.Equal(t, c.EndorsementReward, "1250000")
+				assert.Equal(t, c.BlockReward, "")
+				assert.Equal(t, c.EndorsersPerBlock, 32)
+			},
+		},
+		{
+			name:     "Paris",
+			body:     goldenConstantsParis,
+			block:    blockid.Head(),
+			wantPath: "/chains/main/blocks/head/context/constants",
+			want: func(t *testing.T, c Constants) {
+				assert.Equal(t, c.BlocksPerCycle, 24576)
+				assert.Equal(t, c.PreservedCycles, 2)
+				assert.Equal(t, c.ConsensusRightsDelay, 2)
+				assert.Equal(t, c.EndorsersPerBlock, 7000)
+				assert.Equal(t, c.BlocksPerRollSnapshot, 1536)
+				assert.DeepEqual(t, c.TimeBetweenBlocks, []string{"8"})
+				assert.Equal(t, c.TokensPerRoll, "6000000000")
+				assert.Equal(t, c.BlocksPerVotingPeriod, 5*24576)
+				assert.Equal(t, c.AttestingRewardPerSlot, "")
+			},
+		},
+		{
+			name:     "Nairobi",
+			body:     []byte(`{"blocks_per_cycle":16384,"minimal_block_delay":"15","endorsing_reward_per_slot":"2857"}`),
+			block:    blockid.Head(),
+			wantPath: "/chains/main/blocks/head/context/constants",
+			want: func(t *testing.T, c Constants) {
+				assert.Equal(t, c.AttestingRewardPerSlot, "2857")
+				assert.Equal(t, c.MinimalBlockDelay, "15")
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &client{ReturnBody: tc.body, Bodies: map[string][]byte{
+				"/chains/main/blocks/" + tc.block.String() + "/protocols": goldenProtocols,
+			}}
+			constants, err := NewNetworkService(c).Constants(tc.block)
+			assert.NilError(t, err)
+			assert.Equal(t, c.Path, tc.wantPath)
+			tc.want(t, constants)
+		})
+	}
+}
+
+func Test_ConstantsCache(t *testing.T) {
+	const (
+		babylon = "PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS"
+		paris   = "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ"
+	)
+	c := &client{Bodies: map[string][]byte{
+		"/chains/main/blocks/1/protocols":         []byte(`{"protocol":"` + babylon + `","next_protocol":"` + babylon + `"}`),
+		"/chains/main/blocks/2/protocols":         []byte(`{"protocol":"` + babylon + `","next_protocol":"` + babylon + `"}`),
+		"/chains/main/blocks/3/protocols":         []byte(`{"protocol":"` + babylon + `","next_protocol":"` + paris + `"}`),
+		"/chains/main/blocks/1/context/constants": goldenConstantsBabylon,
+		"/chains/main/blocks/3/context/constants": goldenConstantsParis,
+	}}
+	ns := NewNetworkService(c)
+
+	for _, level := range []int{1, 2, 3} {
+		_, err := ns.Constants(blockid.Level(level))
+		assert.NilError(t, err)
+	}
+	constants, err := ns.Constants(blockid.Level(2))
+	assert.NilError(t, err)
+	assert.Equal(t, constants.BlocksPerCycle, 4096)
+	constants, err = ns.Constants(blockid.Level(3))
+	assert.NilError(t, err)
+	assert.Equal(t, constants.BlocksPerCycle, 24576)
+
+	assert.Equal(t, c.Calls["/chains/main/blocks/1/context/constants"], 1)
+	assert.Equal(t, c.Calls["/chains/main/blocks/2/context/constants"], 0)
+	assert.Equal(t, c.Calls["/chains/main/blocks/3/context/constants"], 1)
+
+	ns.InvalidateConstants()
+	_, err = ns.Constants(blockid.Level(1))
+	assert.NilError(t, err)
+	assert.Equal(t, c.Calls["/chains/main/blocks/1/context/constants"], 2)
+}
@@ -0,0 +1,34 @@
+package network
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// RawContext gets the raw JSON of the context of the block id under path, e.g. "cycle/500" or
+// "contracts/index/tz1.../balance", for the keys the typed API does not cover. Subtrees are cut at
+// depth levels below path, the whole subtree is returned when depth is 0 or less.
+func (n *NetworkService) RawContext(id blockid.BlockID, path string, depth int) (json.RawMessage, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/raw/json"
+	if path = strings.Trim(path, "/"); path != "" {
+		query += "/" + path
+	}
+
+	var params map[string]string
+	if depth > 0 {
+		params = map[string]string{"depth": strconv.Itoa(depth)}
+	}
+	resp, err := n.tzclient.Get(query, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get raw context '%s'", query)
+	}
+	if !json.Valid(resp) {
+		return nil, errors.Errorf("could not get raw context '%s', invalid JSON", query)
+	}
+	return json.RawMessage(resp), nil
+}
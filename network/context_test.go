@@ -0,0 +1,69 @@
+package network
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_RawContext(t *testing.T) {
+	cases := []struct {
+		name       string
+		path       string
+		depth      int
+		body       []byte
+		wantPath   string
+		wantParams map[string]string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:     "Leaf",
+			path:     "/contracts/index/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx/balance/",
+			body:     []byte(`"1000000"`),
+			wantPath: "/chains/main/blocks/head/context/raw/json/contracts/index/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx/balance",
+			want:     `"1000000"`,
+		},
+		{
+			name:       "Subtree cut at depth",
+			path:       "cycle/500",
+			depth:      1,
+			body:       []byte(`{"random_seed":"e1d2","delegate_sampler_state":{},"selected_stake_distribution":[]}`),
+			wantPath:   "/chains/main/blocks/head/context/raw/json/cycle/500",
+			wantParams: map[string]string{"depth": "1"},
+			want:       `{"random_seed":"e1d2","delegate_sampler_state":{},"selected_stake_distribution":[]}`,
+		},
+		{
+			name:       "Root",
+			depth:      1,
+			body:       []byte(`{"contracts":{},"cycle":{},"big_maps":{}}`),
+			wantPath:   "/chains/main/blocks/head/context/raw/json",
+			wantParams: map[string]string{"depth": "1"},
+			want:       `{"contracts":{},"cycle":{},"big_maps":{}}`,
+		},
+		{
+			name:     "Invalid JSON",
+			path:     "cycle",
+			body:     []byte(`<html>`),
+			wantPath: "/chains/main/blocks/head/context/raw/json/cycle",
+			wantErr:  "could not get raw context '/chains/main/blocks/head/context/raw/json/cycle'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &client{ReturnBody: tc.body}
+			raw, err := NewNetworkService(c).RawContext(blockid.Head(), tc.path, tc.depth)
+			assert.Equal(t, c.Path, tc.wantPath)
+			assert.DeepEqual(t, c.Params, tc.wantParams)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(raw), tc.want)
+		})
+	}
+}
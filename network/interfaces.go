@@ -1,8 +1,28 @@
 package network
 
+import (
+	"encoding/json"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
 type TezosNetworkService interface {
 	GetVersions() ([]Version, error)
 	GetConstants() (Constants, error)
+	Constants(id blockid.BlockID) (Constants, error)
+	InvalidateConstants()
+	IssuancePerMinute(id blockid.BlockID) (tez.Mutez, error)
+	YearlyIssuanceRate(id blockid.BlockID) (float64, error)
+	ExpectedIssuance(id blockid.BlockID) ([]ExpectedIssuance, error)
+	AdaptiveIssuanceLaunchCycle(id blockid.BlockID) (*int, error)
+	TotalSupply(id blockid.BlockID) (tez.Mutez, error)
+	TotalFrozenStake(id blockid.BlockID) (tez.Mutez, error)
+	LiquidityBaking(id blockid.BlockID) (LiquidityBaking, error)
+	RawContext(id blockid.BlockID, path string, depth int) (json.RawMessage, error)
+	Seed(id blockid.BlockID) (string, error)
+	SeedComputation(id blockid.BlockID) (SeedComputation, error)
+	Nonce(id blockid.BlockID, level int) (Nonce, error)
 	GetChainID() (string, error)
 	GetConnections() (Connections, error)
 }
@@ -0,0 +1,83 @@
+package network
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// ExpectedIssuance is the issuance expected for a cycle, it is known up to consensus_rights_delay cycles ahead.
+type ExpectedIssuance struct {
+	Cycle                      int       `json:"cycle"`
+	BakingRewardFixedPortion   tez.Mutez `json:"baking_reward_fixed_portion"`
+	BakingRewardBonusPerSlot   tez.Mutez `json:"baking_reward_bonus_per_slot"`
+	AttestingRewardPerSlot     tez.Mutez `json:"attesting_reward_per_slot"`
+	SeedNonceRevelationTip     tez.Mutez `json:"seed_nonce_revelation_tip"`
+	VdfRevelationTip           tez.Mutez `json:"vdf_revelation_tip"`
+	DalAttestingRewardPerShard tez.Mutez `json:"dal_attesting_reward_per_shard,omitempty"`
+}
+
+// IssuancePerMinute gets the tez issued per minute at the block id.
+func (n *NetworkService) IssuancePerMinute(id blockid.BlockID) (tez.Mutez, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/issuance/issuance_per_minute"
+	issuance, err := n.mutez(query)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get issuance per minute '%s'", query)
+	}
+	return issuance, nil
+}
+
+// YearlyIssuanceRate gets the yearly issuance rate at the block id, in percent of the total supply.
+func (n *NetworkService) YearlyIssuanceRate(id blockid.BlockID) (float64, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/issuance/current_yearly_rate"
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get yearly issuance rate '%s'", query)
+	}
+
+	rate, err := unmarshalString(resp)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get yearly issuance rate '%s'", query)
+	}
+	yearlyRate, err := strconv.ParseFloat(rate, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get yearly issuance rate '%s'", query)
+	}
+	return yearlyRate, nil
+}
+
+// ExpectedIssuance gets the issuance expected for the current cycle of the block id and the cycles
+// whose rights are already known.
+func (n *NetworkService) ExpectedIssuance(id blockid.BlockID) ([]ExpectedIssuance, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/issuance/expected_issuance"
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get expected issuance '%s'", query)
+	}
+
+	var issuance []ExpectedIssuance
+	if err := json.Unmarshal(resp, &issuance); err != nil {
+		return nil, errors.Wrapf(err, "could not get expected issuance '%s'", query)
+	}
+	return issuance, nil
+}
+
+// AdaptiveIssuanceLaunchCycle gets the cycle adaptive issuance is activated at, nil while its activation
+// has not been voted yet.
+func (n *NetworkService) AdaptiveIssuanceLaunchCycle(id blockid.BlockID) (*int, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/adaptive_issuance_launch_cycle"
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get adaptive issuance launch cycle '%s'", query)
+	}
+
+	var cycle *int
+	if err := json.Unmarshal(resp, &cycle); err != nil {
+		return nil, errors.Wrapf(err, "could not get adaptive issuance launch cycle '%s'", query)
+	}
+	return cycle, nil
+}
@@ -0,0 +1,101 @@
+package network
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_Issuance(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     []byte
+		wantPath string
+		get      func(n *NetworkService) (interface{}, error)
+		want     interface{}
+		wantErr  string
+	}{
+		{
+			name:     "Issuance per minute",
+			body:     []byte(`"80007812"`),
+			wantPath: "/chains/main/blocks/head/context/issuance/issuance_per_minute",
+			get: func(n *NetworkService) (interface{}, error) {
+				return n.IssuancePerMinute(blockid.Head())
+			},
+			want: tez.Mutez(80007812),
+		},
+		{
+			name:     "Yearly issuance rate",
+			body:     []byte(`"4.93"`),
+			wantPath: "/chains/main/blocks/head/context/issuance/current_yearly_rate",
+			get: func(n *NetworkService) (interface{}, error) {
+				return n.YearlyIssuanceRate(blockid.Head())
+			},
+			want: 4.93,
+		},
+		{
+			name:     "Malformed yearly issuance rate",
+			body:     []byte(`"rate"`),
+			wantPath: "/chains/main/blocks/head/context/issuance/current_yearly_rate",
+			get: func(n *NetworkService) (interface{}, error) {
+				return n.YearlyIssuanceRate(blockid.Head())
+			},
+			wantErr: "could not get yearly issuance rate",
+		},
+		{
+			name:     "Expected issuance",
+			body:     goldenExpectedIssuance,
+			wantPath: "/chains/main/blocks/head/context/issuance/expected_issuance",
+			get: func(n *NetworkService) (interface{}, error) {
+				return n.ExpectedIssuance(blockid.Head())
+			},
+			want: []ExpectedIssuance{
+				{Cycle: 750, BakingRewardFixedPortion: 5022734, BakingRewardBonusPerSlot: 2188, AttestingRewardPerSlot: 8752, SeedNonceRevelationTip: 1046, VdfRevelationTip: 1046},
+				{Cycle: 751, BakingRewardFixedPortion: 5020157, BakingRewardBonusPerSlot: 2187, AttestingRewardPerSlot: 8748, SeedNonceRevelationTip: 1045, VdfRevelationTip: 1045},
+			},
+		},
+		{
+			name:     "Adaptive issuance launched",
+			body:     []byte(`748`),
+			wantPath: "/chains/main/blocks/head/context/adaptive_issuance_launch_cycle",
+			get: func(n *NetworkService) (interface{}, error) {
+				cycle, err := n.AdaptiveIssuanceLaunchCycle(blockid.Head())
+				if cycle == nil {
+					return nil, err
+				}
+				return *cycle, err
+			},
+			want: 748,
+		},
+		{
+			name:     "Adaptive issuance not voted",
+			body:     []byte(`null`),
+			wantPath: "/chains/main/blocks/head/context/adaptive_issuance_launch_cycle",
+			get: func(n *NetworkService) (interface{}, error) {
+				cycle, err := n.AdaptiveIssuanceLaunchCycle(blockid.Head())
+				if cycle == nil {
+					return nil, err
+				}
+				return *cycle, err
+			},
+			want: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &client{ReturnBody: tc.body}
+			have, err := tc.get(NewNetworkService(c))
+			assert.Equal(t, c.Path, tc.wantPath)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, have, tc.want)
+		})
+	}
+}
@@ -40,6 +40,53 @@ var (
 		"hard_storage_limit_per_operation":"60000"
 	 }`)
 
+	goldenConstantsBabylon = []byte(`{
+		"preserved_cycles":5,
+		"blocks_per_cycle":4096,
+		"blocks_per_roll_snapshot":256,
+		"blocks_per_voting_period":32768,
+		"time_between_blocks":["60","40"],
+		"endorsers_per_block":32,
+		"tokens_per_roll":"8000000000",
+		"origination_size":257,
+		"baking_reward_per_endorsement":["1250000","187500"],
+		"endorsement_reward":["1250000","833333"],
+		"cost_per_byte":"1000",
+		"hard_storage_limit_per_operation":"60000"
+	 }`)
+
+	goldenConstantsParis = []byte(`{
+		"proof_of_work_nonce_size":8,
+		"nonce_length":32,
+		"consensus_rights_delay":2,
+		"blocks_preservation_cycles":1,
+		"blocks_per_cycle":24576,
+		"blocks_per_commitment":192,
+		"nonce_revelation_threshold":768,
+		"cycles_per_voting_period":5,
+		"hard_gas_limit_per_operation":"1040000",
+		"hard_gas_limit_per_block":"1733333",
+		"minimal_stake":"6000000000",
+		"minimal_frozen_stake":"600000000",
+		"origination_size":257,
+		"cost_per_byte":"250",
+		"hard_storage_limit_per_operation":"60000",
+		"max_operations_time_to_live":450,
+		"minimal_block_delay":"8",
+		"delay_increment_per_round":"4",
+		"consensus_committee_size":7000,
+		"consensus_threshold":4667,
+		"limit_of_delegation_over_baking":9,
+		"blocks_per_stake_snapshot":1536
+	 }`)
+
+	goldenProtocols = []byte(`{"protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","next_protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS"}`)
+
+	goldenExpectedIssuance = []byte(`[
+		{"cycle":750,"baking_reward_fixed_portion":"5022734","baking_reward_bonus_per_slot":"2188","attesting_reward_per_slot":"8752","seed_nonce_revelation_tip":"1046","vdf_revelation_tip":"1046"},
+		{"cycle":751,"baking_reward_fixed_portion":"5020157","baking_reward_bonus_per_slot":"2187","attesting_reward_per_slot":"8748","seed_nonce_revelation_tip":"1045","vdf_revelation_tip":"1045"}
+	]`)
+
 	goldenChainID = []byte(`"NetXgtSLGNJvNye"`)
 
 	goldenConnections = []byte(`[
@@ -228,12 +275,28 @@ var (
 
 type client struct {
 	ReturnBody []byte
+	// Bodies are returned instead of ReturnBody for the paths they are keyed by
+	Bodies map[string][]byte
+	Path   string
+	Params map[string]string
+	// Calls counts the requests by path
+	Calls map[string]int
 }
 
 func (c *client) Post(path, args string) ([]byte, error) {
+	c.Path = path
 	return c.ReturnBody, nil
 }
 
 func (c *client) Get(path string, params map[string]string) ([]byte, error) {
+	c.Path = path
+	c.Params = params
+	if c.Calls == nil {
+		c.Calls = map[string]int{}
+	}
+	c.Calls[path]++
+	if body, ok := c.Bodies[path]; ok {
+		return body, nil
+	}
 	return c.ReturnBody, nil
 }
@@ -3,7 +3,9 @@ package network
 import (
 	"encoding/json"
 	"strings"
+	"sync"
 
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
 	"github.com/pkg/errors"
 )
@@ -11,6 +13,10 @@ import (
 // NetworkService is wrapper representing network functions
 type NetworkService struct {
 	tzclient tzc.TezosClient
+
+	mu sync.Mutex
+	// constants of the protocols met so far, by protocol hash
+	constants map[string]Constants
 }
 
 // Version represents the network version returned by the Tezos network.
@@ -24,7 +30,8 @@ type Version struct {
 // Versions is an array of Version
 type Versions []Version
 
-// Constants represents the network constants returned by the Tezos network.
+// Constants represents the network constants returned by the Tezos network. Constants renamed by
+// a protocol are set under both their old and their new name, e.g. EndorsersPerBlock and ConsensusCommitteeSize.
 type Constants struct {
 	ProofOfWorkNonceSize         int      `json:"proof_of_work_nonce_size"`
 	NonceLength                  int      `json:"nonce_length"`
@@ -51,6 +58,24 @@ type Constants struct {
 	EndorsementReward            string   `json:"endorsement_reward"`
 	CostPerByte                  string   `json:"cost_per_byte"`
 	HardStorageLimitPerOperation string   `json:"hard_storage_limit_per_operation"`
+
+	// Constants of Tenderbake and of the later protocols
+	MinimalBlockDelay           string `json:"minimal_block_delay"`
+	DelayIncrementPerRound      string `json:"delay_increment_per_round"`
+	ConsensusCommitteeSize      int    `json:"consensus_committee_size"`
+	ConsensusThreshold          int    `json:"consensus_threshold"`
+	ConsensusRightsDelay        int    `json:"consensus_rights_delay"`
+	BlocksPerStakeSnapshot      int    `json:"blocks_per_stake_snapshot"`
+	CyclesPerVotingPeriod       int    `json:"cycles_per_voting_period"`
+	MaxOperationsTimeToLive     int    `json:"max_operations_time_to_live"`
+	NonceRevelationThreshold    int    `json:"nonce_revelation_threshold"`
+	MinimalStake                string `json:"minimal_stake"`
+	MinimalFrozenStake          string `json:"minimal_frozen_stake"`
+	LimitOfDelegationOverBaking int    `json:"limit_of_delegation_over_baking"`
+	BakingRewardFixedPortion    string `json:"baking_reward_fixed_portion"`
+	BakingRewardBonusPerSlot    string `json:"baking_reward_bonus_per_slot"`
+	AttestingRewardPerSlot      string `json:"attesting_reward_per_slot"`
+	LiquidityBakingSubsidy      string `json:"liquidity_baking_subsidy"`
 }
 
 // Connections represents network connections
@@ -80,7 +105,7 @@ type Connections []struct {
 
 // NewNetworkService returns a new NetworkService
 func NewNetworkService(tzclient tzc.TezosClient) *NetworkService {
-	return &NetworkService{tzclient: tzclient}
+	return &NetworkService{tzclient: tzclient, constants: map[string]Constants{}}
 }
 
 // GetVersions gets the network versions of Tezos network the client is using.
@@ -115,8 +140,26 @@ func (n *NetworkService) GetVersions() ([]Version, error) {
 
 // GetConstants gets the network constants for the Tezos network the client is using.
 func (n *NetworkService) GetConstants() (Constants, error) {
-	query := "/chains/main/blocks/head/context/constants"
-	networkConstants := Constants{}
+	return n.Constants(blockid.Head())
+}
+
+// Constants gets the constants of the protocol of the block id. Constants only change when a protocol
+// is activated, they are cached by protocol hash so only the protocol of the block is fetched once the
+// constants of its protocol are known.
+func (n *NetworkService) Constants(id blockid.BlockID) (Constants, error) {
+	protocol, err := n.protocol(id)
+	if err != nil {
+		return Constants{}, errors.Wrapf(err, "could not get network constants of block '%s'", id)
+	}
+
+	n.mu.Lock()
+	networkConstants, ok := n.constants[protocol]
+	n.mu.Unlock()
+	if ok {
+		return networkConstants, nil
+	}
+
+	query := "/chains/main/blocks/" + id.String() + "/context/constants"
 	resp, err := n.tzclient.Get(query, nil)
 	if err != nil {
 		return networkConstants, errors.Wrapf(err, "could not get network constants '%s'", query)
@@ -126,9 +169,41 @@ func (n *NetworkService) GetConstants() (Constants, error) {
 		return networkConstants, errors.Wrapf(err, "could not get network constants '%s'", query)
 	}
 
+	n.mu.Lock()
+	n.constants[protocol] = networkConstants
+	n.mu.Unlock()
+
 	return networkConstants, nil
 }
 
+// InvalidateConstants drops the cached constants, e.g. when the client is pointed to another network
+// whose protocols share hashes with the previous one but not their constants.
+func (n *NetworkService) InvalidateConstants() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.constants = map[string]Constants{}
+}
+
+// protocol gets the hash of the protocol the context of the block id is read with
+func (n *NetworkService) protocol(id blockid.BlockID) (string, error) {
+	query := "/chains/main/blocks/" + id.String() + "/protocols"
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get protocols '%s'", query)
+	}
+
+	var protocols struct {
+		NextProtocol string `json:"next_protocol"`
+	}
+	if err := json.Unmarshal(resp, &protocols); err != nil {
+		return "", errors.Wrapf(err, "could not get protocols '%s'", query)
+	}
+	if protocols.NextProtocol == "" {
+		return "", errors.Errorf("could not get protocols '%s', no protocol", query)
+	}
+	return protocols.NextProtocol, nil
+}
+
 // GetChainID gets the id of the chain with the most fitness
 func (n *NetworkService) GetChainID() (string, error) {
 	query := "/chains/main/chain_id"
@@ -46,6 +46,7 @@ func Test_GetConstants(t *testing.T) {
 		{
 			tzclient: &client{
 				ReturnBody: goldenConstants,
+				Bodies:     map[string][]byte{"/chains/main/blocks/head/protocols": goldenProtocols},
 			},
 			want: goldenConstants,
 		},
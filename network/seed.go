@@ -0,0 +1,88 @@
+package network
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+// Stages of the computation of the seed of a cycle
+const (
+	SeedStageNonceRevelation = "nonce_revelation_stage"
+	SeedStageVdfRevelation   = "vdf_revelation_stage"
+	SeedStageFinished        = "computation_finished"
+)
+
+// SeedComputation is the stage of the computation of the seed of the cycle after next. Nonces are revealed during the
+// nonce revelation stage, the VDF solution is revealed once the stage is over.
+type SeedComputation struct {
+	Stage string
+	// SeedDiscriminant and SeedChallenge are the parameters of the VDF during the VDF revelation stage
+	SeedDiscriminant string
+	SeedChallenge    string
+}
+
+// Nonce is the state of the seed nonce committed to at a level.
+type Nonce struct {
+	// Nonce is the revealed nonce in hexadecimal
+	Nonce string `json:"nonce,omitempty"`
+	// Hash is the committed hash of the nonce while the nonce is not revealed
+	Hash string `json:"hash,omitempty"`
+	// Forgotten is set once the nonce is neither revealed nor revealable anymore
+	Forgotten bool `json:"-"`
+}
+
+// Seed gets the random seed of the cycle of the block id, in hexadecimal.
+func (n *NetworkService) Seed(id blockid.BlockID) (string, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/seed"
+	resp, err := n.tzclient.Post(query, "{}")
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get seed '%s'", query)
+	}
+
+	seed, err := unmarshalString(resp)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get seed '%s'", query)
+	}
+	return seed, nil
+}
+
+// SeedComputation gets the stage of the computation of the seed at the block id.
+func (n *NetworkService) SeedComputation(id blockid.BlockID) (SeedComputation, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/seed_computation"
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return SeedComputation{}, errors.Wrapf(err, "could not get seed computation '%s'", query)
+	}
+
+	var stages map[string]struct {
+		SeedDiscriminant string `json:"seed_discriminant"`
+		SeedChallenge    string `json:"seed_challenge"`
+	}
+	if err := json.Unmarshal(resp, &stages); err != nil {
+		return SeedComputation{}, errors.Wrapf(err, "could not get seed computation '%s'", query)
+	}
+	for stage, params := range stages {
+		return SeedComputation{Stage: stage, SeedDiscriminant: params.SeedDiscriminant, SeedChallenge: params.SeedChallenge}, nil
+	}
+	return SeedComputation{}, errors.Errorf("could not get seed computation '%s', no stage", query)
+}
+
+// Nonce gets the seed nonce committed to by the block at level, as known by the context of the block id.
+func (n *NetworkService) Nonce(id blockid.BlockID, level int) (Nonce, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/nonces/" + strconv.Itoa(level)
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return Nonce{}, errors.Wrapf(err, "could not get nonce '%s'", query)
+	}
+
+	var nonce Nonce
+	if err := json.Unmarshal(resp, &nonce); err != nil {
+		return Nonce{}, errors.Wrapf(err, "could not get nonce '%s'", query)
+	}
+	nonce.Forgotten = nonce.Nonce == "" && nonce.Hash == ""
+	return nonce, nil
+}
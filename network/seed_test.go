@@ -0,0 +1,95 @@
+package network
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+)
+
+func Test_Seed(t *testing.T) {
+	c := &client{ReturnBody: []byte(`"f9b1c6f0b0b4c77a3bbbd3f1c7d2a8f3e0c0cd1f3c1e6b6e0f6ab2b0d6f0b1c2"`)}
+	seed, err := NewNetworkService(c).Seed(blockid.Level(5000000))
+	assert.NilError(t, err)
+	assert.Equal(t, c.Path, "/chains/main/blocks/5000000/context/seed")
+	assert.Equal(t, seed, "f9b1c6f0b0b4c77a3bbbd3f1c7d2a8f3e0c0cd1f3c1e6b6e0f6ab2b0d6f0b1c2")
+}
+
+func Test_SeedComputation(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    []byte
+		want    SeedComputation
+		wantErr string
+	}{
+		{
+			name: "Nonce revelation stage",
+			body: []byte(`{"nonce_revelation_stage":null}`),
+			want: SeedComputation{Stage: SeedStageNonceRevelation},
+		},
+		{
+			name: "VDF revelation stage",
+			body: []byte(`{"vdf_revelation_stage":{"seed_discriminant":"fffd0b","seed_challenge":"0a1b2c"}}`),
+			want: SeedComputation{Stage: SeedStageVdfRevelation, SeedDiscriminant: "fffd0b", SeedChallenge: "0a1b2c"},
+		},
+		{
+			name: "Computation finished",
+			body: []byte(`{"computation_finished":null}`),
+			want: SeedComputation{Stage: SeedStageFinished},
+		},
+		{
+			name:    "No stage",
+			body:    []byte(`{}`),
+			wantErr: "could not get seed computation '/chains/main/blocks/head/context/seed_computation', no stage",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &client{ReturnBody: tc.body}
+			computation, err := NewNetworkService(c).SeedComputation(blockid.Head())
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, c.Path, "/chains/main/blocks/head/context/seed_computation")
+			assert.Equal(t, computation, tc.want)
+		})
+	}
+}
+
+func Test_Nonce(t *testing.T) {
+	cases := []struct {
+		name string
+		body []byte
+		want Nonce
+	}{
+		{
+			name: "Revealed",
+			body: []byte(`{"nonce":"2e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1"}`),
+			want: Nonce{Nonce: "2e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1"},
+		},
+		{
+			name: "Missing",
+			body: []byte(`{"hash":"nceVPRVUY9uAu3bUPdNkxyC1uKbvYqY5B3HGGTSXGv2u6P9P9LceT"}`),
+			want: Nonce{Hash: "nceVPRVUY9uAu3bUPdNkxyC1uKbvYqY5B3HGGTSXGv2u6P9P9LceT"},
+		},
+		{
+			name: "Forgotten",
+			body: []byte(`{}`),
+			want: Nonce{Forgotten: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &client{ReturnBody: tc.body}
+			nonce, err := NewNetworkService(c).Nonce(blockid.Head(), 5000000)
+			assert.NilError(t, err)
+			assert.Equal(t, c.Path, "/chains/main/blocks/head/context/nonces/5000000")
+			assert.Equal(t, nonce, tc.want)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package network
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// LiquidityBaking is the liquidity baking contract and the subsidy it receives at every block.
+type LiquidityBaking struct {
+	CPMMAddress string
+	// Subsidy is zero from the protocols deriving the subsidy from the issuance, e.g. Paris
+	Subsidy tez.Mutez
+}
+
+// TotalSupply gets the amount of tez in circulation at the block id.
+func (n *NetworkService) TotalSupply(id blockid.BlockID) (tez.Mutez, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/total_supply"
+	supply, err := n.mutez(query)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get total supply '%s'", query)
+	}
+	return supply, nil
+}
+
+// TotalFrozenStake gets the amount of tez frozen by bakers and their stakers at the block id.
+func (n *NetworkService) TotalFrozenStake(id blockid.BlockID) (tez.Mutez, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/total_frozen_stake"
+	stake, err := n.mutez(query)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get total frozen stake '%s'", query)
+	}
+	return stake, nil
+}
+
+// LiquidityBaking gets the liquidity baking contract and its subsidy at the block id.
+func (n *NetworkService) LiquidityBaking(id blockid.BlockID) (LiquidityBaking, error) {
+	query := "/chains/main/blocks/" + id.String() + "/context/liquidity_baking/cpmm_address"
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return LiquidityBaking{}, errors.Wrapf(err, "could not get liquidity baking '%s'", query)
+	}
+	address, err := unmarshalString(resp)
+	if err != nil {
+		return LiquidityBaking{}, errors.Wrapf(err, "could not get liquidity baking '%s'", query)
+	}
+
+	constants, err := n.Constants(id)
+	if err != nil {
+		return LiquidityBaking{}, errors.Wrapf(err, "could not get liquidity baking of block '%s'", id)
+	}
+	var subsidy tez.Mutez
+	if constants.LiquidityBakingSubsidy != "" {
+		subsidy, err = tez.ParseMutez(constants.LiquidityBakingSubsidy)
+		if err != nil {
+			return LiquidityBaking{}, errors.Wrapf(err, "could not get liquidity baking of block '%s'", id)
+		}
+	}
+
+	return LiquidityBaking{CPMMAddress: address, Subsidy: subsidy}, nil
+}
+
+// mutez gets an amount of mutez
+func (n *NetworkService) mutez(query string) (tez.Mutez, error) {
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var amount tez.Mutez
+	if err := json.Unmarshal(resp, &amount); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
@@ -0,0 +1,95 @@
+package network
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_Supply(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     []byte
+		wantPath string
+		get      func(n *NetworkService) (tez.Mutez, error)
+		want     tez.Mutez
+		wantErr  string
+	}{
+		{
+			name:     "Total supply",
+			body:     []byte(`"1021428572311874"`),
+			wantPath: "/chains/main/blocks/5000000/context/total_supply",
+			get: func(n *NetworkService) (tez.Mutez, error) {
+				return n.TotalSupply(blockid.Level(5000000))
+			},
+			want: 1021428572311874,
+		},
+		{
+			name:     "Total frozen stake",
+			body:     []byte(`"141542063462384"`),
+			wantPath: "/chains/main/blocks/head/context/total_frozen_stake",
+			get: func(n *NetworkService) (tez.Mutez, error) {
+				return n.TotalFrozenStake(blockid.Head())
+			},
+			want: 141542063462384,
+		},
+		{
+			name:     "Malformed total supply",
+			body:     []byte(`{}`),
+			wantPath: "/chains/main/blocks/head/context/total_supply",
+			get: func(n *NetworkService) (tez.Mutez, error) {
+				return n.TotalSupply(blockid.Head())
+			},
+			wantErr: "could not get total supply '/chains/main/blocks/head/context/total_supply'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &client{ReturnBody: tc.body}
+			amount, err := tc.get(NewNetworkService(c))
+			assert.Equal(t, c.Path, tc.wantPath)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, amount, tc.want)
+		})
+	}
+}
+
+func Test_LiquidityBaking(t *testing.T) {
+	cases := []struct {
+		name      string
+		constants []byte
+		want      LiquidityBaking
+	}{
+		{
+			name:      "Fixed subsidy",
+			constants: []byte(`{"blocks_per_cycle":8192,"liquidity_baking_subsidy":"2500000"}`),
+			want:      LiquidityBaking{CPMMAddress: "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5", Subsidy: 2500000},
+		},
+		{
+			name:      "Subsidy from the issuance",
+			constants: goldenConstantsParis,
+			want:      LiquidityBaking{CPMMAddress: "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &client{Bodies: map[string][]byte{
+				"/chains/main/blocks/head/context/liquidity_baking/cpmm_address": []byte(`"KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5"`),
+				"/chains/main/blocks/head/protocols":                             goldenProtocols,
+				"/chains/main/blocks/head/context/constants":                     tc.constants,
+			}}
+			liquidityBaking, err := NewNetworkService(c).LiquidityBaking(blockid.Head())
+			assert.NilError(t, err)
+			assert.Equal(t, liquidityBaking, tc.want)
+		})
+	}
+}
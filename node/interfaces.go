@@ -1,6 +1,14 @@
 package node
 
+import "context"
+
 type TezosNodeService interface {
 	Bootstrapped() (Bootstrap, error)
 	CommitHash() (string, error)
+	InvalidBlocks() ([]InvalidBlock, error)
+	Checkpoint() (BlockLevel, error)
+	Savepoint() (BlockLevel, error)
+	Caboose() (BlockLevel, error)
+	MonitorBootstrapped(ctx context.Context) (<-chan Bootstrap, error)
+	MonitorValidBlocks(ctx context.Context) (<-chan ValidBlock, error)
 }
@@ -1 +1,50 @@
 package node
+
+import (
+	"context"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+type clientMock struct {
+	ReturnBody []byte
+	Path       string
+}
+
+func (c *clientMock) Post(path, args string) ([]byte, error) {
+	return c.ReturnBody, nil
+}
+
+func (c *clientMock) Get(path string, params map[string]string) ([]byte, error) {
+	c.Path = path
+	return c.ReturnBody, nil
+}
+
+type streamClientMock struct {
+	Values [][]byte
+}
+
+func (c *streamClientMock) Post(path, args string) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *streamClientMock) Get(path string, params map[string]string) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *streamClientMock) Stream(ctx context.Context, path string, params map[string]string, fn tzc.StreamHandler) error {
+	if err := c.StreamOnce(ctx, path, params, fn); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *streamClientMock) StreamOnce(ctx context.Context, path string, params map[string]string, fn tzc.StreamHandler) error {
+	for _, value := range c.Values {
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// ValidBlock is a block validated by the node, on the main branch or on an alternate branch.
+type ValidBlock struct {
+	ChainID string `json:"chain_id"`
+	block.Header
+}
+
+// MonitorBootstrapped returns a channel receiving the blocks the node validates while bootstrapping, read from /monitor/bootstrapped.
+// The channel is closed once the node is bootstrapped, or when ctx is done.
+func (n *NodeService) MonitorBootstrapped(ctx context.Context) (<-chan Bootstrap, error) {
+	streamer, ok := n.tzclient.(tzc.Streamer)
+	if !ok {
+		return nil, errors.New("could not monitor bootstrapped, client does not support streaming")
+	}
+
+	bootstraps := make(chan Bootstrap)
+	go func() {
+		defer close(bootstraps)
+
+		streamer.StreamOnce(ctx, "/monitor/bootstrapped", nil, func(value json.RawMessage) error {
+			b, err := unmarshallBootstrap(value)
+			if err != nil {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case bootstraps <- b:
+				return nil
+			}
+		})
+	}()
+
+	return bootstraps, nil
+}
+
+// MonitorValidBlocks returns a channel receiving every block the node validates, read from /monitor/valid_blocks.
+// Blocks of alternate branches are received too. The channel is closed once ctx is done.
+func (n *NodeService) MonitorValidBlocks(ctx context.Context) (<-chan ValidBlock, error) {
+	streamer, ok := n.tzclient.(tzc.Streamer)
+	if !ok {
+		return nil, errors.New("could not monitor valid blocks, client does not support streaming")
+	}
+
+	blocks := make(chan ValidBlock)
+	go func() {
+		defer close(blocks)
+
+		streamer.Stream(ctx, "/monitor/valid_blocks", nil, func(value json.RawMessage) error {
+			var b ValidBlock
+			if err := json.Unmarshal(value, &b); err != nil {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case blocks <- b:
+				return nil
+			}
+		})
+	}()
+
+	return blocks, nil
+}
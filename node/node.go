@@ -20,6 +20,19 @@ type Bootstrap struct {
 	Timestamp time.Time
 }
 
+// InvalidBlock is a block the node refused, with the errors that made it invalid.
+type InvalidBlock struct {
+	Block  string          `json:"block"`
+	Level  int             `json:"level"`
+	Errors []*tzc.RPCError `json:"errors"`
+}
+
+// BlockLevel is a block of the chain storage of the node, e.g. its checkpoint.
+type BlockLevel struct {
+	BlockHash string `json:"block_hash"`
+	Level     int    `json:"level"`
+}
+
 // NewNodeService returns a new NodeService
 func NewNodeService(tzclient tzc.TezosClient) *NodeService {
 	return &NodeService{tzclient: tzclient}
@@ -59,6 +72,52 @@ func (n *NodeService) CommitHash() (string, error) {
 	return c, nil
 }
 
+// InvalidBlocks gets the blocks the node refused, a sign of a fork or of a misbehaving peer
+func (n *NodeService) InvalidBlocks() ([]InvalidBlock, error) {
+	var blocks []InvalidBlock
+	query := "/chains/main/invalid_blocks"
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return blocks, errors.Wrapf(err, "could not get invalid blocks '%s'", query)
+	}
+
+	if err := json.Unmarshal(resp, &blocks); err != nil {
+		return blocks, errors.Wrapf(err, "could not get invalid blocks '%s'", query)
+	}
+
+	return blocks, nil
+}
+
+// Checkpoint gets the checkpoint of the node, the block every block the node accepts must descend from
+func (n *NodeService) Checkpoint() (BlockLevel, error) {
+	return n.level("checkpoint")
+}
+
+// Savepoint gets the savepoint of the node, the lowest block the node has the metadata of
+func (n *NodeService) Savepoint() (BlockLevel, error) {
+	return n.level("savepoint")
+}
+
+// Caboose gets the caboose of the node, the lowest block the node stores
+func (n *NodeService) Caboose() (BlockLevel, error) {
+	return n.level("caboose")
+}
+
+func (n *NodeService) level(name string) (BlockLevel, error) {
+	var level BlockLevel
+	query := "/chains/main/levels/" + name
+	resp, err := n.tzclient.Get(query, nil)
+	if err != nil {
+		return level, errors.Wrapf(err, "could not get %s '%s'", name, query)
+	}
+
+	if err := json.Unmarshal(resp, &level); err != nil {
+		return level, errors.Wrapf(err, "could not get %s '%s'", name, query)
+	}
+
+	return level, nil
+}
+
 func unmarshallBootstrap(v []byte) (Bootstrap, error) {
 	b := Bootstrap{}
 	err := json.Unmarshal(v, &b)
@@ -1 +1,87 @@
 package node
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_MonitorBootstrapped(t *testing.T) {
+	client := &streamClientMock{
+		Values: [][]byte{
+			[]byte(`{"block":"BLa","timestamp":"2019-07-16T14:59:56Z"}`),
+			[]byte(`{"block":"BLb","timestamp":"2019-07-16T15:00:56Z"}`),
+		},
+	}
+
+	bootstraps, err := NewNodeService(client).MonitorBootstrapped(context.Background())
+	assert.NilError(t, err)
+
+	var blocks []string
+	for b := range bootstraps {
+		blocks = append(blocks, b.Block)
+	}
+	assert.DeepEqual(t, blocks, []string{"BLa", "BLb"})
+}
+
+func Test_MonitorValidBlocks(t *testing.T) {
+	client := &streamClientMock{
+		Values: [][]byte{
+			[]byte(`{"chain_id":"NetXdQprcVkpaWU","hash":"BLa","level":10}`),
+			[]byte(`{"chain_id":"NetXdQprcVkpaWU","hash":"BLb","level":10}`),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks, err := NewNodeService(client).MonitorValidBlocks(ctx)
+	assert.NilError(t, err)
+
+	first, second := <-blocks, <-blocks
+	assert.Equal(t, first.ChainID, "NetXdQprcVkpaWU")
+	assert.Equal(t, first.Hash, "BLa")
+	assert.Equal(t, second.Hash, "BLb")
+	assert.Equal(t, second.Level, 10)
+
+	cancel()
+	for range blocks {
+	}
+}
+
+func Test_InvalidBlocks(t *testing.T) {
+	client := &clientMock{
+		ReturnBody: []byte(`[{"block":"BLa","level":100,"errors":[{"kind":"permanent","id":"validator.invalid_block","invalid_block":"BLa"}]}]`),
+	}
+
+	blocks, err := NewNodeService(client).InvalidBlocks()
+	assert.NilError(t, err)
+	assert.Equal(t, client.Path, "/chains/main/invalid_blocks")
+	assert.Equal(t, len(blocks), 1)
+	assert.Equal(t, blocks[0].Level, 100)
+	assert.Equal(t, blocks[0].Errors[0].ID, "validator.invalid_block")
+}
+
+func Test_Levels(t *testing.T) {
+	cases := []struct {
+		get      func(n *NodeService) (BlockLevel, error)
+		wantPath string
+	}{
+		{get: (*NodeService).Checkpoint, wantPath: "/chains/main/levels/checkpoint"},
+		{get: (*NodeService).Savepoint, wantPath: "/chains/main/levels/savepoint"},
+		{get: (*NodeService).Caboose, wantPath: "/chains/main/levels/caboose"},
+	}
+
+	for _, tc := range cases {
+		client := &clientMock{ReturnBody: []byte(`{"block_hash":"BLa","level":4000}`)}
+		level, err := tc.get(NewNodeService(client))
+		assert.NilError(t, err)
+		assert.Equal(t, client.Path, tc.wantPath)
+		assert.Equal(t, level, BlockLevel{BlockHash: "BLa", Level: 4000})
+
+		client.ReturnBody = []byte("malformed response")
+		_, err = tc.get(NewNodeService(client))
+		assert.Assert(t, err != nil)
+	}
+}
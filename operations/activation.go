@@ -0,0 +1,50 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+)
+
+// ActivationOptions are the optional settings of ActivateAccount.
+type ActivationOptions struct {
+	// Confirmations is the number of blocks to wait for on top of the block including the activation,
+	// ActivateAccount returns as soon as the operation is injected when it is 0
+	Confirmations int
+}
+
+// ActivateAccount activates the account pkh of a faucet or fundraiser commitment with its secret, the activation
+// code of the commitment in hexadecimal. Activations are anonymous, the operation is injected without signature.
+// The hash of the operation is returned.
+func (o *OperationService) ActivateAccount(ctx context.Context, pkh, secret string, opts ActivationOptions) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", errors.Wrapf(err, "could not activate account '%s'", pkh)
+	}
+
+	o = o.withContext(ctx)
+
+	branch, err := o.getHeadHash()
+	if err != nil {
+		return "", errors.Wrapf(err, "could not activate account '%s'", pkh)
+	}
+
+	forged, err := forge.Operation(branch, block.Contents{Kind: block.KindActivateAccount, Pkh: pkh, Secret: secret})
+	if err != nil {
+		return "", errors.Wrapf(err, "could not activate account '%s'", pkh)
+	}
+
+	hash, err := o.InjectionOperation(forged, InjectionOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "could not activate account '%s'", pkh)
+	}
+
+	if opts.Confirmations > 0 {
+		if _, _, err := o.blockService.WaitConfirmedWithOptions(ctx, hash, block.WaitOptions{Confirmations: opts.Confirmations, Branch: branch}); err != nil {
+			return hash, errors.Wrapf(err, "could not activate account '%s'", pkh)
+		}
+	}
+	return hash, nil
+}
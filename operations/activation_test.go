@@ -0,0 +1,58 @@
+package operations
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ActivateAccount(t *testing.T) {
+	cases := []struct {
+		name          string
+		secret        string
+		confirmations int
+		wantArgs      string
+		wantErr       bool
+	}{
+		{
+			name:     "Activation",
+			secret:   "41f98b15efc63fa893d61d7d6eee4a2ce9427ac4",
+			wantArgs: `"eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df0438896346da37c3ea531638153423a5632bd4b2c241f98b15efc63fa893d61d7d6eee4a2ce9427ac4"`,
+		},
+		{
+			name:          "Confirmed activation",
+			secret:        "41f98b15efc63fa893d61d7d6eee4a2ce9427ac4",
+			confirmations: 1,
+			wantArgs:      `"eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df0438896346da37c3ea531638153423a5632bd4b2c241f98b15efc63fa893d61d7d6eee4a2ce9427ac4"`,
+		},
+		{
+			name:    "Invalid secret",
+			secret:  "41f98b15",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				ReturnBody: []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash": []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+				},
+			}
+			blocks := &blockServiceMock{}
+
+			hash, err := NewOperationService(blocks, client).ActivateAccount(context.Background(), "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", tc.secret, ActivationOptions{Confirmations: tc.confirmations})
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "could not activate account 'tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1'")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+			assert.Equal(t, client.Path, "/injection/operation")
+			assert.Equal(t, client.Args, tc.wantArgs)
+			assert.Equal(t, blocks.Confirmations, tc.confirmations)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package operations
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Batch accumulates manager operations of a single source to forge them as one operation.
+type Batch struct {
+	operations *OperationService
+	source     string
+	publicKey  string
+	contents   []block.Contents
+
+	// Estimator estimates the limits and fees of the contents, it can be replaced to change the margins
+	Estimator *Estimator
+}
+
+// ForgedBatch is a forged Batch, Bytes are to be signed and injected.
+type ForgedBatch struct {
+	Branch   string
+	Contents []block.Contents
+	Bytes    []byte
+}
+
+// NewBatch returns a new Batch of operations of source. The public key of source is used to reveal it
+// when it is not revealed yet, it may be empty for revealed accounts.
+func (o *OperationService) NewBatch(source, publicKey string) *Batch {
+	return &Batch{
+		operations: o,
+		source:     source,
+		publicKey:  publicKey,
+		Estimator:  NewEstimator(o),
+	}
+}
+
+// Add adds manager operations to the batch, their source, counter, limits and fee are set by Forge.
+func (b *Batch) Add(contents ...block.Contents) *Batch {
+	for _, c := range contents {
+		c.Source = b.source
+		b.contents = append(b.contents, c)
+	}
+	return b
+}
+
+// Forge prepends a reveal when the source is not revealed, assigns sequential counters to the contents,
+// estimates their limits and fees, and forges them on top of the head. The counters are reserved with the
+// Counters of the service, invalidate them when the operation is not injected.
+func (b *Batch) Forge() (ForgedBatch, error) {
+	var forged ForgedBatch
+	if len(b.contents) == 0 {
+		return forged, errors.New("could not forge batch, no operations")
+	}
+
+	branch, err := b.operations.getHeadHash()
+	if err != nil {
+		return forged, errors.Wrap(err, "could not forge batch")
+	}
+
+	contents, err := b.operations.RevealIfNeeded(b.source, b.publicKey, b.contents)
+	if err != nil {
+		return forged, errors.Wrap(err, "could not forge batch")
+	}
+
+	counter, err := b.operations.counters.next(b.operations, b.source, len(contents))
+	if err != nil {
+		return forged, errors.Wrap(err, "could not forge batch")
+	}
+	numbered := make([]block.Contents, len(contents))
+	for i, c := range contents {
+		c.Counter = tez.NewZarith(int64(counter + i))
+		numbered[i] = c
+	}
+
+	estimated, err := b.Estimator.Estimate(branch, numbered)
+	if err != nil {
+		// The reserved counters will not be used
+		b.operations.counters.Invalidate(b.source)
+		return forged, errors.Wrap(err, "could not forge batch")
+	}
+
+	opBytes, err := forge.Operation(branch, estimated...)
+	if err != nil {
+		b.operations.counters.Invalidate(b.source)
+		return forged, errors.Wrap(err, "could not forge batch")
+	}
+
+	return ForgedBatch{Branch: branch, Contents: estimated, Bytes: opBytes}, nil
+}
+
+func (o *OperationService) getHeadHash() (string, error) {
+	rpc := "/chains/main/blocks/head/hash"
+	resp, err := o.tzclient.Get(rpc, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get head hash '%s'", rpc)
+	}
+	hash, err := unmarshalString(resp)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get head hash '%s'", rpc)
+	}
+	return hash, nil
+}
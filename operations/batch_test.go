@@ -0,0 +1,87 @@
+package operations
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_BatchForge(t *testing.T) {
+	source := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	publicKey := "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"
+	applied := `{"kind":"transaction","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}`
+
+	cases := []struct {
+		name       string
+		publicKey  string
+		managerKey string
+		returnBody string
+		wantKinds  []string
+		wantErr    bool
+	}{
+		{
+			name:       "Revealed source",
+			managerKey: `"` + publicKey + `"`,
+			returnBody: `{"contents":[` + applied + `,` + applied + `]}`,
+			wantKinds:  []string{block.KindTransaction, block.KindDelegation},
+		},
+		{
+			name:       "Source to reveal",
+			publicKey:  publicKey,
+			managerKey: `null`,
+			returnBody: `{"contents":[` + applied + `,` + applied + `,` + applied + `]}`,
+			wantKinds:  []string{block.KindReveal, block.KindTransaction, block.KindDelegation},
+		},
+		{
+			name:       "Source to reveal without public key",
+			managerKey: `null`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				ReturnBody: []byte(tc.returnBody),
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash":                                         []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+					"/chains/main/chain_id":                                                 []byte(`"NetXdQprcVkpaWU"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/counter":     []byte(`"10"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/manager_key": []byte(tc.managerKey),
+				},
+			}
+
+			batch := NewOperationService(nil, client).NewBatch(source, tc.publicKey).Add(
+				block.Contents{Kind: block.KindTransaction, Amount: tez.FromTez(1), Destination: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},
+				block.Contents{Kind: block.KindDelegation, Delegate: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},
+			)
+			forged, err := batch.Forge()
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, forged.Branch, "BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY")
+
+			for i, c := range forged.Contents {
+				assert.Equal(t, c.Kind, tc.wantKinds[i])
+				assert.Equal(t, c.Source, source)
+				assert.Equal(t, c.Counter.Int64(), int64(11+i))
+				assert.Equal(t, c.GasLimit.Int64(), int64(1100))
+				assert.Assert(t, c.Fee > 0)
+			}
+
+			branch, contents, err := forge.Unforge(forged.Bytes)
+			assert.NilError(t, err)
+			assert.Equal(t, branch, forged.Branch)
+			assert.Equal(t, len(contents), len(tc.wantKinds))
+		})
+	}
+
+	_, err := NewOperationService(nil, &postClientMock{}).NewBatch(source, publicKey).Forge()
+	assert.Assert(t, err != nil)
+}
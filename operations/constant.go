@@ -0,0 +1,40 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+)
+
+// GlobalConstantOptions are the optional settings of RegisterGlobalConstant.
+type GlobalConstantOptions struct {
+	// Confirmations is the number of blocks to wait for on top of the block including the registration,
+	// RegisterGlobalConstant returns as soon as the operation is injected when it is 0
+	Confirmations int
+}
+
+// RegisterGlobalConstant registers the Micheline value as a global constant, revealing the account of signer first
+// when it is not revealed. The hash of the operation and the address of the constant are returned, the address is
+// computed from the value so it is known before the operation is included.
+func (o *OperationService) RegisterGlobalConstant(ctx context.Context, signer Signer, value json.RawMessage, opts GlobalConstantOptions) (string, string, error) {
+	address, err := forge.GlobalConstantHash(value)
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not register global constant")
+	}
+
+	o = o.withContext(ctx)
+	batch := o.NewBatch(signer.Address(), signer.PublicKey()).Add(block.Contents{
+		Kind:  block.KindRegisterGlobalConstant,
+		Value: value,
+	})
+
+	hash, err := o.send(ctx, signer, batch, opts.Confirmations)
+	if err != nil {
+		return hash, "", errors.Wrapf(err, "could not register global constant '%s'", address)
+	}
+	return hash, address, nil
+}
@@ -0,0 +1,86 @@
+package operations
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+)
+
+func Test_RegisterGlobalConstant(t *testing.T) {
+	wallet, err := account.NewAccountService(nil, nil, nil).CreateWallet(
+		"normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout",
+		"PYh8nXDQLB",
+	)
+	assert.NilError(t, err)
+	source := wallet.Address
+	applied := `{"kind":"register_global_constant","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000","storage_size":"80","global_address":"exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF"}}}`
+
+	cases := []struct {
+		name        string
+		value       string
+		runBody     string
+		wantAddress string
+		wantErr     string
+	}{
+		{
+			name:        "Registration",
+			value:       `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]}]`,
+			runBody:     `{"contents":[` + applied + `]}`,
+			wantAddress: "exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF",
+		},
+		{
+			name:    "Invalid value",
+			value:   `{"prim":"Nothing"}`,
+			wantErr: "could not register global constant",
+		},
+		{
+			name:    "Constant already registered",
+			value:   `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]}]`,
+			runBody: `{"contents":[{"kind":"register_global_constant","metadata":{"operation_result":{"status":"failed","errors":[{"kind":"branch","id":"proto.019-PtParisB.Expression_already_registered"}]}}}]}`,
+			wantErr: "could not register global constant 'exprtwoXEnkRd9aYrZDJywuj6WmBrH1DEMAgSFiZgmYrziSvhfZevF'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				PostBodies: map[string][]byte{
+					"/chains/main/blocks/head/helpers/scripts/run_operation": []byte(tc.runBody),
+					"/injection/operation": []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+				},
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash":                                         []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+					"/chains/main/chain_id":                                                 []byte(`"NetXdQprcVkpaWU"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/counter":     []byte(`"10"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/manager_key": []byte(`"` + wallet.Pk + `"`),
+				},
+			}
+			o := NewOperationService(&blockServiceMock{}, client)
+
+			hash, address, err := o.RegisterGlobalConstant(context.Background(), NewWalletSigner(wallet), json.RawMessage(tc.value), GlobalConstantOptions{})
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+			assert.Equal(t, address, tc.wantAddress)
+
+			signed, err := hex.DecodeString(strings.Trim(client.Args, `"`))
+			assert.NilError(t, err)
+			_, contents, _, err := forge.UnforgeSigned(signed)
+			assert.NilError(t, err)
+			assert.Equal(t, len(contents), 1)
+			assert.Equal(t, contents[0].Kind, block.KindRegisterGlobalConstant)
+			assert.Equal(t, string(contents[0].Value), tc.value)
+		})
+	}
+}
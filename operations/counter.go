@@ -0,0 +1,68 @@
+package operations
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tzerrors"
+)
+
+// CounterProvider hands out the counters of the manager operations of sources. The counter of a source is
+// fetched from the node once, then incremented locally so that operations injected in quick succession,
+// before the previous ones are included, do not reuse counters. It is safe for concurrent use.
+type CounterProvider struct {
+	operations *OperationService
+
+	mu       sync.Mutex
+	counters map[string]int
+}
+
+// NewCounterProvider returns a new CounterProvider fetching counters with operations.
+func NewCounterProvider(operations *OperationService) *CounterProvider {
+	return &CounterProvider{
+		operations: operations,
+		counters:   make(map[string]int),
+	}
+}
+
+// Next reserves the next n counters of source and returns the first one.
+func (p *CounterProvider) Next(source string, n int) (int, error) {
+	return p.next(p.operations, source, n)
+}
+
+// next is Next fetching the counter with operations, e.g. a service sending its requests with a context
+func (p *CounterProvider) next(operations *OperationService, source string, n int) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counter, ok := p.counters[source]
+	if !ok {
+		var err error
+		if counter, err = operations.getAddressCounter(source); err != nil {
+			return 0, errors.Wrap(err, "could not get next counter")
+		}
+	}
+
+	p.counters[source] = counter + n
+	return counter + 1, nil
+}
+
+// Invalidate forgets the counter of source, the next call to Next fetches it from the node again.
+func (p *CounterProvider) Invalidate(source string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.counters, source)
+}
+
+// InvalidateOnError invalidates the counter of source when the operation of source failed with err because of
+// its counter, counter_in_the_past or counter_in_the_future, or its branch, e.g. branch_refused, and reports if
+// it did.
+func (p *CounterProvider) InvalidateOnError(source string, err error) bool {
+	if !errors.Is(err, tzc.ErrCounterInThePast) && !errors.Is(err, tzc.ErrCounterInTheFuture) && !tzerrors.IsBranchRefused(err) {
+		return false
+	}
+	p.Invalidate(source)
+	return true
+}
@@ -0,0 +1,105 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+func Test_CounterProvider(t *testing.T) {
+	source := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	path := "/chains/main/blocks/head/context/contracts/" + source + "/counter"
+	client := &postClientMock{GetBodies: map[string][]byte{path: []byte(`"10"`)}}
+	counters := NewOperationService(nil, client).Counters()
+
+	counter, err := counters.Next(source, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, counter, 11)
+
+	// The node still returns the counter before the operation is included
+	counter, err = counters.Next(source, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, counter, 13)
+
+	client.GetBodies[path] = []byte(`"12"`)
+	branchRefused := errors.Wrap(&tzc.ResponseError{
+		StatusCode: 500,
+		Errors:     []*tzc.RPCError{{Kind: "branch", ID: "proto.017-PtNairob.contract.counter_in_the_past"}},
+	}, "could not inject operation")
+	balanceTooLow := &tzc.ResponseError{
+		StatusCode: 500,
+		Errors:     []*tzc.RPCError{{Kind: "temporary", ID: "proto.017-PtNairob.contract.balance_too_low"}},
+	}
+
+	assert.Assert(t, !counters.InvalidateOnError(source, balanceTooLow))
+	counter, err = counters.Next(source, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, counter, 14)
+
+	assert.Assert(t, counters.InvalidateOnError(source, branchRefused))
+	counter, err = counters.Next(source, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, counter, 13)
+
+	client.GetBodies[path] = []byte(`"invalid"`)
+	counters.Invalidate(source)
+	_, err = counters.Next(source, 1)
+	assert.Assert(t, err != nil)
+}
+
+func Test_CounterProviderInvalidateOnError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  *tzc.RPCError
+		want bool
+	}{
+		{
+			name: "counter in the past",
+			err:  &tzc.RPCError{Kind: "branch", ID: "proto.019-PtParisB.contract.counter_in_the_past"},
+			want: true,
+		},
+		{
+			name: "counter in the future",
+			err:  &tzc.RPCError{Kind: "temporary", ID: "proto.019-PtParisB.contract.counter_in_the_future"},
+			want: true,
+		},
+		{
+			name: "branch refused",
+			err:  &tzc.RPCError{Kind: "branch", ID: "proto.019-PtParisB.operation.branch_refused"},
+			want: true,
+		},
+		{
+			name: "balance too low",
+			err:  &tzc.RPCError{Kind: "temporary", ID: "proto.019-PtParisB.contract.balance_too_low"},
+			want: false,
+		},
+	}
+
+	source := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	path := "/chains/main/blocks/head/context/contracts/" + source + "/counter"
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{GetBodies: map[string][]byte{path: []byte(`"10"`)}}
+			counters := NewOperationService(nil, client).Counters()
+
+			counter, err := counters.Next(source, 1)
+			assert.NilError(t, err)
+			assert.Equal(t, counter, 11)
+
+			client.GetBodies[path] = []byte(`"20"`)
+			err = errors.Wrap(&tzc.ResponseError{StatusCode: 500, Errors: []*tzc.RPCError{tc.err}}, "could not inject operation")
+			assert.Equal(t, counters.InvalidateOnError(source, err), tc.want)
+
+			want := 12
+			if tc.want {
+				want = 21
+			}
+			counter, err = counters.Next(source, 1)
+			assert.NilError(t, err)
+			assert.Equal(t, counter, want)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+)
+
+// DelegationOptions are the optional settings of SetDelegate and ClearDelegate.
+type DelegationOptions struct {
+	// Confirmations is the number of blocks to wait for on top of the block including the delegation,
+	// the helpers return as soon as the operation is injected when it is 0
+	Confirmations int
+}
+
+// SetDelegate delegates the account of signer to delegate, revealing the account first when it is not revealed.
+// The hash of the operation is returned.
+func (o *OperationService) SetDelegate(ctx context.Context, signer Signer, delegate string, opts DelegationOptions) (string, error) {
+	if delegate == "" {
+		return "", errors.New("could not set delegate, no delegate given")
+	}
+
+	hash, err := o.delegate(ctx, signer, delegate, opts)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not set delegate of '%s' to '%s'", signer.Address(), delegate)
+	}
+	return hash, nil
+}
+
+// ClearDelegate withdraws the delegation of the account of signer. The hash of the operation is returned.
+func (o *OperationService) ClearDelegate(ctx context.Context, signer Signer, opts DelegationOptions) (string, error) {
+	hash, err := o.delegate(ctx, signer, "", opts)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not clear delegate of '%s'", signer.Address())
+	}
+	return hash, nil
+}
+
+// delegate sends a delegation to delegate, a delegation without delegate withdraws it
+func (o *OperationService) delegate(ctx context.Context, signer Signer, delegate string, opts DelegationOptions) (string, error) {
+	o = o.withContext(ctx)
+	batch := o.NewBatch(signer.Address(), signer.PublicKey()).Add(block.Contents{
+		Kind:     block.KindDelegation,
+		Delegate: delegate,
+	})
+	return o.send(ctx, signer, batch, opts.Confirmations)
+}
@@ -0,0 +1,107 @@
+package operations
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+)
+
+func Test_SetDelegate(t *testing.T) {
+	wallet, err := account.NewAccountService(nil, nil, nil).CreateWallet(
+		"normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout",
+		"PYh8nXDQLB",
+	)
+	assert.NilError(t, err)
+	source := wallet.Address
+	applied := `{"kind":"delegation","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}`
+
+	cases := []struct {
+		name         string
+		delegate     string
+		managerKey   string
+		runBody      string
+		wantKinds    []string
+		wantDelegate string
+		wantErr      string
+	}{
+		{
+			name:         "Set delegate",
+			delegate:     "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			managerKey:   `"` + wallet.Pk + `"`,
+			runBody:      `{"contents":[` + applied + `]}`,
+			wantKinds:    []string{block.KindDelegation},
+			wantDelegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		},
+		{
+			name:         "Set delegate of an account to reveal",
+			delegate:     "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			managerKey:   `null`,
+			runBody:      `{"contents":[` + applied + `,` + applied + `]}`,
+			wantKinds:    []string{block.KindReveal, block.KindDelegation},
+			wantDelegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		},
+		{
+			name:       "Clear delegate",
+			managerKey: `"` + wallet.Pk + `"`,
+			runBody:    `{"contents":[` + applied + `]}`,
+			wantKinds:  []string{block.KindDelegation},
+		},
+		{
+			name:       "Delegation failed",
+			delegate:   "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			managerKey: `"` + wallet.Pk + `"`,
+			runBody:    `{"contents":[{"kind":"delegation","metadata":{"operation_result":{"status":"failed","errors":[{"kind":"temporary","id":"proto.018-Proxford.delegate.unregistered_delegate"}]}}}]}`,
+			wantErr:    "could not set delegate of '" + source + "' to 'tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				PostBodies: map[string][]byte{
+					"/chains/main/blocks/head/helpers/scripts/run_operation": []byte(tc.runBody),
+					"/injection/operation": []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+				},
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash":                                         []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+					"/chains/main/chain_id":                                                 []byte(`"NetXdQprcVkpaWU"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/counter":     []byte(`"10"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/manager_key": []byte(tc.managerKey),
+				},
+			}
+			o := NewOperationService(&blockServiceMock{}, client)
+
+			var hash string
+			if tc.delegate == "" {
+				hash, err = o.ClearDelegate(context.Background(), NewWalletSigner(wallet), DelegationOptions{})
+			} else {
+				hash, err = o.SetDelegate(context.Background(), NewWalletSigner(wallet), tc.delegate, DelegationOptions{})
+			}
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+
+			signed, err := hex.DecodeString(strings.Trim(client.Args, `"`))
+			assert.NilError(t, err)
+			_, contents, _, err := forge.UnforgeSigned(signed)
+			assert.NilError(t, err)
+
+			var kinds []string
+			for _, c := range contents {
+				kinds = append(kinds, c.Kind)
+			}
+			assert.DeepEqual(t, kinds, tc.wantKinds)
+			assert.Equal(t, contents[len(contents)-1].Delegate, tc.wantDelegate)
+		})
+	}
+}
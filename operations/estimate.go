@@ -0,0 +1,201 @@
+package operations
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Defaults of the Estimator, the fee parameters are the defaults of the mempool of octez nodes
+const (
+	DefaultMinimalFees                  = tez.Mutez(100)
+	DefaultMinimalNanotezPerByte        = 1000
+	DefaultMinimalNanotezPerGasUnit     = 100
+	DefaultGasMargin                    = 100
+	DefaultStorageMargin                = 20
+	DefaultHardGasLimitPerOperation     = 1040000
+	DefaultHardGasLimitPerBlock         = 2600000
+	DefaultHardStorageLimitPerOperation = 60000
+	DefaultOriginationSize              = 257
+)
+
+// Bytes of an operation that are not part of its contents, its branch and its signature, see operationOverhead
+const (
+	branchSize    = 32
+	signatureSize = 64
+)
+
+// Estimator fills in the gas limit, storage limit and fee of manager operations from a simulation of the
+// operation. The fields hold the margins and fee parameters, NewEstimator sets them to their defaults.
+type Estimator struct {
+	operations TezosOperationsService
+
+	// MinimalFees, MinimalNanotezPerByte and MinimalNanotezPerGasUnit are the parameters of the minimal fee,
+	// minimal_fees + minimal_nanotez_per_byte * size + minimal_nanotez_per_gas_unit * gas
+	MinimalFees              tez.Mutez
+	MinimalNanotezPerByte    int64
+	MinimalNanotezPerGasUnit int64
+
+	// GasMargin and StorageMargin are added to the gas and storage consumed by the simulation
+	GasMargin     int64
+	StorageMargin int64
+
+	// The limits the operation is simulated with, and the storage burnt for every allocated account or contract
+	HardGasLimitPerOperation     int64
+	HardGasLimitPerBlock         int64
+	HardStorageLimitPerOperation int64
+	OriginationSize              int64
+}
+
+// NewEstimator returns a new Estimator simulating operations with operations, with the default margins and fee parameters.
+func NewEstimator(operations TezosOperationsService) *Estimator {
+	return &Estimator{
+		operations:                   operations,
+		MinimalFees:                  DefaultMinimalFees,
+		MinimalNanotezPerByte:        DefaultMinimalNanotezPerByte,
+		MinimalNanotezPerGasUnit:     DefaultMinimalNanotezPerGasUnit,
+		GasMargin:                    DefaultGasMargin,
+		StorageMargin:                DefaultStorageMargin,
+		HardGasLimitPerOperation:     DefaultHardGasLimitPerOperation,
+		HardGasLimitPerBlock:         DefaultHardGasLimitPerBlock,
+		HardStorageLimitPerOperation: DefaultHardStorageLimitPerOperation,
+		OriginationSize:              DefaultOriginationSize,
+	}
+}
+
+// Estimate simulates the operation made of contents on top of branch, and returns contents with their gas limit,
+// storage limit and fee filled in. The counters of contents must be set. An error is returned when the simulation
+// of any content is not applied.
+func (e *Estimator) Estimate(branch string, contents []block.Contents) ([]block.Contents, error) {
+	if len(contents) == 0 {
+		return nil, errors.New("could not estimate operation, no contents")
+	}
+
+	gasLimit := e.HardGasLimitPerOperation
+	if perContent := e.HardGasLimitPerBlock / int64(len(contents)); perContent < gasLimit {
+		gasLimit = perContent
+	}
+
+	simulated := make([]block.Contents, len(contents))
+	for i, c := range contents {
+		if !block.IsManagerKind(c.Kind) {
+			return nil, errors.Errorf("could not estimate operation, content %d is not a manager operation", i)
+		}
+		c.Fee = 0
+		c.GasLimit = tez.NewZarith(gasLimit)
+		c.StorageLimit = tez.NewZarith(e.HardStorageLimitPerOperation)
+		simulated[i] = c
+	}
+
+	operation, err := e.operations.RunOperation(branch, simulated)
+	if isUnrevealedKey(err) {
+		return nil, errors.Wrapf(ErrUnrevealed, "could not estimate operation of '%s': %v", contents[0].Source, err)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not estimate operation")
+	}
+	if len(operation.Contents) != len(contents) {
+		return nil, errors.Errorf("could not estimate operation, simulation returned %d contents instead of %d", len(operation.Contents), len(contents))
+	}
+
+	estimated := make([]block.Contents, len(contents))
+	for i, c := range contents {
+		gas, storage, err := e.consumed(operation.Contents[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not estimate content %d", i)
+		}
+		c.GasLimit = tez.NewZarith(gas + e.GasMargin)
+		c.StorageLimit = tez.NewZarith(storage + e.StorageMargin)
+
+		overhead := 0
+		if i == 0 {
+			overhead = operationOverhead(c.Source)
+		}
+		if c.Fee, err = e.fee(c, overhead); err != nil {
+			return nil, errors.Wrapf(err, "could not estimate content %d", i)
+		}
+		estimated[i] = c
+	}
+
+	return estimated, nil
+}
+
+// consumed returns the gas consumed by a simulated content and the storage it paid for, including its internal operations
+func (e *Estimator) consumed(c block.Contents) (int64, int64, error) {
+	if c.Metadata == nil || c.Metadata.OperationResult == nil {
+		return 0, 0, errors.New("simulation returned no operation result")
+	}
+
+	results := []*block.OperationResult{c.Metadata.OperationResult}
+	for _, internal := range c.Metadata.InternalOperationResults {
+		if internal.Result != nil {
+			results = append(results, internal.Result)
+		}
+	}
+
+	var gas, storage int64
+	for _, result := range results {
+		if result.Status != "applied" {
+			return 0, 0, errors.Errorf("simulation %s%s", result.Status, errorIDs(result.Errors))
+		}
+
+		if result.ConsumedMilligas.Sign() > 0 {
+			gas += (result.ConsumedMilligas.Int64() + 999) / 1000
+		} else {
+			gas += result.ConsumedGas.Int64()
+		}
+
+		storage += result.PaidStorageSizeDiff.Int64()
+		storage += int64(len(result.OriginatedContracts)) * e.OriginationSize
+		if result.AllocatedDestinationContract {
+			storage += e.OriginationSize
+		}
+	}
+
+	return gas, storage, nil
+}
+
+// fee returns the minimal fee of c, whose size grows by overhead bytes. The size of c depends on its fee,
+// the fee is computed again until it covers the size of c with that fee.
+func (e *Estimator) fee(c block.Contents, overhead int) (tez.Mutez, error) {
+	c.Fee = 0
+	for {
+		forged, err := forge.Contents(c)
+		if err != nil {
+			return 0, err
+		}
+
+		nanotez := e.MinimalNanotezPerByte*int64(len(forged)+overhead) + e.MinimalNanotezPerGasUnit*c.GasLimit.Int64()
+		fee := e.MinimalFees + tez.Mutez((nanotez+999)/1000)
+		if fee <= c.Fee {
+			return c.Fee, nil
+		}
+		c.Fee = fee
+	}
+}
+
+// operationOverhead returns the bytes of an operation of source that are not part of its contents, the branch and
+// the signature, a BLS signature for tz4 sources
+func operationOverhead(source string) int {
+	if strings.HasPrefix(source, base58.Tz4.Name) {
+		return branchSize + keys.BLSSignatureSize
+	}
+	return branchSize + signatureSize
+}
+
+func errorIDs(errs []block.Error) string {
+	if len(errs) == 0 {
+		return ""
+	}
+	ids := make([]string, len(errs))
+	for i, err := range errs {
+		ids[i] = err.ID
+	}
+	return ": " + strings.Join(ids, ", ")
+}
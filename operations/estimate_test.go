@@ -0,0 +1,130 @@
+package operations
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_Estimate(t *testing.T) {
+	transfer := block.Contents{
+		Kind:        block.KindTransaction,
+		Source:      "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		Counter:     tez.NewZarith(2),
+		Amount:      1000000,
+		Destination: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+	}
+
+	cases := []struct {
+		name             string
+		returnBody       string
+		wantGasLimit     int64
+		wantStorageLimit int64
+		wantFee          tez.Mutez
+		wantErr          string
+	}{
+		{
+			name: "Transfer to an existing account",
+			returnBody: `{"contents":[{"kind":"transaction","metadata":{"balance_updates":[],
+				"operation_result":{"status":"applied","consumed_milligas":"999001"}}}]}`,
+			wantGasLimit:     1100,
+			wantStorageLimit: 20,
+			wantFee:          360,
+		},
+		{
+			name: "Transfer allocating the destination and calling a contract",
+			returnBody: `{"contents":[{"kind":"transaction","metadata":{"balance_updates":[],
+				"operation_result":{"status":"applied","consumed_milligas":"1000000","allocated_destination_contract":true},
+				"internal_operation_results":[{"kind":"transaction","source":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t","nonce":0,"result":{"status":"applied","consumed_milligas":"2000000","paid_storage_size_diff":"67"}}]}}]}`,
+			wantGasLimit:     3100,
+			wantStorageLimit: 257 + 67 + 20,
+			wantFee:          561,
+		},
+		{
+			name: "Failed simulation",
+			returnBody: `{"contents":[{"kind":"transaction","metadata":{"balance_updates":[],
+				"operation_result":{"status":"failed","errors":[{"kind":"temporary","id":"proto.017-PtNairob.contract.balance_too_low"}]}}}]}`,
+			wantErr: "balance_too_low",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{ReturnBody: []byte(tc.returnBody), GetBody: []byte(`"NetXdQprcVkpaWU"`)}
+			estimator := NewEstimator(NewOperationService(nil, client))
+
+			estimated, err := estimator.Estimate("BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY", []block.Contents{transfer})
+			assert.Assert(t, strings.Contains(client.Args, `"gas_limit":"1040000"`))
+			assert.Assert(t, strings.Contains(client.Args, `"storage_limit":"60000"`))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, estimated[0].GasLimit.Int64(), tc.wantGasLimit)
+			assert.Equal(t, estimated[0].StorageLimit.Int64(), tc.wantStorageLimit)
+			assert.Equal(t, estimated[0].Fee, tc.wantFee)
+			assert.Equal(t, estimated[0].Counter.Int64(), int64(2))
+		})
+	}
+}
+
+func Test_EstimateBLS(t *testing.T) {
+	// the BLS signatures of tz4 sources are 32 bytes longer than the others, which costs 32 mutez more
+	transfer := block.Contents{
+		Kind:        block.KindTransaction,
+		Source:      "tz4TpX5Qb3w7xnnnwSpjFs7Kq35GC4qr3uMg",
+		Counter:     tez.NewZarith(2),
+		Amount:      1000000,
+		Destination: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+	}
+	client := &postClientMock{
+		ReturnBody: []byte(`{"contents":[{"kind":"transaction","metadata":{"balance_updates":[],
+			"operation_result":{"status":"applied","consumed_milligas":"999001"}}}]}`),
+		GetBody: []byte(`"NetXdQprcVkpaWU"`),
+	}
+
+	estimated, err := NewEstimator(NewOperationService(nil, client)).Estimate("BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY", []block.Contents{transfer})
+	assert.NilError(t, err)
+	assert.Equal(t, estimated[0].GasLimit.Int64(), int64(1100))
+	assert.Equal(t, estimated[0].Fee, tez.Mutez(392))
+}
+
+func Test_EstimateBatch(t *testing.T) {
+	contents := []block.Contents{
+		{
+			Kind:      block.KindReveal,
+			Source:    "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			Counter:   tez.NewZarith(1),
+			PublicKey: "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav",
+		},
+		{
+			Kind:        block.KindTransaction,
+			Source:      "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			Counter:     tez.NewZarith(2),
+			Amount:      1000000,
+			Destination: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+		},
+	}
+	client := &postClientMock{
+		ReturnBody: []byte(`{"contents":[
+			{"kind":"reveal","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}},
+			{"kind":"transaction","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}]}`),
+		GetBody: []byte(`"NetXdQprcVkpaWU"`),
+	}
+
+	estimated, err := NewEstimator(NewOperationService(nil, client)).Estimate("BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY", contents)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(client.Args, `"gas_limit":"1040000"`))
+
+	// The branch and signature are paid for by the first content only
+	assert.Equal(t, estimated[0].Fee, tez.Mutez(100+1*(1+21+2+1+2+1+33+96)+110))
+	assert.Equal(t, estimated[1].Fee, tez.Mutez(100+1*(1+21+2+1+2+1+3+22+1)+110))
+
+	_, err = NewEstimator(NewOperationService(nil, client)).Estimate("BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY", nil)
+	assert.Assert(t, err != nil)
+}
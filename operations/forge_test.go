@@ -0,0 +1,80 @@
+package operations
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_ForgeRemote(t *testing.T) {
+	branch := "BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"
+	contents := []block.Contents{
+		{
+			Kind:         block.KindTransaction,
+			Source:       "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			Fee:          1420,
+			Counter:      tez.NewZarith(2),
+			GasLimit:     tez.NewZarith(10307),
+			StorageLimit: tez.NewZarith(0),
+			Amount:       1000000,
+			Destination:  "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+		},
+	}
+	forged := "eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df6c0002298c03ed7d454a101eb7022bc95f7e5f41ac788c0b02c35000c0843d000038896346da37c3ea531638153423a5632bd4b2c200"
+
+	cases := []struct {
+		name         string
+		returnBody   string
+		wantMismatch bool
+		wantErr      bool
+	}{
+		{
+			name:       "Node forged the contents",
+			returnBody: `"` + forged + `"`,
+		},
+		{
+			name:         "Node forged another amount",
+			returnBody:   `"` + strings.Replace(forged, "c0843d", "c1843d", 1) + `"`,
+			wantMismatch: true,
+			wantErr:      true,
+		},
+		{
+			name:         "Node forged another branch",
+			returnBody:   `"` + strings.Replace(forged, "eee3", "eee4", 1) + `"`,
+			wantMismatch: true,
+			wantErr:      true,
+		},
+		{
+			name:         "Node forged an extra content",
+			returnBody:   `"` + forged + forged[64:] + `"`,
+			wantMismatch: true,
+			wantErr:      true,
+		},
+		{
+			name:       "Node returned invalid hex",
+			returnBody: `"zz"`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{ReturnBody: []byte(tc.returnBody)}
+			opBytes, err := NewOperationService(nil, client).ForgeRemote(branch, contents)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/forge/operations")
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				assert.Equal(t, errors.Cause(err) == ErrForgeMismatch, tc.wantMismatch)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(opBytes), forged)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package operations
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_InjectionOperation(t *testing.T) {
+	cases := []struct {
+		name       string
+		opts       InjectionOptions
+		returnBody string
+		wantPath   string
+		wantParams map[string]string
+		wantHash   string
+		wantErr    bool
+	}{
+		{
+			name:       "Default",
+			returnBody: `"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`,
+			wantPath:   "/injection/operation",
+			wantHash:   "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH",
+		},
+		{
+			name:       "Async on another chain",
+			opts:       InjectionOptions{Async: true, Chain: "NetXdQprcVkpaWU"},
+			returnBody: `"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`,
+			wantPath:   "/injection/operation",
+			wantParams: map[string]string{"async": "true", "chain": "NetXdQprcVkpaWU"},
+			wantHash:   "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH",
+		},
+		{
+			name:       "Malformed response",
+			returnBody: `{}`,
+			wantPath:   "/injection/operation",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{ReturnBody: []byte(tc.returnBody)}
+			hash, err := NewOperationService(nil, client).InjectionOperation([]byte{0xca, 0xfe}, tc.opts)
+			assert.Equal(t, client.Path, tc.wantPath)
+			assert.DeepEqual(t, client.Params, tc.wantParams)
+			assert.Equal(t, client.Args, `"cafe"`)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, tc.wantHash)
+		})
+	}
+}
@@ -1,12 +1,36 @@
 package operations
 
 import (
+	"context"
+	"encoding/json"
+
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/delegate"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
 )
 
 type TezosOperationsService interface {
 	CreateBatchPayment(payments []delegate.Payment, wallet account.Wallet, paymentFee int, gaslimit int, batchSize int) ([]string, error)
+	ForgeRemote(branch string, contents []block.Contents) ([]byte, error)
+	PreapplyOperations(operations ...Transfer) ([]block.Operations, error)
+	RunOperation(branch string, contents []block.Contents) (block.Operations, error)
+	SimulateOperation(branch string, contents []block.Contents, opts SimulationOptions) (block.Operations, error)
+	NewBatch(source, publicKey string) *Batch
+	RevealIfNeeded(source, publicKey string, contents []block.Contents) ([]block.Contents, error)
+	Counters() *CounterProvider
+	Transfer(ctx context.Context, signer Signer, to string, amount tez.Mutez, opts TransferOptions) (string, error)
+	SetDelegate(ctx context.Context, signer Signer, delegate string, opts DelegationOptions) (string, error)
+	ClearDelegate(ctx context.Context, signer Signer, opts DelegationOptions) (string, error)
+	Stake(ctx context.Context, signer Signer, amount tez.Mutez, opts StakingOptions) (string, error)
+	Unstake(ctx context.Context, signer Signer, amount tez.Mutez, opts StakingOptions) (string, error)
+	FinalizeUnstake(ctx context.Context, signer Signer, opts StakingOptions) (string, error)
+	ActivateAccount(ctx context.Context, pkh, secret string, opts ActivationOptions) (string, error)
+	RevealSeedNonce(ctx context.Context, level int, nonce string, opts SeedNonceRevelationOptions) (string, error)
+	Originate(ctx context.Context, signer Signer, code json.RawMessage, storage interface{}, opts OriginationOptions) (string, string, error)
+	RegisterGlobalConstant(ctx context.Context, signer Signer, value json.RawMessage, opts GlobalConstantOptions) (string, string, error)
 	InjectOperation(op string) ([]byte, error)
-	GetBlockOperationHashes(id interface{}) ([]string, error)
+	InjectionOperation(signed []byte, opts InjectionOptions) (string, error)
+	GetBlockOperationHashes(id blockid.BlockID) ([]string, error)
 }
@@ -1,5 +1,11 @@
 package operations
 
+import (
+	"context"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+)
+
 // import (
 // 	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
 // )
@@ -43,3 +49,82 @@ package operations
 // func (b *blockServiceMock) IDToString(id interface{}) (string, error) {
 // 	return "", nil
 // }
+
+type postClientMock struct {
+	ReturnBody []byte
+	GetBody    []byte
+	GetBodies  map[string][]byte
+	PostBodies map[string][]byte
+	PostErrors map[string]error
+	Path       string
+	Params     map[string]string
+	Args       string
+}
+
+func (c *postClientMock) Post(path, args string) ([]byte, error) {
+	c.Path, c.Args = path, args
+	if err, ok := c.PostErrors[path]; ok {
+		return nil, err
+	}
+	if body, ok := c.PostBodies[path]; ok {
+		return body, nil
+	}
+	return c.ReturnBody, nil
+}
+
+func (c *postClientMock) PostWithParams(path string, params map[string]string, args string) ([]byte, error) {
+	c.Params = params
+	return c.Post(path, args)
+}
+
+func (c *postClientMock) Get(path string, params map[string]string) ([]byte, error) {
+	if body, ok := c.GetBodies[path]; ok {
+		return body, nil
+	}
+	return c.GetBody, nil
+}
+
+type blockServiceMock struct {
+	block.TezosBlockService
+	Hash          string
+	Confirmations int
+	Branch        string
+	Operation     block.Operations
+}
+
+func (b *blockServiceMock) WaitConfirmedWithOptions(ctx context.Context, opHash string, opts block.WaitOptions) (block.Block, block.Operations, error) {
+	b.Hash, b.Confirmations, b.Branch = opHash, opts.Confirmations, opts.Branch
+	return block.Block{}, b.Operation, nil
+}
+
+// contextClientMock is a postClientMock counting the requests sent without a context
+type contextClientMock struct {
+	postClientMock
+	Contexts []context.Context
+	Unbound  int
+}
+
+func (c *contextClientMock) Post(path, args string) ([]byte, error) {
+	c.Unbound++
+	return c.postClientMock.Post(path, args)
+}
+
+func (c *contextClientMock) PostWithParams(path string, params map[string]string, args string) ([]byte, error) {
+	c.Unbound++
+	return c.postClientMock.PostWithParams(path, params, args)
+}
+
+func (c *contextClientMock) Get(path string, params map[string]string) ([]byte, error) {
+	c.Unbound++
+	return c.postClientMock.Get(path, params)
+}
+
+func (c *contextClientMock) PostContext(ctx context.Context, path string, params map[string]string, args string) ([]byte, error) {
+	c.Contexts = append(c.Contexts, ctx)
+	return c.postClientMock.PostWithParams(path, params, args)
+}
+
+func (c *contextClientMock) GetContext(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+	c.Contexts = append(c.Contexts, ctx)
+	return c.postClientMock.Get(path, params)
+}
@@ -0,0 +1,50 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+)
+
+// SeedNonceRevelationOptions are the optional settings of RevealSeedNonce.
+type SeedNonceRevelationOptions struct {
+	// Confirmations is the number of blocks to wait for on top of the block including the revelation,
+	// RevealSeedNonce returns as soon as the operation is injected when it is 0
+	Confirmations int
+}
+
+// RevealSeedNonce reveals nonce, in hexadecimal, the seed nonce committed to by the block at level. Nonces are revealed
+// during the cycle following their commitment, the rewards of the baker are lost otherwise. Revelations are anonymous,
+// the operation is injected without signature. The hash of the operation is returned.
+func (o *OperationService) RevealSeedNonce(ctx context.Context, level int, nonce string, opts SeedNonceRevelationOptions) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", errors.Wrapf(err, "could not reveal seed nonce of level %d", level)
+	}
+
+	o = o.withContext(ctx)
+
+	branch, err := o.getHeadHash()
+	if err != nil {
+		return "", errors.Wrapf(err, "could not reveal seed nonce of level %d", level)
+	}
+
+	forged, err := forge.Operation(branch, block.Contents{Kind: block.KindSeedNonceRevelation, Level: level, Nonce: nonce})
+	if err != nil {
+		return "", errors.Wrapf(err, "could not reveal seed nonce of level %d", level)
+	}
+
+	hash, err := o.InjectionOperation(forged, InjectionOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "could not reveal seed nonce of level %d", level)
+	}
+
+	if opts.Confirmations > 0 {
+		if _, _, err := o.blockService.WaitConfirmedWithOptions(ctx, hash, block.WaitOptions{Confirmations: opts.Confirmations, Branch: branch}); err != nil {
+			return hash, errors.Wrapf(err, "could not reveal seed nonce of level %d", level)
+		}
+	}
+	return hash, nil
+}
@@ -0,0 +1,58 @@
+package operations
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_RevealSeedNonce(t *testing.T) {
+	cases := []struct {
+		name          string
+		nonce         string
+		confirmations int
+		wantArgs      string
+		wantErr       bool
+	}{
+		{
+			name:     "Revelation",
+			nonce:    "2e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1",
+			wantArgs: `"eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df01004c4b402e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1"`,
+		},
+		{
+			name:          "Confirmed revelation",
+			nonce:         "2e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1",
+			confirmations: 2,
+			wantArgs:      `"eee3dd7dad7d525aa6b124304efc0d8e171373478b03b6f5d4f16036a12fb3df01004c4b402e5e5f3b1b1e7a4f7e6fd4e0c8a1dca0f4d7b1fb3c9a1c1bb73e1bd0e0a7c0d1"`,
+		},
+		{
+			name:    "Invalid nonce",
+			nonce:   "2e5e5f3b",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				ReturnBody: []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash": []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+				},
+			}
+			blocks := &blockServiceMock{}
+
+			hash, err := NewOperationService(blocks, client).RevealSeedNonce(context.Background(), 5000000, tc.nonce, SeedNonceRevelationOptions{Confirmations: tc.confirmations})
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "could not reveal seed nonce of level 5000000")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+			assert.Equal(t, client.Path, "/injection/operation")
+			assert.Equal(t, client.Args, tc.wantArgs)
+			assert.Equal(t, blocks.Confirmations, tc.confirmations)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package operations
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"strconv"
@@ -12,20 +13,30 @@ import (
 
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/delegate"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
 )
 
 var (
 	// maxBatchSize tells how many Transactions per batch are allowed.
 	maxBatchSize = 200
+
+	// ErrForgeMismatch is returned when the bytes forged by a node do not encode the requested contents.
+	ErrForgeMismatch = errors.New("forged bytes do not match the contents")
 )
 
+// DummySignature is a well formed signature for operations that are simulated, whose signature is not checked.
+const DummySignature = "sigUHx32f9wesZ1n2BWpixXz4AQaZggEtchaQNHYGRCoWNAXx45WGW2ua3apUUUAGMLPwAU41QoaFCzVSL61VaessLg4YbbP"
+
 // OperationService is a struct wrapper for operation related functions
 type OperationService struct {
 	blockService block.TezosBlockService
 	tzclient     tzc.TezosClient
+	counters     *CounterProvider
 }
 
 // Conts is helper structure to build out the contents of a a transfer operation to post to the Tezos RPC
@@ -37,16 +48,23 @@ type Conts struct {
 // Transfer a complete transfer request
 type Transfer struct {
 	Conts
-	Protocol  string `json:"protocol"`
+	Protocol  string `json:"protocol,omitempty"`
 	Signature string `json:"signature"`
 }
 
 // NewOperationService returns a New Operation Service
 func NewOperationService(blockService block.TezosBlockService, tzclient tzc.TezosClient) *OperationService {
-	return &OperationService{
+	o := &OperationService{
 		blockService: blockService,
 		tzclient:     tzclient,
 	}
+	o.counters = NewCounterProvider(o)
+	return o
+}
+
+// Counters returns the CounterProvider handing out the counters of the operations forged by the service
+func (o *OperationService) Counters() *CounterProvider {
+	return o.counters
 }
 
 // CreateBatchPayment forges batch payments and returns them ready to inject to a Tezos RPC. PaymentFee must be expressed in mutez and the max batch size allowed is 200.
@@ -71,6 +89,13 @@ func (o *OperationService) CreateBatchPayment(payments []delegate.Payment, walle
 	}
 	counter++
 
+	// The first batch reveals the payment address when it is not revealed yet
+	reveal, err := o.isRevealed(wallet.Address)
+	if err != nil {
+		return operationSignatures, errors.Wrap(err, "could not create batch payment")
+	}
+	reveal = !reveal
+
 	// Split our slice of []Payment into batches
 	batches := o.splitPaymentIntoBatches(payments, batchSize)
 	operationSignatures = make([]string, len(batches))
@@ -78,7 +103,7 @@ func (o *OperationService) CreateBatchPayment(payments []delegate.Payment, walle
 	for k := range batches {
 
 		// Convert (ie: forge) each 'Payment' into an actual Tezos transfer operation
-		operationBytes, operationContents, newCounter, err := o.forgeOperationBytes(blockHead.Hash, counter, wallet, batches[k], paymentFee, gasLimit)
+		operationBytes, operationContents, newCounter, err := o.forgeOperationBytes(blockHead.Hash, counter, wallet, batches[k], paymentFee, gasLimit, reveal && k == 0)
 		if err != nil {
 			return operationSignatures, errors.Wrap(err, "could not create batch payment")
 		}
@@ -122,7 +147,12 @@ func (o *OperationService) signOperationBytes(operationBytes string, wallet acco
 	if err != nil {
 		return "", errors.Wrap(err, "could not sign operation bytes")
 	}
-	opBytes = append(crypto.Prefix_watermark, opBytes...)
+	return signWithWallet(opBytes, wallet)
+}
+
+// signWithWallet signs forged operation bytes with the ed25519 secret key of wallet
+func signWithWallet(opBytes []byte, wallet account.Wallet) (string, error) {
+	opBytes = append(append([]byte{}, crypto.Prefix_watermark...), opBytes...)
 
 	// Generic hash of 32 bytes
 	genericHash, err := blake2b.New(32, []byte{})
@@ -150,16 +180,24 @@ func (o *OperationService) signOperationBytes(operationBytes string, wallet acco
 	return edsig, nil
 }
 
-func (o *OperationService) forgeOperationBytes(branchHash string, counter int, wallet account.Wallet, batch []delegate.Payment, paymentFee int, gaslimit int) (string, Conts, int, error) {
+func (o *OperationService) forgeOperationBytes(branchHash string, counter int, wallet account.Wallet, batch []delegate.Payment, paymentFee int, gaslimit int, reveal bool) (string, Conts, int, error) {
 
 	var contents Conts
 	var combinedOps []block.Contents
 
-	//left here to display how to reveal a new wallet (needs funds to be revealed!)
-	/**
-	  combinedOps = append(combinedOps, StructContents{Kind: "reveal", PublicKey: wallet.pk , Source: wallet.address, Fee: "0", GasLimit: "127", StorageLimit: "0", Counter: strCounter})
-	  counter++
-	**/
+	// Reveal the wallet first, it needs funds to be revealed
+	if reveal {
+		combinedOps = append(combinedOps, block.Contents{
+			Kind:         block.KindReveal,
+			Source:       wallet.Address,
+			Fee:          tez.Mutez(paymentFee),
+			Counter:      tez.NewZarith(int64(counter)),
+			GasLimit:     tez.NewZarith(revealGasLimit),
+			StorageLimit: tez.NewZarith(0),
+			PublicKey:    wallet.Pk,
+		})
+		counter++
+	}
 
 	for k := range batch {
 
@@ -168,12 +206,12 @@ func (o *OperationService) forgeOperationBytes(branchHash string, counter int, w
 			operation := block.Contents{
 				Kind:         "transaction",
 				Source:       wallet.Address,
-				Fee:          strconv.Itoa(paymentFee),
-				GasLimit:     strconv.Itoa(gaslimit),
-				StorageLimit: "0",
-				Amount:       strconv.FormatFloat(crypto.RoundPlus(batch[k].Amount, 0), 'f', -1, 64),
+				Fee:          tez.Mutez(paymentFee),
+				GasLimit:     tez.NewZarith(int64(gaslimit)),
+				StorageLimit: tez.NewZarith(0),
+				Amount:       tez.Mutez(crypto.RoundPlus(batch[k].Amount, 0)),
 				Destination:  batch[k].Address,
-				Counter:      strconv.Itoa(counter),
+				Counter:      tez.NewZarith(int64(counter)),
 			}
 			combinedOps = append(combinedOps, operation)
 			counter++
@@ -182,20 +220,69 @@ func (o *OperationService) forgeOperationBytes(branchHash string, counter int, w
 	contents.Contents = combinedOps
 	contents.Branch = branchHash
 
-	var opBytes string
+	opBytes, err := o.ForgeRemote(branchHash, combinedOps)
+	if err != nil {
+		return "", contents, counter, errors.Wrap(err, "could not forge operation")
+	}
+
+	return hex.EncodeToString(opBytes), contents, counter, nil
+}
+
+// ForgeRemote forges contents on top of branch with the forge RPC of the node, then unforges the returned
+// bytes locally and compares them with contents. ErrForgeMismatch is returned when the node forged anything else,
+// the bytes must not be signed then.
+func (o *OperationService) ForgeRemote(branch string, contents []block.Contents) ([]byte, error) {
+	conts := Conts{Contents: contents, Branch: branch}
+	query := "/chains/main/blocks/head/helpers/forge/operations"
+	resp, err := o.tzclient.Post(query, conts.string())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not forge operation '%s' with contents '%s'", query, conts.string())
+	}
 
-	forge := "/chains/main/blocks/head/helpers/forge/operations"
-	output, err := o.tzclient.Post(forge, contents.string())
+	forgedHex, err := unmarshalString(resp)
 	if err != nil {
-		return "", contents, counter, errors.Wrapf(err, "could not forge operation '%s' with contents '%s'", forge, contents.string())
+		return nil, errors.Wrapf(err, "could not forge operation '%s' with contents '%s'", query, conts.string())
 	}
+	forged, err := hex.DecodeString(forgedHex)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not forge operation '%s' with contents '%s'", query, conts.string())
+	}
+
+	if err := verifyForged(forged, branch, contents); err != nil {
+		return nil, errors.Wrapf(err, "could not verify forged operation '%s'", forgedHex)
+	}
+
+	return forged, nil
+}
 
-	err = json.Unmarshal(output, &opBytes)
+// verifyForged checks that forged holds branch and contents, comparing their canonical encodings
+func verifyForged(forged []byte, branch string, contents []block.Contents) error {
+	unforgedBranch, unforged, err := forge.Unforge(forged)
 	if err != nil {
-		return "", contents, counter, errors.Wrapf(err, "could not forge operation '%s' with contents '%s'", forge, contents.string())
+		return errors.Wrapf(ErrForgeMismatch, "%s", err)
+	}
+	if unforgedBranch != branch {
+		return errors.Wrapf(ErrForgeMismatch, "branch '%s' instead of '%s'", unforgedBranch, branch)
+	}
+	if len(unforged) != len(contents) {
+		return errors.Wrapf(ErrForgeMismatch, "%d contents instead of %d", len(unforged), len(contents))
+	}
+
+	for i := range contents {
+		want, err := forge.Contents(contents[i])
+		if err != nil {
+			return err
+		}
+		got, err := forge.Contents(unforged[i])
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(got, want) {
+			return errors.Wrapf(ErrForgeMismatch, "content %d differs", i)
+		}
 	}
 
-	return opBytes, contents, counter, nil
+	return nil
 }
 
 // Pre-apply an operation, or batch of operations, to a Tezos node to ensure correctness
@@ -208,23 +295,118 @@ func (o *OperationService) preApplyOperations(paymentOperations Conts, signature
 	transfer.Branch = blockHead.Hash
 	transfer.Protocol = blockHead.Protocol
 
-	// RPC says outer element must be JSON array
-	var transfers = []Transfer{transfer}
+	if _, err := o.PreapplyOperations(transfer); err != nil {
+		return errors.Wrap(err, "could not preapply operations")
+	}
+
+	return nil
+}
+
+// PreapplyOperations validates signed operations against the context of the head without injecting them,
+// and returns them with the metadata of their contents, e.g. their operation results and balance updates.
+func (o *OperationService) PreapplyOperations(operations ...Transfer) ([]block.Operations, error) {
+	var preapplied []block.Operations
 
-	// Convert object to JSON string
-	transfersOp, err := json.Marshal(transfers)
+	// RPC says outer element must be JSON array
+	transfersOp, err := json.Marshal(operations)
 	if err != nil {
-		return errors.Wrap(err, "could not preapply operations, could not marshal into json")
+		return preapplied, errors.Wrap(err, "could not preapply operations, could not marshal into json")
 	}
 
 	// POST the JSON to the RPC
 	query := "/chains/main/blocks/head/helpers/preapply/operations"
-	_, err = o.tzclient.Post(query, string(transfersOp))
+	resp, err := o.tzclient.Post(query, string(transfersOp))
 	if err != nil {
-		return errors.Wrapf(err, "could not preapply operations '%s' with contents '%s'", query, string(transfersOp))
+		return preapplied, errors.Wrapf(err, "could not preapply operations '%s' with contents '%s'", query, string(transfersOp))
 	}
 
-	return nil
+	if err := json.Unmarshal(resp, &preapplied); err != nil {
+		return preapplied, errors.Wrapf(err, "could not preapply operations '%s' with contents '%s'", query, string(transfersOp))
+	}
+
+	return preapplied, nil
+}
+
+// runOperation is the body of /helpers/scripts/run_operation and /helpers/scripts/simulate_operation
+type runOperation struct {
+	BlocksBeforeActivation int      `json:"blocks_before_activation,omitempty"`
+	Operation              Transfer `json:"operation"`
+	ChainID                string   `json:"chain_id"`
+	Latency                int      `json:"latency,omitempty"`
+}
+
+// SimulationOptions are the options of SimulateOperation.
+type SimulationOptions struct {
+	// BlocksBeforeActivation simulates the operation that many blocks ahead, e.g. before a protocol activation
+	BlocksBeforeActivation int
+	// Latency is the number of blocks expected before the operation is included, it makes the simulation
+	// account for gas cost changes in the meantime
+	Latency int
+	// SuccessorLevel simulates the operation in the level following the head instead of the head
+	SuccessorLevel bool
+}
+
+// RunOperation simulates the operation made of contents on top of branch, signed with DummySignature.
+// Nothing is paid, the contents are returned with their metadata holding the consumed gas, the storage
+// diffs and the errors of the operation.
+func (o *OperationService) RunOperation(branch string, contents []block.Contents) (block.Operations, error) {
+	return o.simulate("/chains/main/blocks/head/helpers/scripts/run_operation", branch, contents, SimulationOptions{})
+}
+
+// SimulateOperation simulates the operation made of contents on top of branch like RunOperation with
+// /helpers/scripts/simulate_operation, which supports simulating it a few blocks ahead.
+func (o *OperationService) SimulateOperation(branch string, contents []block.Contents, opts SimulationOptions) (block.Operations, error) {
+	return o.simulate("/chains/main/blocks/head/helpers/scripts/simulate_operation", branch, contents, opts)
+}
+
+func (o *OperationService) simulate(query, branch string, contents []block.Contents, opts SimulationOptions) (block.Operations, error) {
+	var operation block.Operations
+	chainID, err := o.getChainID()
+	if err != nil {
+		return operation, errors.Wrap(err, "could not simulate operation")
+	}
+
+	run := runOperation{
+		BlocksBeforeActivation: opts.BlocksBeforeActivation,
+		Operation: Transfer{
+			Conts:     Conts{Contents: contents, Branch: branch},
+			Signature: DummySignature,
+		},
+		ChainID: chainID,
+		Latency: opts.Latency,
+	}
+	runOp, err := json.Marshal(run)
+	if err != nil {
+		return operation, errors.Wrap(err, "could not simulate operation, could not marshal into json")
+	}
+
+	params := make(map[string]string)
+	if opts.SuccessorLevel {
+		params["successor_level"] = "true"
+	}
+	resp, err := tzc.PostParams(o.tzclient, query, params, string(runOp))
+	if err != nil {
+		return operation, errors.Wrapf(err, "could not simulate operation '%s' with contents '%s'", query, string(runOp))
+	}
+
+	if err := json.Unmarshal(resp, &operation); err != nil {
+		return operation, errors.Wrapf(err, "could not simulate operation '%s' with contents '%s'", query, string(runOp))
+	}
+
+	return operation, nil
+}
+
+func (o *OperationService) getChainID() (string, error) {
+	query := "/chains/main/chain_id"
+	resp, err := o.tzclient.Get(query, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get chain id '%s'", query)
+	}
+	chainID, err := unmarshalString(resp)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get chain id '%s'", query)
+	}
+	return chainID, nil
 }
 
 // InjectOperation injects an signed operation string and returns the response
@@ -241,6 +423,45 @@ func (o *OperationService) InjectOperation(op string) ([]byte, error) {
 	return resp, nil
 }
 
+// InjectionOptions are the query parameters of /injection/operation.
+type InjectionOptions struct {
+	// Async makes the node answer before the operation is validated and propagated
+	Async bool
+	// Chain is the alias or ID of the chain the operation must be injected in, e.g. main
+	Chain string
+}
+
+func (o InjectionOptions) params() map[string]string {
+	params := make(map[string]string)
+	if o.Async {
+		params["async"] = "true"
+	}
+	if o.Chain != "" {
+		params["chain"] = o.Chain
+	}
+	return params
+}
+
+// InjectionOperation injects the bytes of a signed operation and returns the hash of the operation
+func (o *OperationService) InjectionOperation(signed []byte, opts InjectionOptions) (string, error) {
+	post := "/injection/operation"
+	jsonBytes, err := json.Marshal(hex.EncodeToString(signed))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not inject operation '%s'", post)
+	}
+	resp, err := tzc.PostParams(o.tzclient, post, opts.params(), string(jsonBytes))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not inject operation '%s' with contents '%s'", post, string(jsonBytes))
+	}
+
+	hash, err := unmarshalString(resp)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not inject operation '%s' with contents '%s'", post, string(jsonBytes))
+	}
+
+	return hash, nil
+}
+
 //Getting the Counter of an address from the RPC
 func (o *OperationService) getAddressCounter(address string) (int, error) {
 	rpc := "/chains/main/blocks/head/context/contracts/" + address + "/counter"
@@ -271,8 +492,8 @@ func (o *OperationService) splitPaymentIntoBatches(rewards []delegate.Payment, b
 	return batches
 }
 
-// GetBlockOperationHashes returns list of operations in block at specific level
-func (o *OperationService) GetBlockOperationHashes(id interface{}) ([]string, error) {
+// GetBlockOperationHashes returns list of operations in the block with id
+func (o *OperationService) GetBlockOperationHashes(id blockid.BlockID) ([]string, error) {
 
 	var operations []string
 	block, err := o.blockService.Get(id)
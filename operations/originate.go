@@ -0,0 +1,96 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// OriginationOptions are the optional settings of Originate.
+type OriginationOptions struct {
+	// Balance is transferred from the account of the signer to the new contract
+	Balance tez.Mutez
+	// Delegate is the delegate of the new contract
+	Delegate string
+	// Confirmations is the number of blocks to wait for on top of the block including the origination
+	Confirmations int
+}
+
+// Originate originates a contract with the Micheline code and initial storage, revealing the account of signer
+// first when it is not revealed. The storage is either Micheline JSON, as a json.RawMessage or []byte, or a
+// value marshaling to Micheline JSON. Originate waits for the operation to be included and returns its hash
+// and the address of the new contract, read from the result of the operation.
+func (o *OperationService) Originate(ctx context.Context, signer Signer, code json.RawMessage, storage interface{}, opts OriginationOptions) (string, string, error) {
+	initial, err := michelineJSON(storage)
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not originate contract")
+	}
+
+	o = o.withContext(ctx)
+	batch := o.NewBatch(signer.Address(), signer.PublicKey()).Add(block.Contents{
+		Kind:     block.KindOrigination,
+		Balance:  opts.Balance,
+		Delegate: opts.Delegate,
+		Script:   &block.Script{Code: code, Storage: initial},
+	})
+
+	hash, branch, err := o.inject(ctx, signer, batch)
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not originate contract")
+	}
+
+	_, operation, err := o.blockService.WaitConfirmedWithOptions(ctx, hash, block.WaitOptions{Confirmations: opts.Confirmations, Branch: branch})
+	if err != nil {
+		return hash, "", errors.Wrapf(err, "could not originate contract with operation '%s'", hash)
+	}
+
+	address, err := originatedContract(operation)
+	if err != nil {
+		return hash, "", errors.Wrapf(err, "could not originate contract with operation '%s'", hash)
+	}
+	return hash, address, nil
+}
+
+// michelineJSON returns the Micheline JSON of value, as is for json.RawMessage and []byte
+func michelineJSON(value interface{}) (json.RawMessage, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		raw = v
+	case []byte:
+		raw = v
+	default:
+		var err error
+		if raw, err = json.Marshal(value); err != nil {
+			return nil, errors.Wrap(err, "could not marshal storage")
+		}
+	}
+
+	if !json.Valid(raw) {
+		return nil, errors.New("storage is not valid Micheline JSON")
+	}
+	return raw, nil
+}
+
+// originatedContract returns the address of the contract originated by the origination of operation
+func originatedContract(operation block.Operations) (string, error) {
+	for _, c := range operation.Contents {
+		if c.Kind != block.KindOrigination || c.Metadata == nil || c.Metadata.OperationResult == nil {
+			continue
+		}
+
+		result := c.Metadata.OperationResult
+		if result.Status != "applied" {
+			return "", errors.Errorf("origination %s", result.Status)
+		}
+		if len(result.OriginatedContracts) == 0 {
+			return "", errors.New("no originated contract in the result of the origination")
+		}
+		return result.OriginatedContracts[0], nil
+	}
+	return "", errors.New("no origination result in the operation")
+}
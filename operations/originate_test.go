@@ -0,0 +1,106 @@
+package operations
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_Originate(t *testing.T) {
+	wallet, err := account.NewAccountService(nil, nil, nil).CreateWallet(
+		"normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout",
+		"PYh8nXDQLB",
+	)
+	assert.NilError(t, err)
+	source := wallet.Address
+	code := json.RawMessage(`[{"prim":"parameter","args":[{"prim":"int"}]},{"prim":"storage","args":[{"prim":"int"}]},{"prim":"code","args":[[{"prim":"CAR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`)
+	simulated := `{"contents":[{"kind":"origination","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1500000","originated_contracts":["KT1Simulated"],"paid_storage_size_diff":"40"}}}]}`
+
+	cases := []struct {
+		name        string
+		storage     interface{}
+		included    string
+		wantStorage string
+		wantAddress string
+		wantErr     string
+	}{
+		{
+			name:        "Micheline storage",
+			storage:     json.RawMessage(`{"int":"42"}`),
+			included:    `{"hash":"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH","contents":[{"kind":"origination","metadata":{"operation_result":{"status":"applied","originated_contracts":["KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi"]}}}]}`,
+			wantStorage: `{"int":"42"}`,
+			wantAddress: "KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi",
+		},
+		{
+			name: "Typed storage",
+			storage: struct {
+				Int string `json:"int"`
+			}{"7"},
+			included:    `{"hash":"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH","contents":[{"kind":"origination","metadata":{"operation_result":{"status":"applied","originated_contracts":["KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi"]}}}]}`,
+			wantStorage: `{"int":"7"}`,
+			wantAddress: "KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi",
+		},
+		{
+			name:    "Invalid storage",
+			storage: []byte(`{"int":`),
+			wantErr: "storage is not valid Micheline JSON",
+		},
+		{
+			name:     "Origination backtracked",
+			storage:  json.RawMessage(`{"int":"42"}`),
+			included: `{"hash":"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH","contents":[{"kind":"origination","metadata":{"operation_result":{"status":"backtracked"}}}]}`,
+			wantErr:  "origination backtracked",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				PostBodies: map[string][]byte{
+					"/chains/main/blocks/head/helpers/scripts/run_operation": []byte(simulated),
+					"/injection/operation": []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+				},
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash":                                         []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+					"/chains/main/chain_id":                                                 []byte(`"NetXdQprcVkpaWU"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/counter":     []byte(`"10"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/manager_key": []byte(`"` + wallet.Pk + `"`),
+				},
+			}
+			blocks := &blockServiceMock{}
+			if tc.included != "" {
+				assert.NilError(t, json.Unmarshal([]byte(tc.included), &blocks.Operation))
+			}
+
+			hash, address, err := NewOperationService(blocks, client).Originate(context.Background(), NewWalletSigner(wallet), code, tc.storage, OriginationOptions{Balance: tez.FromTez(1)})
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+			assert.Equal(t, address, tc.wantAddress)
+			assert.Equal(t, blocks.Hash, hash)
+
+			signed, err := hex.DecodeString(strings.Trim(client.Args, `"`))
+			assert.NilError(t, err)
+			_, contents, _, err := forge.UnforgeSigned(signed)
+			assert.NilError(t, err)
+			assert.Equal(t, len(contents), 1)
+			assert.Equal(t, contents[0].Kind, block.KindOrigination)
+			assert.Equal(t, contents[0].Balance, tez.FromTez(1))
+			assert.Equal(t, string(contents[0].Script.Storage), tc.wantStorage)
+			// 40 bytes paid and the size of an originated contract
+			assert.Equal(t, contents[0].StorageLimit.Int64(), int64(40+DefaultOriginationSize+DefaultStorageMargin))
+		})
+	}
+}
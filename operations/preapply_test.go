@@ -0,0 +1,73 @@
+package operations
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_PreapplyOperations(t *testing.T) {
+	transfer := Transfer{
+		Conts: Conts{
+			Branch: "BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY",
+			Contents: []block.Contents{
+				{
+					Kind:         block.KindTransaction,
+					Source:       "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+					Fee:          1420,
+					Counter:      tez.NewZarith(2),
+					GasLimit:     tez.NewZarith(10307),
+					StorageLimit: tez.NewZarith(0),
+					Amount:       1000000,
+					Destination:  "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+				},
+			},
+		},
+		Protocol:  "PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf",
+		Signature: "sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe",
+	}
+
+	cases := []struct {
+		name       string
+		returnBody string
+		wantStatus string
+		wantErr    bool
+	}{
+		{
+			name: "Applied",
+			returnBody: `[{"contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1420","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"1000000","destination":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+				"metadata":{"balance_updates":[{"kind":"contract","contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"-1420","origin":"block"}],
+				"operation_result":{"status":"applied","balance_updates":[{"kind":"contract","contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"-1000000","origin":"block"},{"kind":"contract","contract":"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1","change":"1000000","origin":"block"}],"consumed_milligas":"1000000"}}}],
+				"signature":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe"}]`,
+			wantStatus: "applied",
+		},
+		{
+			name:       "Malformed response",
+			returnBody: `{"contents":[]}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{ReturnBody: []byte(tc.returnBody)}
+			preapplied, err := NewOperationService(nil, client).PreapplyOperations(transfer)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/preapply/operations")
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, len(preapplied), 1)
+
+			metadata := preapplied[0].Contents[0].Metadata
+			assert.Equal(t, metadata.OperationResult.Status, tc.wantStatus)
+			assert.Equal(t, metadata.OperationResult.ConsumedMilligas.Int64(), int64(1000000))
+			assert.Equal(t, metadata.OperationResult.BalanceUpdates[1].Change, tez.Mutez(1000000))
+			assert.Equal(t, metadata.BalanceUpdates[0].Change, tez.Mutez(-1420))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package operations
+
+import (
+	"encoding/json"
+	stderrors "errors"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// revealGasLimit is the gas limit of reveals added to operations that are not estimated
+const revealGasLimit = 1000
+
+// ErrUnrevealed is returned when the source of manager operations is not revealed and its public key is not known
+// to reveal it. Give the public key of the source, e.g. to NewBatch, so a reveal is prepended to the operations.
+var ErrUnrevealed = errors.New("source is not revealed, its public key is needed to reveal it")
+
+// RevealIfNeeded returns contents with a reveal of publicKey prepended when source is not revealed yet, the reveal
+// must be the first manager operation of a source. The counters, limits and fees of the contents are left as they
+// are. ErrUnrevealed is returned when source is not revealed and publicKey is empty.
+func (o *OperationService) RevealIfNeeded(source, publicKey string, contents []block.Contents) ([]block.Contents, error) {
+	for _, c := range contents {
+		if c.Kind == block.KindReveal {
+			return contents, nil
+		}
+	}
+
+	revealed, err := o.isRevealed(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not reveal '%s'", source)
+	}
+	if revealed {
+		return contents, nil
+	}
+	if publicKey == "" {
+		return nil, errors.Wrapf(ErrUnrevealed, "could not reveal '%s'", source)
+	}
+
+	reveal := block.Contents{Kind: block.KindReveal, Source: source, PublicKey: publicKey}
+	return append([]block.Contents{reveal}, contents...), nil
+}
+
+// isRevealed reports if the public key of address is revealed
+func (o *OperationService) isRevealed(address string) (bool, error) {
+	rpc := "/chains/main/blocks/head/context/contracts/" + address + "/manager_key"
+	resp, err := o.tzclient.Get(rpc, nil)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get manager key '%s'", rpc)
+	}
+
+	var key *string
+	if err := json.Unmarshal(resp, &key); err != nil {
+		return false, errors.Wrapf(err, "could not get manager key '%s'", rpc)
+	}
+	return key != nil, nil
+}
+
+// isUnrevealedKey reports if err is the error of the node for operations of an unrevealed source
+func isUnrevealedKey(err error) bool {
+	return stderrors.Is(err, tzc.ErrUnrevealedKey)
+}
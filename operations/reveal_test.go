@@ -0,0 +1,92 @@
+package operations
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_RevealIfNeeded(t *testing.T) {
+	source := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	publicKey := "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"
+	transfer := block.Contents{Kind: block.KindTransaction, Source: source, Amount: tez.FromTez(1), Destination: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"}
+	reveal := block.Contents{Kind: block.KindReveal, Source: source, PublicKey: publicKey}
+
+	cases := []struct {
+		name       string
+		managerKey string
+		publicKey  string
+		contents   []block.Contents
+		wantKinds  []string
+		wantErr    error
+	}{
+		{
+			name:       "Revealed source",
+			managerKey: `"` + publicKey + `"`,
+			contents:   []block.Contents{transfer},
+			wantKinds:  []string{block.KindTransaction},
+		},
+		{
+			name:       "Unrevealed source",
+			managerKey: `null`,
+			publicKey:  publicKey,
+			contents:   []block.Contents{transfer},
+			wantKinds:  []string{block.KindReveal, block.KindTransaction},
+		},
+		{
+			name:       "Contents revealing the source",
+			managerKey: `null`,
+			publicKey:  publicKey,
+			contents:   []block.Contents{reveal, transfer},
+			wantKinds:  []string{block.KindReveal, block.KindTransaction},
+		},
+		{
+			name:       "Unrevealed source without public key",
+			managerKey: `null`,
+			contents:   []block.Contents{transfer},
+			wantErr:    ErrUnrevealed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{GetBody: []byte(tc.managerKey)}
+			contents, err := NewOperationService(nil, client).RevealIfNeeded(source, tc.publicKey, tc.contents)
+			if tc.wantErr != nil {
+				assert.Assert(t, stderrors.Is(err, tc.wantErr))
+				return
+			}
+			assert.NilError(t, err)
+
+			var kinds []string
+			for _, c := range contents {
+				kinds = append(kinds, c.Kind)
+			}
+			assert.DeepEqual(t, kinds, tc.wantKinds)
+			assert.Equal(t, contents[0].PublicKey, tc.publicKey)
+		})
+	}
+}
+
+func Test_EstimateUnrevealed(t *testing.T) {
+	client := &postClientMock{
+		GetBody: []byte(`"NetXdQprcVkpaWU"`),
+		PostErrors: map[string]error{
+			"/chains/main/blocks/head/helpers/scripts/run_operation": &tzc.ResponseError{
+				StatusCode: 500,
+				Errors:     []*tzc.RPCError{{Kind: "branch", ID: "proto.018-Proxford.contract.unrevealed_key"}},
+			},
+		},
+	}
+
+	_, err := NewEstimator(NewOperationService(nil, client)).Estimate("BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY", []block.Contents{
+		{Kind: block.KindTransaction, Source: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Counter: tez.NewZarith(2), Amount: 1, Destination: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"},
+	})
+	assert.Assert(t, stderrors.Is(err, ErrUnrevealed))
+	assert.ErrorContains(t, err, "could not estimate operation of 'tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx'")
+}
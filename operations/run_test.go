@@ -0,0 +1,123 @@
+package operations
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_RunOperation(t *testing.T) {
+	contents := []block.Contents{
+		{
+			Kind:         block.KindTransaction,
+			Source:       "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			Fee:          0,
+			Counter:      tez.NewZarith(2),
+			GasLimit:     tez.NewZarith(1040000),
+			StorageLimit: tez.NewZarith(60000),
+			Amount:       0,
+			Destination:  "KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+		},
+	}
+
+	cases := []struct {
+		name       string
+		getBody    string
+		returnBody string
+		wantStatus string
+		wantErr    bool
+	}{
+		{
+			name:    "Applied",
+			getBody: `"NetXdQprcVkpaWU"`,
+			returnBody: `{"contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"0","counter":"2","gas_limit":"1040000","storage_limit":"60000","amount":"0","destination":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+				"metadata":{"balance_updates":[],"operation_result":{"status":"applied","storage":{"int":"1"},"consumed_milligas":"2345678","storage_size":"62","paid_storage_size_diff":"1"}}}]}`,
+			wantStatus: "applied",
+		},
+		{
+			name:    "Failed",
+			getBody: `"NetXdQprcVkpaWU"`,
+			returnBody: `{"contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"0","counter":"2","gas_limit":"1040000","storage_limit":"60000","amount":"0","destination":"KT1VLb6tJLgmcWTSx7ud4U2n3cNHRBQgxa1t",
+				"metadata":{"balance_updates":[],"operation_result":{"status":"failed","errors":[{"kind":"temporary","id":"proto.017-PtNairob.michelson_v1.script_rejected"}]}}}]}`,
+			wantStatus: "failed",
+		},
+		{
+			name:    "Missing chain id",
+			getBody: `{}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{ReturnBody: []byte(tc.returnBody), GetBody: []byte(tc.getBody)}
+			operation, err := NewOperationService(nil, client).RunOperation("BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY", contents)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, "/chains/main/blocks/head/helpers/scripts/run_operation")
+			assert.Assert(t, strings.Contains(client.Args, `"signature":"`+DummySignature+`"},"chain_id":"NetXdQprcVkpaWU"}`))
+			assert.Assert(t, !strings.Contains(client.Args, `"protocol"`))
+			assert.Equal(t, operation.Contents[0].Metadata.OperationResult.Status, tc.wantStatus)
+		})
+	}
+}
+
+func Test_SimulateOperation(t *testing.T) {
+	contents := []block.Contents{
+		{
+			Kind:         block.KindDelegation,
+			Source:       "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			Counter:      tez.NewZarith(3),
+			GasLimit:     tez.NewZarith(1040000),
+			StorageLimit: tez.NewZarith(60000),
+		},
+	}
+	returnBody := `{"contents":[{"kind":"delegation","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"0","counter":"3","gas_limit":"1040000","storage_limit":"60000",
+		"metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}]}`
+
+	cases := []struct {
+		name       string
+		opts       SimulationOptions
+		wantPath   string
+		wantParams map[string]string
+		wantArgs   []string
+		skipArgs   []string
+	}{
+		{
+			name:     "Head",
+			wantPath: "/chains/main/blocks/head/helpers/scripts/simulate_operation",
+			skipArgs: []string{`"latency"`, `"blocks_before_activation"`},
+		},
+		{
+			name:       "Ahead of the head",
+			opts:       SimulationOptions{BlocksBeforeActivation: 2, Latency: 3, SuccessorLevel: true},
+			wantPath:   "/chains/main/blocks/head/helpers/scripts/simulate_operation",
+			wantParams: map[string]string{"successor_level": "true"},
+			wantArgs:   []string{`{"blocks_before_activation":2,"operation":`, `"chain_id":"NetXdQprcVkpaWU","latency":3}`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{ReturnBody: []byte(returnBody), GetBody: []byte(`"NetXdQprcVkpaWU"`)}
+			operation, err := NewOperationService(nil, client).SimulateOperation("BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY", contents, tc.opts)
+			assert.NilError(t, err)
+			assert.Equal(t, client.Path, tc.wantPath)
+			assert.DeepEqual(t, client.Params, tc.wantParams)
+			for _, arg := range tc.wantArgs {
+				assert.Assert(t, strings.Contains(client.Args, arg), arg)
+			}
+			for _, arg := range tc.skipArgs {
+				assert.Assert(t, !strings.Contains(client.Args, arg), arg)
+			}
+			assert.Equal(t, operation.Contents[0].Metadata.OperationResult.ConsumedMilligas.Int64(), int64(1000000))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package operations
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/signer"
+)
+
+// Signer signs the operations of an account for the helpers of the OperationService, see signer.Signer.
+type Signer = signer.Signer
+
+// walletSigner is a Signer using the ed25519 secret key of a wallet
+type walletSigner struct {
+	wallet account.Wallet
+}
+
+// NewWalletSigner returns a Signer signing with the secret key of wallet.
+func NewWalletSigner(wallet account.Wallet) Signer {
+	return &walletSigner{wallet: wallet}
+}
+
+func (w *walletSigner) Address() string {
+	return w.wallet.Address
+}
+
+func (w *walletSigner) PublicKey() string {
+	return w.wallet.Pk
+}
+
+func (w *walletSigner) Sign(watermark signer.Watermark, message []byte) (string, error) {
+	return signer.NewKeySigner(keys.Ed25519PrivateKey(w.wallet.Kp.PrivKey)).Sign(watermark, message)
+}
+
+// sign signs forged with s and returns the bytes of the signed operation
+func sign(s Signer, forged []byte) ([]byte, error) {
+	signature, err := signer.SignOperation(s, forged, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not sign operation as '%s'", s.Address())
+	}
+
+	sig, err := keys.ParseSignature(signature)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not sign operation as '%s'", s.Address())
+	}
+	return append(append([]byte{}, forged...), sig...), nil
+}
@@ -0,0 +1,150 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Entrypoints of the staking pseudo-operations, transfers of an account to itself
+const (
+	EntrypointStake           = "stake"
+	EntrypointUnstake         = "unstake"
+	EntrypointFinalizeUnstake = "finalize_unstake"
+)
+
+// Categories of the balance updates of staked and unstaked tez
+const (
+	CategoryDeposits         = "deposits"
+	CategoryUnstakedDeposits = "unstaked_deposits"
+)
+
+// StakingOptions are the optional settings of Stake, Unstake and FinalizeUnstake.
+type StakingOptions struct {
+	// Confirmations is the number of blocks to wait for on top of the block including the operation,
+	// the helpers return as soon as the operation is injected when it is 0
+	Confirmations int
+}
+
+// StakeUpdate is a balance update of the staked or unstaked deposits of a staker.
+type StakeUpdate struct {
+	// Category is CategoryDeposits or CategoryUnstakedDeposits
+	Category string
+	// Staker is the account staking, empty for the stake shared by the delegators of Delegate
+	Staker   string
+	Delegate string
+	// Cycle is the cycle tez were unstaked at, for unstaked deposits
+	Cycle  int
+	Change tez.Mutez
+}
+
+// StakeContents returns the transaction of address to itself staking amount with its delegate.
+func StakeContents(address string, amount tez.Mutez) block.Contents {
+	return stakingContents(address, EntrypointStake, amount)
+}
+
+// UnstakeContents returns the transaction of address to itself requesting to unstake amount.
+func UnstakeContents(address string, amount tez.Mutez) block.Contents {
+	return stakingContents(address, EntrypointUnstake, amount)
+}
+
+// FinalizeUnstakeContents returns the transaction of address to itself making its finalizable unstaked tez spendable.
+func FinalizeUnstakeContents(address string) block.Contents {
+	return stakingContents(address, EntrypointFinalizeUnstake, 0)
+}
+
+func stakingContents(address, entrypoint string, amount tez.Mutez) block.Contents {
+	return block.Contents{
+		Kind:        block.KindTransaction,
+		Amount:      amount,
+		Destination: address,
+		Parameters: &block.Parameters{
+			Entrypoint: entrypoint,
+			Value:      json.RawMessage(`{"prim":"Unit"}`),
+		},
+	}
+}
+
+// Stake stakes amount of the account of signer with its delegate. The hash of the operation is returned.
+func (o *OperationService) Stake(ctx context.Context, signer Signer, amount tez.Mutez, opts StakingOptions) (string, error) {
+	hash, err := o.stake(ctx, signer, StakeContents(signer.Address(), amount), opts)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not stake %s tez of '%s'", amount.TezString(), signer.Address())
+	}
+	return hash, nil
+}
+
+// Unstake requests to unstake amount of the account of signer, the tez are frozen until they are finalizable.
+// The hash of the operation is returned.
+func (o *OperationService) Unstake(ctx context.Context, signer Signer, amount tez.Mutez, opts StakingOptions) (string, error) {
+	hash, err := o.stake(ctx, signer, UnstakeContents(signer.Address(), amount), opts)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not unstake %s tez of '%s'", amount.TezString(), signer.Address())
+	}
+	return hash, nil
+}
+
+// FinalizeUnstake makes the finalizable unstaked tez of the account of signer spendable. The hash of the operation is returned.
+func (o *OperationService) FinalizeUnstake(ctx context.Context, signer Signer, opts StakingOptions) (string, error) {
+	hash, err := o.stake(ctx, signer, FinalizeUnstakeContents(signer.Address()), opts)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not finalize unstake of '%s'", signer.Address())
+	}
+	return hash, nil
+}
+
+// stake sends the staking pseudo-operation contents
+func (o *OperationService) stake(ctx context.Context, signer Signer, contents block.Contents, opts StakingOptions) (string, error) {
+	o = o.withContext(ctx)
+	batch := o.NewBatch(signer.Address(), signer.PublicKey()).Add(contents)
+	return o.send(ctx, signer, batch, opts.Confirmations)
+}
+
+// StakeUpdates returns the updates of staked and unstaked deposits among updates, the balance updates of a staking
+// pseudo-operation or of a block.
+func StakeUpdates(updates []block.BalanceUpdates) ([]StakeUpdate, error) {
+	var stakeUpdates []StakeUpdate
+	for _, update := range updates {
+		if update.Category != CategoryDeposits && update.Category != CategoryUnstakedDeposits {
+			continue
+		}
+
+		stakeUpdate := StakeUpdate{
+			Category: update.Category,
+			Delegate: update.Delegate,
+			Cycle:    update.Cycle,
+			Change:   update.Change,
+		}
+		if len(update.Staker) > 0 {
+			var staker struct {
+				Contract      string `json:"contract"`
+				Delegate      string `json:"delegate"`
+				BakerOwnStake string `json:"baker_own_stake"`
+				BakerEdge     string `json:"baker_edge"`
+				Shared        string `json:"shared"`
+			}
+			if err := json.Unmarshal(update.Staker, &staker); err != nil {
+				return nil, errors.Wrapf(err, "could not parse staker '%s'", string(update.Staker))
+			}
+
+			for _, s := range []string{staker.Contract, staker.BakerOwnStake, staker.BakerEdge} {
+				if s != "" {
+					stakeUpdate.Staker = s
+					break
+				}
+			}
+			for _, d := range []string{staker.Delegate, staker.BakerOwnStake, staker.BakerEdge, staker.Shared} {
+				if d != "" {
+					stakeUpdate.Delegate = d
+					break
+				}
+			}
+		}
+		stakeUpdates = append(stakeUpdates, stakeUpdate)
+	}
+	return stakeUpdates, nil
+}
@@ -0,0 +1,161 @@
+package operations
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_Stake(t *testing.T) {
+	wallet, err := account.NewAccountService(nil, nil, nil).CreateWallet(
+		"normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout",
+		"PYh8nXDQLB",
+	)
+	assert.NilError(t, err)
+	source := wallet.Address
+	applied := `{"kind":"transaction","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}`
+
+	cases := []struct {
+		name           string
+		entrypoint     string
+		amount         tez.Mutez
+		runBody        string
+		wantEntrypoint string
+		wantErr        string
+	}{
+		{
+			name:           "Stake",
+			entrypoint:     EntrypointStake,
+			amount:         1000000,
+			runBody:        `{"contents":[` + applied + `]}`,
+			wantEntrypoint: "stake",
+		},
+		{
+			name:           "Unstake",
+			entrypoint:     EntrypointUnstake,
+			amount:         500000,
+			runBody:        `{"contents":[` + applied + `]}`,
+			wantEntrypoint: "unstake",
+		},
+		{
+			name:           "Finalize unstake",
+			entrypoint:     EntrypointFinalizeUnstake,
+			runBody:        `{"contents":[` + applied + `]}`,
+			wantEntrypoint: "finalize_unstake",
+		},
+		{
+			name:       "Stake failed",
+			entrypoint: EntrypointStake,
+			amount:     1000000,
+			runBody:    `{"contents":[{"kind":"transaction","metadata":{"operation_result":{"status":"failed","errors":[{"kind":"temporary","id":"proto.019-PtParisB.staking_to_delegate_that_refuses_external_staking"}]}}}]}`,
+			wantErr:    "could not stake 1 tez of '" + source + "'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				PostBodies: map[string][]byte{
+					"/chains/main/blocks/head/helpers/scripts/run_operation": []byte(tc.runBody),
+					"/injection/operation": []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+				},
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash":                                         []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+					"/chains/main/chain_id":                                                 []byte(`"NetXdQprcVkpaWU"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/counter":     []byte(`"10"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/manager_key": []byte(`"` + wallet.Pk + `"`),
+				},
+			}
+			o := NewOperationService(&blockServiceMock{}, client)
+
+			var hash string
+			switch tc.entrypoint {
+			case EntrypointStake:
+				hash, err = o.Stake(context.Background(), NewWalletSigner(wallet), tc.amount, StakingOptions{})
+			case EntrypointUnstake:
+				hash, err = o.Unstake(context.Background(), NewWalletSigner(wallet), tc.amount, StakingOptions{})
+			default:
+				hash, err = o.FinalizeUnstake(context.Background(), NewWalletSigner(wallet), StakingOptions{})
+			}
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+
+			signed, err := hex.DecodeString(strings.Trim(client.Args, `"`))
+			assert.NilError(t, err)
+			_, contents, _, err := forge.UnforgeSigned(signed)
+			assert.NilError(t, err)
+
+			assert.Equal(t, len(contents), 1)
+			assert.Equal(t, contents[0].Kind, block.KindTransaction)
+			assert.Equal(t, contents[0].Destination, source)
+			assert.Equal(t, contents[0].Amount, tc.amount)
+			assert.Equal(t, contents[0].Parameters.Entrypoint, tc.wantEntrypoint)
+		})
+	}
+}
+
+func Test_StakeUpdates(t *testing.T) {
+	cases := []struct {
+		name    string
+		updates string
+		want    []StakeUpdate
+		wantErr string
+	}{
+		{
+			name: "Stake of a delegator",
+			updates: `[
+				{"kind":"contract","contract":"tz1TGu6TN5GSez2ndXXeDX6LgUDvLzPLqgYV","change":"-1000000","origin":"block"},
+				{"kind":"freezer","category":"deposits","staker":{"baker_own_stake":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"change":"20000","origin":"block"},
+				{"kind":"freezer","category":"deposits","staker":{"delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"change":"1000000","origin":"block"}
+			]`,
+			want: []StakeUpdate{
+				{Category: CategoryDeposits, Staker: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Change: 20000},
+				{Category: CategoryDeposits, Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Change: 1000000},
+			},
+		},
+		{
+			name: "Unstake",
+			updates: `[
+				{"kind":"freezer","category":"deposits","staker":{"contract":"tz1TGu6TN5GSez2ndXXeDX6LgUDvLzPLqgYV","delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"change":"-500000","origin":"block"},
+				{"kind":"freezer","category":"unstaked_deposits","staker":{"contract":"tz1TGu6TN5GSez2ndXXeDX6LgUDvLzPLqgYV","delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"cycle":750,"change":"500000","origin":"block"}
+			]`,
+			want: []StakeUpdate{
+				{Category: CategoryDeposits, Staker: "tz1TGu6TN5GSez2ndXXeDX6LgUDvLzPLqgYV", Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Change: -500000},
+				{Category: CategoryUnstakedDeposits, Staker: "tz1TGu6TN5GSez2ndXXeDX6LgUDvLzPLqgYV", Delegate: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Cycle: 750, Change: 500000},
+			},
+		},
+		{
+			name:    "Malformed staker",
+			updates: `[{"kind":"freezer","category":"deposits","staker":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"1"}]`,
+			wantErr: "could not parse staker",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var updates []block.BalanceUpdates
+			assert.NilError(t, json.Unmarshal([]byte(tc.updates), &updates))
+
+			stakeUpdates, err := StakeUpdates(updates)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, stakeUpdates, tc.want)
+		})
+	}
+}
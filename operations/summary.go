@@ -0,0 +1,132 @@
+package operations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/micheline"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// Summary is a human readable summary of an operation, to show what is signed to a user approving it rather than
+// opaque bytes.
+type Summary struct {
+	Branch   string
+	Contents []ContentsSummary
+	// Amount is the total of the tez sent by the contents
+	Amount tez.Mutez
+	// Fee is the total of the fees of the contents
+	Fee tez.Mutez
+}
+
+// ContentsSummary is the summary of one of the contents of an operation.
+type ContentsSummary struct {
+	Kind   string
+	Source string
+	// Destination is the account receiving the tez or tickets of the contents, the delegate of a delegation or of a
+	// drained delegate, or the new consensus key of a delegate
+	Destination string
+	// Amount is the amount of a transaction or the balance of an origination
+	Amount tez.Mutez
+	Fee    tez.Mutez
+	// Entrypoint is the entrypoint called by a transaction to a contract or by a ticket transfer, empty for the default
+	// one
+	Entrypoint string
+	// Parameters are the parameters of the call to the entrypoint printed in Michelson
+	Parameters   string
+	GasLimit     tez.Zarith
+	StorageLimit tez.Zarith
+}
+
+// Summarize summarizes the operation of contents on top of branch, before or after forging it.
+func Summarize(branch string, contents []block.Contents) Summary {
+	summary := Summary{Branch: branch, Contents: make([]ContentsSummary, 0, len(contents))}
+	for _, c := range contents {
+		s := ContentsSummary{
+			Kind:         c.Kind,
+			Source:       c.Source,
+			Fee:          c.Fee,
+			GasLimit:     c.GasLimit,
+			StorageLimit: c.StorageLimit,
+		}
+
+		switch c.Kind {
+		case block.KindTransaction:
+			s.Destination, s.Amount = c.Destination, c.Amount
+			if c.Parameters != nil {
+				s.Entrypoint, s.Parameters = c.Parameters.Entrypoint, printParameters(c.Parameters.Value)
+			}
+		case block.KindOrigination:
+			s.Amount = c.Balance
+		case block.KindDelegation:
+			s.Destination = c.Delegate
+		case block.KindIncreasePaidStorage:
+			s.Destination = c.Destination
+		case block.KindUpdateConsensusKey:
+			s.Destination = c.Pk
+		case block.KindTransferTicket:
+			s.Destination, s.Entrypoint = c.Destination, c.Entrypoint
+		case block.KindDrainDelegate:
+			s.Source, s.Destination = c.Delegate, c.Destination
+		}
+		if s.Entrypoint == "default" && (s.Parameters == "" || s.Parameters == "Unit") {
+			s.Entrypoint, s.Parameters = "", ""
+		}
+
+		summary.Amount += s.Amount
+		summary.Fee += s.Fee
+		summary.Contents = append(summary.Contents, s)
+	}
+	return summary
+}
+
+// SummarizeForged summarizes a forged operation, e.g. the bytes a remote signer is asked to sign, see forge.Unforge
+// for the supported contents.
+func SummarizeForged(forged []byte) (Summary, error) {
+	branch, contents, err := forge.Unforge(forged)
+	if err != nil {
+		return Summary{}, errors.Wrap(err, "could not summarize operation")
+	}
+	return Summarize(branch, contents), nil
+}
+
+// String returns the summary as text, one line per contents followed by the totals, e.g.
+//
+//	transaction from tz1... to KT1... calling transfer with Pair 1 2: 0 tez, fee 0.001 tez
+func (s Summary) String() string {
+	var b strings.Builder
+	for _, c := range s.Contents {
+		b.WriteString(c.Kind)
+		if c.Source != "" {
+			b.WriteString(" from " + c.Source)
+		}
+		if c.Destination != "" {
+			b.WriteString(" to " + c.Destination)
+		}
+		if c.Entrypoint != "" {
+			b.WriteString(" calling " + c.Entrypoint)
+		}
+		if c.Parameters != "" {
+			b.WriteString(" with " + c.Parameters)
+		}
+		fmt.Fprintf(&b, ": %s tez, fee %s tez\n", c.Amount.TezString(), c.Fee.TezString())
+	}
+	fmt.Fprintf(&b, "total: %s tez, fee %s tez", s.Amount.TezString(), s.Fee.TezString())
+	return b.String()
+}
+
+// printParameters prints the Micheline JSON value of parameters in Michelson, or returns it as is when it is invalid
+func printParameters(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+	node, err := micheline.Unmarshal(value)
+	if err != nil {
+		return string(value)
+	}
+	return micheline.Print(node)
+}
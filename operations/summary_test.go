@@ -0,0 +1,46 @@
+package operations
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_SummarizeForged(t *testing.T) {
+	branch := "BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT"
+	source := "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"
+	manager := func(kind string, counter int64) block.Contents {
+		return block.Contents{Kind: kind, Source: source, Fee: 1000, Counter: tez.NewZarith(counter), GasLimit: tez.NewZarith(1500), StorageLimit: tez.NewZarith(300)}
+	}
+
+	transfer := manager(block.KindTransaction, 1)
+	transfer.Amount, transfer.Destination = tez.FromTez(1.5), "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ"
+	call := manager(block.KindTransaction, 2)
+	call.Destination = "KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi"
+	call.Parameters = &block.Parameters{Entrypoint: "transfer", Value: json.RawMessage(`{"prim":"Pair","args":[{"int":"1"},{"string":"a"}]}`)}
+	delegation := manager(block.KindDelegation, 3)
+	delegation.Delegate = "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ"
+
+	forged, err := forge.Operation(branch, transfer, call, delegation)
+	assert.NilError(t, err)
+
+	summary, err := SummarizeForged(forged)
+	assert.NilError(t, err)
+	assert.Equal(t, summary.Branch, branch)
+	assert.Equal(t, summary.Amount, tez.FromTez(1.5))
+	assert.Equal(t, summary.Fee, tez.Mutez(3000))
+	assert.Equal(t, len(summary.Contents), 3)
+	assert.Equal(t, summary.Contents[1].Entrypoint, "transfer")
+	assert.Equal(t, summary.String(), `transaction from tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1 to tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ: 1.5 tez, fee 0.001 tez
+transaction from tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1 to KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi calling transfer with Pair 1 "a": 0 tez, fee 0.001 tez
+delegation from tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1 to tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ: 0 tez, fee 0.001 tez
+total: 1.5 tez, fee 0.003 tez`)
+
+	_, err = SummarizeForged(forged[:40])
+	assert.ErrorContains(t, err, "could not summarize operation")
+}
@@ -0,0 +1,89 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+// TransferOptions are the optional settings of Transfer.
+type TransferOptions struct {
+	// Parameters of the call when the destination is a contract
+	Parameters *block.Parameters
+	// Confirmations is the number of blocks to wait for on top of the block including the transfer,
+	// Transfer returns as soon as the operation is injected when it is 0
+	Confirmations int
+}
+
+// Transfer sends amount from the account of signer to the address to, like octez-client transfer.
+// The counter, limits and fee are set by a Batch, revealing the account first when it is not revealed,
+// and the operation is signed and injected. The hash of the operation is returned.
+func (o *OperationService) Transfer(ctx context.Context, signer Signer, to string, amount tez.Mutez, opts TransferOptions) (string, error) {
+	o = o.withContext(ctx)
+	batch := o.NewBatch(signer.Address(), signer.PublicKey()).Add(block.Contents{
+		Kind:        block.KindTransaction,
+		Amount:      amount,
+		Destination: to,
+		Parameters:  opts.Parameters,
+	})
+
+	hash, err := o.send(ctx, signer, batch, opts.Confirmations)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not transfer %s tez to '%s'", amount.TezString(), to)
+	}
+	return hash, nil
+}
+
+// send injects batch signed with signer, then waits for confirmations blocks when it is not 0
+func (o *OperationService) send(ctx context.Context, signer Signer, batch *Batch, confirmations int) (string, error) {
+	hash, branch, err := o.inject(ctx, signer, batch)
+	if err != nil || confirmations == 0 {
+		return hash, err
+	}
+
+	if _, _, err := o.blockService.WaitConfirmedWithOptions(ctx, hash, block.WaitOptions{Confirmations: confirmations, Branch: branch}); err != nil {
+		return hash, err
+	}
+	return hash, nil
+}
+
+// inject forges batch, signs it with signer and injects it. The hash of the operation and the branch it was
+// forged on are returned.
+func (o *OperationService) inject(ctx context.Context, signer Signer, batch *Batch) (string, string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", "", err
+	}
+
+	forged, err := batch.Forge()
+	if err != nil {
+		return "", "", err
+	}
+
+	signed, err := sign(signer, forged.Bytes)
+	if err != nil {
+		o.counters.Invalidate(signer.Address())
+		return "", "", err
+	}
+
+	hash, err := o.InjectionOperation(signed, InjectionOptions{})
+	if err != nil {
+		// The counters of a rejected operation are not used
+		o.counters.Invalidate(signer.Address())
+		return "", "", err
+	}
+	return hash, forged.Branch, nil
+}
+
+// withContext returns a copy of o sending its requests with ctx, see tzc.WithContext. The copy shares the
+// counters of o.
+func (o *OperationService) withContext(ctx context.Context) *OperationService {
+	return &OperationService{
+		blockService: o.blockService,
+		tzclient:     tzc.WithContext(ctx, o.tzclient),
+		counters:     o.counters,
+	}
+}
@@ -0,0 +1,129 @@
+package operations
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ed25519"
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/account"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/tez"
+)
+
+func Test_Transfer(t *testing.T) {
+	wallet, err := account.NewAccountService(nil, nil, nil).CreateWallet(
+		"normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout",
+		"PYh8nXDQLB",
+	)
+	assert.NilError(t, err)
+	source := wallet.Address
+	applied := `{"kind":"transaction","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}`
+
+	cases := []struct {
+		name          string
+		confirmations int
+		injectErr     error
+		wantErr       bool
+	}{
+		{
+			name: "Injected",
+		},
+		{
+			name:          "Confirmed",
+			confirmations: 2,
+		},
+		{
+			name:      "Injection refused",
+			injectErr: errors.New("counter in the future"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &postClientMock{
+				PostBodies: map[string][]byte{
+					"/chains/main/blocks/head/helpers/scripts/run_operation": []byte(`{"contents":[` + applied + `]}`),
+					"/injection/operation": []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+				},
+				GetBodies: map[string][]byte{
+					"/chains/main/blocks/head/hash":                                         []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+					"/chains/main/chain_id":                                                 []byte(`"NetXdQprcVkpaWU"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/counter":     []byte(`"10"`),
+					"/chains/main/blocks/head/context/contracts/" + source + "/manager_key": []byte(`"` + wallet.Pk + `"`),
+				},
+			}
+			if tc.injectErr != nil {
+				client.PostErrors = map[string]error{"/injection/operation": tc.injectErr}
+			}
+			blocks := &blockServiceMock{}
+			o := NewOperationService(blocks, client)
+
+			hash, err := o.Transfer(context.Background(), NewWalletSigner(wallet), "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", tez.FromTez(1), TransferOptions{Confirmations: tc.confirmations})
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "could not transfer 1 tez to 'tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1'")
+				// The counter is fetched again for the next operation
+				counter, err := o.Counters().Next(source, 1)
+				assert.NilError(t, err)
+				assert.Equal(t, counter, 11)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hash, "ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH")
+			assert.Equal(t, client.Path, "/injection/operation")
+			assert.Equal(t, blocks.Confirmations, tc.confirmations)
+			if tc.confirmations > 0 {
+				// only the blocks above the branch are searched for the operation
+				assert.Equal(t, blocks.Branch, "BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY")
+			}
+
+			signed, err := hex.DecodeString(strings.Trim(client.Args, `"`))
+			assert.NilError(t, err)
+			forged, signature := signed[:len(signed)-64], signed[len(signed)-64:]
+			digest := blake2b.Sum256(append([]byte{3}, forged...))
+			assert.Assert(t, ed25519.Verify(wallet.Kp.PubKey, digest[:], signature))
+		})
+	}
+}
+
+type contextKey struct{}
+
+func Test_TransferContext(t *testing.T) {
+	wallet, err := account.NewAccountService(nil, nil, nil).CreateWallet(
+		"normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout",
+		"PYh8nXDQLB",
+	)
+	assert.NilError(t, err)
+	source := wallet.Address
+	applied := `{"kind":"transaction","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}`
+
+	client := &contextClientMock{postClientMock: postClientMock{
+		PostBodies: map[string][]byte{
+			"/chains/main/blocks/head/helpers/scripts/run_operation": []byte(`{"contents":[` + applied + `]}`),
+			"/injection/operation": []byte(`"ooVwpqzYhZV4FEbnxdN4wzjtWDa7NqegYX4FyyJQHPrDgzAoTsH"`),
+		},
+		GetBodies: map[string][]byte{
+			"/chains/main/blocks/head/hash":                                         []byte(`"BMXVTnGN7rwaCE34yuAuKzTHaPgyCUBxuVkM2Bbfo5jZvrrbZrY"`),
+			"/chains/main/chain_id":                                                 []byte(`"NetXdQprcVkpaWU"`),
+			"/chains/main/blocks/head/context/contracts/" + source + "/counter":     []byte(`"10"`),
+			"/chains/main/blocks/head/context/contracts/" + source + "/manager_key": []byte(`"` + wallet.Pk + `"`),
+		},
+	}}
+	o := NewOperationService(&blockServiceMock{}, client)
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "transfer")
+	_, err = o.Transfer(ctx, NewWalletSigner(wallet), "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", tez.FromTez(1), TransferOptions{})
+	assert.NilError(t, err)
+
+	// every request of the transfer is sent with its context
+	assert.Equal(t, client.Unbound, 0)
+	assert.Assert(t, len(client.Contexts) > 0)
+	for _, reqCtx := range client.Contexts {
+		assert.Equal(t, reqCtx.Value(contextKey{}), "transfer")
+	}
+}
@@ -0,0 +1,125 @@
+package signer
+
+import (
+	"crypto/elliptic"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+var (
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	oidP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+)
+
+// KMS is an ECDSA key held by a cloud KMS, e.g. an AWS KMS key of spec ECC_SECG_P256K1 or ECC_NIST_P256, or a GCP
+// KMS key version of algorithm EC_SIGN_SECP256K1_SHA256 or EC_SIGN_P256_SHA256. Implementations wrap the client of
+// the SDK of the cloud, the key never leaves the KMS.
+type KMS interface {
+	// PublicKey returns the DER or PEM encoded public key, as returned by GetPublicKey of AWS and GCP
+	PublicKey() ([]byte, error)
+	// SignDigest signs a digest of 32 bytes without hashing it again, e.g. with the DIGEST message type of AWS or as
+	// the sha256 digest of GCP, and returns the DER encoded signature
+	SignDigest(digest []byte) ([]byte, error)
+}
+
+// kmsSigner is a Signer using a KMS key
+type kmsSigner struct {
+	kms    KMS
+	public keys.PublicKey
+	order  *big.Int
+}
+
+// NewKMSSigner returns a Signer signing with the secp256k1 or P-256 key of kms, as the tz2 or tz3 account of the key.
+// It gets the public key from the KMS.
+func NewKMSSigner(kms KMS) (Signer, error) {
+	der, err := kms.PublicKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get KMS public key")
+	}
+	if block, _ := pem.Decode(der); block != nil {
+		der = block.Bytes
+	}
+
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.Wrap(err, "could not parse KMS public key")
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve); err != nil {
+		return nil, errors.Wrap(err, "could not parse KMS public key")
+	}
+
+	point := info.PublicKey.RightAlign()
+	switch {
+	case curve.Equal(oidSecp256k1):
+		key, err := secp256k1.ParsePubKey(point)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse KMS public key")
+		}
+		return &kmsSigner{kms: kms, public: keys.Secp256k1PublicKey(key.SerializeCompressed()), order: secp256k1.Params().N}, nil
+	case curve.Equal(oidP256):
+		x, y := elliptic.Unmarshal(elliptic.P256(), point)
+		if x == nil {
+			return nil, errors.New("could not parse KMS public key, not a point of P-256")
+		}
+		return &kmsSigner{kms: kms, public: keys.P256PublicKey(elliptic.MarshalCompressed(elliptic.P256(), x, y)), order: elliptic.P256().Params().N}, nil
+	}
+	return nil, errors.Errorf("could not parse KMS public key, unsupported curve %s", curve)
+}
+
+func (s *kmsSigner) Address() string {
+	return s.public.Address()
+}
+
+func (s *kmsSigner) PublicKey() string {
+	return s.public.String()
+}
+
+func (s *kmsSigner) Sign(watermark Watermark, message []byte) (string, error) {
+	digest := blake2b.Sum256(append(append([]byte{}, watermark...), message...))
+	der, err := s.kms.SignDigest(digest[:])
+	if err != nil {
+		return "", errors.Wrapf(err, "could not sign as '%s'", s.Address())
+	}
+	signature, err := s.signature(der)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not sign as '%s'", s.Address())
+	}
+	return keys.EncodeSignature(s.public, signature), nil
+}
+
+// signature converts the DER encoded signature of a KMS to the 64 bytes of r and s of Tezos, with s in the lower half
+// of the order of the curve as Tezos requires for secp256k1
+func (s *kmsSigner) signature(der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid DER signature")
+	}
+	if len(rest) > 0 || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(s.order) >= 0 || sig.S.Cmp(s.order) >= 0 {
+		return nil, errors.New("invalid DER signature")
+	}
+
+	if sig.S.Cmp(new(big.Int).Rsh(s.order, 1)) > 0 {
+		sig.S.Sub(s.order, sig.S)
+	}
+
+	signature := make([]byte, 64)
+	r, sb := sig.R.Bytes(), sig.S.Bytes()
+	copy(signature[32-len(r):32], r)
+	copy(signature[64-len(sb):], sb)
+	return signature, nil
+}
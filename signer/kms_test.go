@@ -0,0 +1,148 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secpecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/blake2b"
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+func Test_KMSSigner(t *testing.T) {
+	digest := blake2b.Sum256([]byte("\x03operation"))
+
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	p256Key, err := x509.MarshalPKIXPublicKey(&p256.PublicKey)
+	assert.NilError(t, err)
+	p256R, p256S, err := ecdsa.Sign(rand.Reader, p256, digest[:])
+	assert.NilError(t, err)
+
+	secp, err := secp256k1.GeneratePrivateKey()
+	assert.NilError(t, err)
+	ecParams, _ := asn1.Marshal(oidSecp256k1)
+	secpKey, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, Parameters: asn1.RawValue{FullBytes: ecParams}},
+		PublicKey: asn1.BitString{Bytes: secp.PubKey().SerializeUncompressed(), BitLength: 65 * 8},
+	})
+	assert.NilError(t, err)
+	secpSecret, err := keys.NewSecp256k1FromSecret(secp.Serialize())
+	assert.NilError(t, err)
+	secpSignature, err := secpSecret.Sign([]byte("\x03operation"))
+	assert.NilError(t, err)
+	secpR, secpS := new(big.Int).SetBytes(secpSignature[:32]), new(big.Int).SetBytes(secpSignature[32:])
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NilError(t, err)
+	p384Key, err := x509.MarshalPKIXPublicKey(&p384.PublicKey)
+	assert.NilError(t, err)
+
+	// derSignature encodes r and s, or r and the other s of the same signature when high is set
+	derSignature := func(r, s, order *big.Int, high bool) []byte {
+		if high == (s.Cmp(new(big.Int).Rsh(order, 1)) <= 0) {
+			s = new(big.Int).Sub(order, s)
+		}
+		der, _ := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		return der
+	}
+
+	cases := []struct {
+		name      string
+		key       []byte
+		signature []byte
+		public    keys.PublicKey
+		prefix    string
+		order     *big.Int
+		verify    func(r, s *big.Int) bool
+		wantErr   string
+	}{
+		{
+			name:      "P-256",
+			key:       p256Key,
+			signature: derSignature(p256R, p256S, elliptic.P256().Params().N, false),
+			public:    keys.P256PublicKey(elliptic.MarshalCompressed(elliptic.P256(), p256.X, p256.Y)),
+			prefix:    "p2sig",
+			order:     elliptic.P256().Params().N,
+			verify:    func(r, s *big.Int) bool { return ecdsa.Verify(&p256.PublicKey, digest[:], r, s) },
+		},
+		{
+			name:      "P-256 PEM high S",
+			key:       pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: p256Key}),
+			signature: derSignature(p256R, p256S, elliptic.P256().Params().N, true),
+			public:    keys.P256PublicKey(elliptic.MarshalCompressed(elliptic.P256(), p256.X, p256.Y)),
+			prefix:    "p2sig",
+			order:     elliptic.P256().Params().N,
+			verify:    func(r, s *big.Int) bool { return ecdsa.Verify(&p256.PublicKey, digest[:], r, s) },
+		},
+		{
+			name:      "secp256k1 high S",
+			key:       secpKey,
+			signature: derSignature(secpR, secpS, secp256k1.Params().N, true),
+			public:    secpSecret.Public(),
+			prefix:    "spsig",
+			order:     secp256k1.Params().N,
+			verify: func(r, s *big.Int) bool {
+				var rs, ss secp256k1.ModNScalar
+				rs.SetByteSlice(r.Bytes())
+				ss.SetByteSlice(s.Bytes())
+				return secpecdsa.NewSignature(&rs, &ss).Verify(digest[:], secp.PubKey())
+			},
+		},
+		{
+			name:    "P-384",
+			key:     p384Key,
+			wantErr: "could not parse KMS public key, unsupported curve 1.3.132.0.34",
+		},
+		{
+			name:      "Invalid signature",
+			key:       secpKey,
+			signature: []byte{1, 2, 3},
+			public:    secpSecret.Public(),
+			wantErr:   "invalid DER signature",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kms := &kmsMock{Key: tc.key, Signature: tc.signature}
+			s, err := NewKMSSigner(kms)
+			if tc.public == nil {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, s.Address(), tc.public.Address())
+			assert.Equal(t, s.PublicKey(), tc.public.String())
+
+			signature, err := s.Sign(GenericOperation, []byte("operation"))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, kms.Digest, digest[:])
+
+			assert.Equal(t, signature[:5], tc.prefix)
+			decoded, err := crypto.Decode(signature)
+			assert.NilError(t, err)
+			r, ss := new(big.Int).SetBytes(decoded[len(decoded)-64:len(decoded)-32]), new(big.Int).SetBytes(decoded[len(decoded)-32:])
+			assert.Assert(t, ss.Cmp(new(big.Int).Rsh(tc.order, 1)) <= 0)
+			assert.Assert(t, tc.verify(r, ss))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package signer
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MessagePrefix starts the text of the off-chain messages dapps ask wallets to sign, see Message.
+const MessagePrefix = "Tezos Signed Message: "
+
+// Tags of the packed Michelson string of a message payload
+const (
+	packedTag = 0x05
+	stringTag = 0x01
+)
+
+// Message is an off-chain message a dapp asks a wallet to sign, e.g. through Beacon to log a user in. Its text is
+// "Tezos Signed Message: <url> <time> <input>" with the time in ISO 8601.
+type Message struct {
+	// URL of the dapp asking to sign
+	URL string
+	// Time the message was created at, which a backend checks to reject old messages
+	Time time.Time
+	// Input is the content of the message, e.g. a nonce of the login
+	Input string
+}
+
+// String returns the text of m, as signed by wallets.
+func (m Message) String() string {
+	return MessagePrefix + m.URL + " " + m.Time.UTC().Format("2006-01-02T15:04:05.000Z") + " " + m.Input
+}
+
+// ParseMessage parses the text of a message, e.g. returned by VerifyMessage.
+func ParseMessage(text string) (Message, error) {
+	if !strings.HasPrefix(text, MessagePrefix) {
+		return Message{}, errors.Errorf("could not parse message '%s', it does not start with '%s'", text, MessagePrefix)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(text, MessagePrefix), " ", 3)
+	if len(parts) != 3 {
+		return Message{}, errors.Errorf("could not parse message '%s', expected an url, a time and an input", text)
+	}
+	t, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return Message{}, errors.Wrapf(err, "could not parse message '%s'", text)
+	}
+	return Message{URL: parts[0], Time: t, Input: parts[2]}, nil
+}
+
+// MessagePayload returns the payload of the text of an off-chain message, the bytes a wallet signs as Micheline
+// with MichelineExpression: the packed Michelson string of text.
+func MessagePayload(text string) []byte {
+	payload := make([]byte, 6, 6+len(text))
+	payload[0], payload[1] = packedTag, stringTag
+	binary.BigEndian.PutUint32(payload[2:], uint32(len(text)))
+	return append(payload, text...)
+}
+
+// ParseMessagePayload returns the text of the payload of an off-chain message.
+func ParseMessagePayload(payload []byte) (string, error) {
+	if len(payload) < 6 || payload[0] != packedTag || payload[1] != stringTag {
+		return "", errors.New("could not parse message payload, not a packed string")
+	}
+	if int(binary.BigEndian.Uint32(payload[2:6])) != len(payload)-6 {
+		return "", errors.New("could not parse message payload, invalid length")
+	}
+	return string(payload[6:]), nil
+}
+
+// SignMessage signs the payload of the off-chain message text with s, like a wallet does, and returns the
+// signature.
+func SignMessage(s Signer, text string) (string, error) {
+	signature, err := s.Sign(MichelineExpression, MessagePayload(text))
+	if err != nil {
+		return "", errors.Wrap(err, "could not sign message")
+	}
+	return signature, nil
+}
+
+// VerifyMessage verifies that signature is a signature by publicKey of payload, the payload of an off-chain message
+// sent by a dapp with the signature of the wallet, and returns the text of the message. The dapp, time and input of
+// the message are left to the caller to check, e.g. with ParseMessage.
+func VerifyMessage(publicKey string, payload []byte, signature string) (string, error) {
+	text, err := ParseMessagePayload(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "could not verify message")
+	}
+	if err := VerifySignature(publicKey, MichelineExpression, payload, signature); err != nil {
+		return "", errors.Wrap(err, "could not verify message")
+	}
+	return text, nil
+}
@@ -0,0 +1,68 @@
+package signer
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+func Test_Message(t *testing.T) {
+	m := Message{URL: "example.com", Time: time.Date(2021, 1, 14, 15, 16, 4, 0, time.UTC), Input: "Log in with nonce 42"}
+	text := m.String()
+	assert.Equal(t, text, "Tezos Signed Message: example.com 2021-01-14T15:16:04.000Z Log in with nonce 42")
+	assert.Equal(t, hex.EncodeToString(MessagePayload(text)[:6]), "05010000004f")
+
+	parsed, err := ParseMessage(text)
+	assert.NilError(t, err)
+	assert.Equal(t, parsed.URL, m.URL)
+	assert.Assert(t, parsed.Time.Equal(m.Time))
+	assert.Equal(t, parsed.Input, m.Input)
+
+	_, err = ParseMessage("Hello")
+	assert.Error(t, err, "could not parse message 'Hello', it does not start with 'Tezos Signed Message: '")
+	_, err = ParseMessage("Tezos Signed Message: example.com now")
+	assert.Error(t, err, "could not parse message 'Tezos Signed Message: example.com now', expected an url, a time and an input")
+}
+
+func Test_VerifyMessage(t *testing.T) {
+	key, err := keys.ParsePrivateKey("edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X")
+	assert.NilError(t, err)
+	s := NewKeySigner(key)
+	text := "Tezos Signed Message: example.com 2021-01-14T15:16:04.000Z Log in"
+	signature, err := SignMessage(s, text)
+	assert.NilError(t, err)
+	other, err := SignMessage(s, text+" again")
+	assert.NilError(t, err)
+
+	cases := []struct {
+		name      string
+		payload   []byte
+		signature string
+		wantErr   string
+	}{
+		{name: "Valid", payload: MessagePayload(text), signature: signature},
+		{name: "Other message", payload: MessagePayload(text), signature: other, wantErr: "could not verify message: invalid signature"},
+		{name: "Not a string", payload: []byte{5, 0, 1}, signature: signature, wantErr: "could not verify message: could not parse message payload, not a packed string"},
+		{name: "Truncated", payload: MessagePayload(text)[:20], signature: signature, wantErr: "could not verify message: could not parse message payload, invalid length"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := VerifyMessage(s.PublicKey(), tc.payload, tc.signature)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, text)
+		})
+	}
+
+	_, err = VerifyMessage(s.PublicKey(), MessagePayload(text), other)
+	assert.Equal(t, errors.Cause(err), ErrInvalidSignature)
+}
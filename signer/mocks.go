@@ -0,0 +1,45 @@
+package signer
+
+import "strings"
+
+type clientMock struct {
+	// Bodies are returned for the paths they are keyed by, without query
+	Bodies map[string][]byte
+	Path   string
+	Params map[string]string
+	Args   string
+}
+
+func (c *clientMock) Post(path, args string) ([]byte, error) {
+	c.Path, c.Args = path, args
+	return c.body(path), nil
+}
+
+func (c *clientMock) PostWithParams(path string, params map[string]string, args string) ([]byte, error) {
+	c.Params = params
+	return c.Post(path, args)
+}
+
+func (c *clientMock) Get(path string, params map[string]string) ([]byte, error) {
+	c.Path = path
+	return c.body(path), nil
+}
+
+func (c *clientMock) body(path string) []byte {
+	return c.Bodies[strings.SplitN(path, "?", 2)[0]]
+}
+
+type kmsMock struct {
+	Key       []byte
+	Signature []byte
+	Digest    []byte
+}
+
+func (k *kmsMock) PublicKey() ([]byte, error) {
+	return k.Key, nil
+}
+
+func (k *kmsMock) SignDigest(digest []byte) ([]byte, error) {
+	k.Digest = digest
+	return k.Signature, nil
+}
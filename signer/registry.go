@@ -0,0 +1,73 @@
+package signer
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownSigner is returned by a Registry asked to sign as an address no Signer is registered for.
+var ErrUnknownSigner = errors.New("unknown signer")
+
+// Registry maps addresses to the Signers signing as them, whatever their backend, so that code signing as many
+// accounts, e.g. payouts, only names the address to sign as. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	signers map[string]Signer
+}
+
+// NewRegistry returns a Registry of signers.
+func NewRegistry(signers ...Signer) *Registry {
+	r := &Registry{signers: make(map[string]Signer, len(signers))}
+	for _, s := range signers {
+		r.Register(s)
+	}
+	return r
+}
+
+// Register registers s for its address, replacing the Signer registered for it if any.
+func (r *Registry) Register(s Signer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.signers[s.Address()] = s
+}
+
+// Unregister removes the Signer of address.
+func (r *Registry) Unregister(address string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.signers, address)
+}
+
+// Signer returns the Signer of address, e.g. to pass to the helpers of the operations package, or ErrUnknownSigner.
+func (r *Registry) Signer(address string) (Signer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.signers[address]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownSigner, "could not get signer of '%s'", address)
+	}
+	return s, nil
+}
+
+// Addresses returns the sorted addresses of the registered signers.
+func (r *Registry) Addresses() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	addresses := make([]string, 0, len(r.signers))
+	for address := range r.signers {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// Sign signs message prefixed with watermark as address, with the Signer registered for it.
+func (r *Registry) Sign(address string, watermark Watermark, message []byte) (string, error) {
+	s, err := r.Signer(address)
+	if err != nil {
+		return "", err
+	}
+	return s.Sign(watermark, message)
+}
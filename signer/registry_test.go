@@ -0,0 +1,54 @@
+package signer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+func Test_Registry(t *testing.T) {
+	ed, err := keys.ParsePrivateKey("edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X")
+	assert.NilError(t, err)
+	p256, err := keys.GenerateP256()
+	assert.NilError(t, err)
+	edSigner, p256Signer := NewKeySigner(ed), NewKeySigner(p256)
+
+	r := NewRegistry(edSigner)
+	r.Register(p256Signer)
+	assert.DeepEqual(t, r.Addresses(), []string{"tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ", p256Signer.Address()})
+
+	cases := []struct {
+		name    string
+		address string
+		want    Signer
+		wantErr string
+	}{
+		{name: "ed25519", address: edSigner.Address(), want: edSigner},
+		{name: "P-256", address: p256Signer.Address(), want: p256Signer},
+		{name: "Unknown", address: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1", wantErr: "could not get signer of 'tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1': unknown signer"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			signature, err := r.Sign(tc.address, GenericOperation, []byte("operation"))
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				assert.Equal(t, errors.Cause(err), ErrUnknownSigner)
+				return
+			}
+			assert.NilError(t, err)
+			assert.NilError(t, VerifySignature(tc.want.PublicKey(), GenericOperation, []byte("operation"), signature))
+
+			s, err := r.Signer(tc.address)
+			assert.NilError(t, err)
+			assert.Equal(t, s, tc.want)
+		})
+	}
+
+	r.Unregister(edSigner.Address())
+	_, err = r.Signer(edSigner.Address())
+	assert.Equal(t, errors.Cause(err), ErrUnknownSigner)
+}
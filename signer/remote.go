@@ -0,0 +1,130 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+// authenticationTag is the tag of the bytes signed to authenticate a request to a remote signer
+const authenticationTag = 4
+
+// RemoteOptions are the optional settings of a remote signer.
+type RemoteOptions struct {
+	// Authentication is the key signing the requests to a remote signer started with --require-authentication,
+	// one of its authorized keys
+	Authentication keys.PrivateKey
+}
+
+// remoteSigner is a Signer using a remote signer, octez-signer or any signer implementing its protocol
+type remoteSigner struct {
+	client    client.TezosClient
+	address   string
+	publicKey string
+	opts      RemoteOptions
+}
+
+// NewRemoteSigner returns a Signer signing as address with the remote signer reached by c, e.g. an octez-signer at
+// client.New("http://localhost:6732"). It gets the public key of address from the remote signer, which fails when
+// the signer does not hold the key of address.
+func NewRemoteSigner(c client.TezosClient, address string, opts RemoteOptions) (Signer, error) {
+	query := "/keys/" + address
+	resp, err := c.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get public key '%s'", query)
+	}
+
+	var key struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := json.Unmarshal(resp, &key); err != nil {
+		return nil, errors.Wrapf(err, "could not get public key '%s'", query)
+	}
+	public, err := keys.ParsePublicKey(key.PublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get public key '%s'", query)
+	}
+	if public.Address() != address {
+		return nil, errors.Errorf("could not get public key '%s', got the key of '%s'", query, public.Address())
+	}
+
+	return &remoteSigner{client: c, address: address, publicKey: key.PublicKey, opts: opts}, nil
+}
+
+// RemoteAuthorizedKeys returns the addresses of the keys a remote signer accepts to authenticate requests, it returns
+// nil when the signer does not require authentication.
+func RemoteAuthorizedKeys(c client.TezosClient) ([]string, error) {
+	query := "/authorized_keys"
+	resp, err := c.Get(query, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get authorized keys '%s'", query)
+	}
+
+	var authorized struct {
+		Keys []string `json:"authorized_keys"`
+	}
+	if err := json.Unmarshal(resp, &authorized); err != nil {
+		return nil, errors.Wrapf(err, "could not get authorized keys '%s'", query)
+	}
+	return authorized.Keys, nil
+}
+
+func (s *remoteSigner) Address() string {
+	return s.address
+}
+
+func (s *remoteSigner) PublicKey() string {
+	return s.publicKey
+}
+
+func (s *remoteSigner) Sign(watermark Watermark, message []byte) (string, error) {
+	query := "/keys/" + s.address
+	data := append(append([]byte{}, watermark...), message...)
+
+	params := make(map[string]string)
+	if s.opts.Authentication != nil {
+		authentication, err := s.authenticate(data)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not sign '%s'", query)
+		}
+		params["authentication"] = authentication
+	}
+
+	args, err := json.Marshal(hex.EncodeToString(data))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not sign '%s'", query)
+	}
+	resp, err := client.PostParams(s.client, query, params, string(args))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not sign '%s'", query)
+	}
+
+	var signature struct {
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(resp, &signature); err != nil {
+		return "", errors.Wrapf(err, "could not sign '%s'", query)
+	}
+	return signature.Signature, nil
+}
+
+// authenticate signs data for the address of s with the authentication key, as the remote signer expects it
+func (s *remoteSigner) authenticate(data []byte) (string, error) {
+	pkh, err := forge.PublicKeyHash(s.address)
+	if err != nil {
+		return "", err
+	}
+	request := append(append([]byte{authenticationTag}, pkh...), data...)
+
+	key := s.opts.Authentication
+	signature, err := key.Sign(request)
+	if err != nil {
+		return "", err
+	}
+	return keys.EncodeSignature(key.Public(), signature), nil
+}
@@ -0,0 +1,90 @@
+package signer
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/forge"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+func Test_RemoteSigner(t *testing.T) {
+	key, err := keys.ParsePrivateKey("edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X")
+	assert.NilError(t, err)
+	authentication, err := keys.GenerateEd25519()
+	assert.NilError(t, err)
+
+	address := key.Public().Address()
+	signature, err := NewKeySigner(key).Sign(GenericOperation, []byte{1, 2})
+	assert.NilError(t, err)
+
+	pkh, err := forge.PublicKeyHash(address)
+	assert.NilError(t, err)
+	auth, err := authentication.Sign(append(append([]byte{4}, pkh...), 3, 1, 2))
+	assert.NilError(t, err)
+
+	cases := []struct {
+		name       string
+		opts       RemoteOptions
+		address    string
+		wantPath   string
+		wantParams map[string]string
+		wantErr    string
+	}{
+		{
+			name:     "Unauthenticated",
+			address:  address,
+			wantPath: "/keys/" + address,
+		},
+		{
+			name:       "Authenticated",
+			opts:       RemoteOptions{Authentication: authentication},
+			address:    address,
+			wantPath:   "/keys/" + address,
+			wantParams: map[string]string{"authentication": keys.EncodeSignature(authentication.Public(), auth)},
+		},
+		{
+			name:    "Unknown key",
+			address: "tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1",
+			wantErr: "could not get public key '/keys/tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &clientMock{Bodies: map[string][]byte{
+				"/keys/" + address: []byte(`{"public_key":"` + key.Public().String() + `","signature":"` + signature + `"}`),
+			}}
+
+			s, err := NewRemoteSigner(c, tc.address, tc.opts)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, s.Address(), address)
+			assert.Equal(t, s.PublicKey(), key.Public().String())
+
+			got, err := s.Sign(GenericOperation, []byte{1, 2})
+			assert.NilError(t, err)
+			assert.Equal(t, got, signature)
+			assert.Equal(t, c.Path, tc.wantPath)
+			assert.DeepEqual(t, c.Params, tc.wantParams)
+			assert.Equal(t, c.Args, `"`+hex.EncodeToString([]byte{3, 1, 2})+`"`)
+		})
+	}
+}
+
+func Test_RemoteAuthorizedKeys(t *testing.T) {
+	c := &clientMock{Bodies: map[string][]byte{"/authorized_keys": []byte(`{"authorized_keys":["tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"]}`)}}
+	authorized, err := RemoteAuthorizedKeys(c)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, authorized, []string{"tz1Qny7jVMGiwRrP9FikRK95jTNbJcffTpx1"})
+
+	c.Bodies["/authorized_keys"] = []byte(`{}`)
+	authorized, err = RemoteAuthorizedKeys(c)
+	assert.NilError(t, err)
+	assert.Assert(t, authorized == nil)
+}
@@ -0,0 +1,50 @@
+// Package signer signs Tezos operations and messages, with keys held in memory or by backends like hardware wallets
+// and remote signers that never expose them.
+package signer
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+// Watermark is the prefix Tezos adds to bytes before signing them, it tells what the bytes are so that a signature
+// of an operation cannot be replayed as the signature of a block.
+type Watermark []byte
+
+// Signer signs as a Tezos account, the forging and injection helpers of the operations package accept any Signer.
+// SignOperation and SignBlock sign with the watermark of what they sign.
+type Signer interface {
+	// Address is the address of the account, the source of the operations it signs
+	Address() string
+	// PublicKey is the base58 encoded public key of the account, used to reveal it
+	PublicKey() string
+	// Sign signs message prefixed with watermark and returns the base58 encoded signature, e.g. edsig...
+	Sign(watermark Watermark, message []byte) (string, error)
+}
+
+// keySigner is a Signer using a secret key held in memory
+type keySigner struct {
+	key keys.PrivateKey
+}
+
+// NewKeySigner returns a Signer signing with the secret key key, e.g. a key parsed with keys.ParsePrivateKey.
+func NewKeySigner(key keys.PrivateKey) Signer {
+	return &keySigner{key: key}
+}
+
+func (s *keySigner) Address() string {
+	return s.key.Public().Address()
+}
+
+func (s *keySigner) PublicKey() string {
+	return s.key.Public().String()
+}
+
+func (s *keySigner) Sign(watermark Watermark, message []byte) (string, error) {
+	signature, err := s.key.Sign(append(append([]byte{}, watermark...), message...))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not sign as '%s'", s.Address())
+	}
+	return keys.EncodeSignature(s.key.Public(), signature), nil
+}
@@ -0,0 +1,54 @@
+package signer
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ed25519"
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+func Test_KeySigner(t *testing.T) {
+	ed, err := keys.ParsePrivateKey("edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X")
+	assert.NilError(t, err)
+	secp, err := keys.GenerateSecp256k1()
+	assert.NilError(t, err)
+	p256, err := keys.GenerateP256()
+	assert.NilError(t, err)
+	bls, err := keys.GenerateBLS()
+	assert.NilError(t, err)
+
+	cases := []struct {
+		name   string
+		key    keys.PrivateKey
+		prefix string
+	}{
+		{name: "ed25519", key: ed, prefix: "edsig"},
+		{name: "secp256k1", key: secp, prefix: "spsig"},
+		{name: "P-256", key: p256, prefix: "p2sig"},
+		{name: "BLS", key: bls, prefix: "BLsig"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewKeySigner(tc.key)
+			assert.Equal(t, s.Address(), tc.key.Public().Address())
+			assert.Equal(t, s.PublicKey(), tc.key.Public().String())
+
+			signature, err := s.Sign(GenericOperation, []byte("operation"))
+			assert.NilError(t, err)
+			assert.Equal(t, signature[:len(tc.prefix)], tc.prefix)
+		})
+	}
+
+	// the watermark is signed with the message
+	signature, err := NewKeySigner(ed).Sign(GenericOperation, []byte("operation"))
+	assert.NilError(t, err)
+	decoded, err := crypto.Decode(signature)
+	assert.NilError(t, err)
+	digest := blake2b.Sum256([]byte("\x03operation"))
+	assert.Assert(t, ed25519.Verify(ed25519.PublicKey(ed.Public().Bytes()), digest[:], decoded[len(crypto.Prefix_edsig):]))
+}
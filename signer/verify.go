@@ -0,0 +1,37 @@
+package signer
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+// ErrInvalidSignature is returned by VerifySignature when a signature is well formed but is not a signature of the
+// message by the key.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// VerifySignature verifies that signature is a signature of message prefixed with watermark by publicKey, e.g. to
+// authenticate a user signing a message with a wallet or to check a signature before injecting an operation.
+// publicKey and signature are base58 encoded, of any curve, and signature may be a generic sig. It returns
+// ErrInvalidSignature when the signature does not match.
+func VerifySignature(publicKey string, watermark Watermark, message []byte, signature string) error {
+	public, err := keys.ParsePublicKey(publicKey)
+	if err != nil {
+		return errors.Wrap(err, "could not verify signature")
+	}
+	sig, err := keys.ParseSignature(signature)
+	if err != nil {
+		return errors.Wrap(err, "could not verify signature")
+	}
+
+	// a signature of another curve than the key is invalid even if its bytes verify
+	if !strings.HasPrefix(signature, "sig") && keys.EncodeSignature(public, sig) != signature {
+		return ErrInvalidSignature
+	}
+	if !public.Verify(append(append([]byte{}, watermark...), message...), sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package signer
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/crypto"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+func Test_VerifySignature(t *testing.T) {
+	ed, err := keys.ParsePrivateKey("edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X")
+	assert.NilError(t, err)
+	secp, err := keys.GenerateSecp256k1()
+	assert.NilError(t, err)
+	p256, err := keys.GenerateP256()
+	assert.NilError(t, err)
+	bls, err := keys.GenerateBLS()
+	assert.NilError(t, err)
+
+	sign := func(key keys.PrivateKey) string {
+		signature, err := NewKeySigner(key).Sign(GenericOperation, []byte("message"))
+		assert.NilError(t, err)
+		return signature
+	}
+	generic := func(signature string) string {
+		sig, err := keys.ParseSignature(signature)
+		assert.NilError(t, err)
+		return crypto.B58cencode(sig, crypto.Prefix_sig)
+	}
+
+	cases := []struct {
+		name      string
+		publicKey string
+		watermark Watermark
+		signature string
+		wantErr   string
+	}{
+		{name: "ed25519", publicKey: ed.Public().String(), watermark: GenericOperation, signature: sign(ed)},
+		{name: "secp256k1", publicKey: secp.Public().String(), watermark: GenericOperation, signature: sign(secp)},
+		{name: "P-256", publicKey: p256.Public().String(), watermark: GenericOperation, signature: sign(p256)},
+		{name: "BLS", publicKey: bls.Public().String(), watermark: GenericOperation, signature: sign(bls)},
+		{name: "Generic", publicKey: p256.Public().String(), watermark: GenericOperation, signature: generic(sign(p256))},
+		{name: "Wrong watermark", publicKey: ed.Public().String(), watermark: MichelineExpression, signature: sign(ed), wantErr: "invalid signature"},
+		{name: "Wrong key", publicKey: secp.Public().String(), watermark: GenericOperation, signature: sign(p256), wantErr: "invalid signature"},
+		{name: "Wrong curve", publicKey: ed.Public().String(), watermark: GenericOperation, signature: crypto.B58cencode(make([]byte, 64), crypto.Prefix_spsig), wantErr: "invalid signature"},
+		{name: "Not a signature", publicKey: ed.Public().String(), signature: ed.Public().Address(), wantErr: "could not verify signature: could not parse signature"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifySignature(tc.publicKey, tc.watermark, []byte("message"), tc.signature)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
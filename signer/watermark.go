@@ -0,0 +1,92 @@
+package signer
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/base58"
+)
+
+// Watermarks of what Tezos signs. Consensus watermarks are followed by the chain id, see BlockWatermark,
+// PreattestationWatermark and AttestationWatermark.
+var (
+	// GenericOperation is the watermark of every operation but consensus operations, e.g. transactions,
+	// delegations and originations
+	GenericOperation = Watermark{3}
+	// MichelineExpression is no watermark, packed Michelson data already starts with 0x05, e.g. the payloads of
+	// off-chain messages
+	MichelineExpression = Watermark{}
+)
+
+// Tags of consensus watermarks
+const (
+	blockTag          = 0x11
+	preattestationTag = 0x12
+	attestationTag    = 0x13
+)
+
+// Tags of the consensus operations signed with a consensus watermark, the first contents of an operation follow
+// its branch
+const (
+	tagPreattestation     = 20
+	tagAttestation        = 21
+	tagAttestationWithDAL = 23
+	operationBranchSize   = 32
+)
+
+// BlockWatermark returns the watermark of the block headers of the chain chainID, e.g. NetXdQprcVkpaWU.
+func BlockWatermark(chainID string) (Watermark, error) {
+	return consensusWatermark(blockTag, chainID)
+}
+
+// PreattestationWatermark returns the watermark of the preattestations of the chain chainID.
+func PreattestationWatermark(chainID string) (Watermark, error) {
+	return consensusWatermark(preattestationTag, chainID)
+}
+
+// AttestationWatermark returns the watermark of the attestations of the chain chainID.
+func AttestationWatermark(chainID string) (Watermark, error) {
+	return consensusWatermark(attestationTag, chainID)
+}
+
+func consensusWatermark(tag byte, chainID string) (Watermark, error) {
+	id, err := base58.Decode(chainID, base58.ChainID)
+	if err != nil {
+		return nil, errors.Errorf("invalid chain id '%s'", chainID)
+	}
+	return append(Watermark{tag}, id...), nil
+}
+
+// OperationWatermark returns the watermark of the forged operation: the watermark of preattestations or
+// attestations on the chain chainID for these consensus operations, GenericOperation otherwise. chainID may be empty
+// for other operations.
+func OperationWatermark(forged []byte, chainID string) (Watermark, error) {
+	if len(forged) <= operationBranchSize {
+		return nil, errors.New("could not get operation watermark, invalid operation")
+	}
+
+	switch forged[operationBranchSize] {
+	case tagPreattestation:
+		return PreattestationWatermark(chainID)
+	case tagAttestation, tagAttestationWithDAL:
+		return AttestationWatermark(chainID)
+	}
+	return GenericOperation, nil
+}
+
+// SignOperation signs the forged operation with s, with the watermark returned by OperationWatermark.
+func SignOperation(s Signer, forged []byte, chainID string) (string, error) {
+	watermark, err := OperationWatermark(forged, chainID)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not sign operation as '%s'", s.Address())
+	}
+	return s.Sign(watermark, forged)
+}
+
+// SignBlock signs the forged block header with s, with the block watermark of the chain chainID.
+func SignBlock(s Signer, header []byte, chainID string) (string, error) {
+	watermark, err := BlockWatermark(chainID)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not sign block as '%s'", s.Address())
+	}
+	return s.Sign(watermark, header)
+}
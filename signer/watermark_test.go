@@ -0,0 +1,59 @@
+package signer
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/keys"
+)
+
+func Test_OperationWatermark(t *testing.T) {
+	// operation returns a forged operation with contents of the tag on top of a zero branch
+	operation := func(tag ...byte) []byte {
+		return append(make([]byte, 32), tag...)
+	}
+
+	cases := []struct {
+		name    string
+		forged  []byte
+		chainID string
+		want    string
+		wantErr string
+	}{
+		{name: "Transaction", forged: operation(108), want: "03"},
+		{name: "Preattestation", forged: operation(20), chainID: "NetXdQprcVkpaWU", want: "127a06a770"},
+		{name: "Attestation", forged: operation(21), chainID: "NetXdQprcVkpaWU", want: "137a06a770"},
+		{name: "Attestation with DAL", forged: operation(23), chainID: "NetXdQprcVkpaWU", want: "137a06a770"},
+		{name: "Attestation without chain id", forged: operation(21), wantErr: "invalid chain id ''"},
+		{name: "Branch only", forged: operation(), wantErr: "could not get operation watermark, invalid operation"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			watermark, err := OperationWatermark(tc.forged, tc.chainID)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, hex.EncodeToString(watermark), tc.want)
+		})
+	}
+}
+
+func Test_SignBlock(t *testing.T) {
+	key, err := keys.ParsePrivateKey("edsk362Ypv3qLgbnGvZK7JwqNbwiLGe18XhTMFQY4gUonqnaCPiT6X")
+	assert.NilError(t, err)
+	s := NewKeySigner(key)
+
+	signature, err := SignBlock(s, []byte("header"), "NetXdQprcVkpaWU")
+	assert.NilError(t, err)
+	want, err := s.Sign(Watermark{0x11, 0x7a, 0x06, 0xa7, 0x70}, []byte("header"))
+	assert.NilError(t, err)
+	assert.Equal(t, signature, want)
+
+	_, err = SignBlock(s, []byte("header"), "BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2")
+	assert.Error(t, err, "could not sign block as 'tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ': invalid chain id 'BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2'")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/block"
+	"github.com/DefinitelyNotAGoat/go-tezos/v2/blockid"
 	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/cycle"
 	"github.com/DefinitelyNotAGoat/go-tezos/v2/network"
@@ -87,7 +88,7 @@ func (s *SnapshotService) Get(cycle int) (Snapshot, error) {
 		snap.AssociatedBlock = 1
 	}
 
-	block, err := s.blockService.Get(snap.AssociatedBlock)
+	block, err := s.blockService.Get(blockid.Level(snap.AssociatedBlock))
 	if err != nil {
 		return snap, errors.Wrapf(err, "could not get snapshot '%s'", query)
 	}
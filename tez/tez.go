@@ -0,0 +1,112 @@
+package tez
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MutezPerTez is the number of mutez in one tez.
+const MutezPerTez = 1000000
+
+// Mutez is an amount of mutez, the smallest unit of tez. Being an integer it supports
+// arithmetic and comparison operators directly, e.g. fee+amount > balance.
+// It is marshaled to JSON as a string the way the RPC expects.
+type Mutez int64
+
+// FromTez returns the Mutez closest to tez.
+func FromTez(tez float64) Mutez {
+	return Mutez(math.Round(tez * MutezPerTez))
+}
+
+// ParseMutez parses an amount of mutez such as "1500000".
+func ParseMutez(s string) (Mutez, error) {
+	m, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse mutez '%s'", s)
+	}
+	return Mutez(m), nil
+}
+
+// ParseTez parses an amount of tez with up to six decimals such as "1.5" without loss of precision.
+func ParseTez(s string) (Mutez, error) {
+	sign := int64(1)
+	digits := s
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = -1, digits[1:]
+	}
+
+	units, decimals := digits, ""
+	if i := strings.Index(digits, "."); i >= 0 {
+		units, decimals = digits[:i], digits[i+1:]
+	}
+	if units == "" || len(decimals) > 6 || strings.ContainsAny(units+decimals, "+-") {
+		return 0, errors.Errorf("could not parse tez '%s'", s)
+	}
+
+	tez, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse tez '%s'", s)
+	}
+	var mutez int64
+	if decimals != "" {
+		mutez, err = strconv.ParseInt(decimals+strings.Repeat("0", 6-len(decimals)), 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not parse tez '%s'", s)
+		}
+	}
+	if tez > (math.MaxInt64-mutez)/MutezPerTez {
+		return 0, errors.Errorf("could not parse tez '%s', overflow", s)
+	}
+
+	return Mutez(sign * (tez*MutezPerTez + mutez)), nil
+}
+
+// Tez returns m in tez.
+func (m Mutez) Tez() float64 {
+	return float64(m) / MutezPerTez
+}
+
+// TezString returns m in tez without loss of precision, e.g. "1.5".
+func (m Mutez) TezString() string {
+	sign := ""
+	abs := uint64(m)
+	if m < 0 {
+		sign = "-"
+		abs = uint64(-m)
+	}
+
+	s := sign + strconv.FormatUint(abs/MutezPerTez, 10)
+	if decimals := abs % MutezPerTez; decimals != 0 {
+		s += "." + strings.TrimRight(strconv.FormatUint(decimals+MutezPerTez, 10)[1:], "0")
+	}
+	return s
+}
+
+// String returns m in mutez.
+func (m Mutez) String() string {
+	return strconv.FormatInt(int64(m), 10)
+}
+
+// MarshalJSON marshals m as a string of mutez.
+func (m Mutez) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON unmarshals a string or a number of mutez.
+func (m *Mutez) UnmarshalJSON(v []byte) error {
+	s := strings.Trim(string(v), `"`)
+	if s == "null" {
+		return nil
+	}
+
+	mutez, err := ParseMutez(s)
+	if err != nil {
+		return err
+	}
+	*m = mutez
+	return nil
+}
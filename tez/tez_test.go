@@ -0,0 +1,92 @@
+package tez
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ParseTez(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    Mutez
+		wantErr bool
+	}{
+		{input: "1", want: 1000000},
+		{input: "1.5", want: 1500000},
+		{input: "0.000001", want: 1},
+		{input: "-2.25", want: -2250000},
+		{input: "1.0000001", wantErr: true},
+		{input: ".5", wantErr: true},
+		{input: "1.-5", wantErr: true},
+		{input: "abc", wantErr: true},
+		{input: "9223372036854.775808", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		m, err := ParseTez(tc.input)
+		if tc.wantErr {
+			assert.Assert(t, err != nil, tc.input)
+			continue
+		}
+		assert.NilError(t, err, tc.input)
+		assert.Equal(t, m, tc.want)
+		assert.Equal(t, m.TezString(), tc.input)
+	}
+}
+
+func Test_Mutez(t *testing.T) {
+	assert.Equal(t, FromTez(1.1), Mutez(1100000))
+	assert.Equal(t, Mutez(1500000).Tez(), 1.5)
+	assert.Equal(t, Mutez(1500000).String(), "1500000")
+	assert.Equal(t, Mutez(1500).TezString(), "0.0015")
+
+	var amounts struct {
+		Fee    Mutez `json:"fee"`
+		Amount Mutez `json:"amount"`
+	}
+	assert.NilError(t, json.Unmarshal([]byte(`{"fee":"1420","amount":25}`), &amounts))
+	assert.Equal(t, amounts.Fee, Mutez(1420))
+	assert.Equal(t, amounts.Amount, Mutez(25))
+	assert.Assert(t, amounts.Fee+amounts.Amount > 1440)
+
+	v, err := json.Marshal(amounts)
+	assert.NilError(t, err)
+	assert.Equal(t, string(v), `{"fee":"1420","amount":"25"}`)
+
+	assert.Assert(t, json.Unmarshal([]byte(`{"fee":"1.5"}`), &amounts) != nil)
+}
+
+func Test_Zarith(t *testing.T) {
+	var zero Zarith
+	assert.Equal(t, zero.String(), "0")
+	assert.Equal(t, zero.Add(NewZarith(5)).Int64(), int64(5))
+
+	huge, err := ParseZarith("123456789012345678901234567890")
+	assert.NilError(t, err)
+	assert.Assert(t, !huge.IsInt64())
+	assert.Equal(t, huge.Add(NewZarith(10)).String(), "123456789012345678901234567900")
+	assert.Equal(t, huge.Sub(huge).Sign(), 0)
+	assert.Equal(t, NewZarith(6).Mul(NewZarith(7)).Int64(), int64(42))
+	assert.Equal(t, NewZarith(-7).Div(NewZarith(2)).Int64(), int64(-3))
+	assert.Equal(t, NewZarith(1).Cmp(NewZarith(2)), -1)
+
+	b := big.NewInt(3)
+	z := NewZarithFromBig(b)
+	b.SetInt64(4)
+	assert.Equal(t, z.Int64(), int64(3))
+
+	var gas struct {
+		ConsumedGas Zarith `json:"consumed_gas"`
+	}
+	assert.NilError(t, json.Unmarshal([]byte(`{"consumed_gas":"10207"}`), &gas))
+	assert.Equal(t, gas.ConsumedGas.Int64(), int64(10207))
+	v, err := json.Marshal(gas)
+	assert.NilError(t, err)
+	assert.Equal(t, string(v), `{"consumed_gas":"10207"}`)
+
+	_, err = ParseZarith("12a")
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,112 @@
+package tez
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Zarith is an arbitrary precision integer, used by the RPC for gas, storage sizes, counters and
+// other values that may not fit in an int64. The zero value is 0. Zarith values are immutable,
+// arithmetic methods return new values. It is marshaled to JSON as a string the way the RPC expects.
+type Zarith struct {
+	i *big.Int
+}
+
+// NewZarith returns the Zarith of i.
+func NewZarith(i int64) Zarith {
+	return Zarith{i: big.NewInt(i)}
+}
+
+// NewZarithFromBig returns the Zarith of a copy of i.
+func NewZarithFromBig(i *big.Int) Zarith {
+	return Zarith{i: new(big.Int).Set(i)}
+}
+
+// ParseZarith parses a decimal integer such as "1040000".
+func ParseZarith(s string) (Zarith, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Zarith{}, errors.Errorf("could not parse zarith '%s'", s)
+	}
+	return Zarith{i: i}, nil
+}
+
+// Big returns a copy of z as a *big.Int.
+func (z Zarith) Big() *big.Int {
+	return new(big.Int).Set(z.big())
+}
+
+// Int64 returns z as an int64, the result is undefined when z does not fit.
+func (z Zarith) Int64() int64 {
+	return z.big().Int64()
+}
+
+// IsInt64 reports if z fits in an int64.
+func (z Zarith) IsInt64() bool {
+	return z.big().IsInt64()
+}
+
+// Add returns z+o.
+func (z Zarith) Add(o Zarith) Zarith {
+	return Zarith{i: new(big.Int).Add(z.big(), o.big())}
+}
+
+// Sub returns z-o.
+func (z Zarith) Sub(o Zarith) Zarith {
+	return Zarith{i: new(big.Int).Sub(z.big(), o.big())}
+}
+
+// Mul returns z*o.
+func (z Zarith) Mul(o Zarith) Zarith {
+	return Zarith{i: new(big.Int).Mul(z.big(), o.big())}
+}
+
+// Div returns z/o truncated towards zero. It panics when o is 0.
+func (z Zarith) Div(o Zarith) Zarith {
+	return Zarith{i: new(big.Int).Quo(z.big(), o.big())}
+}
+
+// Cmp returns -1, 0 or +1 when z is lower than, equal to or greater than o.
+func (z Zarith) Cmp(o Zarith) int {
+	return z.big().Cmp(o.big())
+}
+
+// Sign returns -1, 0 or +1 when z is negative, zero or positive.
+func (z Zarith) Sign() int {
+	return z.big().Sign()
+}
+
+// String returns z in base 10.
+func (z Zarith) String() string {
+	return z.big().String()
+}
+
+// MarshalJSON marshals z as a string.
+func (z Zarith) MarshalJSON() ([]byte, error) {
+	return json.Marshal(z.String())
+}
+
+// UnmarshalJSON unmarshals a string or a number.
+func (z *Zarith) UnmarshalJSON(v []byte) error {
+	s := strings.Trim(string(v), `"`)
+	if s == "null" {
+		return nil
+	}
+
+	parsed, err := ParseZarith(s)
+	if err != nil {
+		return err
+	}
+	*z = parsed
+	return nil
+}
+
+func (z Zarith) big() *big.Int {
+	if z.i == nil {
+		return new(big.Int)
+	}
+	return z.i
+}
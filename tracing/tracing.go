@@ -0,0 +1,68 @@
+// Package tracing instruments the Tezos RPC client with OpenTelemetry spans.
+package tracing
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+const instrumentationName = "github.com/DefinitelyNotAGoat/go-tezos/v2/tracing"
+
+// WithTracerProvider is a client option that emits a span from tp for every RPC call, see Middleware.
+func WithTracerProvider(tp trace.TracerProvider) tzc.Option {
+	return tzc.WithMiddleware(Middleware(tp))
+}
+
+// Middleware returns client middleware that emits a span for every RPC call. Spans are named after
+// the endpoint with identifiers replaced by placeholders, and carry the full path, the block ID,
+// the latency and the status of the call.
+func Middleware(tp trace.TracerProvider) tzc.Middleware {
+	tracer := tp.Tracer(instrumentationName)
+	return func(next tzc.Handler) tzc.Handler {
+		return func(req *tzc.Request) (*tzc.Response, error) {
+			ctx, span := tracer.Start(req.Context, req.Method+" "+tzc.Endpoint(req.Path), trace.WithSpanKind(trace.SpanKindClient))
+			defer span.End()
+
+			span.SetAttributes(
+				attribute.String("rpc.system", "tezos"),
+				attribute.String("http.method", req.Method),
+				attribute.String("tezos.rpc.path", req.Path),
+			)
+			if blockID := BlockID(req.Path); blockID != "" {
+				span.SetAttributes(attribute.String("tezos.block_id", blockID))
+			}
+
+			req.Context = ctx
+			start := time.Now()
+			resp, err := next(req)
+			span.SetAttributes(attribute.Int64("tezos.rpc.latency_ms", time.Since(start).Milliseconds()))
+
+			if resp != nil {
+				span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// BlockID returns the block identifier of a /chains/{chain}/blocks/{block_id} path, or an empty string.
+func BlockID(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "blocks" && i >= 3 && segments[i-3] == "chains" {
+			return segments[i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/assert"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+func Test_Middleware(t *testing.T) {
+	cases := []struct {
+		path     string
+		respErr  error
+		wantName string
+		wantCode codes.Code
+	}{
+		{
+			path:     "/chains/main/blocks/BLTGSUUjDpaHe7BYZa1zsrccJ7skurNiHZ1mpCz3cak9GnDfRoT/context/contracts/tz1VQnqCCqX4K5sP3FNkVSNKTdCAMJDd3E1n/balance",
+			wantName: "GET /chains/{chain}/blocks/{block_id}/context/contracts/{contract}/balance",
+			wantCode: codes.Unset,
+		},
+		{
+			path:     "/network/versions",
+			respErr:  errors.New("connection refused"),
+			wantName: "GET /network/versions",
+			wantCode: codes.Error,
+		},
+	}
+
+	for _, tc := range cases {
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+		handler := Middleware(tp)(func(req *tzc.Request) (*tzc.Response, error) {
+			return &tzc.Response{StatusCode: http.StatusOK}, tc.respErr
+		})
+		_, err := handler(&tzc.Request{Context: context.Background(), Method: http.MethodGet, Path: tc.path})
+		assert.Equal(t, err, tc.respErr)
+
+		spans := recorder.Ended()
+		assert.Equal(t, len(spans), 1)
+		assert.Equal(t, spans[0].Name(), tc.wantName)
+		assert.Equal(t, spans[0].Status().Code, tc.wantCode)
+
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range spans[0].Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		assert.Equal(t, attrs["tezos.rpc.path"].AsString(), tc.path)
+		assert.Equal(t, attrs["tezos.block_id"].AsString(), BlockID(tc.path))
+	}
+}
+
+func Test_BlockID(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{path: "/chains/main/blocks/head", want: "head"},
+		{path: "/chains/main/blocks/524067/header", want: "524067"},
+		{path: "/chains/main/chain_id", want: ""},
+		{path: "/injection/operation", want: ""},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, BlockID(tc.path), tc.want)
+	}
+}
@@ -0,0 +1,145 @@
+// Package tzerrors classifies errors returned by Tezos nodes so callers can decide
+// whether an operation should be sent again, re-forged, re-signed or dropped.
+package tzerrors
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+// Action is what a caller should do after a request failed.
+type Action int
+
+const (
+	// None means there was no error.
+	None Action = iota
+	// Retry means the same request may succeed later, e.g. the node was unreachable or overloaded.
+	Retry
+	// Reforge means the operation must be forged again with a fresh branch or counter, then signed and sent.
+	Reforge
+	// Resign means the operation must be signed again before being sent.
+	Resign
+	// GiveUp means the operation can never succeed as is.
+	GiveUp
+)
+
+// Kinds of errors returned by Tezos nodes.
+const (
+	KindTemporary = "temporary"
+	KindBranch    = "branch"
+	KindPermanent = "permanent"
+)
+
+// reforgeIDs are errors fixed by forging the operation again, whatever their kind.
+var reforgeIDs = []error{
+	tzc.ErrCounterInThePast,
+	&tzc.RPCError{ID: "operation.outdated"},
+	&tzc.RPCError{ID: "outdated_operation"},
+	&tzc.RPCError{ID: "operation.branch_refused"},
+}
+
+// resignIDs are errors fixed by signing the operation again.
+var resignIDs = []error{
+	tzc.ErrInvalidSignature,
+}
+
+func (a Action) String() string {
+	switch a {
+	case None:
+		return "none"
+	case Retry:
+		return "retry"
+	case Reforge:
+		return "reforge"
+	case Resign:
+		return "resign"
+	case GiveUp:
+		return "give up"
+	default:
+		return "unknown"
+	}
+}
+
+// Classify returns what a caller should do after err. Transport errors, when the node could not
+// be reached, and errors the node failed to serve are retried. A cancelled or expired context
+// and any other local error, e.g. an operation that could not be forged or a response that could
+// not be decoded, are given up. When a node returns several errors the most severe action wins,
+// GiveUp being the most severe and Retry the least.
+func Classify(err error) Action {
+	if err == nil {
+		return None
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return GiveUp
+	}
+
+	var respErr *tzc.ResponseError
+	if !errors.As(err, &respErr) {
+		var urlErr *url.Error
+		var netErr net.Error
+		if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+			return Retry
+		}
+		return GiveUp
+	}
+
+	if len(respErr.Errors) == 0 {
+		if respErr.StatusCode >= 500 {
+			return Retry
+		}
+		return GiveUp
+	}
+
+	action := None
+	for _, rpcErr := range respErr.Errors {
+		if a := classifyRPCError(rpcErr); a > action {
+			action = a
+		}
+	}
+	return action
+}
+
+func classifyRPCError(rpcErr *tzc.RPCError) Action {
+	for _, target := range resignIDs {
+		if rpcErr.Is(target) {
+			return Resign
+		}
+	}
+	for _, target := range reforgeIDs {
+		if rpcErr.Is(target) {
+			return Reforge
+		}
+	}
+
+	switch rpcErr.Kind {
+	case KindTemporary:
+		return Retry
+	case KindBranch:
+		return Reforge
+	default:
+		return GiveUp
+	}
+}
+
+// IsRetriable reports if the request that failed with err may succeed if attempted again,
+// possibly after being re-forged or re-signed.
+func IsRetriable(err error) bool {
+	action := Classify(err)
+	return action != None && action != GiveUp
+}
+
+// IsTemporary reports if the request that failed with err may succeed if sent again unchanged.
+func IsTemporary(err error) bool {
+	return Classify(err) == Retry
+}
+
+// IsBranchRefused reports if err was caused by the branch or counter of the operation,
+// meaning it must be forged again.
+func IsBranchRefused(err error) bool {
+	return Classify(err) == Reforge
+}
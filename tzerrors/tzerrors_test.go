@@ -0,0 +1,125 @@
+package tzerrors
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+
+	tzc "github.com/DefinitelyNotAGoat/go-tezos/v2/client"
+)
+
+func Test_Classify(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want Action
+	}{
+		{
+			name: "no error",
+			want: None,
+		},
+		{
+			name: "transport error",
+			err:  errors.Wrap(&url.Error{Op: "Get", URL: "http://127.0.0.1:8732/chains/main/blocks/head", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")}}, "could not get block"),
+			want: Retry,
+		},
+		{
+			name: "network error",
+			err:  &net.DNSError{Err: "i/o timeout", Name: "node.example.com", IsTimeout: true},
+			want: Retry,
+		},
+		{
+			name: "context cancelled",
+			err:  errors.Wrap(context.Canceled, "could not get block"),
+			want: GiveUp,
+		},
+		{
+			name: "context deadline exceeded",
+			err:  &url.Error{Op: "Get", URL: "http://127.0.0.1:8732/chains/main/blocks/head", Err: context.DeadlineExceeded},
+			want: GiveUp,
+		},
+		{
+			name: "local error",
+			err:  errors.New("could not forge operation: invalid destination"),
+			want: GiveUp,
+		},
+		{
+			name: "decode error",
+			err:  errors.Wrap(json.Unmarshal([]byte("{"), &struct{}{}), "could not get block"),
+			want: GiveUp,
+		},
+		{
+			name: "node unavailable",
+			err:  &tzc.ResponseError{StatusCode: http.StatusServiceUnavailable},
+			want: Retry,
+		},
+		{
+			name: "not found",
+			err:  &tzc.ResponseError{StatusCode: http.StatusNotFound},
+			want: GiveUp,
+		},
+		{
+			name: "counter in the past",
+			err: &tzc.ResponseError{StatusCode: http.StatusInternalServerError, Errors: []*tzc.RPCError{
+				{Kind: KindTemporary, ID: "proto.005-PsBabyM1.contract.counter_in_the_past"},
+			}},
+			want: Reforge,
+		},
+		{
+			name: "counter in the future",
+			err: &tzc.ResponseError{StatusCode: http.StatusInternalServerError, Errors: []*tzc.RPCError{
+				{Kind: KindTemporary, ID: "proto.005-PsBabyM1.contract.counter_in_the_future"},
+			}},
+			want: Retry,
+		},
+		{
+			name: "branch refused",
+			err: &tzc.ResponseError{StatusCode: http.StatusInternalServerError, Errors: []*tzc.RPCError{
+				{Kind: KindBranch, ID: "proto.005-PsBabyM1.operation.unknown_branch"},
+			}},
+			want: Reforge,
+		},
+		{
+			name: "invalid signature",
+			err: &tzc.ResponseError{StatusCode: http.StatusInternalServerError, Errors: []*tzc.RPCError{
+				{Kind: KindPermanent, ID: "proto.005-PsBabyM1.operation.invalid_signature"},
+			}},
+			want: Resign,
+		},
+		{
+			name: "balance too low wins over counter",
+			err: errors.Wrap(&tzc.ResponseError{StatusCode: http.StatusInternalServerError, Errors: []*tzc.RPCError{
+				{Kind: KindTemporary, ID: "proto.005-PsBabyM1.contract.counter_in_the_past"},
+				{Kind: KindPermanent, ID: "proto.005-PsBabyM1.contract.balance_too_low"},
+			}}, "could not inject operation"),
+			want: GiveUp,
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, Classify(tc.err), tc.want, tc.name)
+	}
+}
+
+func Test_IsRetriable(t *testing.T) {
+	assert.Assert(t, !IsRetriable(nil))
+	refused := &url.Error{Op: "Post", URL: "http://127.0.0.1:8732/injection/operation", Err: errors.New("connection refused")}
+	assert.Assert(t, IsRetriable(refused))
+	assert.Assert(t, IsTemporary(refused))
+	assert.Assert(t, !IsRetriable(errors.New("could not forge operation")))
+	assert.Assert(t, !IsTemporary(context.Canceled))
+	assert.Assert(t, !IsRetriable(&tzc.ResponseError{StatusCode: http.StatusBadRequest}))
+
+	branchRefused := &tzc.ResponseError{StatusCode: http.StatusInternalServerError, Errors: []*tzc.RPCError{
+		{Kind: KindBranch, ID: "proto.005-PsBabyM1.operation.unknown_branch"},
+	}}
+	assert.Assert(t, IsRetriable(branchRefused))
+	assert.Assert(t, IsBranchRefused(branchRefused))
+	assert.Assert(t, !IsTemporary(branchRefused))
+}